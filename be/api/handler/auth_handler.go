@@ -5,8 +5,11 @@ import (
 	"BE_Friends_Management/internal/domain/dto"
 	service "BE_Friends_Management/internal/service/auth"
 	"BE_Friends_Management/pkg"
+	"BE_Friends_Management/pkg/utils"
 	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
@@ -63,7 +66,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		log.Error("Happened error when mapping request. Error: ", err)
 		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
 	}
-	accessToken, refreshToken, err := h.service.Login(request.Email, request.Password)
+	accessToken, refreshToken, err := h.service.Login(request.Email, request.Password, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
 		log.Error("Happened error when creating new friendship. Error: ", err)
 		switch {
@@ -118,6 +121,7 @@ func (h *AuthHandler) RefreshAccessToken(c *gin.Context) {
 // @Accept 		 json
 // @Produce      json
 // @Param 		 request body dto.LogoutRequest true "User's refresh token"
+// @param Authorization header string false "Access token to revoke along with the refresh token"
 // @Router       /api/auth/logout [POST]
 // @Success      200   {object}  dto.ApiResponseSuccessStruct
 func (h *AuthHandler) Logout(c *gin.Context) {
@@ -127,7 +131,8 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 		log.Error("Happened error when mapping request. Error: ", err)
 		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
 	}
-	err := h.service.Logout(request.RefreshToken)
+	rawAccessToken := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	err := h.service.Logout(request.RefreshToken, rawAccessToken)
 	if err != nil {
 		log.Error("Happened error when logging out. Error: ", err)
 		switch {
@@ -145,3 +150,163 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, pkg.BuildResponseSuccessNoData())
 }
+
+// User godoc
+// @Summary      Logout everywhere
+// @Description  Revoke every refresh token and access token of the authenticated user, including the one making the request.
+// @Tags         Auth
+// @Accept 		 json
+// @Produce      json
+// @param Authorization header string true "Authorization"
+// @Router       /api/auth/logout-all [POST]
+// @Success      200   {object}  dto.ApiResponseSuccessNoData
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	err := h.service.LogoutAll(authUserId)
+	if err != nil {
+		log.Error("Happened error when logging out everywhere. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when logging out everywhere.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessNoData())
+}
+
+// User godoc
+// @Summary      Get my sessions
+// @Description  Get the sessions of the authenticated user, one per active refresh token, newest first. The user agent and IP address are the ones of the login that started the session.
+// @Tags         Auth
+// @Accept 		 json
+// @Produce      json
+// @param Authorization header string true "Authorization"
+// @Router       /api/me/sessions [GET]
+// @Success      200   {object}  dto.ApiResponseSuccessWithSessions
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *AuthHandler) GetSessions(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	h.getSessions(c, authUserId)
+}
+
+// User godoc
+// @Summary      Revoke one of my sessions
+// @Description  Revoke a session of the authenticated user. Refresh tokens rotated out of the same login are revoked with it.
+// @Tags         Auth
+// @Accept 		 json
+// @Produce      json
+// @Param 		 id path int true "Session ID"
+// @param Authorization header string true "Authorization"
+// @Router       /api/me/sessions/{id} [DELETE]
+// @Success      200   {object}  dto.ApiResponseSuccessNoData
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	sessionId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error("Happened error when converting sessionId to int64. Error: ", err)
+		pkg.PanicExeption(constant.InvalidRequest, "Happened error when converting sessionId to int64")
+	}
+	err = h.service.RevokeSession(authUserId, sessionId)
+	if err != nil {
+		log.Error("Happened error when revoking session. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrSessionNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when revoking session.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessNoData())
+}
+
+// User godoc
+// @Summary      Get a user's sessions
+// @Description  Get the sessions of any user, one per active refresh token, newest first.
+// @Tags         Users Management
+// @Accept 		 json
+// @Produce      json
+// @Param 		 id path int true "User ID"
+// @param Authorization header string true "Authorization"
+// @Router       /api/users/{id}/sessions [GET]
+// @Success      200   {object}  dto.ApiResponseSuccessWithSessions
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *AuthHandler) GetUserSessions(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	userId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error("Happened error when converting userId to int64. Error: ", err)
+		pkg.PanicExeption(constant.InvalidRequest, "Happened error when converting userId to int64")
+	}
+	h.getSessions(c, userId)
+}
+
+// User godoc
+// @Summary      Force logout of a user
+// @Description  Revoke every refresh token and access token of a user, e.g. when the account is compromised.
+// @Tags         Users Management
+// @Accept 		 json
+// @Produce      json
+// @Param 		 id path int true "User ID"
+// @param Authorization header string true "Authorization"
+// @Router       /api/users/{id}/sessions [DELETE]
+// @Success      200   {object}  dto.ApiResponseSuccessNoData
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *AuthHandler) LogoutUser(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	userId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error("Happened error when converting userId to int64. Error: ", err)
+		pkg.PanicExeption(constant.InvalidRequest, "Happened error when converting userId to int64")
+	}
+	err = h.service.LogoutAll(userId)
+	if err != nil {
+		log.Error("Happened error when forcing logout of user. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when forcing logout of user.")
+		}
+	}
+	log.WithFields(log.Fields{
+		"security_event": "forced_logout",
+		"user_id":        userId,
+		"admin_id":       utils.GetAuthUserId(c),
+	}).Warn("Admin revoked every session of a user")
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessNoData())
+}
+
+func (h *AuthHandler) getSessions(c *gin.Context, userId int64) {
+	userTokens, err := h.service.GetSessions(userId)
+	if err != nil {
+		log.Error("Happened error when getting sessions. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when getting sessions.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithSessions(utils.ConvertUserTokensToSessions(userTokens)))
+}
@@ -23,11 +23,11 @@ func NewBlockRelationshipHandler(service service.BlockRelationshipService) *Bloc
 
 // Block godoc
 // @Summary      Create new block relationship
-// @Description  Create new block relationship
+// @Description  Create new block relationship, for a duration (e.g. 30m, 8h) or until unblocked. An expired block is lifted automatically.
 // @Tags         BlockRelationship
 // @Accept 		json
 // @Produce      json
-// @Param 		 request body dto.CreateBlockRequest true "Requestor's email and target's email"
+// @Param 		 request body dto.CreateBlockRequest true "Requestor's email, target's email and optional duration"
 // @param Authorization header string true "Authorization"
 // @Router       /api/block [POST]
 // @Success      200   {object}  dto.ApiResponseSuccessNoData
@@ -43,11 +43,11 @@ func (h *BlockRelationshipHandler) CreateBlockRelationship(c *gin.Context) {
 		log.Error("Happened error when mapping request. Error: ", err)
 		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
 	}
-	err := h.service.CreateBlockRelationship(authUserId, request.Requestor, request.Target)
+	err := h.service.CreateBlockRelationship(authUserId, request.Requestor, request.Target, request.Duration)
 	if err != nil {
 		log.Error("Happened error when creating new block relationship. Error: ", err)
 		switch {
-		case errors.Is(err, service.ErrInvalidRequest):
+		case errors.Is(err, service.ErrInvalidRequest), errors.Is(err, service.ErrInvalidDuration):
 			pkg.PanicExeption(constant.InvalidRequest, err.Error())
 		case errors.Is(err, service.ErrUserNotFound):
 			pkg.PanicExeption(constant.DataNotFound, err.Error())
@@ -63,3 +63,88 @@ func (h *BlockRelationshipHandler) CreateBlockRelationship(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, pkg.BuildResponseSuccessNoData())
 }
+
+// Block godoc
+// @Summary      Delete block relationship
+// @Description  Lift a block. Only the requestor of the block or an admin can lift it.
+// @Tags         BlockRelationship
+// @Accept 		json
+// @Produce      json
+// @Param 		 request body dto.DeleteBlockRequest true "Requestor's email and target's email"
+// @param Authorization header string true "Authorization"
+// @Router       /api/block [DELETE]
+// @Success      200   {object}  dto.ApiResponseSuccessNoData
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *BlockRelationshipHandler) DeleteBlockRelationship(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	authUserRole := utils.GetAuthUserRole(c)
+	var request dto.DeleteBlockRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error("Happened error when mapping request. Error: ", err)
+		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
+	}
+	err := h.service.DeleteBlockRelationship(authUserId, authUserRole, request.Requestor, request.Target)
+	if err != nil {
+		log.Error("Happened error when deleting block relationship. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrInvalidRequest):
+			pkg.PanicExeption(constant.InvalidRequest, err.Error())
+		case errors.Is(err, service.ErrUserNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		case errors.Is(err, service.ErrNotBlocked):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		case errors.Is(err, service.ErrNotPermitted):
+			pkg.PanicExeption(constant.StatusForbidden, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when deleting block relationship.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessNoData())
+}
+
+// Block godoc
+// @Summary      Retrieve blocked users
+// @Description  Retrieve the users blocked by an email address, newest first
+// @Tags         BlockRelationship
+// @Accept 		json
+// @Produce      json
+// @Param 		 email query string true "Email address"
+// @Param 		 limit query int false "Page size"
+// @Param 		 offset query int false "Number of blocks to skip"
+// @param Authorization header string true "Authorization"
+// @Router       /api/block [GET]
+// @Success      200   {object}  dto.ApiResponseSuccessWithBlockedUsers
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *BlockRelationshipHandler) RetrieveBlockedUsers(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	authUserRole := utils.GetAuthUserRole(c)
+	requestEmail := c.Query("email")
+	if requestEmail == "" {
+		log.Error("Happened error when mapping request. Error: received no email input.")
+		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
+		return
+	}
+	limit, offset := utils.GetPaginationParams(c)
+	blockRelationships, count, err := h.service.RetrieveBlockedUsers(authUserId, authUserRole, requestEmail, limit, offset)
+	if err != nil {
+		log.Error("Happened error when retrieving blocked users. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		case errors.Is(err, service.ErrNotPermitted):
+			pkg.PanicExeption(constant.StatusForbidden, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when retrieving blocked users.")
+		}
+	}
+	blockedUsers := utils.ConvertBlockRelationshipsToBlockedUsers(blockRelationships)
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithBlockedUsers(blockedUsers, count))
+}
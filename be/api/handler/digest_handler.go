@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/dto"
+	service "BE_Friends_Management/internal/service/digest"
+	"BE_Friends_Management/pkg"
+	"BE_Friends_Management/pkg/utils"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+type DigestHandler struct {
+	service service.DigestService
+}
+
+func NewDigestHandler(service service.DigestService) *DigestHandler {
+	return &DigestHandler{service: service}
+}
+
+// Digest godoc
+// @Summary      Get digest preference
+// @Description  How often the authenticated user gets a digest email of missed updates
+// @Tags         Digest
+// @Accept       json
+// @Produce      json
+// @param Authorization header string true "Authorization"
+// @Router       /api/me/digest [GET]
+// @Success      200   {object}  dto.ApiResponseSuccessWithDigestPreference
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *DigestHandler) GetDigestPreference(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	preference, err := h.service.GetPreference(authUserId)
+	if err != nil {
+		log.Error("Happened error when getting digest preference. Error: ", err)
+		pkg.PanicExeption(constant.UnknownError, "Happened error when getting digest preference.")
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithDigestPreference(utils.ConvertDigestPreferenceToResponse(preference)))
+}
+
+// Digest godoc
+// @Summary      Update digest preference
+// @Description  Set digest emails of missed updates to off, daily or weekly
+// @Tags         Digest
+// @Accept       json
+// @Produce      json
+// @Param 		 request body dto.UpdateDigestPreferenceRequest true "Digest frequency: off, daily or weekly"
+// @param Authorization header string true "Authorization"
+// @Router       /api/me/digest [PUT]
+// @Success      200   {object}  dto.ApiResponseSuccessWithDigestPreference
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *DigestHandler) UpdateDigestPreference(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	var request dto.UpdateDigestPreferenceRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error("Happened error when mapping request. Error: ", err)
+		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
+	}
+	preference, err := h.service.SetFrequency(authUserId, request.Frequency)
+	if err != nil {
+		log.Error("Happened error when updating digest preference. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrInvalidFrequency):
+			pkg.PanicExeption(constant.InvalidRequest, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when updating digest preference.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithDigestPreference(utils.ConvertDigestPreferenceToResponse(preference)))
+}
@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/dto"
+	service "BE_Friends_Management/internal/service/friend_request"
+	"BE_Friends_Management/pkg"
+	"BE_Friends_Management/pkg/utils"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+type FriendRequestHandler struct {
+	service service.FriendRequestService
+}
+
+func NewFriendRequestHandler(service service.FriendRequestService) *FriendRequestHandler {
+	return &FriendRequestHandler{service: service}
+}
+
+// FriendRequest godoc
+// @Summary      Send friend request
+// @Description  Send a friend request from requestor to target. The friendship is only created once the target accepts.
+// @Tags         FriendRequest
+// @Accept 		json
+// @Produce      json
+// @Param 		 request body dto.SendFriendRequestRequest true "Requestor's email and target's email"
+// @param Authorization header string true "Authorization"
+// @Router       /api/friend-requests [POST]
+// @Success      200   {object}  dto.ApiResponseSuccessWithFriendRequest
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *FriendRequestHandler) SendFriendRequest(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	var request dto.SendFriendRequestRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error("Happened error when mapping request. Error: ", err)
+		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
+	}
+	friendRequest, err := h.service.SendFriendRequest(authUserId, request.Requestor, request.Target)
+	if err != nil {
+		log.Error("Happened error when sending friend request. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrInvalidRequest):
+			pkg.PanicExeption(constant.InvalidRequest, err.Error())
+		case errors.Is(err, service.ErrUserNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		case errors.Is(err, service.ErrAlreadyFriend):
+			pkg.PanicExeption(constant.Conflict, err.Error())
+		case errors.Is(err, service.ErrAlreadyRequested):
+			pkg.PanicExeption(constant.Conflict, err.Error())
+		case errors.Is(err, service.ErrIsBlocked):
+			pkg.PanicExeption(constant.StatusForbidden, err.Error())
+		case errors.Is(err, service.ErrNotPermitted):
+			pkg.PanicExeption(constant.StatusForbidden, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when sending friend request.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithFriendRequest(utils.ConvertFriendRequestToResponse(friendRequest)))
+}
+
+// FriendRequest godoc
+// @Summary      Retrieve incoming friend requests
+// @Description  Retrieve pending friend requests sent to an email address
+// @Tags         FriendRequest
+// @Accept 		json
+// @Produce      json
+// @Param 		 email query string true "Email address"
+// @param Authorization header string true "Authorization"
+// @Router       /api/friend-requests/incoming [GET]
+// @Success      200   {object}  dto.ApiResponseSuccessWithFriendRequests
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *FriendRequestHandler) RetrieveIncomingFriendRequests(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	authUserRole := utils.GetAuthUserRole(c)
+	requestEmail := c.Query("email")
+	if requestEmail == "" {
+		log.Error("Happened error when mapping request. Error: received no email input.")
+		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
+		return
+	}
+	friendRequests, err := h.service.RetrieveIncomingFriendRequests(authUserId, authUserRole, requestEmail)
+	if err != nil {
+		log.Error("Happened error when retrieving incoming friend requests. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		case errors.Is(err, service.ErrNotPermitted):
+			pkg.PanicExeption(constant.StatusForbidden, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when retrieving incoming friend requests.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithFriendRequests(utils.ConvertFriendRequestsToResponses(friendRequests)))
+}
+
+// FriendRequest godoc
+// @Summary      Retrieve outgoing friend requests
+// @Description  Retrieve pending friend requests sent by an email address
+// @Tags         FriendRequest
+// @Accept 		json
+// @Produce      json
+// @Param 		 email query string true "Email address"
+// @param Authorization header string true "Authorization"
+// @Router       /api/friend-requests/outgoing [GET]
+// @Success      200   {object}  dto.ApiResponseSuccessWithFriendRequests
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *FriendRequestHandler) RetrieveOutgoingFriendRequests(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	authUserRole := utils.GetAuthUserRole(c)
+	requestEmail := c.Query("email")
+	if requestEmail == "" {
+		log.Error("Happened error when mapping request. Error: received no email input.")
+		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
+		return
+	}
+	friendRequests, err := h.service.RetrieveOutgoingFriendRequests(authUserId, authUserRole, requestEmail)
+	if err != nil {
+		log.Error("Happened error when retrieving outgoing friend requests. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		case errors.Is(err, service.ErrNotPermitted):
+			pkg.PanicExeption(constant.StatusForbidden, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when retrieving outgoing friend requests.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithFriendRequests(utils.ConvertFriendRequestsToResponses(friendRequests)))
+}
+
+// FriendRequest godoc
+// @Summary      Accept friend request
+// @Description  Accept a pending friend request and create the friendship. Only the target can accept.
+// @Tags         FriendRequest
+// @Accept 		json
+// @Produce      json
+// @Param 		 id path string true "Friend request ID"
+// @param Authorization header string true "Authorization"
+// @Router       /api/friend-requests/{id}/accept [POST]
+// @Success      200   {object}  dto.ApiResponseSuccessNoData
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *FriendRequestHandler) AcceptFriendRequest(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	requestId := parseFriendRequestId(c)
+	err := h.service.AcceptFriendRequest(authUserId, requestId)
+	if err != nil {
+		log.Error("Happened error when accepting friend request. Error: ", err)
+		handleFriendRequestTransitionError(err, "Happened error when accepting friend request.")
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessNoData())
+}
+
+// FriendRequest godoc
+// @Summary      Reject friend request
+// @Description  Reject a pending friend request. Only the target can reject.
+// @Tags         FriendRequest
+// @Accept 		json
+// @Produce      json
+// @Param 		 id path string true "Friend request ID"
+// @param Authorization header string true "Authorization"
+// @Router       /api/friend-requests/{id}/reject [POST]
+// @Success      200   {object}  dto.ApiResponseSuccessNoData
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *FriendRequestHandler) RejectFriendRequest(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	requestId := parseFriendRequestId(c)
+	err := h.service.RejectFriendRequest(authUserId, requestId)
+	if err != nil {
+		log.Error("Happened error when rejecting friend request. Error: ", err)
+		handleFriendRequestTransitionError(err, "Happened error when rejecting friend request.")
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessNoData())
+}
+
+// FriendRequest godoc
+// @Summary      Cancel friend request
+// @Description  Cancel a pending friend request. Only the requestor can cancel.
+// @Tags         FriendRequest
+// @Accept 		json
+// @Produce      json
+// @Param 		 id path string true "Friend request ID"
+// @param Authorization header string true "Authorization"
+// @Router       /api/friend-requests/{id}/cancel [POST]
+// @Success      200   {object}  dto.ApiResponseSuccessNoData
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *FriendRequestHandler) CancelFriendRequest(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	requestId := parseFriendRequestId(c)
+	err := h.service.CancelFriendRequest(authUserId, requestId)
+	if err != nil {
+		log.Error("Happened error when cancelling friend request. Error: ", err)
+		handleFriendRequestTransitionError(err, "Happened error when cancelling friend request.")
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessNoData())
+}
+
+func parseFriendRequestId(c *gin.Context) int64 {
+	requestId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error("Happened error when converting friend request ID to int64. Error: ", err)
+		pkg.PanicExeption(constant.InvalidRequest, "Happened error when converting friend request ID to int64")
+	}
+	return requestId
+}
+
+func handleFriendRequestTransitionError(err error, defaultMessage string) {
+	switch {
+	case errors.Is(err, service.ErrFriendRequestNotFound):
+		pkg.PanicExeption(constant.DataNotFound, err.Error())
+	case errors.Is(err, service.ErrFriendRequestNotPending):
+		pkg.PanicExeption(constant.Conflict, err.Error())
+	case errors.Is(err, service.ErrAlreadyFriend):
+		pkg.PanicExeption(constant.Conflict, err.Error())
+	case errors.Is(err, service.ErrIsBlocked):
+		pkg.PanicExeption(constant.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrNotPermitted):
+		pkg.PanicExeption(constant.StatusForbidden, err.Error())
+	default:
+		pkg.PanicExeption(constant.UnknownError, defaultMessage)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"BE_Friends_Management/pkg/utils"
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
@@ -23,7 +24,7 @@ func NewFriendshipHandler(service service.FriendshipService) *FriendshipHandler
 
 // Friendship godoc
 // @Summary      Create new friendship
-// @Description  Create new friendship
+// @Description  Directly create a friendship between two email addresses. Users must go through friend requests instead.
 // @Tags         Friendship
 // @Accept 		json
 // @Produce      json
@@ -38,12 +39,13 @@ func NewFriendshipHandler(service service.FriendshipService) *FriendshipHandler
 func (h *FriendshipHandler) CreateFriendship(c *gin.Context) {
 	defer pkg.PanicHandler(c)
 	authUserId := utils.GetAuthUserId(c)
+	authUserRole := utils.GetAuthUserRole(c)
 	var request dto.CreateFriendshipRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
 		log.Error("Happened error when mapping request. Error: ", err)
 		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
 	}
-	err := h.service.CreateFriendship(authUserId, request.Friends[0], request.Friends[1])
+	err := h.service.CreateFriendship(authUserId, authUserRole, request.Friends[0], request.Friends[1])
 	if err != nil {
 		log.Error("Happened error when creating new friendship. Error: ", err)
 		switch {
@@ -71,6 +73,8 @@ func (h *FriendshipHandler) CreateFriendship(c *gin.Context) {
 // @Accept 		json
 // @Produce      json
 // @Param 		 email query string true "Email address"
+// @Param 		 limit query int false "Page size"
+// @Param 		 after query string false "Cursor returned as next_cursor by the previous page"
 // @param Authorization header string true "Authorization"
 // @Router       /api/friendship/friends [GET]
 // @Success      200   {object}  dto.ApiResponseSuccessWithFriendsList
@@ -88,7 +92,8 @@ func (h *FriendshipHandler) RetrieveFriendsList(c *gin.Context) {
 		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
 		return
 	}
-	friends, err := h.service.RetrieveFriendsList(authUserId, authUserRole, requestEmail)
+	limit, afterId := utils.GetCursorPaginationParams(c)
+	friends, count, err := h.service.RetrieveFriendsList(authUserId, authUserRole, requestEmail, afterId, limit)
 	if err != nil {
 		log.Error("Happened error when retrieving friends list. Error: ", err)
 		switch {
@@ -100,9 +105,12 @@ func (h *FriendshipHandler) RetrieveFriendsList(c *gin.Context) {
 			pkg.PanicExeption(constant.UnknownError, "Happened error when retrieving friends list.")
 		}
 	}
+	var lastId int64
+	if len(friends) > 0 {
+		lastId = friends[len(friends)-1].Id
+	}
 	emails := utils.ConvertUsersToEmails(friends)
-	count := h.service.CountFriends(friends)
-	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithFriendsList(emails, count))
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithFriendsList(emails, utils.BuildNextCursor(lastId, len(friends), limit), count))
 }
 
 // Friendship godoc
@@ -113,6 +121,8 @@ func (h *FriendshipHandler) RetrieveFriendsList(c *gin.Context) {
 // @Produce      json
 // @Param 		 email1 query string true "Email address of user 1"
 // @Param 		 email2 query string true "Email address of user 2"
+// @Param 		 limit query int false "Page size"
+// @Param 		 after query string false "Cursor returned as next_cursor by the previous page"
 // @param Authorization header string true "Authorization"
 // @Router       /api/friendship/common-friends [GET]
 // @Success      200   {object}  dto.ApiResponseSuccessWithFriendsList
@@ -136,7 +146,8 @@ func (h *FriendshipHandler) RetrieveCommonFriends(c *gin.Context) {
 		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
 		return
 	}
-	friends, err := h.service.RetrieveCommonFriends(authUserId, authUserRole, requestEmail1, requestEmail2)
+	limit, afterId := utils.GetCursorPaginationParams(c)
+	friends, count, err := h.service.RetrieveCommonFriends(authUserId, authUserRole, requestEmail1, requestEmail2, afterId, limit)
 	if err != nil {
 		log.Error("Happened error when retrieving common friends list. Error: ", err)
 		switch {
@@ -150,7 +161,150 @@ func (h *FriendshipHandler) RetrieveCommonFriends(c *gin.Context) {
 			pkg.PanicExeption(constant.UnknownError, "Happened error when retrieving common friends list.")
 		}
 	}
+	var lastId int64
+	if len(friends) > 0 {
+		lastId = friends[len(friends)-1].Id
+	}
 	emails := utils.ConvertUsersToEmails(friends)
-	count := h.service.CountFriends(friends)
-	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithFriendsList(emails, count))
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithFriendsList(emails, utils.BuildNextCursor(lastId, len(friends), limit), count))
+}
+
+// Friendship godoc
+// @Summary      Delete friendship
+// @Description  Delete friendship between two email addresses, optionally removing their mutual subscriptions
+// @Tags         Friendship
+// @Accept 		json
+// @Produce      json
+// @Param 		 request body dto.DeleteFriendshipRequest true "List of 2 friend emails and whether to remove mutual subscriptions"
+// @param Authorization header string true "Authorization"
+// @Router       /api/friendship [DELETE]
+// @Success      200   {object}  dto.ApiResponseSuccessNoData
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *FriendshipHandler) DeleteFriendship(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	var request dto.DeleteFriendshipRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error("Happened error when mapping request. Error: ", err)
+		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
+	}
+	err := h.service.DeleteFriendship(authUserId, request.Friends[0], request.Friends[1], request.RemoveSubscriptions)
+	if err != nil {
+		log.Error("Happened error when deleting friendship. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrInvalidRequest):
+			pkg.PanicExeption(constant.InvalidRequest, err.Error())
+		case errors.Is(err, service.ErrUserNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		case errors.Is(err, service.ErrNotFriend):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		case errors.Is(err, service.ErrNotPermitted):
+			pkg.PanicExeption(constant.StatusForbidden, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when deleting friendship.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessNoData())
+}
+
+// Friendship godoc
+// @Summary      Retrieve friend suggestions for an email address
+// @Description  Suggest friends of friends ranked by number of mutual friends, excluding existing friends and blocked users
+// @Tags         Friendship
+// @Accept 		json
+// @Produce      json
+// @Param 		 email query string true "Email address"
+// @Param 		 limit query int false "Page size"
+// @Param 		 offset query int false "Number of suggestions to skip"
+// @param Authorization header string true "Authorization"
+// @Router       /api/friendship/suggestions [GET]
+// @Success      200   {object}  dto.ApiResponseSuccessWithFriendSuggestions
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *FriendshipHandler) RetrieveFriendSuggestions(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	authUserRole := utils.GetAuthUserRole(c)
+	requestEmail := c.Query("email")
+	if requestEmail == "" {
+		log.Error("Happened error when mapping request. Error: received no email input.")
+		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
+		return
+	}
+	limit, offset := utils.GetPaginationParams(c)
+	suggestions, err := h.service.RetrieveFriendSuggestions(authUserId, authUserRole, requestEmail, limit, offset)
+	if err != nil {
+		log.Error("Happened error when retrieving friend suggestions. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		case errors.Is(err, service.ErrNotPermitted):
+			pkg.PanicExeption(constant.StatusForbidden, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when retrieving friend suggestions.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithFriendSuggestions(utils.ConvertFriendSuggestionsToResponses(suggestions)))
+}
+
+// Friendship godoc
+// @Summary      Retrieve the shortest friendship path between two email addresses
+// @Description  Retrieve the chain of emails connecting two users through friendships, skipping users involved in a block with either of them
+// @Tags         Friendship
+// @Accept 		json
+// @Produce      json
+// @Param 		 from query string true "Email address of the start user"
+// @Param 		 to query string true "Email address of the end user"
+// @Param 		 max_depth query int false "Maximum number of friendship hops"
+// @param Authorization header string true "Authorization"
+// @Router       /api/friendship/path [GET]
+// @Success      200   {object}  dto.ApiResponseSuccessWithFriendshipPath
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *FriendshipHandler) RetrieveFriendshipPath(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	authUserRole := utils.GetAuthUserRole(c)
+	fromEmail := c.Query("from")
+	toEmail := c.Query("to")
+	if fromEmail == "" || toEmail == "" {
+		log.Error("Happened error when mapping request. Error: received no email input.")
+		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
+		return
+	}
+	maxDepth := constant.DefaultPathMaxDepth
+	if rawMaxDepth := c.Query("max_depth"); rawMaxDepth != "" {
+		parsedMaxDepth, err := strconv.Atoi(rawMaxDepth)
+		if err != nil || parsedMaxDepth <= 0 {
+			log.Error("Happened error when mapping request. Error: invalid max_depth ", rawMaxDepth)
+			pkg.PanicExeption(constant.InvalidRequest, "Invalid max_depth.")
+		}
+		maxDepth = min(parsedMaxDepth, constant.MaxPathMaxDepth)
+	}
+	path, err := h.service.RetrieveFriendshipPath(authUserId, authUserRole, fromEmail, toEmail, maxDepth)
+	if err != nil {
+		log.Error("Happened error when retrieving friendship path. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrInvalidRequest):
+			pkg.PanicExeption(constant.InvalidRequest, err.Error())
+		case errors.Is(err, service.ErrUserNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		case errors.Is(err, service.ErrPathNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		case errors.Is(err, service.ErrIsBlocked):
+			pkg.PanicExeption(constant.StatusForbidden, err.Error())
+		case errors.Is(err, service.ErrNotPermitted):
+			pkg.PanicExeption(constant.StatusForbidden, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when retrieving friendship path.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithFriendshipPath(utils.ConvertUsersToEmails(path)))
 }
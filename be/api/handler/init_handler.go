@@ -1,25 +1,43 @@
 package handler
 
 import (
+	"BE_Friends_Management/internal/keyring"
+	"BE_Friends_Management/internal/realtime"
 	service "BE_Friends_Management/internal/service"
 )
 
 type Handlers struct {
-	User                *UserHandler
-	Friendship          *FriendshipHandler
-	Subscription        *SubscriptionHandler
-	BlockRelationship   *BlockRelationshipHandler
-	NotificationHandler *NotificationHandler
-	AuthHandler         *AuthHandler
+	User                          *UserHandler
+	Friendship                    *FriendshipHandler
+	FriendRequest                 *FriendRequestHandler
+	Subscription                  *SubscriptionHandler
+	BlockRelationship             *BlockRelationshipHandler
+	Mute                          *MuteHandler
+	NotificationHandler           *NotificationHandler
+	StreamHandler                 *StreamHandler
+	WebSocketHandler              *WebSocketHandler
+	WebhookHandler                *WebhookHandler
+	DigestHandler                 *DigestHandler
+	NotificationPreferenceHandler *NotificationPreferenceHandler
+	AuthHandler                   *AuthHandler
+	JwksHandler                   *JwksHandler
 }
 
-func NewHandlers(services *service.Service) *Handlers {
+func NewHandlers(services *service.Service, hub *realtime.Hub, ring *keyring.KeyRing) *Handlers {
 	return &Handlers{
-		User:                NewUserHandler(services.User),
-		Friendship:          NewFriendshipHandler(services.Friendship),
-		Subscription:        NewSubscriptionHandler(services.Subscription),
-		BlockRelationship:   NewBlockRelationshipHandler(services.BlockRelationship),
-		NotificationHandler: NewNotificationHandler(services.Notification),
-		AuthHandler:         NewAuthHandler(services.Auth),
+		User:                          NewUserHandler(services.User),
+		Friendship:                    NewFriendshipHandler(services.Friendship),
+		FriendRequest:                 NewFriendRequestHandler(services.FriendRequest),
+		Subscription:                  NewSubscriptionHandler(services.Subscription),
+		BlockRelationship:             NewBlockRelationshipHandler(services.BlockRelationship),
+		Mute:                          NewMuteHandler(services.Mute),
+		NotificationHandler:           NewNotificationHandler(services.Notification),
+		StreamHandler:                 NewStreamHandler(services.Notification, hub),
+		WebSocketHandler:              NewWebSocketHandler(services.Notification, hub),
+		WebhookHandler:                NewWebhookHandler(services.Webhook),
+		DigestHandler:                 NewDigestHandler(services.Digest),
+		NotificationPreferenceHandler: NewNotificationPreferenceHandler(services.NotificationPreference),
+		AuthHandler:                   NewAuthHandler(services.Auth),
+		JwksHandler:                   NewJwksHandler(ring),
 	}
 }
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"BE_Friends_Management/internal/keyring"
+	"BE_Friends_Management/pkg/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JwksHandler struct {
+	ring *keyring.KeyRing
+}
+
+func NewJwksHandler(ring *keyring.KeyRing) *JwksHandler {
+	return &JwksHandler{ring: ring}
+}
+
+// Jwks godoc
+// @Summary      Get the public keys of access tokens
+// @Description  JSON Web Key Set other services verify access tokens with, matching the kid header of a token to the kid of a key. Keys stay listed while tokens they signed may still be valid. Empty when tokens are signed with a shared secret.
+// @Tags         Auth
+// @Produce      json
+// @Router       /.well-known/jwks.json [GET]
+// @Success      200   {object}  dto.JwksResponse
+func (h *JwksHandler) GetJwks(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, utils.ConvertKeyRingToJwks(h.ring))
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/dto"
+	service "BE_Friends_Management/internal/service/mute"
+	"BE_Friends_Management/pkg"
+	"BE_Friends_Management/pkg/utils"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+type MuteHandler struct {
+	service service.MuteService
+}
+
+func NewMuteHandler(service service.MuteService) *MuteHandler {
+	return &MuteHandler{service: service}
+}
+
+// Mute godoc
+// @Summary      Mute a user
+// @Description  Silently stop receiving the target's updates, for a duration (e.g. 30m, 8h) or until unmuted. Friendships and subscriptions are left alone and the target is not told. Muting again replaces the expiry.
+// @Tags         Mute
+// @Accept 		json
+// @Produce      json
+// @Param 		 request body dto.CreateMuteRequest true "Requestor's email, target's email and optional duration"
+// @param Authorization header string true "Authorization"
+// @Router       /api/mute [POST]
+// @Success      200   {object}  dto.ApiResponseSuccessNoData
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *MuteHandler) CreateMute(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	authUserRole := utils.GetAuthUserRole(c)
+	var request dto.CreateMuteRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error("Happened error when mapping request. Error: ", err)
+		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
+	}
+	_, err := h.service.CreateMute(authUserId, authUserRole, request.Requestor, request.Target, request.Duration)
+	if err != nil {
+		log.Error("Happened error when muting user. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrInvalidRequest):
+			pkg.PanicExeption(constant.InvalidRequest, err.Error())
+		case errors.Is(err, service.ErrInvalidDuration):
+			pkg.PanicExeption(constant.InvalidRequest, err.Error())
+		case errors.Is(err, service.ErrUserNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		case errors.Is(err, service.ErrNotPermitted):
+			pkg.PanicExeption(constant.StatusForbidden, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when muting user.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessNoData())
+}
+
+// Mute godoc
+// @Summary      Unmute a user
+// @Description  Lift a mute. Only the requestor of the mute or an admin can lift it.
+// @Tags         Mute
+// @Accept 		json
+// @Produce      json
+// @Param 		 request body dto.DeleteMuteRequest true "Requestor's email and target's email"
+// @param Authorization header string true "Authorization"
+// @Router       /api/mute [DELETE]
+// @Success      200   {object}  dto.ApiResponseSuccessNoData
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *MuteHandler) DeleteMute(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	authUserRole := utils.GetAuthUserRole(c)
+	var request dto.DeleteMuteRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error("Happened error when mapping request. Error: ", err)
+		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
+	}
+	err := h.service.DeleteMute(authUserId, authUserRole, request.Requestor, request.Target)
+	if err != nil {
+		log.Error("Happened error when unmuting user. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrInvalidRequest):
+			pkg.PanicExeption(constant.InvalidRequest, err.Error())
+		case errors.Is(err, service.ErrUserNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		case errors.Is(err, service.ErrNotMuted):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		case errors.Is(err, service.ErrNotPermitted):
+			pkg.PanicExeption(constant.StatusForbidden, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when unmuting user.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessNoData())
+}
+
+// Mute godoc
+// @Summary      Retrieve muted users
+// @Description  Retrieve the users currently muted by an email address, newest first. Expired mutes are left out.
+// @Tags         Mute
+// @Accept 		json
+// @Produce      json
+// @Param 		 email query string true "Email address"
+// @Param 		 limit query int false "Page size"
+// @Param 		 offset query int false "Number of mutes to skip"
+// @param Authorization header string true "Authorization"
+// @Router       /api/mute [GET]
+// @Success      200   {object}  dto.ApiResponseSuccessWithMutedUsers
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *MuteHandler) RetrieveMutedUsers(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	authUserRole := utils.GetAuthUserRole(c)
+	requestEmail := c.Query("email")
+	if requestEmail == "" {
+		log.Error("Happened error when mapping request. Error: received no email input.")
+		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
+		return
+	}
+	limit, offset := utils.GetPaginationParams(c)
+	mutes, count, err := h.service.RetrieveMutedUsers(authUserId, authUserRole, requestEmail, limit, offset)
+	if err != nil {
+		log.Error("Happened error when retrieving muted users. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		case errors.Is(err, service.ErrNotPermitted):
+			pkg.PanicExeption(constant.StatusForbidden, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when retrieving muted users.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithMutedUsers(utils.ConvertMutesToMutedUsers(mutes), count))
+}
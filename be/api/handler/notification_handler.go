@@ -8,6 +8,7 @@ import (
 	"BE_Friends_Management/pkg/utils"
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
@@ -23,14 +24,16 @@ func NewNotificationHandler(service service.NotificationService) *NotificationHa
 
 // Notification godoc
 // @Summary      Get update recipients
-// @Description  Get all email addresses that can receive updates from an email address.
+// @Description  Get the users an update from an email address would notify now, according to their notification preferences. Every recipient still gets the update in their feed.
 // @Tags         Notification
 // @Accept 		json
 // @Produce      json
 // @Param 		 request body dto.GetUpdateRecipientsRequest true "Sender email and update text"
+// @Param 		 limit query int false "Page size"
+// @Param 		 after query string false "Cursor returned as next_cursor by the previous page"
 // @param Authorization header string true "Authorization"
 // @Router       /api/update-recipients [POST]
-// @Success      200   {object}  dto.ApiResponseSuccessNoData
+// @Success      200   {object}  dto.ApiResponseSuccessWithRecipients
 // @securityDefinitions.apiKey token
 // @in header
 // @name Authorization
@@ -44,7 +47,8 @@ func (h *NotificationHandler) GetUpdateRecipients(c *gin.Context) {
 		log.Error("Happened error when mapping request. Error: ", err)
 		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
 	}
-	recipients, err := h.service.GetUpdateRecipients(authUserId, authUserRole, request.Sender, request.Text)
+	limit, afterId := utils.GetCursorPaginationParams(c)
+	recipients, count, err := h.service.GetUpdateRecipients(authUserId, authUserRole, request.Sender, request.Text, afterId, limit)
 	if err != nil {
 		log.Error("Happened error when getting recipients. Error: ", err)
 		switch {
@@ -56,6 +60,143 @@ func (h *NotificationHandler) GetUpdateRecipients(c *gin.Context) {
 			pkg.PanicExeption(constant.UnknownError, "Happened error when getting recipients.")
 		}
 	}
+	var lastId int64
+	if len(recipients) > 0 {
+		lastId = recipients[len(recipients)-1].Id
+	}
 	recipientEmails := utils.ConvertUsersToEmails(recipients)
-	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithRecipients(recipientEmails))
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithRecipients(recipientEmails, utils.BuildNextCursor(lastId, len(recipients), limit), count))
+}
+
+// Notification godoc
+// @Summary      Publish update
+// @Description  Publish an update and deliver it to the recipients computed at publish time.
+// @Tags         Notification
+// @Accept 		json
+// @Produce      json
+// @Param 		 request body dto.PublishUpdateRequest true "Sender email and update text"
+// @param Authorization header string true "Authorization"
+// @Router       /api/updates [POST]
+// @Success      200   {object}  dto.ApiResponseSuccessWithUpdate
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *NotificationHandler) PublishUpdate(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	authUserRole := utils.GetAuthUserRole(c)
+	var request dto.PublishUpdateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error("Happened error when mapping request. Error: ", err)
+		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
+	}
+	update, recipientCount, err := h.service.PublishUpdate(authUserId, authUserRole, request.Sender, request.Text)
+	if err != nil {
+		log.Error("Happened error when publishing update. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		case errors.Is(err, service.ErrNotPermitted):
+			pkg.PanicExeption(constant.StatusForbidden, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when publishing update.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithUpdate(utils.ConvertUpdateToResponse(update, recipientCount)))
+}
+
+// Notification godoc
+// @Summary      Get feed
+// @Description  Get the updates delivered to the authenticated user, newest first, with read flags.
+// @Tags         Notification
+// @Produce      json
+// @Param 		 limit query int false "Page size"
+// @Param 		 after query string false "Cursor returned as next_cursor by the previous page"
+// @param Authorization header string true "Authorization"
+// @Router       /api/feed [GET]
+// @Success      200   {object}  dto.ApiResponseSuccessWithFeed
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *NotificationHandler) GetFeed(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	limit, beforeId := utils.GetCursorPaginationParams(c)
+	deliveries, count, unreadCount, err := h.service.GetFeed(authUserId, beforeId, limit)
+	if err != nil {
+		log.Error("Happened error when getting feed. Error: ", err)
+		pkg.PanicExeption(constant.UnknownError, "Happened error when getting feed.")
+	}
+	var lastId int64
+	if len(deliveries) > 0 {
+		lastId = deliveries[len(deliveries)-1].Id
+	}
+	items := utils.ConvertDeliveriesToFeedItems(deliveries)
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithFeed(items, utils.BuildNextCursor(lastId, len(deliveries), limit), count, unreadCount))
+}
+
+// Notification godoc
+// @Summary      Mark feed item as read
+// @Description  Mark an update of the authenticated user's feed as read.
+// @Tags         Notification
+// @Produce      json
+// @Param        id   path      int  true  "Feed item ID"
+// @param Authorization header string true "Authorization"
+// @Router       /api/feed/{id}/read [POST]
+// @Success      200   {object}  dto.ApiResponseSuccessNoData
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *NotificationHandler) MarkFeedItemRead(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	deliveryId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error("Happened error when converting feed item ID to int64. Error: ", err)
+		pkg.PanicExeption(constant.InvalidRequest, "Happened error when converting feed item ID to int64")
+	}
+	err = h.service.MarkFeedItemRead(authUserId, deliveryId)
+	if err != nil {
+		log.Error("Happened error when marking feed item as read. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrFeedItemNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when marking feed item as read.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessNoData())
+}
+
+// Notification godoc
+// @Summary      Get mentions
+// @Description  Get the updates that mentioned the authenticated user by @handle or email, newest first.
+// @Tags         Notification
+// @Produce      json
+// @Param 		 limit query int false "Page size"
+// @Param 		 after query string false "Cursor returned as next_cursor by the previous page"
+// @param Authorization header string true "Authorization"
+// @Router       /api/me/mentions [GET]
+// @Success      200   {object}  dto.ApiResponseSuccessWithMentions
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *NotificationHandler) GetMentions(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	limit, beforeId := utils.GetCursorPaginationParams(c)
+	mentions, count, err := h.service.GetMentions(authUserId, beforeId, limit)
+	if err != nil {
+		log.Error("Happened error when getting mentions. Error: ", err)
+		pkg.PanicExeption(constant.UnknownError, "Happened error when getting mentions.")
+	}
+	var lastId int64
+	if len(mentions) > 0 {
+		lastId = mentions[len(mentions)-1].Id
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithMentions(utils.ConvertMentionsToResponses(mentions), utils.BuildNextCursor(lastId, len(mentions), limit), count))
 }
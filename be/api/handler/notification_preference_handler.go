@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/dto"
+	service "BE_Friends_Management/internal/service/notification_preference"
+	"BE_Friends_Management/pkg"
+	"BE_Friends_Management/pkg/utils"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+type NotificationPreferenceHandler struct {
+	service service.NotificationPreferenceService
+}
+
+func NewNotificationPreferenceHandler(service service.NotificationPreferenceService) *NotificationPreferenceHandler {
+	return &NotificationPreferenceHandler{service: service}
+}
+
+// NotificationPreference godoc
+// @Summary      Get notification preferences
+// @Description  Channels, event types and quiet hours the authenticated user is notified with
+// @Tags         Notification preferences
+// @Accept       json
+// @Produce      json
+// @param Authorization header string true "Authorization"
+// @Router       /api/me/notification-preferences [GET]
+// @Success      200   {object}  dto.ApiResponseSuccessWithNotificationPreference
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *NotificationPreferenceHandler) GetNotificationPreference(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	preference, err := h.service.GetPreference(authUserId)
+	if err != nil {
+		log.Error("Happened error when getting notification preferences. Error: ", err)
+		pkg.PanicExeption(constant.UnknownError, "Happened error when getting notification preferences.")
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithNotificationPreference(utils.ConvertNotificationPreferenceToResponse(preference)))
+}
+
+// NotificationPreference godoc
+// @Summary      Update notification preferences
+// @Description  Replace the channels (in_app, email, webhook), event types (friend_update, subscription_update, mention, friend_request) and quiet hours the authenticated user is notified with
+// @Tags         Notification preferences
+// @Accept       json
+// @Produce      json
+// @Param 		 request body dto.UpdateNotificationPreferenceRequest true "Channels, event types and optional quiet hours"
+// @param Authorization header string true "Authorization"
+// @Router       /api/me/notification-preferences [PUT]
+// @Success      200   {object}  dto.ApiResponseSuccessWithNotificationPreference
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *NotificationPreferenceHandler) UpdateNotificationPreference(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	var request dto.UpdateNotificationPreferenceRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error("Happened error when mapping request. Error: ", err)
+		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
+	}
+	preference, err := h.service.UpdatePreference(authUserId, utils.ConvertNotificationPreferenceRequestToEntity(request))
+	if err != nil {
+		log.Error("Happened error when updating notification preferences. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrInvalidChannel):
+			pkg.PanicExeption(constant.InvalidRequest, err.Error())
+		case errors.Is(err, service.ErrInvalidEventType):
+			pkg.PanicExeption(constant.InvalidRequest, err.Error())
+		case errors.Is(err, service.ErrInvalidQuietHours):
+			pkg.PanicExeption(constant.InvalidRequest, err.Error())
+		case errors.Is(err, service.ErrInvalidTimezone):
+			pkg.PanicExeption(constant.InvalidRequest, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when updating notification preferences.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithNotificationPreference(utils.ConvertNotificationPreferenceToResponse(preference)))
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/entity"
+	"BE_Friends_Management/internal/realtime"
+	service "BE_Friends_Management/internal/service/notification"
+	"BE_Friends_Management/pkg"
+	"BE_Friends_Management/pkg/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+type StreamHandler struct {
+	service service.NotificationService
+	hub     *realtime.Hub
+}
+
+func NewStreamHandler(service service.NotificationService, hub *realtime.Hub) *StreamHandler {
+	return &StreamHandler{service: service, hub: hub}
+}
+
+// Stream godoc
+// @Summary      Stream updates
+// @Description  Server-Sent Events stream of updates delivered to the authenticated user. Each event id is a feed item id; reconnecting with Last-Event-ID replays the missed items.
+// @Tags         Notification
+// @Produce      text/event-stream
+// @Param        Last-Event-ID header string false "Id of the last event received"
+// @param Authorization header string true "Authorization"
+// @Router       /api/stream [GET]
+// @Success      200
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *StreamHandler) Stream(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	var lastEventId int64 = 0
+	if rawLastEventId := c.GetHeader("Last-Event-ID"); rawLastEventId != "" {
+		parsedLastEventId, err := strconv.ParseInt(rawLastEventId, 10, 64)
+		if err != nil || parsedLastEventId < 0 {
+			log.Error("Happened error when mapping request. Error: invalid Last-Event-ID ", rawLastEventId)
+			pkg.PanicExeption(constant.InvalidRequest, "Invalid Last-Event-ID.")
+		}
+		lastEventId = parsedLastEventId
+	}
+
+	// Subscribe before replaying so nothing published in between is lost;
+	// live events already covered by the replay are skipped by id.
+	subscription := h.hub.Subscribe(authUserId)
+	defer h.hub.Unsubscribe(subscription)
+
+	var missed []*entity.UpdateDelivery
+	if lastEventId > 0 {
+		var err error
+		missed, err = h.service.GetMissedFeed(authUserId, lastEventId, constant.StreamReplayLimit)
+		if err != nil {
+			log.Error("Happened error when replaying missed updates. Error: ", err)
+			pkg.PanicExeption(constant.UnknownError, "Happened error when replaying missed updates.")
+		}
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	sse.Event{}.WriteContentType(c.Writer)
+	c.Writer.Flush()
+
+	// The replay is read a page at a time until a short page shows it caught
+	// up; live events are only sent past the last replayed id, so stopping
+	// after the first page would lose the rest of the backlog.
+	for {
+		for _, delivery := range missed {
+			h.writeEvent(c, realtime.Event{Id: delivery.Id, Type: realtime.EventUpdate, Payload: delivery})
+			lastEventId = delivery.Id
+		}
+		if len(missed) < constant.StreamReplayLimit || c.Request.Context().Err() != nil {
+			break
+		}
+		var err error
+		missed, err = h.service.GetMissedFeed(authUserId, lastEventId, constant.StreamReplayLimit)
+		if err != nil {
+			// The response has started, so the stream is closed instead; the
+			// client reconnects with the last id it received.
+			log.Error("Happened error when replaying missed updates. Error: ", err)
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(constant.StreamHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-subscription.Events:
+			if !ok {
+				log.Info("Closing stream of user ", authUserId)
+				return
+			}
+			// Event ids of the stream are feed item ids, so only updates are
+			// sent here; relationship events go through the WebSocket.
+			if event.Type != realtime.EventUpdate || event.Id <= lastEventId {
+				continue
+			}
+			h.writeEvent(c, event)
+			lastEventId = event.Id
+		case <-heartbeat.C:
+			if _, err := c.Writer.WriteString(": heartbeat\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+func (h *StreamHandler) writeEvent(c *gin.Context, event realtime.Event) {
+	c.Render(-1, sse.Event{
+		Id:    strconv.FormatInt(event.Id, 10),
+		Event: event.Type,
+		Data:  utils.ConvertRealtimeEventData(event),
+	})
+	c.Writer.Flush()
+}
@@ -63,3 +63,125 @@ func (h *SubscriptionHandler) CreateSubscription(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, pkg.BuildResponseSuccessNoData())
 }
+
+// Subscription godoc
+// @Summary      Delete subscription
+// @Description  Unsubscribe requestor from target's updates
+// @Tags         Subscription
+// @Accept 		json
+// @Produce      json
+// @Param 		 request body dto.DeleteSubscriptionRequest true "Requestor's email and target's email"
+// @param Authorization header string true "Authorization"
+// @Router       /api/subscription [DELETE]
+// @Success      200   {object}  dto.ApiResponseSuccessNoData
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *SubscriptionHandler) DeleteSubscription(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	authUserRole := utils.GetAuthUserRole(c)
+	var request dto.DeleteSubscriptionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error("Happened error when mapping request. Error: ", err)
+		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
+	}
+	err := h.service.DeleteSubscription(authUserId, authUserRole, request.Requestor, request.Target)
+	if err != nil {
+		log.Error("Happened error when deleting subscription. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrInvalidRequest):
+			pkg.PanicExeption(constant.InvalidRequest, err.Error())
+		case errors.Is(err, service.ErrUserNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		case errors.Is(err, service.ErrNotSubscribed):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		case errors.Is(err, service.ErrNotPermitted):
+			pkg.PanicExeption(constant.StatusForbidden, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when deleting subscription.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessNoData())
+}
+
+// Subscription godoc
+// @Summary      Retrieve following list
+// @Description  Retrieve the email addresses an email address has subscribed to
+// @Tags         Subscription
+// @Accept 		json
+// @Produce      json
+// @Param 		 email query string true "Email address"
+// @param Authorization header string true "Authorization"
+// @Router       /api/subscription/following [GET]
+// @Success      200   {object}  dto.ApiResponseSuccessWithSubscriptionList
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *SubscriptionHandler) RetrieveFollowing(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	authUserRole := utils.GetAuthUserRole(c)
+	requestEmail := c.Query("email")
+	if requestEmail == "" {
+		log.Error("Happened error when mapping request. Error: received no email input.")
+		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
+		return
+	}
+	following, err := h.service.RetrieveFollowing(authUserId, authUserRole, requestEmail)
+	if err != nil {
+		log.Error("Happened error when retrieving following list. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		case errors.Is(err, service.ErrNotPermitted):
+			pkg.PanicExeption(constant.StatusForbidden, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when retrieving following list.")
+		}
+	}
+	emails := utils.ConvertUsersToEmails(following)
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithSubscriptionList(emails))
+}
+
+// Subscription godoc
+// @Summary      Retrieve followers list
+// @Description  Retrieve the email addresses subscribed to an email address
+// @Tags         Subscription
+// @Accept 		json
+// @Produce      json
+// @Param 		 email query string true "Email address"
+// @param Authorization header string true "Authorization"
+// @Router       /api/subscription/followers [GET]
+// @Success      200   {object}  dto.ApiResponseSuccessWithSubscriptionList
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *SubscriptionHandler) RetrieveFollowers(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	authUserRole := utils.GetAuthUserRole(c)
+	requestEmail := c.Query("email")
+	if requestEmail == "" {
+		log.Error("Happened error when mapping request. Error: received no email input.")
+		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
+		return
+	}
+	followers, err := h.service.RetrieveFollowers(authUserId, authUserRole, requestEmail)
+	if err != nil {
+		log.Error("Happened error when retrieving followers list. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		case errors.Is(err, service.ErrNotPermitted):
+			pkg.PanicExeption(constant.StatusForbidden, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when retrieving followers list.")
+		}
+	}
+	emails := utils.ConvertUsersToEmails(followers)
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithSubscriptionList(emails))
+}
@@ -3,8 +3,10 @@ package handler
 import (
 	"BE_Friends_Management/api/handler"
 	"BE_Friends_Management/internal/domain/dto"
+	"BE_Friends_Management/internal/domain/entity"
 	service "BE_Friends_Management/internal/service/block_relationship"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -20,11 +22,30 @@ type MockBlockRelationshipService struct {
 	mock.Mock
 }
 
-func (m *MockBlockRelationshipService) CreateBlockRelationship(authUserId int64, requestor, target string) error {
-	args := m.Called(authUserId, requestor, target)
+func (m *MockBlockRelationshipService) CreateBlockRelationship(authUserId int64, requestor, target, duration string) error {
+	args := m.Called(authUserId, requestor, target, duration)
 	return args.Error(0)
 }
 
+func (m *MockBlockRelationshipService) DeleteBlockRelationship(authUserId int64, authUserRole string, requestor, target string) error {
+	args := m.Called(authUserId, authUserRole, requestor, target)
+	return args.Error(0)
+}
+
+func (m *MockBlockRelationshipService) RetrieveBlockedUsers(authUserId int64, authUserRole string, email string, limit, offset int) ([]*entity.BlockRelationship, int64, error) {
+	args := m.Called(authUserId, authUserRole, email, limit, offset)
+	return args.Get(0).([]*entity.BlockRelationship), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockBlockRelationshipService) SweepExpiredBlocks(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBlockRelationshipService) Run(ctx context.Context) {
+	m.Called(ctx)
+}
+
 func TestCreateBlockRelationship(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -46,7 +67,7 @@ func TestCreateBlockRelationship(t *testing.T) {
 			serviceError:   nil,
 			expectedStatus: http.StatusOK,
 			setupMock: func(m *MockBlockRelationshipService) {
-				m.On("CreateBlockRelationship", int64(1), "user1@example.com", "user2@example.com").Return(nil)
+				m.On("CreateBlockRelationship", int64(1), "user1@example.com", "user2@example.com", "").Return(nil)
 			},
 		},
 		{
@@ -69,7 +90,21 @@ func TestCreateBlockRelationship(t *testing.T) {
 			serviceError:   service.ErrInvalidRequest,
 			expectedStatus: http.StatusBadRequest,
 			setupMock: func(m *MockBlockRelationshipService) {
-				m.On("CreateBlockRelationship", int64(1), "user1@example.com", "user2@example.com").Return(service.ErrInvalidRequest)
+				m.On("CreateBlockRelationship", int64(1), "user1@example.com", "user2@example.com", "").Return(service.ErrInvalidRequest)
+			},
+		},
+		{
+			name:       "Service Error - Invalid Duration",
+			authUserId: 1,
+			requestBody: dto.CreateBlockRequest{
+				Requestor: "user1@example.com",
+				Target:    "user2@example.com",
+				Duration:  "forever",
+			},
+			serviceError:   service.ErrInvalidDuration,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(m *MockBlockRelationshipService) {
+				m.On("CreateBlockRelationship", int64(1), "user1@example.com", "user2@example.com", "forever").Return(service.ErrInvalidDuration)
 			},
 		},
 		{
@@ -82,7 +117,7 @@ func TestCreateBlockRelationship(t *testing.T) {
 			serviceError:   service.ErrUserNotFound,
 			expectedStatus: http.StatusNotFound,
 			setupMock: func(m *MockBlockRelationshipService) {
-				m.On("CreateBlockRelationship", int64(1), "user1@example.com", "user2@example.com").Return(service.ErrUserNotFound)
+				m.On("CreateBlockRelationship", int64(1), "user1@example.com", "user2@example.com", "").Return(service.ErrUserNotFound)
 			},
 		},
 		{
@@ -95,7 +130,7 @@ func TestCreateBlockRelationship(t *testing.T) {
 			serviceError:   service.ErrAlreadyBlocked,
 			expectedStatus: http.StatusConflict,
 			setupMock: func(m *MockBlockRelationshipService) {
-				m.On("CreateBlockRelationship", int64(1), "user1@example.com", "user2@example.com").Return(service.ErrAlreadyBlocked)
+				m.On("CreateBlockRelationship", int64(1), "user1@example.com", "user2@example.com", "").Return(service.ErrAlreadyBlocked)
 			},
 		},
 		{
@@ -108,7 +143,7 @@ func TestCreateBlockRelationship(t *testing.T) {
 			serviceError:   service.ErrNotSubscribed,
 			expectedStatus: http.StatusForbidden,
 			setupMock: func(m *MockBlockRelationshipService) {
-				m.On("CreateBlockRelationship", int64(1), "user1@example.com", "user2@example.com").Return(service.ErrNotSubscribed)
+				m.On("CreateBlockRelationship", int64(1), "user1@example.com", "user2@example.com", "").Return(service.ErrNotSubscribed)
 			},
 		},
 		{
@@ -121,7 +156,7 @@ func TestCreateBlockRelationship(t *testing.T) {
 			serviceError:   errors.New("unknown error"),
 			expectedStatus: http.StatusInternalServerError,
 			setupMock: func(m *MockBlockRelationshipService) {
-				m.On("CreateBlockRelationship", int64(1), "user1@example.com", "user2@example.com").Return(errors.New("unknown error"))
+				m.On("CreateBlockRelationship", int64(1), "user1@example.com", "user2@example.com", "").Return(errors.New("unknown error"))
 			},
 		},
 	}
@@ -155,3 +190,153 @@ func TestCreateBlockRelationship(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteBlockRelationship(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		expectedStatus int
+		setupMock      func(*MockBlockRelationshipService)
+	}{
+		{
+			name: "Success",
+			requestBody: dto.DeleteBlockRequest{
+				Requestor: "user1@example.com",
+				Target:    "user2@example.com",
+			},
+			expectedStatus: http.StatusOK,
+			setupMock: func(m *MockBlockRelationshipService) {
+				m.On("DeleteBlockRelationship", int64(1), "user", "user1@example.com", "user2@example.com").Return(nil)
+			},
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(m *MockBlockRelationshipService) {},
+		},
+		{
+			name: "Service Error - Not Blocked",
+			requestBody: dto.DeleteBlockRequest{
+				Requestor: "user1@example.com",
+				Target:    "user2@example.com",
+			},
+			expectedStatus: http.StatusNotFound,
+			setupMock: func(m *MockBlockRelationshipService) {
+				m.On("DeleteBlockRelationship", int64(1), "user", "user1@example.com", "user2@example.com").Return(service.ErrNotBlocked)
+			},
+		},
+		{
+			name: "Service Error - Not Permitted",
+			requestBody: dto.DeleteBlockRequest{
+				Requestor: "user1@example.com",
+				Target:    "user2@example.com",
+			},
+			expectedStatus: http.StatusForbidden,
+			setupMock: func(m *MockBlockRelationshipService) {
+				m.On("DeleteBlockRelationship", int64(1), "user", "user1@example.com", "user2@example.com").Return(service.ErrNotPermitted)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockBlockRelationshipService)
+			tt.setupMock(mockService)
+
+			handler := handler.NewBlockRelationshipHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			var jsonBody []byte
+			var err error
+			if tt.requestBody != "invalid json" {
+				jsonBody, err = json.Marshal(tt.requestBody)
+				assert.NoError(t, err)
+			} else {
+				jsonBody = []byte("invalid json")
+			}
+
+			c.Request, _ = http.NewRequest("DELETE", "/api/block", bytes.NewBuffer(jsonBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("authUserId", 1)
+			c.Set("authUserRole", "user")
+			handler.DeleteBlockRelationship(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRetrieveBlockedUsers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		setupMock      func(*MockBlockRelationshipService)
+	}{
+		{
+			name:           "Success with default pagination",
+			query:          "email=user1@example.com",
+			expectedStatus: http.StatusOK,
+			setupMock: func(m *MockBlockRelationshipService) {
+				blocks := []*entity.BlockRelationship{{RequestorId: 1, TargetId: 2, Target: &entity.User{Email: "user2@example.com"}}}
+				m.On("RetrieveBlockedUsers", int64(1), "user", "user1@example.com", 20, 0).Return(blocks, int64(1), nil)
+			},
+		},
+		{
+			name:           "Success with limit capped",
+			query:          "email=user1@example.com&limit=1000&offset=5",
+			expectedStatus: http.StatusOK,
+			setupMock: func(m *MockBlockRelationshipService) {
+				m.On("RetrieveBlockedUsers", int64(1), "user", "user1@example.com", 100, 5).Return([]*entity.BlockRelationship{}, int64(5), nil)
+			},
+		},
+		{
+			name:           "Missing email",
+			query:          "",
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(m *MockBlockRelationshipService) {},
+		},
+		{
+			name:           "Invalid limit",
+			query:          "email=user1@example.com&limit=abc",
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(m *MockBlockRelationshipService) {},
+		},
+		{
+			name:           "Service Error - Not Permitted",
+			query:          "email=user1@example.com",
+			expectedStatus: http.StatusForbidden,
+			setupMock: func(m *MockBlockRelationshipService) {
+				m.On("RetrieveBlockedUsers", int64(1), "user", "user1@example.com", 20, 0).Return([]*entity.BlockRelationship{}, int64(0), service.ErrNotPermitted)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockBlockRelationshipService)
+			tt.setupMock(mockService)
+
+			handler := handler.NewBlockRelationshipHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Request, _ = http.NewRequest("GET", "/api/block?"+tt.query, nil)
+			c.Set("authUserId", 1)
+			c.Set("authUserRole", "user")
+			handler.RetrieveBlockedUsers(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
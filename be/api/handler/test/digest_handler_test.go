@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"BE_Friends_Management/api/handler"
+	"BE_Friends_Management/internal/domain/dto"
+	"BE_Friends_Management/internal/domain/entity"
+	service "BE_Friends_Management/internal/service/digest"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDigestService struct {
+	mock.Mock
+}
+
+func (m *MockDigestService) GetPreference(authUserId int64) (*entity.DigestPreference, error) {
+	args := m.Called(authUserId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.DigestPreference), args.Error(1)
+}
+
+func (m *MockDigestService) SetFrequency(authUserId int64, frequency string) (*entity.DigestPreference, error) {
+	args := m.Called(authUserId, frequency)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.DigestPreference), args.Error(1)
+}
+
+func (m *MockDigestService) SendDueDigests(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockDigestService) Run(ctx context.Context) {
+	m.Called(ctx)
+}
+
+func TestGetDigestPreference(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name              string
+		expectedStatus    int
+		expectedFrequency string
+		setupMock         func(*MockDigestService)
+	}{
+		{
+			name:              "Success",
+			expectedStatus:    http.StatusOK,
+			expectedFrequency: entity.DigestWeekly,
+			setupMock: func(m *MockDigestService) {
+				m.On("GetPreference", int64(1)).Return(&entity.DigestPreference{UserId: 1, Frequency: entity.DigestWeekly}, nil)
+			},
+		},
+		{
+			name:           "Unknown Error",
+			expectedStatus: http.StatusInternalServerError,
+			setupMock: func(m *MockDigestService) {
+				m.On("GetPreference", int64(1)).Return(nil, errors.New("database error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockDigestService)
+			tt.setupMock(mockService)
+
+			handler := handler.NewDigestHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/me/digest", nil)
+			c.Set("authUserId", 1)
+			handler.GetDigestPreference(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response dto.ApiResponseSuccessWithDigestPreference
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedFrequency, response.DigestPreference.Frequency)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateDigestPreference(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		expectedStatus int
+		setupMock      func(*MockDigestService)
+	}{
+		{
+			name:           "Success",
+			requestBody:    dto.UpdateDigestPreferenceRequest{Frequency: entity.DigestDaily},
+			expectedStatus: http.StatusOK,
+			setupMock: func(m *MockDigestService) {
+				m.On("SetFrequency", int64(1), entity.DigestDaily).Return(&entity.DigestPreference{UserId: 1, Frequency: entity.DigestDaily}, nil)
+			},
+		},
+		{
+			name:           "Missing Frequency",
+			requestBody:    dto.UpdateDigestPreferenceRequest{},
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(m *MockDigestService) {},
+		},
+		{
+			name:           "Invalid Frequency",
+			requestBody:    dto.UpdateDigestPreferenceRequest{Frequency: "hourly"},
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(m *MockDigestService) {
+				m.On("SetFrequency", int64(1), "hourly").Return(nil, service.ErrInvalidFrequency)
+			},
+		},
+		{
+			name:           "Unknown Error",
+			requestBody:    dto.UpdateDigestPreferenceRequest{Frequency: entity.DigestDaily},
+			expectedStatus: http.StatusInternalServerError,
+			setupMock: func(m *MockDigestService) {
+				m.On("SetFrequency", int64(1), entity.DigestDaily).Return(nil, errors.New("database error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockDigestService)
+			tt.setupMock(mockService)
+
+			handler := handler.NewDigestHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			reqBody, _ := json.Marshal(tt.requestBody)
+			c.Request = httptest.NewRequest(http.MethodPut, "/api/me/digest", bytes.NewBuffer(reqBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("authUserId", 1)
+			handler.UpdateDigestPreference(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package handler
+
+import (
+	"BE_Friends_Management/api/handler"
+	"BE_Friends_Management/internal/domain/dto"
+	"BE_Friends_Management/internal/domain/entity"
+	service "BE_Friends_Management/internal/service/friend_request"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFriendRequestService struct {
+	mock.Mock
+}
+
+func (m *MockFriendRequestService) SendFriendRequest(authUserId int64, requestorEmail, targetEmail string) (*entity.FriendRequest, error) {
+	args := m.Called(authUserId, requestorEmail, targetEmail)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.FriendRequest), args.Error(1)
+}
+func (m *MockFriendRequestService) RetrieveIncomingFriendRequests(authUserId int64, authUserRole string, email string) ([]*entity.FriendRequest, error) {
+	args := m.Called(authUserId, authUserRole, email)
+	return args.Get(0).([]*entity.FriendRequest), args.Error(1)
+}
+func (m *MockFriendRequestService) RetrieveOutgoingFriendRequests(authUserId int64, authUserRole string, email string) ([]*entity.FriendRequest, error) {
+	args := m.Called(authUserId, authUserRole, email)
+	return args.Get(0).([]*entity.FriendRequest), args.Error(1)
+}
+func (m *MockFriendRequestService) AcceptFriendRequest(authUserId int64, requestId int64) error {
+	args := m.Called(authUserId, requestId)
+	return args.Error(0)
+}
+func (m *MockFriendRequestService) RejectFriendRequest(authUserId int64, requestId int64) error {
+	args := m.Called(authUserId, requestId)
+	return args.Error(0)
+}
+func (m *MockFriendRequestService) CancelFriendRequest(authUserId int64, requestId int64) error {
+	args := m.Called(authUserId, requestId)
+	return args.Error(0)
+}
+
+func TestFriendRequestHandler_SendFriendRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		expectedStatus int
+		setupMock      func(*MockFriendRequestService)
+	}{
+		{
+			name: "Success",
+			requestBody: dto.SendFriendRequestRequest{
+				Requestor: "andy@example.com",
+				Target:    "john@example.com",
+			},
+			expectedStatus: http.StatusOK,
+			setupMock: func(m *MockFriendRequestService) {
+				friendRequest := &entity.FriendRequest{
+					Id:        1,
+					Status:    entity.FriendRequestPending,
+					Requestor: &entity.User{Email: "andy@example.com"},
+					Target:    &entity.User{Email: "john@example.com"},
+				}
+				m.On("SendFriendRequest", int64(1), "andy@example.com", "john@example.com").Return(friendRequest, nil)
+			},
+		},
+		{
+			name:           "Invalid JSON request",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(m *MockFriendRequestService) {},
+		},
+		{
+			name: "Service returns ErrAlreadyRequested",
+			requestBody: dto.SendFriendRequestRequest{
+				Requestor: "andy@example.com",
+				Target:    "john@example.com",
+			},
+			expectedStatus: http.StatusConflict,
+			setupMock: func(m *MockFriendRequestService) {
+				m.On("SendFriendRequest", int64(1), "andy@example.com", "john@example.com").Return(nil, service.ErrAlreadyRequested)
+			},
+		},
+		{
+			name: "Service returns ErrIsBlocked",
+			requestBody: dto.SendFriendRequestRequest{
+				Requestor: "andy@example.com",
+				Target:    "john@example.com",
+			},
+			expectedStatus: http.StatusForbidden,
+			setupMock: func(m *MockFriendRequestService) {
+				m.On("SendFriendRequest", int64(1), "andy@example.com", "john@example.com").Return(nil, service.ErrIsBlocked)
+			},
+		},
+		{
+			name: "Service returns unknown error",
+			requestBody: dto.SendFriendRequestRequest{
+				Requestor: "andy@example.com",
+				Target:    "john@example.com",
+			},
+			expectedStatus: http.StatusInternalServerError,
+			setupMock: func(m *MockFriendRequestService) {
+				m.On("SendFriendRequest", int64(1), "andy@example.com", "john@example.com").Return(nil, assert.AnError)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockFriendRequestService)
+			tt.setupMock(mockService)
+
+			handler := handler.NewFriendRequestHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			var reqBody []byte
+			if str, ok := tt.requestBody.(string); ok {
+				reqBody = []byte(str)
+			} else {
+				reqBody, _ = json.Marshal(tt.requestBody)
+			}
+
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/friend-requests", bytes.NewBuffer(reqBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("authUserId", 1)
+			handler.SendFriendRequest(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFriendRequestHandler_RetrieveIncomingFriendRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		emailParam     string
+		expectedStatus int
+		expectedCount  int64
+		setupMock      func(*MockFriendRequestService)
+	}{
+		{
+			name:           "Success",
+			emailParam:     "john@example.com",
+			expectedStatus: http.StatusOK,
+			expectedCount:  1,
+			setupMock: func(m *MockFriendRequestService) {
+				friendRequests := []*entity.FriendRequest{{
+					Id:        1,
+					Status:    entity.FriendRequestPending,
+					Requestor: &entity.User{Email: "andy@example.com"},
+					Target:    &entity.User{Email: "john@example.com"},
+				}}
+				m.On("RetrieveIncomingFriendRequests", int64(1), "user", "john@example.com").Return(friendRequests, nil)
+			},
+		},
+		{
+			name:           "Missing email parameter",
+			emailParam:     "",
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(m *MockFriendRequestService) {},
+		},
+		{
+			name:           "Service returns ErrNotPermitted",
+			emailParam:     "john@example.com",
+			expectedStatus: http.StatusForbidden,
+			setupMock: func(m *MockFriendRequestService) {
+				m.On("RetrieveIncomingFriendRequests", int64(1), "user", "john@example.com").Return([]*entity.FriendRequest{}, service.ErrNotPermitted)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockFriendRequestService)
+			tt.setupMock(mockService)
+
+			handler := handler.NewFriendRequestHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/friend-requests/incoming", nil)
+			if tt.emailParam != "" {
+				q := req.URL.Query()
+				q.Add("email", tt.emailParam)
+				req.URL.RawQuery = q.Encode()
+			}
+			c.Request = req
+			c.Set("authUserId", 1)
+			c.Set("authUserRole", "user")
+			handler.RetrieveIncomingFriendRequests(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response dto.ApiResponseSuccessWithFriendRequests
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedCount, response.Count)
+				assert.Equal(t, "andy@example.com", response.FriendRequests[0].Requestor)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFriendRequestHandler_AcceptFriendRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		idParam        string
+		expectedStatus int
+		setupMock      func(*MockFriendRequestService)
+	}{
+		{
+			name:           "Success",
+			idParam:        "10",
+			expectedStatus: http.StatusOK,
+			setupMock: func(m *MockFriendRequestService) {
+				m.On("AcceptFriendRequest", int64(1), int64(10)).Return(nil)
+			},
+		},
+		{
+			name:           "Invalid ID",
+			idParam:        "abc",
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(m *MockFriendRequestService) {},
+		},
+		{
+			name:           "Service returns ErrFriendRequestNotFound",
+			idParam:        "10",
+			expectedStatus: http.StatusNotFound,
+			setupMock: func(m *MockFriendRequestService) {
+				m.On("AcceptFriendRequest", int64(1), int64(10)).Return(service.ErrFriendRequestNotFound)
+			},
+		},
+		{
+			name:           "Service returns ErrFriendRequestNotPending",
+			idParam:        "10",
+			expectedStatus: http.StatusConflict,
+			setupMock: func(m *MockFriendRequestService) {
+				m.On("AcceptFriendRequest", int64(1), int64(10)).Return(service.ErrFriendRequestNotPending)
+			},
+		},
+		{
+			name:           "Service returns ErrNotPermitted",
+			idParam:        "10",
+			expectedStatus: http.StatusForbidden,
+			setupMock: func(m *MockFriendRequestService) {
+				m.On("AcceptFriendRequest", int64(1), int64(10)).Return(service.ErrNotPermitted)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockFriendRequestService)
+			tt.setupMock(mockService)
+
+			handler := handler.NewFriendRequestHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/friend-requests/"+tt.idParam+"/accept", nil)
+			c.Params = gin.Params{{Key: "id", Value: tt.idParam}}
+			c.Set("authUserId", 1)
+			handler.AcceptFriendRequest(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"BE_Friends_Management/internal/domain/dto"
 	"BE_Friends_Management/internal/domain/entity"
 	service "BE_Friends_Management/internal/service/friendship"
+	"BE_Friends_Management/pkg/utils"
 	"bytes"
 	"encoding/json"
 	"net/http"
@@ -20,22 +21,34 @@ type MockFriendshipService struct {
 	mock.Mock
 }
 
-func (m *MockFriendshipService) CreateFriendship(authUserId int64, email1, email2 string) error {
-	args := m.Called(authUserId, email1, email2)
+func (m *MockFriendshipService) CreateFriendship(authUserId int64, authUserRole string, email1, email2 string) error {
+	args := m.Called(authUserId, authUserRole, email1, email2)
 	return args.Error(0)
 }
-func (m *MockFriendshipService) RetrieveFriendsList(authUserId int64, authUserRole string, email string) ([]*entity.User, error) {
-	args := m.Called(authUserId, authUserRole, email)
-	return args.Get(0).([]*entity.User), args.Error(1)
+func (m *MockFriendshipService) RetrieveFriendsList(authUserId int64, authUserRole string, email string, afterId int64, limit int) ([]*entity.User, int64, error) {
+	args := m.Called(authUserId, authUserRole, email, afterId, limit)
+	return args.Get(0).([]*entity.User), args.Get(1).(int64), args.Error(2)
 }
-func (m *MockFriendshipService) RetrieveCommonFriends(authUserId int64, authUserRole string, email1, email2 string) ([]*entity.User, error) {
-	args := m.Called(authUserId, authUserRole, email1, email2)
-	return args.Get(0).([]*entity.User), args.Error(1)
+func (m *MockFriendshipService) RetrieveCommonFriends(authUserId int64, authUserRole string, email1, email2 string, afterId int64, limit int) ([]*entity.User, int64, error) {
+	args := m.Called(authUserId, authUserRole, email1, email2, afterId, limit)
+	return args.Get(0).([]*entity.User), args.Get(1).(int64), args.Error(2)
 }
 func (m *MockFriendshipService) CountFriends(users []*entity.User) int64 {
 	args := m.Called(users)
 	return int64(args.Int(0))
 }
+func (m *MockFriendshipService) DeleteFriendship(authUserId int64, email1, email2 string, removeSubscriptions bool) error {
+	args := m.Called(authUserId, email1, email2, removeSubscriptions)
+	return args.Error(0)
+}
+func (m *MockFriendshipService) RetrieveFriendshipPath(authUserId int64, authUserRole string, fromEmail, toEmail string, maxDepth int) ([]*entity.User, error) {
+	args := m.Called(authUserId, authUserRole, fromEmail, toEmail, maxDepth)
+	return args.Get(0).([]*entity.User), args.Error(1)
+}
+func (m *MockFriendshipService) RetrieveFriendSuggestions(authUserId int64, authUserRole string, email string, limit, offset int) ([]*entity.FriendSuggestion, error) {
+	args := m.Called(authUserId, authUserRole, email, limit, offset)
+	return args.Get(0).([]*entity.FriendSuggestion), args.Error(1)
+}
 
 func TestFriendshipHandler_CreateFriendship(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -57,7 +70,7 @@ func TestFriendshipHandler_CreateFriendship(t *testing.T) {
 			serviceError:   nil,
 			expectedStatus: http.StatusOK,
 			setupMock: func(m *MockFriendshipService) {
-				m.On("CreateFriendship", int64(1), "andy@example.com", "john@example.com").Return(nil)
+				m.On("CreateFriendship", int64(1), "admin", "andy@example.com", "john@example.com").Return(nil)
 			},
 		},
 		{
@@ -76,7 +89,7 @@ func TestFriendshipHandler_CreateFriendship(t *testing.T) {
 			serviceError:   service.ErrInvalidRequest,
 			expectedStatus: http.StatusBadRequest,
 			setupMock: func(m *MockFriendshipService) {
-				m.On("CreateFriendship", int64(1), "andy@example.com", "john@example.com").Return(service.ErrInvalidRequest)
+				m.On("CreateFriendship", int64(1), "admin", "andy@example.com", "john@example.com").Return(service.ErrInvalidRequest)
 			},
 		},
 		{
@@ -88,7 +101,7 @@ func TestFriendshipHandler_CreateFriendship(t *testing.T) {
 			serviceError:   service.ErrUserNotFound,
 			expectedStatus: http.StatusNotFound,
 			setupMock: func(m *MockFriendshipService) {
-				m.On("CreateFriendship", int64(1), "andy@example.com", "john@example.com").Return(service.ErrUserNotFound)
+				m.On("CreateFriendship", int64(1), "admin", "andy@example.com", "john@example.com").Return(service.ErrUserNotFound)
 			},
 		},
 		{
@@ -100,7 +113,7 @@ func TestFriendshipHandler_CreateFriendship(t *testing.T) {
 			serviceError:   service.ErrAlreadyFriend,
 			expectedStatus: http.StatusConflict,
 			setupMock: func(m *MockFriendshipService) {
-				m.On("CreateFriendship", int64(1), "andy@example.com", "john@example.com").Return(service.ErrAlreadyFriend)
+				m.On("CreateFriendship", int64(1), "admin", "andy@example.com", "john@example.com").Return(service.ErrAlreadyFriend)
 			},
 		},
 		{
@@ -112,7 +125,7 @@ func TestFriendshipHandler_CreateFriendship(t *testing.T) {
 			serviceError:   assert.AnError,
 			expectedStatus: http.StatusInternalServerError,
 			setupMock: func(m *MockFriendshipService) {
-				m.On("CreateFriendship", int64(1), "andy@example.com", "john@example.com").Return(assert.AnError)
+				m.On("CreateFriendship", int64(1), "admin", "andy@example.com", "john@example.com").Return(assert.AnError)
 			},
 		},
 	}
@@ -137,6 +150,7 @@ func TestFriendshipHandler_CreateFriendship(t *testing.T) {
 			c.Request = httptest.NewRequest(http.MethodPost, "/api/friendship", bytes.NewBuffer(reqBody))
 			c.Request.Header.Set("Content-Type", "application/json")
 			c.Set("authUserId", 1)
+			c.Set("authUserRole", "admin")
 			handler.CreateFriendship(c)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
@@ -173,8 +187,7 @@ func TestFriendshipHandler_RetrieveFriendsList(t *testing.T) {
 					{Email: "john@example.com"},
 					{Email: "jane@example.com"},
 				}
-				m.On("RetrieveFriendsList", int64(1), "user", "andy@example.com").Return(users, nil)
-				m.On("CountFriends", users).Return(2)
+				m.On("RetrieveFriendsList", int64(1), "user", "andy@example.com", int64(0), 20).Return(users, int64(len(users)), nil)
 			},
 		},
 		{
@@ -194,7 +207,7 @@ func TestFriendshipHandler_RetrieveFriendsList(t *testing.T) {
 			serviceError:   service.ErrUserNotFound,
 			expectedStatus: http.StatusNotFound,
 			setupMock: func(m *MockFriendshipService) {
-				m.On("RetrieveFriendsList", int64(1), "user", "nonexistent@example.com").Return([]*entity.User{}, service.ErrUserNotFound)
+				m.On("RetrieveFriendsList", int64(1), "user", "nonexistent@example.com", int64(0), 20).Return([]*entity.User{}, int64(0), service.ErrUserNotFound)
 			},
 		},
 		{
@@ -206,7 +219,7 @@ func TestFriendshipHandler_RetrieveFriendsList(t *testing.T) {
 			serviceError:   assert.AnError,
 			expectedStatus: http.StatusInternalServerError,
 			setupMock: func(m *MockFriendshipService) {
-				m.On("RetrieveFriendsList", int64(1), "user", "andy@example.com").Return([]*entity.User{}, assert.AnError)
+				m.On("RetrieveFriendsList", int64(1), "user", "andy@example.com", int64(0), 20).Return([]*entity.User{}, int64(0), assert.AnError)
 			},
 		},
 		{
@@ -217,8 +230,7 @@ func TestFriendshipHandler_RetrieveFriendsList(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			setupMock: func(m *MockFriendshipService) {
 				users := []*entity.User{}
-				m.On("RetrieveFriendsList", int64(1), "user", "lonely@example.com").Return(users, nil)
-				m.On("CountFriends", users).Return(0)
+				m.On("RetrieveFriendsList", int64(1), "user", "lonely@example.com", int64(0), 20).Return(users, int64(len(users)), nil)
 			},
 		},
 	}
@@ -250,6 +262,47 @@ func TestFriendshipHandler_RetrieveFriendsList(t *testing.T) {
 	}
 }
 
+func TestFriendshipHandler_RetrieveFriendsListPagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Full page returns next cursor", func(t *testing.T) {
+		mockService := new(MockFriendshipService)
+		users := []*entity.User{{Id: 5, Email: "john@example.com"}}
+		mockService.On("RetrieveFriendsList", int64(1), "user", "andy@example.com", int64(2), 1).Return(users, int64(3), nil)
+		handler := handler.NewFriendshipHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/friendship/friends?email=andy@example.com&limit=1&after="+utils.EncodeCursor(2), nil)
+		c.Set("authUserId", 1)
+		c.Set("authUserRole", "user")
+		handler.RetrieveFriendsList(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response dto.ApiResponseSuccessWithFriendsList
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, []string{"john@example.com"}, response.Friends)
+		assert.Equal(t, utils.EncodeCursor(5), response.NextCursor)
+		assert.Equal(t, int64(3), response.Count)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid cursor", func(t *testing.T) {
+		mockService := new(MockFriendshipService)
+		handler := handler.NewFriendshipHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/friendship/friends?email=andy@example.com&after=bm90LWFuLWlk", nil)
+		c.Set("authUserId", 1)
+		c.Set("authUserRole", "user")
+		handler.RetrieveFriendsList(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
 func TestFriendshipHandler_RetrieveCommonFriends(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -281,8 +334,7 @@ func TestFriendshipHandler_RetrieveCommonFriends(t *testing.T) {
 					{Email: "jane@example.com"},
 					{Email: "bob@example.com"},
 				}
-				m.On("RetrieveCommonFriends", int64(1), "user", "andy@example.com", "john@example.com").Return(users, nil)
-				m.On("CountFriends", users).Return(2)
+				m.On("RetrieveCommonFriends", int64(1), "user", "andy@example.com", "john@example.com", int64(0), 20).Return(users, int64(len(users)), nil)
 			},
 		},
 		{
@@ -322,7 +374,7 @@ func TestFriendshipHandler_RetrieveCommonFriends(t *testing.T) {
 			serviceError:   service.ErrInvalidRequest,
 			expectedStatus: http.StatusBadRequest,
 			setupMock: func(m *MockFriendshipService) {
-				m.On("RetrieveCommonFriends", int64(1), "user", "andy@example.com", "john@example.com").Return([]*entity.User{}, service.ErrInvalidRequest)
+				m.On("RetrieveCommonFriends", int64(1), "user", "andy@example.com", "john@example.com", int64(0), 20).Return([]*entity.User{}, int64(0), service.ErrInvalidRequest)
 			},
 		},
 		{
@@ -335,7 +387,7 @@ func TestFriendshipHandler_RetrieveCommonFriends(t *testing.T) {
 			serviceError:   service.ErrUserNotFound,
 			expectedStatus: http.StatusNotFound,
 			setupMock: func(m *MockFriendshipService) {
-				m.On("RetrieveCommonFriends", int64(1), "user", "nonexistent@example.com", "john@example.com").Return([]*entity.User{}, service.ErrUserNotFound)
+				m.On("RetrieveCommonFriends", int64(1), "user", "nonexistent@example.com", "john@example.com", int64(0), 20).Return([]*entity.User{}, int64(0), service.ErrUserNotFound)
 			},
 		},
 		{
@@ -348,7 +400,7 @@ func TestFriendshipHandler_RetrieveCommonFriends(t *testing.T) {
 			serviceError:   assert.AnError,
 			expectedStatus: http.StatusInternalServerError,
 			setupMock: func(m *MockFriendshipService) {
-				m.On("RetrieveCommonFriends", int64(1), "user", "andy@example.com", "john@example.com").Return([]*entity.User{}, assert.AnError)
+				m.On("RetrieveCommonFriends", int64(1), "user", "andy@example.com", "john@example.com", int64(0), 20).Return([]*entity.User{}, int64(0), assert.AnError)
 			},
 		},
 		{
@@ -362,8 +414,7 @@ func TestFriendshipHandler_RetrieveCommonFriends(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			setupMock: func(m *MockFriendshipService) {
 				users := []*entity.User{}
-				m.On("RetrieveCommonFriends", int64(1), "user", "andy@example.com", "john@example.com").Return(users, nil)
-				m.On("CountFriends", users).Return(0)
+				m.On("RetrieveCommonFriends", int64(1), "user", "andy@example.com", "john@example.com", int64(0), 20).Return(users, int64(len(users)), nil)
 			},
 		},
 	}
@@ -397,3 +448,283 @@ func TestFriendshipHandler_RetrieveCommonFriends(t *testing.T) {
 		})
 	}
 }
+
+func TestFriendshipHandler_DeleteFriendship(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		expectedStatus int
+		setupMock      func(*MockFriendshipService)
+	}{
+		{
+			name: "Success",
+			requestBody: dto.DeleteFriendshipRequest{
+				Friends: []string{"andy@example.com", "john@example.com"},
+			},
+			expectedStatus: http.StatusOK,
+			setupMock: func(m *MockFriendshipService) {
+				m.On("DeleteFriendship", int64(1), "andy@example.com", "john@example.com", false).Return(nil)
+			},
+		},
+		{
+			name: "Success with subscriptions removal",
+			requestBody: dto.DeleteFriendshipRequest{
+				Friends:             []string{"andy@example.com", "john@example.com"},
+				RemoveSubscriptions: true,
+			},
+			expectedStatus: http.StatusOK,
+			setupMock: func(m *MockFriendshipService) {
+				m.On("DeleteFriendship", int64(1), "andy@example.com", "john@example.com", true).Return(nil)
+			},
+		},
+		{
+			name:           "Invalid JSON request",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(m *MockFriendshipService) {},
+		},
+		{
+			name: "Service returns ErrNotFriend",
+			requestBody: dto.DeleteFriendshipRequest{
+				Friends: []string{"andy@example.com", "john@example.com"},
+			},
+			expectedStatus: http.StatusNotFound,
+			setupMock: func(m *MockFriendshipService) {
+				m.On("DeleteFriendship", int64(1), "andy@example.com", "john@example.com", false).Return(service.ErrNotFriend)
+			},
+		},
+		{
+			name: "Service returns ErrNotPermitted",
+			requestBody: dto.DeleteFriendshipRequest{
+				Friends: []string{"andy@example.com", "john@example.com"},
+			},
+			expectedStatus: http.StatusForbidden,
+			setupMock: func(m *MockFriendshipService) {
+				m.On("DeleteFriendship", int64(1), "andy@example.com", "john@example.com", false).Return(service.ErrNotPermitted)
+			},
+		},
+		{
+			name: "Service returns unknown error",
+			requestBody: dto.DeleteFriendshipRequest{
+				Friends: []string{"andy@example.com", "john@example.com"},
+			},
+			expectedStatus: http.StatusInternalServerError,
+			setupMock: func(m *MockFriendshipService) {
+				m.On("DeleteFriendship", int64(1), "andy@example.com", "john@example.com", false).Return(assert.AnError)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockFriendshipService)
+			tt.setupMock(mockService)
+
+			handler := handler.NewFriendshipHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			var reqBody []byte
+			if str, ok := tt.requestBody.(string); ok {
+				reqBody = []byte(str)
+			} else {
+				reqBody, _ = json.Marshal(tt.requestBody)
+			}
+
+			c.Request = httptest.NewRequest(http.MethodDelete, "/api/friendship", bytes.NewBuffer(reqBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("authUserId", 1)
+			handler.DeleteFriendship(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFriendshipHandler_RetrieveFriendSuggestions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockFriendshipService)
+		expectedStatus int
+		expectedCount  int64
+	}{
+		{
+			name:  "Success",
+			query: "email=andy@example.com",
+			setupMock: func(m *MockFriendshipService) {
+				suggestions := []*entity.FriendSuggestion{
+					{UserId: 7, Email: "kate@example.com", MutualFriends: 3},
+					{UserId: 4, Email: "bob@example.com", MutualFriends: 1},
+				}
+				m.On("RetrieveFriendSuggestions", int64(1), "user", "andy@example.com", 20, 0).Return(suggestions, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name:  "Success with pagination",
+			query: "email=andy@example.com&limit=5&offset=10",
+			setupMock: func(m *MockFriendshipService) {
+				m.On("RetrieveFriendSuggestions", int64(1), "user", "andy@example.com", 5, 10).Return([]*entity.FriendSuggestion{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedCount:  0,
+		},
+		{
+			name:           "Missing email parameter",
+			query:          "",
+			setupMock:      func(m *MockFriendshipService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "Service returns ErrUserNotFound",
+			query: "email=nonexistent@example.com",
+			setupMock: func(m *MockFriendshipService) {
+				m.On("RetrieveFriendSuggestions", int64(1), "user", "nonexistent@example.com", 20, 0).Return([]*entity.FriendSuggestion{}, service.ErrUserNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:  "Service returns ErrNotPermitted",
+			query: "email=john@example.com",
+			setupMock: func(m *MockFriendshipService) {
+				m.On("RetrieveFriendSuggestions", int64(1), "user", "john@example.com", 20, 0).Return([]*entity.FriendSuggestion{}, service.ErrNotPermitted)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:  "Service returns unknown error",
+			query: "email=andy@example.com",
+			setupMock: func(m *MockFriendshipService) {
+				m.On("RetrieveFriendSuggestions", int64(1), "user", "andy@example.com", 20, 0).Return([]*entity.FriendSuggestion{}, assert.AnError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockFriendshipService)
+			tt.setupMock(mockService)
+
+			handler := handler.NewFriendshipHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/friendship/suggestions?"+tt.query, nil)
+			c.Set("authUserId", 1)
+			c.Set("authUserRole", "user")
+			handler.RetrieveFriendSuggestions(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response dto.ApiResponseSuccessWithFriendSuggestions
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedCount, response.Count)
+				if tt.expectedCount > 0 {
+					assert.Equal(t, "kate@example.com", response.Suggestions[0].Email)
+					assert.Equal(t, int64(3), response.Suggestions[0].MutualFriends)
+				}
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFriendshipHandler_RetrieveFriendshipPath(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockFriendshipService)
+		expectedStatus int
+	}{
+		{
+			name:  "Success",
+			query: "from=andy@example.com&to=kate@example.com",
+			setupMock: func(m *MockFriendshipService) {
+				path := []*entity.User{{Email: "andy@example.com"}, {Email: "john@example.com"}, {Email: "kate@example.com"}}
+				m.On("RetrieveFriendshipPath", int64(1), "user", "andy@example.com", "kate@example.com", 4).Return(path, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "Max depth is capped",
+			query: "from=andy@example.com&to=kate@example.com&max_depth=50",
+			setupMock: func(m *MockFriendshipService) {
+				path := []*entity.User{{Email: "andy@example.com"}, {Email: "john@example.com"}, {Email: "kate@example.com"}}
+				m.On("RetrieveFriendshipPath", int64(1), "user", "andy@example.com", "kate@example.com", 6).Return(path, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid max depth",
+			query:          "from=andy@example.com&to=kate@example.com&max_depth=0",
+			setupMock:      func(m *MockFriendshipService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Missing to parameter",
+			query:          "from=andy@example.com",
+			setupMock:      func(m *MockFriendshipService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "Path not found",
+			query: "from=andy@example.com&to=kate@example.com",
+			setupMock: func(m *MockFriendshipService) {
+				m.On("RetrieveFriendshipPath", int64(1), "user", "andy@example.com", "kate@example.com", 4).Return([]*entity.User{}, service.ErrPathNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:  "Blocked",
+			query: "from=andy@example.com&to=kate@example.com",
+			setupMock: func(m *MockFriendshipService) {
+				m.On("RetrieveFriendshipPath", int64(1), "user", "andy@example.com", "kate@example.com", 4).Return([]*entity.User{}, service.ErrIsBlocked)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:  "Unknown error",
+			query: "from=andy@example.com&to=kate@example.com",
+			setupMock: func(m *MockFriendshipService) {
+				m.On("RetrieveFriendshipPath", int64(1), "user", "andy@example.com", "kate@example.com", 4).Return([]*entity.User{}, assert.AnError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockFriendshipService)
+			tt.setupMock(mockService)
+
+			handler := handler.NewFriendshipHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/friendship/path?"+tt.query, nil)
+			c.Set("authUserId", 1)
+			c.Set("authUserRole", "user")
+			handler.RetrieveFriendshipPath(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response dto.ApiResponseSuccessWithFriendshipPath
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, []string{"andy@example.com", "john@example.com", "kate@example.com"}, response.Path)
+				assert.Equal(t, 2, response.Degree)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
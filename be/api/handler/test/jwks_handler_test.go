@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"BE_Friends_Management/api/handler"
+	"BE_Friends_Management/config"
+	"BE_Friends_Management/internal/domain/dto"
+	"BE_Friends_Management/internal/keyring"
+	"BE_Friends_Management/pkg/utils"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestKeyRing(t *testing.T, signingKeyId string) *keyring.KeyRing {
+	dir := t.TempDir()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	rsaRaw, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	assert.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	edRaw, err := x509.MarshalPKCS8PrivateKey(edKey)
+	assert.NoError(t, err)
+	for kid, raw := range map[string][]byte{"rsa-1": rsaRaw, "ed-1": edRaw} {
+		encoded := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: raw})
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, kid+".pem"), encoded, 0o600))
+	}
+	ring, err := keyring.Load(dir, signingKeyId)
+	assert.NoError(t, err)
+	return ring
+}
+
+func getJwks(t *testing.T, ring *keyring.KeyRing) dto.JwksResponse {
+	router := gin.New()
+	router.GET("/.well-known/jwks.json", handler.NewJwksHandler(ring).GetJwks)
+	req, _ := http.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+	var jwks dto.JwksResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &jwks))
+	return jwks
+}
+
+// publicKeyFromJwk rebuilds a public key the way another service would.
+func publicKeyFromJwk(t *testing.T, jwk dto.JwkResponse) interface{} {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		assert.NoError(t, err)
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		assert.NoError(t, err)
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		assert.NoError(t, err)
+		return ed25519.PublicKey(x)
+	}
+	t.Fatalf("unexpected key type %q", jwk.Kty)
+	return nil
+}
+
+func TestGetJwks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("lists every key of the ring", func(t *testing.T) {
+		jwks := getJwks(t, newTestKeyRing(t, ""))
+
+		assert.Len(t, jwks.Keys, 2)
+		assert.Equal(t, "ed-1", jwks.Keys[0].Kid)
+		assert.Equal(t, "OKP", jwks.Keys[0].Kty)
+		assert.Equal(t, "Ed25519", jwks.Keys[0].Crv)
+		assert.Equal(t, "EdDSA", jwks.Keys[0].Alg)
+		assert.Equal(t, "rsa-1", jwks.Keys[1].Kid)
+		assert.Equal(t, "RSA", jwks.Keys[1].Kty)
+		assert.Equal(t, "RS256", jwks.Keys[1].Alg)
+		assert.Equal(t, "AQAB", jwks.Keys[1].E)
+	})
+
+	t.Run("no keys without a ring", func(t *testing.T) {
+		jwks := getJwks(t, nil)
+
+		assert.NotNil(t, jwks.Keys)
+		assert.Empty(t, jwks.Keys)
+	})
+
+	for _, signingKeyId := range []string{"rsa-1", "ed-1"} {
+		t.Run("access token verifies with the published key "+signingKeyId, func(t *testing.T) {
+			ring := newTestKeyRing(t, signingKeyId)
+			utils.SetAccessKeyRing(ring)
+			defer utils.SetAccessKeyRing(nil)
+			jwks := getJwks(t, ring)
+
+			accessToken, err := utils.GenerateAccessToken(1, "user", time.Now().Add(time.Hour))
+			assert.NoError(t, err)
+
+			token, err := jwt.Parse(accessToken, func(token *jwt.Token) (interface{}, error) {
+				for _, jwk := range jwks.Keys {
+					if jwk.Kid == token.Header["kid"] && jwk.Alg == token.Method.Alg() {
+						return publicKeyFromJwk(t, jwk), nil
+					}
+				}
+				return nil, utils.ErrInvalidAccessToken
+			})
+			assert.NoError(t, err)
+			assert.True(t, token.Valid)
+			assert.Equal(t, signingKeyId, token.Header["kid"])
+
+			claims, err := utils.ParseAccessToken(accessToken)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), claims.UserId)
+		})
+	}
+
+	t.Run("token signed by a replaced key is rejected", func(t *testing.T) {
+		otherRing := newTestKeyRing(t, "")
+		utils.SetAccessKeyRing(otherRing)
+		accessToken, err := utils.GenerateAccessToken(1, "user", time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		utils.SetAccessKeyRing(newTestKeyRing(t, ""))
+		defer utils.SetAccessKeyRing(nil)
+
+		_, err = utils.ParseAccessToken(accessToken)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("tokens signed before the ring was set up still verify", func(t *testing.T) {
+		config.AccessSecret = "test-access-secret"
+		accessToken, err := utils.GenerateAccessToken(1, "user", time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		utils.SetAccessKeyRing(newTestKeyRing(t, ""))
+		defer utils.SetAccessKeyRing(nil)
+
+		claims, err := utils.ParseAccessToken(accessToken)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), claims.UserId)
+	})
+}
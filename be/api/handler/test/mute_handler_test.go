@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"BE_Friends_Management/api/handler"
+	"BE_Friends_Management/internal/domain/dto"
+	"BE_Friends_Management/internal/domain/entity"
+	service "BE_Friends_Management/internal/service/mute"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMuteService struct {
+	mock.Mock
+}
+
+func (m *MockMuteService) CreateMute(authUserId int64, authUserRole string, requestor, target, duration string) (*entity.Mute, error) {
+	args := m.Called(authUserId, authUserRole, requestor, target, duration)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Mute), args.Error(1)
+}
+
+func (m *MockMuteService) DeleteMute(authUserId int64, authUserRole string, requestor, target string) error {
+	args := m.Called(authUserId, authUserRole, requestor, target)
+	return args.Error(0)
+}
+
+func (m *MockMuteService) RetrieveMutedUsers(authUserId int64, authUserRole string, email string, limit, offset int) ([]*entity.Mute, int64, error) {
+	args := m.Called(authUserId, authUserRole, email, limit, offset)
+	return args.Get(0).([]*entity.Mute), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockMuteService) SweepExpiredMutes(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockMuteService) Run(ctx context.Context) {
+	m.Called(ctx)
+}
+
+func TestCreateMute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	request := dto.CreateMuteRequest{Requestor: "user1@example.com", Target: "user2@example.com", Duration: "8h"}
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		expectedStatus int
+		setupMock      func(*MockMuteService)
+	}{
+		{
+			name:           "Success",
+			requestBody:    request,
+			expectedStatus: http.StatusOK,
+			setupMock: func(m *MockMuteService) {
+				m.On("CreateMute", int64(1), "user", "user1@example.com", "user2@example.com", "8h").Return(&entity.Mute{RequestorId: 1, TargetId: 2}, nil)
+			},
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(m *MockMuteService) {},
+		},
+		{
+			name:           "Missing Target",
+			requestBody:    dto.CreateMuteRequest{Requestor: "user1@example.com"},
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(m *MockMuteService) {},
+		},
+		{
+			name:           "Service Error - Invalid Duration",
+			requestBody:    request,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(m *MockMuteService) {
+				m.On("CreateMute", int64(1), "user", "user1@example.com", "user2@example.com", "8h").Return(nil, service.ErrInvalidDuration)
+			},
+		},
+		{
+			name:           "Service Error - User Not Found",
+			requestBody:    request,
+			expectedStatus: http.StatusNotFound,
+			setupMock: func(m *MockMuteService) {
+				m.On("CreateMute", int64(1), "user", "user1@example.com", "user2@example.com", "8h").Return(nil, service.ErrUserNotFound)
+			},
+		},
+		{
+			name:           "Service Error - Not Permitted",
+			requestBody:    request,
+			expectedStatus: http.StatusForbidden,
+			setupMock: func(m *MockMuteService) {
+				m.On("CreateMute", int64(1), "user", "user1@example.com", "user2@example.com", "8h").Return(nil, service.ErrNotPermitted)
+			},
+		},
+		{
+			name:           "Service Error - Unknown",
+			requestBody:    request,
+			expectedStatus: http.StatusInternalServerError,
+			setupMock: func(m *MockMuteService) {
+				m.On("CreateMute", int64(1), "user", "user1@example.com", "user2@example.com", "8h").Return(nil, errors.New("database error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockMuteService)
+			tt.setupMock(mockService)
+
+			handler := handler.NewMuteHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			reqBody, _ := json.Marshal(tt.requestBody)
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/mute", bytes.NewBuffer(reqBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("authUserId", 1)
+			c.Set("authUserRole", "user")
+			handler.CreateMute(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteMute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	request := dto.DeleteMuteRequest{Requestor: "user1@example.com", Target: "user2@example.com"}
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		expectedStatus int
+		setupMock      func(*MockMuteService)
+	}{
+		{
+			name:           "Success",
+			requestBody:    request,
+			expectedStatus: http.StatusOK,
+			setupMock: func(m *MockMuteService) {
+				m.On("DeleteMute", int64(1), "user", "user1@example.com", "user2@example.com").Return(nil)
+			},
+		},
+		{
+			name:           "Service Error - Not Muted",
+			requestBody:    request,
+			expectedStatus: http.StatusNotFound,
+			setupMock: func(m *MockMuteService) {
+				m.On("DeleteMute", int64(1), "user", "user1@example.com", "user2@example.com").Return(service.ErrNotMuted)
+			},
+		},
+		{
+			name:           "Service Error - Not Permitted",
+			requestBody:    request,
+			expectedStatus: http.StatusForbidden,
+			setupMock: func(m *MockMuteService) {
+				m.On("DeleteMute", int64(1), "user", "user1@example.com", "user2@example.com").Return(service.ErrNotPermitted)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockMuteService)
+			tt.setupMock(mockService)
+
+			handler := handler.NewMuteHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			reqBody, _ := json.Marshal(tt.requestBody)
+			c.Request = httptest.NewRequest(http.MethodDelete, "/api/mute", bytes.NewBuffer(reqBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("authUserId", 1)
+			c.Set("authUserRole", "user")
+			handler.DeleteMute(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRetrieveMutedUsers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	expiresAt := time.Date(2025, 8, 7, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		setupMock      func(*MockMuteService)
+	}{
+		{
+			name:           "Success",
+			query:          "email=user1@example.com",
+			expectedStatus: http.StatusOK,
+			setupMock: func(m *MockMuteService) {
+				mutes := []*entity.Mute{{RequestorId: 1, TargetId: 2, ExpiresAt: &expiresAt, Target: &entity.User{Email: "user2@example.com"}}}
+				m.On("RetrieveMutedUsers", int64(1), "user", "user1@example.com", 20, 0).Return(mutes, int64(1), nil)
+			},
+		},
+		{
+			name:           "Missing email",
+			query:          "",
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(m *MockMuteService) {},
+		},
+		{
+			name:           "Service Error - Not Permitted",
+			query:          "email=user1@example.com",
+			expectedStatus: http.StatusForbidden,
+			setupMock: func(m *MockMuteService) {
+				m.On("RetrieveMutedUsers", int64(1), "user", "user1@example.com", 20, 0).Return([]*entity.Mute{}, int64(0), service.ErrNotPermitted)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockMuteService)
+			tt.setupMock(mockService)
+
+			handler := handler.NewMuteHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Request, _ = http.NewRequest("GET", "/api/mute?"+tt.query, nil)
+			c.Set("authUserId", 1)
+			c.Set("authUserRole", "user")
+			handler.RetrieveMutedUsers(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response dto.ApiResponseSuccessWithMutedUsers
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "user2@example.com", response.MutedUsers[0].Email)
+				assert.Equal(t, expiresAt, *response.MutedUsers[0].ExpiresAt)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -22,9 +22,37 @@ type MockNotificationService struct {
 	mock.Mock
 }
 
-func (m *MockNotificationService) GetUpdateRecipients(authUserId int64, authUserRole, sender, text string) ([]*entity.User, error) {
+func (m *MockNotificationService) GetUpdateRecipients(authUserId int64, authUserRole, sender, text string, afterId int64, limit int) ([]*entity.User, int64, error) {
+	args := m.Called(authUserId, authUserRole, sender, text, afterId, limit)
+	return args.Get(0).([]*entity.User), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockNotificationService) PublishUpdate(authUserId int64, authUserRole, sender, text string) (*entity.Update, int64, error) {
 	args := m.Called(authUserId, authUserRole, sender, text)
-	return args.Get(0).([]*entity.User), args.Error(1)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).(*entity.Update), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockNotificationService) GetFeed(authUserId int64, beforeId int64, limit int) ([]*entity.UpdateDelivery, int64, int64, error) {
+	args := m.Called(authUserId, beforeId, limit)
+	return args.Get(0).([]*entity.UpdateDelivery), args.Get(1).(int64), args.Get(2).(int64), args.Error(3)
+}
+
+func (m *MockNotificationService) GetMissedFeed(authUserId int64, lastDeliveryId int64, limit int) ([]*entity.UpdateDelivery, error) {
+	args := m.Called(authUserId, lastDeliveryId, limit)
+	return args.Get(0).([]*entity.UpdateDelivery), args.Error(1)
+}
+
+func (m *MockNotificationService) MarkFeedItemRead(authUserId int64, deliveryId int64) error {
+	args := m.Called(authUserId, deliveryId)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) GetMentions(authUserId int64, beforeId int64, limit int) ([]*entity.Mention, int64, error) {
+	args := m.Called(authUserId, beforeId, limit)
+	return args.Get(0).([]*entity.Mention), args.Get(1).(int64), args.Error(2)
 }
 
 func TestGetUpdateRecipients(t *testing.T) {
@@ -52,7 +80,7 @@ func TestGetUpdateRecipients(t *testing.T) {
 					{Email: "user2@example.com"},
 					{Email: "user3@example.com"},
 				}
-				m.On("GetUpdateRecipients", int64(1), "user", "sender@example.com", "Hello").Return(users, nil)
+				m.On("GetUpdateRecipients", int64(1), "user", "sender@example.com", "Hello", int64(0), 20).Return(users, int64(len(users)), nil)
 			},
 			expectedCode: http.StatusOK,
 		},
@@ -73,7 +101,7 @@ func TestGetUpdateRecipients(t *testing.T) {
 				Text:   "Hello",
 			},
 			mockSetup: func(m *MockNotificationService) {
-				m.On("GetUpdateRecipients", int64(1), "user", "sender@example.com", "Hello").Return([]*entity.User{}, service.ErrUserNotFound)
+				m.On("GetUpdateRecipients", int64(1), "user", "sender@example.com", "Hello", int64(0), 20).Return([]*entity.User{}, int64(0), service.ErrUserNotFound)
 			},
 			expectedCode: http.StatusNotFound,
 		},
@@ -86,7 +114,7 @@ func TestGetUpdateRecipients(t *testing.T) {
 				Text:   "Hello",
 			},
 			mockSetup: func(m *MockNotificationService) {
-				m.On("GetUpdateRecipients", int64(1), "user", "sender@example.com", "Hello").Return([]*entity.User{}, errors.New("database error"))
+				m.On("GetUpdateRecipients", int64(1), "user", "sender@example.com", "Hello", int64(0), 20).Return([]*entity.User{}, int64(0), errors.New("database error"))
 			},
 			expectedCode: http.StatusInternalServerError,
 		},
@@ -121,3 +149,293 @@ func TestGetUpdateRecipients(t *testing.T) {
 		})
 	}
 }
+
+func TestPublishUpdate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		request      interface{}
+		mockSetup    func(*MockNotificationService)
+		expectedCode int
+	}{
+		{
+			name: "Success",
+			request: dto.PublishUpdateRequest{
+				Sender: "sender@example.com",
+				Text:   "Hello",
+			},
+			mockSetup: func(m *MockNotificationService) {
+				update := &entity.Update{Id: 1, Text: "Hello", Sender: &entity.User{Email: "sender@example.com"}}
+				m.On("PublishUpdate", int64(1), "user", "sender@example.com", "Hello").Return(update, int64(2), nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Invalid Request",
+			request:      "invalid json",
+			mockSetup:    func(m *MockNotificationService) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "User Not Found",
+			request: dto.PublishUpdateRequest{
+				Sender: "sender@example.com",
+				Text:   "Hello",
+			},
+			mockSetup: func(m *MockNotificationService) {
+				m.On("PublishUpdate", int64(1), "user", "sender@example.com", "Hello").Return(nil, int64(0), service.ErrUserNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "Not Permitted",
+			request: dto.PublishUpdateRequest{
+				Sender: "sender@example.com",
+				Text:   "Hello",
+			},
+			mockSetup: func(m *MockNotificationService) {
+				m.On("PublishUpdate", int64(1), "user", "sender@example.com", "Hello").Return(nil, int64(0), service.ErrNotPermitted)
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name: "Unknown Error",
+			request: dto.PublishUpdateRequest{
+				Sender: "sender@example.com",
+				Text:   "Hello",
+			},
+			mockSetup: func(m *MockNotificationService) {
+				m.On("PublishUpdate", int64(1), "user", "sender@example.com", "Hello").Return(nil, int64(0), errors.New("database error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockNotificationService)
+			tt.mockSetup(mockService)
+			handler := handler.NewNotificationHandler(mockService)
+
+			var body []byte
+			if tt.name == "Invalid Request" {
+				body = []byte("invalid json")
+			} else {
+				body, _ = json.Marshal(tt.request)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/updates", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Set("authUserId", int64(1))
+			c.Set("authUserRole", "user")
+
+			handler.PublishUpdate(c)
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetFeed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		query        string
+		mockSetup    func(*MockNotificationService)
+		expectedCode int
+	}{
+		{
+			name:  "Success",
+			query: "?limit=2",
+			mockSetup: func(m *MockNotificationService) {
+				deliveries := []*entity.UpdateDelivery{
+					{Id: 9, UpdateId: 5, Update: &entity.Update{Id: 5, Text: "Second", Sender: &entity.User{Email: "sender@example.com"}}},
+					{Id: 8, UpdateId: 4, Update: &entity.Update{Id: 4, Text: "First", Sender: &entity.User{Email: "sender@example.com"}}},
+				}
+				m.On("GetFeed", int64(1), int64(0), 2).Return(deliveries, int64(3), int64(2), nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Invalid Cursor",
+			query:        "?after=***",
+			mockSetup:    func(m *MockNotificationService) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Unknown Error",
+			query: "",
+			mockSetup: func(m *MockNotificationService) {
+				m.On("GetFeed", int64(1), int64(0), 20).Return([]*entity.UpdateDelivery{}, int64(0), int64(0), errors.New("database error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockNotificationService)
+			tt.mockSetup(mockService)
+			handler := handler.NewNotificationHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/feed"+tt.query, nil)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Set("authUserId", int64(1))
+			c.Set("authUserRole", "user")
+
+			handler.GetFeed(c)
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			if tt.name == "Success" {
+				var response dto.ApiResponseSuccessWithFeed
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Len(t, response.Items, 2)
+				assert.Equal(t, "Second", response.Items[0].Text)
+				assert.False(t, response.Items[0].Read)
+				assert.NotEmpty(t, response.NextCursor)
+				assert.Equal(t, int64(2), response.UnreadCount)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMarkFeedItemRead(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		idParam      string
+		mockSetup    func(*MockNotificationService)
+		expectedCode int
+	}{
+		{
+			name:    "Success",
+			idParam: "9",
+			mockSetup: func(m *MockNotificationService) {
+				m.On("MarkFeedItemRead", int64(1), int64(9)).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Invalid ID",
+			idParam:      "abc",
+			mockSetup:    func(m *MockNotificationService) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:    "Not Found",
+			idParam: "9",
+			mockSetup: func(m *MockNotificationService) {
+				m.On("MarkFeedItemRead", int64(1), int64(9)).Return(service.ErrFeedItemNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:    "Unknown Error",
+			idParam: "9",
+			mockSetup: func(m *MockNotificationService) {
+				m.On("MarkFeedItemRead", int64(1), int64(9)).Return(errors.New("database error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockNotificationService)
+			tt.mockSetup(mockService)
+			handler := handler.NewNotificationHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/feed/"+tt.idParam+"/read", nil)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Params = gin.Params{{Key: "id", Value: tt.idParam}}
+			c.Set("authUserId", int64(1))
+			c.Set("authUserRole", "user")
+
+			handler.MarkFeedItemRead(c)
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetMentions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		query        string
+		mockSetup    func(*MockNotificationService)
+		expectedCode int
+	}{
+		{
+			name:  "Success",
+			query: "?limit=1",
+			mockSetup: func(m *MockNotificationService) {
+				mentions := []*entity.Mention{
+					{Id: 9, UpdateId: 5, Update: &entity.Update{Id: 5, Text: "Hi @alice", Sender: &entity.User{Email: "sender@example.com"}}},
+				}
+				m.On("GetMentions", int64(1), int64(0), 1).Return(mentions, int64(3), nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Invalid Cursor",
+			query:        "?after=***",
+			mockSetup:    func(m *MockNotificationService) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Unknown Error",
+			query: "",
+			mockSetup: func(m *MockNotificationService) {
+				m.On("GetMentions", int64(1), int64(0), 20).Return([]*entity.Mention{}, int64(0), errors.New("database error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockNotificationService)
+			tt.mockSetup(mockService)
+			handler := handler.NewNotificationHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/me/mentions"+tt.query, nil)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Set("authUserId", int64(1))
+			c.Set("authUserRole", "user")
+
+			handler.GetMentions(c)
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			if tt.name == "Success" {
+				var response dto.ApiResponseSuccessWithMentions
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Len(t, response.Mentions, 1)
+				assert.Equal(t, "Hi @alice", response.Mentions[0].Text)
+				assert.Equal(t, "sender@example.com", response.Mentions[0].Sender)
+				assert.NotEmpty(t, response.NextCursor)
+				assert.Equal(t, int64(3), response.Count)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"BE_Friends_Management/api/handler"
+	"BE_Friends_Management/internal/domain/dto"
+	"BE_Friends_Management/internal/domain/entity"
+	service "BE_Friends_Management/internal/service/notification_preference"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotificationPreferenceService struct {
+	mock.Mock
+}
+
+func (m *MockNotificationPreferenceService) GetPreference(authUserId int64) (*entity.NotificationPreference, error) {
+	args := m.Called(authUserId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.NotificationPreference), args.Error(1)
+}
+
+func (m *MockNotificationPreferenceService) UpdatePreference(authUserId int64, preference *entity.NotificationPreference) (*entity.NotificationPreference, error) {
+	args := m.Called(authUserId, preference)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.NotificationPreference), args.Error(1)
+}
+
+func TestGetNotificationPreference(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name               string
+		expectedStatus     int
+		expectedQuietHours *dto.QuietHoursResponse
+		setupMock          func(*MockNotificationPreferenceService)
+	}{
+		{
+			name:           "Success",
+			expectedStatus: http.StatusOK,
+			setupMock: func(m *MockNotificationPreferenceService) {
+				m.On("GetPreference", int64(1)).Return(&entity.NotificationPreference{
+					UserId:     1,
+					Channels:   entity.NotificationChannels,
+					EventTypes: entity.NotificationTypes,
+				}, nil)
+			},
+		},
+		{
+			name:           "With Quiet Hours",
+			expectedStatus: http.StatusOK,
+			expectedQuietHours: &dto.QuietHoursResponse{
+				Start:      "22:00",
+				End:        "07:00",
+				Timezone:   "Europe/Paris",
+				EventTypes: []string{entity.NotificationSubscriptionUpdate},
+			},
+			setupMock: func(m *MockNotificationPreferenceService) {
+				m.On("GetPreference", int64(1)).Return(&entity.NotificationPreference{
+					UserId:               1,
+					Channels:             entity.NotificationChannels,
+					EventTypes:           entity.NotificationTypes,
+					QuietHoursStart:      "22:00",
+					QuietHoursEnd:        "07:00",
+					QuietHoursTimezone:   "Europe/Paris",
+					QuietHoursEventTypes: []string{entity.NotificationSubscriptionUpdate},
+				}, nil)
+			},
+		},
+		{
+			name:           "Unknown Error",
+			expectedStatus: http.StatusInternalServerError,
+			setupMock: func(m *MockNotificationPreferenceService) {
+				m.On("GetPreference", int64(1)).Return(nil, errors.New("database error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockNotificationPreferenceService)
+			tt.setupMock(mockService)
+
+			handler := handler.NewNotificationPreferenceHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/me/notification-preferences", nil)
+			c.Set("authUserId", 1)
+			handler.GetNotificationPreference(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response dto.ApiResponseSuccessWithNotificationPreference
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, entity.NotificationChannels, response.NotificationPreference.Channels)
+				assert.Equal(t, tt.expectedQuietHours, response.NotificationPreference.QuietHours)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateNotificationPreference(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	quietHours := &dto.QuietHoursRequest{Start: "22:00", End: "07:00", Timezone: "UTC"}
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		expectedStatus int
+		setupMock      func(*MockNotificationPreferenceService)
+	}{
+		{
+			name: "Success",
+			requestBody: dto.UpdateNotificationPreferenceRequest{
+				Channels:   []string{entity.ChannelEmail},
+				EventTypes: []string{entity.NotificationMention},
+				QuietHours: quietHours,
+			},
+			expectedStatus: http.StatusOK,
+			setupMock: func(m *MockNotificationPreferenceService) {
+				m.On("UpdatePreference", int64(1), mock.MatchedBy(func(preference *entity.NotificationPreference) bool {
+					return preference.QuietHoursStart == "22:00" && preference.QuietHoursEnd == "07:00" && len(preference.Channels) == 1
+				})).Return(&entity.NotificationPreference{UserId: 1, Channels: []string{entity.ChannelEmail}}, nil)
+			},
+		},
+		{
+			name:           "Everything Off",
+			requestBody:    dto.UpdateNotificationPreferenceRequest{Channels: []string{}, EventTypes: []string{}},
+			expectedStatus: http.StatusOK,
+			setupMock: func(m *MockNotificationPreferenceService) {
+				m.On("UpdatePreference", int64(1), mock.Anything).Return(&entity.NotificationPreference{UserId: 1}, nil)
+			},
+		},
+		{
+			name:           "Missing Channels",
+			requestBody:    dto.UpdateNotificationPreferenceRequest{EventTypes: []string{}},
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(m *MockNotificationPreferenceService) {},
+		},
+		{
+			name: "Quiet Hours Without End",
+			requestBody: dto.UpdateNotificationPreferenceRequest{
+				Channels:   []string{},
+				EventTypes: []string{},
+				QuietHours: &dto.QuietHoursRequest{Start: "22:00"},
+			},
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(m *MockNotificationPreferenceService) {},
+		},
+		{
+			name:           "Invalid Channel",
+			requestBody:    dto.UpdateNotificationPreferenceRequest{Channels: []string{"sms"}, EventTypes: []string{}},
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(m *MockNotificationPreferenceService) {
+				m.On("UpdatePreference", int64(1), mock.Anything).Return(nil, service.ErrInvalidChannel)
+			},
+		},
+		{
+			name:           "Invalid Event Type",
+			requestBody:    dto.UpdateNotificationPreferenceRequest{Channels: []string{}, EventTypes: []string{"like"}},
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(m *MockNotificationPreferenceService) {
+				m.On("UpdatePreference", int64(1), mock.Anything).Return(nil, service.ErrInvalidEventType)
+			},
+		},
+		{
+			name:           "Invalid Quiet Hours",
+			requestBody:    dto.UpdateNotificationPreferenceRequest{Channels: []string{}, EventTypes: []string{}, QuietHours: &dto.QuietHoursRequest{Start: "25:00", End: "07:00"}},
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(m *MockNotificationPreferenceService) {
+				m.On("UpdatePreference", int64(1), mock.Anything).Return(nil, service.ErrInvalidQuietHours)
+			},
+		},
+		{
+			name:           "Invalid Timezone",
+			requestBody:    dto.UpdateNotificationPreferenceRequest{Channels: []string{}, EventTypes: []string{}, QuietHours: &dto.QuietHoursRequest{Start: "22:00", End: "07:00", Timezone: "Mars/Olympus"}},
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(m *MockNotificationPreferenceService) {
+				m.On("UpdatePreference", int64(1), mock.Anything).Return(nil, service.ErrInvalidTimezone)
+			},
+		},
+		{
+			name:           "Unknown Error",
+			requestBody:    dto.UpdateNotificationPreferenceRequest{Channels: []string{}, EventTypes: []string{}},
+			expectedStatus: http.StatusInternalServerError,
+			setupMock: func(m *MockNotificationPreferenceService) {
+				m.On("UpdatePreference", int64(1), mock.Anything).Return(nil, errors.New("database error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockNotificationPreferenceService)
+			tt.setupMock(mockService)
+
+			handler := handler.NewNotificationPreferenceHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			reqBody, _ := json.Marshal(tt.requestBody)
+			c.Request = httptest.NewRequest(http.MethodPut, "/api/me/notification-preferences", bytes.NewBuffer(reqBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("authUserId", 1)
+			handler.UpdateNotificationPreference(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
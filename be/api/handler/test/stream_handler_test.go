@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"BE_Friends_Management/api/handler"
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/entity"
+	"BE_Friends_Management/internal/realtime"
+
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		lastEventId    string
+		mockSetup      func(*MockNotificationService)
+		publish        []realtime.Event
+		expectedCode   int
+		expectedEvents []string
+	}{
+		{
+			name:      "Live Update",
+			mockSetup: func(m *MockNotificationService) {},
+			publish: []realtime.Event{
+				{Id: 9, Type: realtime.EventUpdate, Payload: &entity.UpdateDelivery{Id: 9, UpdateId: 5, Update: &entity.Update{Id: 5, Text: "Live"}}},
+			},
+			expectedCode:   http.StatusOK,
+			expectedEvents: []string{"id:9\nevent:update\ndata:{\"id\":9,\"update_id\":5,\"sender\":\"\",\"text\":\"Live\""},
+		},
+		{
+			name:        "Resume From Last Event Id",
+			lastEventId: "7",
+			mockSetup: func(m *MockNotificationService) {
+				missed := []*entity.UpdateDelivery{
+					{Id: 8, UpdateId: 4, Update: &entity.Update{Id: 4, Text: "Missed"}},
+				}
+				m.On("GetMissedFeed", int64(1), int64(7), constant.StreamReplayLimit).Return(missed, nil)
+			},
+			publish: []realtime.Event{
+				{Id: 8, Type: realtime.EventUpdate, Payload: &entity.UpdateDelivery{Id: 8, UpdateId: 4, Update: &entity.Update{Id: 4, Text: "Missed"}}},
+				{Id: 9, Type: realtime.EventUpdate, Payload: &entity.UpdateDelivery{Id: 9, UpdateId: 5, Update: &entity.Update{Id: 5, Text: "Live"}}},
+			},
+			expectedCode:   http.StatusOK,
+			expectedEvents: []string{"id:8\nevent:update", "id:9\nevent:update"},
+		},
+		{
+			name:        "Resume Replays Every Page",
+			lastEventId: "7",
+			mockSetup: func(m *MockNotificationService) {
+				firstPage := make([]*entity.UpdateDelivery, 0, constant.StreamReplayLimit)
+				for id := int64(8); id < 8+constant.StreamReplayLimit; id++ {
+					firstPage = append(firstPage, &entity.UpdateDelivery{Id: id, UpdateId: 4, Update: &entity.Update{Id: 4, Text: "Missed"}})
+				}
+				lastId := int64(7 + constant.StreamReplayLimit)
+				m.On("GetMissedFeed", int64(1), int64(7), constant.StreamReplayLimit).Return(firstPage, nil)
+				m.On("GetMissedFeed", int64(1), lastId, constant.StreamReplayLimit).Return([]*entity.UpdateDelivery{
+					{Id: lastId + 1, UpdateId: 5, Update: &entity.Update{Id: 5, Text: "Missed too"}},
+				}, nil)
+			},
+			publish: []realtime.Event{
+				{Id: 8 + constant.StreamReplayLimit, Type: realtime.EventUpdate, Payload: &entity.UpdateDelivery{Id: 8 + constant.StreamReplayLimit, UpdateId: 5, Update: &entity.Update{Id: 5, Text: "Missed too"}}},
+			},
+			expectedCode: http.StatusOK,
+			expectedEvents: []string{
+				"id:8\nevent:update",
+				fmt.Sprintf("id:%d\nevent:update", 7+constant.StreamReplayLimit),
+				fmt.Sprintf("id:%d\nevent:update", 8+constant.StreamReplayLimit),
+			},
+		},
+		{
+			name:         "Invalid Last Event Id",
+			lastEventId:  "abc",
+			mockSetup:    func(m *MockNotificationService) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "Replay Error",
+			lastEventId: "7",
+			mockSetup: func(m *MockNotificationService) {
+				m.On("GetMissedFeed", int64(1), int64(7), constant.StreamReplayLimit).Return([]*entity.UpdateDelivery{}, errors.New("database error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockNotificationService)
+			tt.mockSetup(mockService)
+			hub := realtime.NewHub(constant.StreamBufferSize)
+			handler := handler.NewStreamHandler(mockService, hub)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			req := httptest.NewRequest(http.MethodGet, "/api/stream", nil).WithContext(ctx)
+			if tt.lastEventId != "" {
+				req.Header.Set("Last-Event-ID", tt.lastEventId)
+			}
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Set("authUserId", int64(1))
+			c.Set("authUserRole", "user")
+
+			done := make(chan struct{})
+			go func() {
+				handler.Stream(c)
+				close(done)
+			}()
+
+			if tt.expectedCode == http.StatusOK {
+				assert.Eventually(t, func() bool { return hub.SubscriberCount(1) == 1 }, time.Second, 5*time.Millisecond)
+				for _, event := range tt.publish {
+					hub.Publish(1, event)
+				}
+				time.Sleep(50 * time.Millisecond)
+				cancel()
+			}
+			<-done
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			body := w.Body.String()
+			for _, expectedEvent := range tt.expectedEvents {
+				assert.Equal(t, 1, strings.Count(body, expectedEvent), body)
+			}
+			if tt.expectedCode == http.StatusOK {
+				assert.Contains(t, w.Header().Get("Content-Type"), "text/event-stream")
+			}
+			assert.Equal(t, 0, hub.SubscriberCount(1))
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
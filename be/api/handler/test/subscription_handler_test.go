@@ -3,6 +3,7 @@ package handler
 import (
 	"BE_Friends_Management/api/handler"
 	"BE_Friends_Management/internal/domain/dto"
+	"BE_Friends_Management/internal/domain/entity"
 	service "BE_Friends_Management/internal/service/subscription"
 	"bytes"
 	"encoding/json"
@@ -25,6 +26,21 @@ func (m *MockSubscriptionService) CreateSubscription(authUserId int64, requestor
 	return args.Error(0)
 }
 
+func (m *MockSubscriptionService) DeleteSubscription(authUserId int64, authUserRole string, requestor, target string) error {
+	args := m.Called(authUserId, authUserRole, requestor, target)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionService) RetrieveFollowing(authUserId int64, authUserRole string, email string) ([]*entity.User, error) {
+	args := m.Called(authUserId, authUserRole, email)
+	return args.Get(0).([]*entity.User), args.Error(1)
+}
+
+func (m *MockSubscriptionService) RetrieveFollowers(authUserId int64, authUserRole string, email string) ([]*entity.User, error) {
+	args := m.Called(authUserId, authUserRole, email)
+	return args.Get(0).([]*entity.User), args.Error(1)
+}
+
 func TestSubscriptionHandler_CreateSubscription(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -124,3 +140,141 @@ func TestSubscriptionHandler_CreateSubscription(t *testing.T) {
 		})
 	}
 }
+
+func TestSubscriptionHandler_DeleteSubscription(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		requestBody  interface{}
+		serviceError error
+		expectedCode int
+	}{
+		{
+			name:         "Success",
+			requestBody:  dto.DeleteSubscriptionRequest{Requestor: "user1@example.com", Target: "user2@example.com"},
+			serviceError: nil,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Invalid JSON",
+			requestBody:  "invalid json",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Not Subscribed",
+			requestBody:  dto.DeleteSubscriptionRequest{Requestor: "user1@example.com", Target: "user2@example.com"},
+			serviceError: service.ErrNotSubscribed,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "Not Permitted",
+			requestBody:  dto.DeleteSubscriptionRequest{Requestor: "user1@example.com", Target: "user2@example.com"},
+			serviceError: service.ErrNotPermitted,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "Unknown Error",
+			requestBody:  dto.DeleteSubscriptionRequest{Requestor: "user1@example.com", Target: "user2@example.com"},
+			serviceError: errors.New("unknown error"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSubscriptionService)
+			handler := handler.NewSubscriptionHandler(mockService)
+
+			var jsonData []byte
+			if request, ok := tt.requestBody.(dto.DeleteSubscriptionRequest); ok {
+				mockService.On("DeleteSubscription", int64(1), "user", request.Requestor, request.Target).Return(tt.serviceError)
+				jsonData, _ = json.Marshal(request)
+			} else {
+				jsonData = []byte(tt.requestBody.(string))
+			}
+
+			req, _ := http.NewRequest("DELETE", "/api/subscription", bytes.NewBuffer(jsonData))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Set("authUserId", 1)
+			c.Set("authUserRole", "user")
+
+			handler.DeleteSubscription(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSubscriptionHandler_RetrieveFollowers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		email        string
+		users        []*entity.User
+		serviceError error
+		expectedCode int
+	}{
+		{
+			name:         "Success",
+			email:        "user1@example.com",
+			users:        []*entity.User{{Email: "user2@example.com"}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Missing email",
+			email:        "",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "User Not Found",
+			email:        "user1@example.com",
+			users:        []*entity.User{},
+			serviceError: service.ErrUserNotFound,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "Not Permitted",
+			email:        "user1@example.com",
+			users:        []*entity.User{},
+			serviceError: service.ErrNotPermitted,
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSubscriptionService)
+			handler := handler.NewSubscriptionHandler(mockService)
+			if tt.email != "" {
+				mockService.On("RetrieveFollowers", int64(1), "user", tt.email).Return(tt.users, tt.serviceError)
+			}
+
+			req, _ := http.NewRequest("GET", "/api/subscription/followers?email="+tt.email, nil)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Set("authUserId", 1)
+			c.Set("authUserRole", "user")
+
+			handler.RetrieveFollowers(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode == http.StatusOK {
+				var response dto.ApiResponseSuccessWithSubscriptionList
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, []string{"user2@example.com"}, response.Emails)
+				assert.Equal(t, int64(1), response.Count)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"BE_Friends_Management/api/handler"
 	"BE_Friends_Management/constant"
 	"BE_Friends_Management/internal/domain/entity"
+	service "BE_Friends_Management/internal/service/users"
+	"bytes"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -20,9 +22,9 @@ type MockUserService struct {
 	mock.Mock
 }
 
-func (m *MockUserService) GetAllUser() ([]*entity.User, error) {
-	args := m.Called()
-	return args.Get(0).([]*entity.User), args.Error(1)
+func (m *MockUserService) GetAllUser(afterId int64, limit int) ([]*entity.User, int64, error) {
+	args := m.Called(afterId, limit)
+	return args.Get(0).([]*entity.User), args.Get(1).(int64), args.Error(2)
 }
 
 func (m *MockUserService) GetUserById(id int64) (*entity.User, error) {
@@ -40,6 +42,16 @@ func (m *MockUserService) UpdateUser(id int64, email string, password string) (*
 	return args.Get(0).(*entity.User), args.Error(1)
 }
 
+func (m *MockUserService) SetEmailOptOut(id int64, optOut bool) error {
+	args := m.Called(id, optOut)
+	return args.Error(0)
+}
+
+func (m *MockUserService) SetHandle(id int64, handle string) (*entity.User, error) {
+	args := m.Called(id, handle)
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
 func TestUserHandler_GetAllUser(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -56,7 +68,7 @@ func TestUserHandler_GetAllUser(t *testing.T) {
 					{Id: 1, Email: "user1@example.com", CreatedAt: time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)},
 					{Id: 2, Email: "user2@example.com", CreatedAt: time.Date(2025, 8, 2, 10, 0, 0, 0, time.UTC)},
 				}
-				m.On("GetAllUser").Return(users, nil)
+				m.On("GetAllUser", int64(0), 20).Return(users, int64(len(users)), nil)
 			},
 			expectedStatus: http.StatusOK,
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -74,7 +86,7 @@ func TestUserHandler_GetAllUser(t *testing.T) {
 			name: "Success with empty users",
 			setupMock: func(m *MockUserService) {
 				users := []*entity.User{}
-				m.On("GetAllUser").Return(users, nil)
+				m.On("GetAllUser", int64(0), 20).Return(users, int64(len(users)), nil)
 			},
 			expectedStatus: http.StatusOK,
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -92,20 +104,20 @@ func TestUserHandler_GetAllUser(t *testing.T) {
 			name:           "Service returns database error",
 			expectedStatus: http.StatusInternalServerError,
 			setupMock: func(m *MockUserService) {
-				m.On("GetAllUser").Return(nil, errors.New("database connection failed"))
+				m.On("GetAllUser", int64(0), 20).Return(nil, int64(0), errors.New("database connection failed"))
 			},
 		},
 		{
 			name:           "Service returns generic error",
 			expectedStatus: http.StatusInternalServerError,
 			setupMock: func(m *MockUserService) {
-				m.On("GetAllUser").Return(nil, errors.New("unexpected error"))
+				m.On("GetAllUser", int64(0), 20).Return(nil, int64(0), errors.New("unexpected error"))
 			},
 		},
 		{
 			name: "Service returns nil users with no error",
 			setupMock: func(m *MockUserService) {
-				m.On("GetAllUser").Return([]*entity.User{}, nil)
+				m.On("GetAllUser", int64(0), 20).Return([]*entity.User{}, int64(0), nil)
 			},
 			expectedStatus: http.StatusOK,
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -637,3 +649,144 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserHandler_SetEmailOptOut(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func(*MockUserService)
+		expectedStatus int
+	}{
+		{
+			name:        "Success - opt out",
+			requestBody: `{"opt_out": true}`,
+			setupMock: func(m *MockUserService) {
+				m.On("SetEmailOptOut", int64(1), true).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "Success - opt back in",
+			requestBody: `{"opt_out": false}`,
+			setupMock: func(m *MockUserService) {
+				m.On("SetEmailOptOut", int64(1), false).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Missing opt_out",
+			requestBody:    `{}`,
+			setupMock:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Service returns database error",
+			requestBody: `{"opt_out": true}`,
+			setupMock: func(m *MockUserService) {
+				m.On("SetEmailOptOut", int64(1), true).Return(errors.New("database connection failed"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockUserService)
+			userHandler := handler.NewUserHandler(mockService)
+
+			tt.setupMock(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/api/me/email-opt-out", bytes.NewBufferString(tt.requestBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("authUserId", 1)
+
+			userHandler.SetEmailOptOut(c)
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_SetHandle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handle := "alice"
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setupMock      func(*MockUserService)
+		expectedStatus int
+	}{
+		{
+			name:        "Success",
+			requestBody: `{"handle": "@Alice"}`,
+			setupMock: func(m *MockUserService) {
+				m.On("SetHandle", int64(1), "@Alice").Return(&entity.User{Id: 1, Email: "alice@example.com", Handle: &handle}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "Success - clear handle",
+			requestBody: `{"handle": ""}`,
+			setupMock: func(m *MockUserService) {
+				m.On("SetHandle", int64(1), "").Return(&entity.User{Id: 1, Email: "alice@example.com"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Missing handle",
+			requestBody:    `{}`,
+			setupMock:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Invalid handle",
+			requestBody: `{"handle": "a b"}`,
+			setupMock: func(m *MockUserService) {
+				m.On("SetHandle", int64(1), "a b").Return((*entity.User)(nil), service.ErrInvalidHandle)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Handle taken",
+			requestBody: `{"handle": "alice"}`,
+			setupMock: func(m *MockUserService) {
+				m.On("SetHandle", int64(1), "alice").Return((*entity.User)(nil), service.ErrHandleTaken)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:        "Service returns database error",
+			requestBody: `{"handle": "alice"}`,
+			setupMock: func(m *MockUserService) {
+				m.On("SetHandle", int64(1), "alice").Return((*entity.User)(nil), errors.New("database connection failed"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockUserService)
+			userHandler := handler.NewUserHandler(mockService)
+
+			tt.setupMock(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/api/me/handle", bytes.NewBufferString(tt.requestBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("authUserId", 1)
+
+			userHandler.SetHandle(c)
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package handler
+
+import (
+	"BE_Friends_Management/api/handler"
+	"BE_Friends_Management/internal/domain/dto"
+	"BE_Friends_Management/internal/domain/entity"
+	service "BE_Friends_Management/internal/service/webhook"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhookService struct {
+	mock.Mock
+}
+
+func (m *MockWebhookService) Deliver(event entity.Event) error {
+	args := m.Called(event)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) CreateSubscription(url, secret string, eventTypes []string) (*entity.WebhookSubscription, error) {
+	args := m.Called(url, secret, eventTypes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookService) GetSubscriptions() ([]*entity.WebhookSubscription, error) {
+	args := m.Called()
+	return args.Get(0).([]*entity.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookService) DeleteSubscription(subscriptionId int64) error {
+	args := m.Called(subscriptionId)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) GetDeliveries(subscriptionId int64, limit, offset int) ([]*entity.WebhookDelivery, int64, error) {
+	args := m.Called(subscriptionId, limit, offset)
+	return args.Get(0).([]*entity.WebhookDelivery), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWebhookService) DispatchDueDeliveries(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockWebhookService) Run(ctx context.Context) {
+	m.Called(ctx)
+}
+
+func TestCreateWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	eventTypes := []string{entity.EventFriendshipCreated}
+	validRequest := dto.CreateWebhookRequest{Url: "https://example.com/hook", Secret: "secret", EventTypes: eventTypes}
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		expectedStatus int
+		setupMock      func(*MockWebhookService)
+	}{
+		{
+			name:           "Success",
+			requestBody:    validRequest,
+			expectedStatus: http.StatusOK,
+			setupMock: func(m *MockWebhookService) {
+				m.On("CreateSubscription", "https://example.com/hook", "secret", eventTypes).Return(&entity.WebhookSubscription{Id: 1, Url: "https://example.com/hook", Secret: "secret", EventTypes: eventTypes}, nil)
+			},
+		},
+		{
+			name:           "Missing Secret",
+			requestBody:    dto.CreateWebhookRequest{Url: "https://example.com/hook", EventTypes: eventTypes},
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(m *MockWebhookService) {},
+		},
+		{
+			name:           "Invalid Url",
+			requestBody:    validRequest,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(m *MockWebhookService) {
+				m.On("CreateSubscription", "https://example.com/hook", "secret", eventTypes).Return(nil, service.ErrInvalidUrl)
+			},
+		},
+		{
+			name:           "Invalid Event Type",
+			requestBody:    validRequest,
+			expectedStatus: http.StatusBadRequest,
+			setupMock: func(m *MockWebhookService) {
+				m.On("CreateSubscription", "https://example.com/hook", "secret", eventTypes).Return(nil, service.ErrInvalidEventType)
+			},
+		},
+		{
+			name:           "Unknown Error",
+			requestBody:    validRequest,
+			expectedStatus: http.StatusInternalServerError,
+			setupMock: func(m *MockWebhookService) {
+				m.On("CreateSubscription", "https://example.com/hook", "secret", eventTypes).Return(nil, errors.New("database error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockWebhookService)
+			tt.setupMock(mockService)
+
+			handler := handler.NewWebhookHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			jsonBody, err := json.Marshal(tt.requestBody)
+			assert.NoError(t, err)
+			c.Request, _ = http.NewRequest("POST", "/api/webhooks", bytes.NewBuffer(jsonBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("authUserId", int64(1))
+			c.Set("authUserRole", "admin")
+			handler.CreateWebhook(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.NotContains(t, w.Body.String(), `"secret"`)
+				var response dto.ApiResponseSuccessWithWebhook
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, int64(1), response.Webhook.Id)
+				assert.Equal(t, eventTypes, response.Webhook.EventTypes)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetWebhooks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		expectedStatus int
+		expectedCount  int64
+		setupMock      func(*MockWebhookService)
+	}{
+		{
+			name:           "Success",
+			expectedStatus: http.StatusOK,
+			expectedCount:  2,
+			setupMock: func(m *MockWebhookService) {
+				m.On("GetSubscriptions").Return([]*entity.WebhookSubscription{{Id: 1}, {Id: 2}}, nil)
+			},
+		},
+		{
+			name:           "Unknown Error",
+			expectedStatus: http.StatusInternalServerError,
+			setupMock: func(m *MockWebhookService) {
+				m.On("GetSubscriptions").Return([]*entity.WebhookSubscription(nil), errors.New("database error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockWebhookService)
+			tt.setupMock(mockService)
+
+			handler := handler.NewWebhookHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("GET", "/api/webhooks", nil)
+			handler.GetWebhooks(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response dto.ApiResponseSuccessWithWebhooks
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedCount, response.Count)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		webhookId      string
+		expectedStatus int
+		setupMock      func(*MockWebhookService)
+	}{
+		{
+			name:           "Success",
+			webhookId:      "1",
+			expectedStatus: http.StatusOK,
+			setupMock: func(m *MockWebhookService) {
+				m.On("DeleteSubscription", int64(1)).Return(nil)
+			},
+		},
+		{
+			name:           "Invalid Id",
+			webhookId:      "abc",
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(m *MockWebhookService) {},
+		},
+		{
+			name:           "Not Found",
+			webhookId:      "2",
+			expectedStatus: http.StatusNotFound,
+			setupMock: func(m *MockWebhookService) {
+				m.On("DeleteSubscription", int64(2)).Return(service.ErrWebhookNotFound)
+			},
+		},
+		{
+			name:           "Unknown Error",
+			webhookId:      "3",
+			expectedStatus: http.StatusInternalServerError,
+			setupMock: func(m *MockWebhookService) {
+				m.On("DeleteSubscription", int64(3)).Return(errors.New("database error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockWebhookService)
+			tt.setupMock(mockService)
+
+			handler := handler.NewWebhookHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("DELETE", "/api/webhooks/"+tt.webhookId, nil)
+			c.Params = gin.Params{{Key: "id", Value: tt.webhookId}}
+			handler.DeleteWebhook(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetWebhookDeliveries(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	deliveredAt := time.Now()
+	deliveries := []*entity.WebhookDelivery{
+		{Id: 2, EventId: "evt_2", EventType: entity.EventBlockCreated, Status: entity.WebhookDeliveryPending, Attempts: 1, ResponseStatus: 500, LastError: "unexpected response status 500"},
+		{Id: 1, EventId: "evt_1", EventType: entity.EventFriendshipCreated, Status: entity.WebhookDeliverySucceeded, Attempts: 1, ResponseStatus: 200, DeliveredAt: &deliveredAt},
+	}
+
+	tests := []struct {
+		name           string
+		webhookId      string
+		query          string
+		expectedStatus int
+		setupMock      func(*MockWebhookService)
+	}{
+		{
+			name:           "Success",
+			webhookId:      "1",
+			query:          "limit=10&offset=0",
+			expectedStatus: http.StatusOK,
+			setupMock: func(m *MockWebhookService) {
+				m.On("GetDeliveries", int64(1), 10, 0).Return(deliveries, int64(2), nil)
+			},
+		},
+		{
+			name:           "Invalid Id",
+			webhookId:      "abc",
+			expectedStatus: http.StatusBadRequest,
+			setupMock:      func(m *MockWebhookService) {},
+		},
+		{
+			name:           "Not Found",
+			webhookId:      "2",
+			expectedStatus: http.StatusNotFound,
+			setupMock: func(m *MockWebhookService) {
+				m.On("GetDeliveries", int64(2), 20, 0).Return([]*entity.WebhookDelivery(nil), int64(0), service.ErrWebhookNotFound)
+			},
+		},
+		{
+			name:           "Unknown Error",
+			webhookId:      "3",
+			expectedStatus: http.StatusInternalServerError,
+			setupMock: func(m *MockWebhookService) {
+				m.On("GetDeliveries", int64(3), 20, 0).Return([]*entity.WebhookDelivery(nil), int64(0), errors.New("database error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockWebhookService)
+			tt.setupMock(mockService)
+
+			handler := handler.NewWebhookHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("GET", "/api/webhooks/"+tt.webhookId+"/deliveries?"+tt.query, nil)
+			c.Params = gin.Params{{Key: "id", Value: tt.webhookId}}
+			handler.GetWebhookDeliveries(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response dto.ApiResponseSuccessWithWebhookDeliveries
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, int64(2), response.Count)
+				assert.Equal(t, "evt_2", response.Deliveries[0].EventId)
+				assert.Equal(t, "unexpected response status 500", response.Deliveries[0].LastError)
+				assert.NotNil(t, response.Deliveries[1].DeliveredAt)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
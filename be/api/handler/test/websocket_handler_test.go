@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"BE_Friends_Management/api/handler"
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/dto"
+	"BE_Friends_Management/internal/domain/entity"
+	"BE_Friends_Management/internal/realtime"
+	service "BE_Friends_Management/internal/service/notification"
+
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/net/websocket"
+)
+
+func setupWebSocketServer(t *testing.T, mockService *MockNotificationService, hub *realtime.Hub) *websocket.Conn {
+	gin.SetMode(gin.TestMode)
+	wsHandler := handler.NewWebSocketHandler(mockService, hub)
+	r := gin.New()
+	r.GET("/api/ws", func(c *gin.Context) {
+		c.Set("authUserId", int64(1))
+		c.Set("authUserRole", "user")
+		c.Next()
+	}, wsHandler.Serve)
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/api/ws", "", server.URL)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	assert.Eventually(t, func() bool { return hub.SubscriberCount(1) == 1 }, time.Second, 5*time.Millisecond)
+	return conn
+}
+
+func receiveWebSocketMessage(t *testing.T, conn *websocket.Conn) map[string]interface{} {
+	var message map[string]interface{}
+	assert.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	assert.NoError(t, websocket.JSON.Receive(conn, &message))
+	return message
+}
+
+func TestWebSocket_DeliversEvents(t *testing.T) {
+	mockService := new(MockNotificationService)
+	hub := realtime.NewHub(constant.StreamBufferSize)
+	conn := setupWebSocketServer(t, mockService, hub)
+
+	hub.Publish(1, realtime.Event{Id: 9, Type: realtime.EventUpdate, Payload: &entity.UpdateDelivery{Id: 9, UpdateId: 5, Update: &entity.Update{Id: 5, Text: "Hello"}}})
+	hub.Publish(1, realtime.Event{Id: 3, Type: realtime.EventFriendRequest, Payload: &entity.FriendRequest{Id: 3, Status: entity.FriendRequestPending, Requestor: &entity.User{Email: "user2@example.com"}}})
+	hub.Publish(1, realtime.Event{Type: realtime.EventBlock, Payload: &entity.BlockRelationship{Requestor: &entity.User{Email: "user1@example.com"}, Target: &entity.User{Email: "user2@example.com"}}})
+
+	update := receiveWebSocketMessage(t, conn)
+	assert.Equal(t, "update", update["type"])
+	assert.Equal(t, float64(9), update["id"])
+	assert.Equal(t, "Hello", update["data"].(map[string]interface{})["text"])
+
+	friendRequest := receiveWebSocketMessage(t, conn)
+	assert.Equal(t, "friend_request", friendRequest["type"])
+	assert.Equal(t, "user2@example.com", friendRequest["data"].(map[string]interface{})["requestor"])
+
+	block := receiveWebSocketMessage(t, conn)
+	assert.Equal(t, "block", block["type"])
+	assert.Equal(t, "user2@example.com", block["data"].(map[string]interface{})["target"])
+}
+
+func TestWebSocket_Acks(t *testing.T) {
+	tests := []struct {
+		name          string
+		message       interface{}
+		mockSetup     func(*MockNotificationService, chan struct{})
+		expectedError string
+	}{
+		{
+			name:    "Ack Update",
+			message: dto.WebSocketInboundMessage{Type: "ack", Event: "update", Id: 9},
+			mockSetup: func(m *MockNotificationService, called chan struct{}) {
+				m.On("MarkFeedItemRead", int64(1), int64(9)).Return(nil).Run(func(_ mock.Arguments) { close(called) })
+			},
+		},
+		{
+			name:    "Ack Unknown Feed Item",
+			message: dto.WebSocketInboundMessage{Type: "ack", Event: "update", Id: 9},
+			mockSetup: func(m *MockNotificationService, called chan struct{}) {
+				m.On("MarkFeedItemRead", int64(1), int64(9)).Return(service.ErrFeedItemNotFound)
+			},
+			expectedError: service.ErrFeedItemNotFound.Error(),
+		},
+		{
+			name:    "Ack Failure",
+			message: dto.WebSocketInboundMessage{Type: "ack", Event: "update", Id: 9},
+			mockSetup: func(m *MockNotificationService, called chan struct{}) {
+				m.On("MarkFeedItemRead", int64(1), int64(9)).Return(errors.New("database error"))
+			},
+			expectedError: "Happened error when acknowledging event.",
+		},
+		{
+			name:          "Unsupported Message",
+			message:       dto.WebSocketInboundMessage{Type: "subscribe"},
+			mockSetup:     func(m *MockNotificationService, called chan struct{}) {},
+			expectedError: "Unsupported message type.",
+		},
+		{
+			name:          "Invalid Message",
+			message:       "not an object",
+			mockSetup:     func(m *MockNotificationService, called chan struct{}) {},
+			expectedError: "Invalid message format.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockNotificationService)
+			called := make(chan struct{})
+			tt.mockSetup(mockService, called)
+			hub := realtime.NewHub(constant.StreamBufferSize)
+			conn := setupWebSocketServer(t, mockService, hub)
+
+			assert.NoError(t, websocket.JSON.Send(conn, tt.message))
+
+			if tt.expectedError != "" {
+				message := receiveWebSocketMessage(t, conn)
+				assert.Equal(t, "error", message["type"])
+				assert.Equal(t, tt.expectedError, message["error"])
+			} else {
+				select {
+				case <-called:
+				case <-time.After(time.Second):
+					t.Fatal("ack was not handled")
+				}
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebSocket_ClosesWhenHubCloses(t *testing.T) {
+	mockService := new(MockNotificationService)
+	hub := realtime.NewHub(constant.StreamBufferSize)
+	conn := setupWebSocketServer(t, mockService, hub)
+
+	hub.Close()
+
+	var message map[string]interface{}
+	assert.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	err := websocket.JSON.Receive(conn, &message)
+	assert.Error(t, err)
+	assert.Equal(t, 0, hub.SubscriberCount(1))
+}
+
+func TestWebSocket_DropsSlowConsumer(t *testing.T) {
+	mockService := new(MockNotificationService)
+	hub := realtime.NewHub(1)
+	conn := setupWebSocketServer(t, mockService, hub)
+
+	// The client never reads, so once the connection's buffer is full the
+	// hub drops it instead of blocking the publisher.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := int64(1); i <= 10000 && hub.SubscriberCount(1) > 0; i++ {
+			hub.Publish(1, realtime.Event{Id: i, Type: realtime.EventUpdate, Payload: &entity.UpdateDelivery{Id: i, Update: &entity.Update{Text: strings.Repeat("x", 1024)}}})
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("publisher blocked on a slow consumer")
+	}
+	assert.Equal(t, 0, hub.SubscriberCount(1))
+	conn.Close()
+}
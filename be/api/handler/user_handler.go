@@ -2,8 +2,10 @@ package handler
 
 import (
 	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/dto"
 	service "BE_Friends_Management/internal/service/users"
 	"BE_Friends_Management/pkg"
+	"BE_Friends_Management/pkg/utils"
 	"errors"
 	"net/http"
 	"strconv"
@@ -26,6 +28,8 @@ func NewUserHandler(service service.UserService) *UserHandler {
 // @Tags         Users Management
 // @Accept       json
 // @Produce      json
+// @Param 		 limit query int false "Page size"
+// @Param 		 after query string false "Cursor returned as next_cursor by the previous page"
 // @param Authorization header string true "Authorization"
 // @Router       /api/users [GET]
 // @Success      200   {object}  dto.ApiResponseSuccessStruct
@@ -35,12 +39,17 @@ func NewUserHandler(service service.UserService) *UserHandler {
 // @Security JWT
 func (h *UserHandler) GetAllUser(c *gin.Context) {
 	defer pkg.PanicHandler(c)
-	users, err := h.service.GetAllUser()
+	limit, afterId := utils.GetCursorPaginationParams(c)
+	users, count, err := h.service.GetAllUser(afterId, limit)
 	if err != nil {
 		log.Error("Happened error when getting all users. Error: ", err)
 		pkg.PanicExeption(constant.UnknownError, "Happened error when getting all users")
 	}
-	c.JSON(http.StatusOK, pkg.BuildResponseSuccess(constant.Success, users))
+	var lastId int64
+	if len(users) > 0 {
+		lastId = users[len(users)-1].Id
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithCursor(constant.Success, users, utils.BuildNextCursor(lastId, len(users), limit), count))
 }
 
 // User godoc
@@ -151,3 +160,72 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, pkg.BuildResponseSuccess(constant.Success, updatedUser))
 }
+
+// User godoc
+// @Summary      Opt out of notification emails
+// @Description  Turn notification emails for the authenticated user off, or back on.
+// @Tags         Users Management
+// @Accept       json
+// @Produce      json
+// @Param 		 request body dto.EmailOptOutRequest true "Whether to opt out"
+// @param Authorization header string true "Authorization"
+// @Router       /api/me/email-opt-out [PUT]
+// @Success      200   {object}  dto.ApiResponseSuccessNoData
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *UserHandler) SetEmailOptOut(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	var request dto.EmailOptOutRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error("Happened error when mapping request. Error: ", err)
+		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
+	}
+	err := h.service.SetEmailOptOut(authUserId, *request.OptOut)
+	if err != nil {
+		log.Error("Happened error when updating email opt-out. Error: ", err)
+		pkg.PanicExeption(constant.UnknownError, "Happened error when updating email opt-out")
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessNoData())
+}
+
+// User godoc
+// @Summary      Set handle
+// @Description  Set the handle others use to @mention the authenticated user: 3 to 30 letters, digits or underscores, case-insensitive. An empty handle clears it.
+// @Tags         Users Management
+// @Accept       json
+// @Produce      json
+// @Param 		 request body dto.SetHandleRequest true "New handle"
+// @param Authorization header string true "Authorization"
+// @Router       /api/me/handle [PUT]
+// @Success      200   {object}  dto.ApiResponseSuccessStruct
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *UserHandler) SetHandle(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	authUserId := utils.GetAuthUserId(c)
+	var request dto.SetHandleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error("Happened error when mapping request. Error: ", err)
+		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
+	}
+	updatedUser, err := h.service.SetHandle(authUserId, *request.Handle)
+	if err != nil {
+		log.Error("Happened error when setting handle. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrInvalidHandle):
+			pkg.PanicExeption(constant.InvalidRequest, err.Error())
+		case errors.Is(err, service.ErrHandleTaken):
+			pkg.PanicExeption(constant.Conflict, err.Error())
+		case errors.Is(err, service.ErrUserNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when setting handle")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccess(constant.Success, updatedUser))
+}
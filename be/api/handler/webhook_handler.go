@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/dto"
+	service "BE_Friends_Management/internal/service/webhook"
+	"BE_Friends_Management/pkg"
+	"BE_Friends_Management/pkg/utils"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+type WebhookHandler struct {
+	service service.WebhookService
+}
+
+func NewWebhookHandler(service service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+// Webhook godoc
+// @Summary      Create webhook subscription
+// @Description  Register a URL that receives the given event types. Every delivery is signed with the secret as X-Webhook-Signature: sha256=<hex HMAC-SHA256 of the body>.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param 		 request body dto.CreateWebhookRequest true "Target URL, signing secret and event types"
+// @param Authorization header string true "Authorization"
+// @Router       /api/webhooks [POST]
+// @Success      200   {object}  dto.ApiResponseSuccessWithWebhook
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	var request dto.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Error("Happened error when mapping request. Error: ", err)
+		pkg.PanicExeption(constant.InvalidRequest, "Invalid request format.")
+	}
+	subscription, err := h.service.CreateSubscription(request.Url, request.Secret, request.EventTypes)
+	if err != nil {
+		log.Error("Happened error when creating webhook. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrInvalidUrl):
+			pkg.PanicExeption(constant.InvalidRequest, err.Error())
+		case errors.Is(err, service.ErrInvalidEventType):
+			pkg.PanicExeption(constant.InvalidRequest, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when creating webhook.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithWebhook(utils.ConvertWebhookToResponse(subscription)))
+}
+
+// Webhook godoc
+// @Summary      List webhook subscriptions
+// @Description  List every webhook subscription
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @param Authorization header string true "Authorization"
+// @Router       /api/webhooks [GET]
+// @Success      200   {object}  dto.ApiResponseSuccessWithWebhooks
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	subscriptions, err := h.service.GetSubscriptions()
+	if err != nil {
+		log.Error("Happened error when getting webhooks. Error: ", err)
+		pkg.PanicExeption(constant.UnknownError, "Happened error when getting webhooks.")
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithWebhooks(utils.ConvertWebhooksToResponses(subscriptions)))
+}
+
+// Webhook godoc
+// @Summary      Delete webhook subscription
+// @Description  Delete a webhook subscription along with its delivery log
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Webhook ID"
+// @param Authorization header string true "Authorization"
+// @Router       /api/webhooks/{id} [DELETE]
+// @Success      200   {object}  dto.ApiResponseSuccessNoData
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	webhookId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error("Happened error when converting webhook ID to int64. Error: ", err)
+		pkg.PanicExeption(constant.InvalidRequest, "Happened error when converting webhook ID to int64")
+	}
+	err = h.service.DeleteSubscription(webhookId)
+	if err != nil {
+		log.Error("Happened error when deleting webhook. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrWebhookNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when deleting webhook.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessNoData())
+}
+
+// Webhook godoc
+// @Summary      Get webhook delivery log
+// @Description  List the delivery attempts of a webhook subscription, newest first
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Webhook ID"
+// @Param 		 limit query int false "Page size"
+// @Param 		 offset query int false "Offset"
+// @param Authorization header string true "Authorization"
+// @Router       /api/webhooks/{id}/deliveries [GET]
+// @Success      200   {object}  dto.ApiResponseSuccessWithWebhookDeliveries
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *WebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	defer pkg.PanicHandler(c)
+	webhookId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error("Happened error when converting webhook ID to int64. Error: ", err)
+		pkg.PanicExeption(constant.InvalidRequest, "Happened error when converting webhook ID to int64")
+	}
+	limit, offset := utils.GetPaginationParams(c)
+	deliveries, count, err := h.service.GetDeliveries(webhookId, limit, offset)
+	if err != nil {
+		log.Error("Happened error when getting webhook deliveries. Error: ", err)
+		switch {
+		case errors.Is(err, service.ErrWebhookNotFound):
+			pkg.PanicExeption(constant.DataNotFound, err.Error())
+		default:
+			pkg.PanicExeption(constant.UnknownError, "Happened error when getting webhook deliveries.")
+		}
+	}
+	c.JSON(http.StatusOK, pkg.BuildResponseSuccessWithWebhookDeliveries(utils.ConvertWebhookDeliveriesToResponses(deliveries), count))
+}
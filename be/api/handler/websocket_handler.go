@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/dto"
+	"BE_Friends_Management/internal/realtime"
+	service "BE_Friends_Management/internal/service/notification"
+	"BE_Friends_Management/pkg/utils"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	webSocketMessageAck   = "ack"
+	webSocketMessageError = "error"
+)
+
+type WebSocketHandler struct {
+	service service.NotificationService
+	hub     *realtime.Hub
+}
+
+func NewWebSocketHandler(service service.NotificationService, hub *realtime.Hub) *WebSocketHandler {
+	return &WebSocketHandler{service: service, hub: hub}
+}
+
+// WebSocket godoc
+// @Summary      WebSocket gateway
+// @Description  WebSocket delivering update, friend_request, block and unblock events to the authenticated user as {"type","id","data"} messages. Clients acknowledge with {"type":"ack","event":"update","id":<feed item id>}, which marks the feed item as read.
+// @Tags         Notification
+// @param Authorization header string true "Authorization"
+// @Router       /api/ws [GET]
+// @Success      101
+// @securityDefinitions.apiKey token
+// @in header
+// @name Authorization
+// @Security JWT
+func (h *WebSocketHandler) Serve(c *gin.Context) {
+	authUserId := utils.GetAuthUserId(c)
+	server := websocket.Server{
+		Handler: func(conn *websocket.Conn) {
+			h.serveConnection(conn, authUserId)
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// serveConnection pumps hub events to the client until the client leaves,
+// a write misses its deadline, or the hub drops the subscription because
+// its buffer filled up.
+func (h *WebSocketHandler) serveConnection(conn *websocket.Conn, authUserId int64) {
+	conn.MaxPayloadBytes = constant.WebSocketMaxMessageSize
+	subscription := h.hub.Subscribe(authUserId)
+	defer h.hub.Unsubscribe(subscription)
+	defer conn.Close()
+
+	var writeMu sync.Mutex
+	send := func(message dto.WebSocketOutboundMessage) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		if err := conn.SetWriteDeadline(time.Now().Add(constant.WebSocketWriteTimeout)); err != nil {
+			return err
+		}
+		return websocket.JSON.Send(conn, message)
+	}
+
+	readerDone := make(chan struct{})
+	go func() {
+		defer close(readerDone)
+		h.readMessages(conn, authUserId, send)
+	}()
+
+	for {
+		select {
+		case <-readerDone:
+			return
+		case event, ok := <-subscription.Events:
+			if !ok {
+				log.Info("Closing websocket of user ", authUserId)
+				return
+			}
+			err := send(dto.WebSocketOutboundMessage{
+				Type: event.Type,
+				Id:   event.Id,
+				Data: utils.ConvertRealtimeEventData(event),
+			})
+			if err != nil {
+				log.Warn("Dropping websocket of user ", authUserId, ". Error: ", err)
+				return
+			}
+		}
+	}
+}
+
+func (h *WebSocketHandler) readMessages(conn *websocket.Conn, authUserId int64, send func(dto.WebSocketOutboundMessage) error) {
+	for {
+		var message dto.WebSocketInboundMessage
+		err := websocket.JSON.Receive(conn, &message)
+		if err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				send(dto.WebSocketOutboundMessage{Type: webSocketMessageError, Error: "Invalid message format."})
+				continue
+			}
+			return
+		}
+		if message.Type != webSocketMessageAck {
+			send(dto.WebSocketOutboundMessage{Type: webSocketMessageError, Error: "Unsupported message type."})
+			continue
+		}
+		// Only update deliveries are persisted per recipient; acks of other
+		// events need no bookkeeping.
+		if message.Event != realtime.EventUpdate {
+			continue
+		}
+		err = h.service.MarkFeedItemRead(authUserId, message.Id)
+		if err != nil {
+			log.Error("Happened error when acknowledging event. Error: ", err)
+			switch {
+			case errors.Is(err, service.ErrFeedItemNotFound):
+				send(dto.WebSocketOutboundMessage{Type: webSocketMessageError, Id: message.Id, Error: err.Error()})
+			default:
+				send(dto.WebSocketOutboundMessage{Type: webSocketMessageError, Id: message.Id, Error: "Happened error when acknowledging event."})
+			}
+		}
+	}
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/revocation"
 	"context"
 	"errors"
 	"fmt"
@@ -17,8 +18,19 @@ import (
 var (
 	ErrAccessTokenExpires = errors.New("access token has expired")
 	ErrNotPermitted       = errors.New("action not permitted")
+	ErrAccessTokenRevoked = errors.New("access token has been revoked")
 )
 
+// accessTokenDenylist is checked by ValidateAccessToken once set. Tokens are
+// not checked for revocation while it is nil.
+var accessTokenDenylist *revocation.Denylist
+
+// SetAccessTokenDenylist makes ValidateAccessToken reject the access tokens
+// revoked in denylist. It must be called before the server starts.
+func SetAccessTokenDenylist(denylist *revocation.Denylist) {
+	accessTokenDenylist = denylist
+}
+
 func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
@@ -70,6 +82,10 @@ func ValidateAccessToken() gin.HandlerFunc {
 			log.Error("Happened error when validating access token. Error: ", ErrAccessTokenExpires)
 			pkg.PanicExeption(constant.Unauthorized, ErrAccessTokenExpires.Error())
 		}
+		if accessTokenDenylist != nil && accessTokenDenylist.IsRevoked(claims) {
+			log.Error("Happened error when validating access token. Error: ", ErrAccessTokenRevoked)
+			pkg.PanicExeption(constant.Unauthorized, ErrAccessTokenRevoked.Error())
+		}
 		c.Set("authUserId", claims.UserId)
 		c.Set("authUserRole", claims.Role)
 		c.Next()
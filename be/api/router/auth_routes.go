@@ -2,6 +2,7 @@ package api
 
 import (
 	"BE_Friends_Management/api/handler"
+	"BE_Friends_Management/api/middleware"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -12,4 +13,11 @@ func registerAuthRoutes(api *gin.RouterGroup, h *handler.AuthHandler, db *gorm.D
 	api.POST("/auth/login", h.Login)
 	api.POST("/auth/refresh", h.RefreshAccessToken)
 	api.POST("auth/logout", h.Logout)
+	// The group serves unauthenticated routes too, so the session routes
+	// validate the access token themselves.
+	api.POST("/auth/logout-all", middleware.ValidateAccessToken(), middleware.RequireAnyRole([]string{"admin", "user"}), h.LogoutAll)
+	api.GET("/me/sessions", middleware.ValidateAccessToken(), middleware.RequireAnyRole([]string{"admin", "user"}), h.GetSessions)
+	api.DELETE("/me/sessions/:id", middleware.ValidateAccessToken(), middleware.RequireAnyRole([]string{"admin", "user"}), h.RevokeSession)
+	api.GET("/users/:id/sessions", middleware.ValidateAccessToken(), middleware.RequireAnyRole([]string{"admin"}), h.GetUserSessions)
+	api.DELETE("/users/:id/sessions", middleware.ValidateAccessToken(), middleware.RequireAnyRole([]string{"admin"}), h.LogoutUser)
 }
@@ -11,4 +11,6 @@ import (
 func registerBlockRoutes(api *gin.RouterGroup, h *handler.BlockRelationshipHandler, db *gorm.DB) {
 	api.Use(middleware.ValidateAccessToken())
 	api.POST("/block", middleware.RequireAnyRole([]string{"user"}), h.CreateBlockRelationship)
+	api.DELETE("/block", middleware.RequireAnyRole([]string{"admin", "user"}), h.DeleteBlockRelationship)
+	api.GET("/block", middleware.RequireAnyRole([]string{"admin", "user"}), h.RetrieveBlockedUsers)
 }
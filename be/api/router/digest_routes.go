@@ -0,0 +1,15 @@
+package api
+
+import (
+	"BE_Friends_Management/api/handler"
+	"BE_Friends_Management/api/middleware"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func registerDigestRoutes(api *gin.RouterGroup, h *handler.DigestHandler, db *gorm.DB) {
+	api.Use(middleware.ValidateAccessToken())
+	api.GET("/me/digest", middleware.RequireAnyRole([]string{"admin", "user"}), h.GetDigestPreference)
+	api.PUT("/me/digest", middleware.RequireAnyRole([]string{"admin", "user"}), h.UpdateDigestPreference)
+}
@@ -0,0 +1,19 @@
+package api
+
+import (
+	"BE_Friends_Management/api/handler"
+	"BE_Friends_Management/api/middleware"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func registerFriendRequestRoutes(api *gin.RouterGroup, h *handler.FriendRequestHandler, db *gorm.DB) {
+	api.Use(middleware.ValidateAccessToken())
+	api.POST("/friend-requests", middleware.RequireAnyRole([]string{"user"}), h.SendFriendRequest)
+	api.GET("/friend-requests/incoming", middleware.RequireAnyRole([]string{"admin", "user"}), h.RetrieveIncomingFriendRequests)
+	api.GET("/friend-requests/outgoing", middleware.RequireAnyRole([]string{"admin", "user"}), h.RetrieveOutgoingFriendRequests)
+	api.POST("/friend-requests/:id/accept", middleware.RequireAnyRole([]string{"user"}), h.AcceptFriendRequest)
+	api.POST("/friend-requests/:id/reject", middleware.RequireAnyRole([]string{"user"}), h.RejectFriendRequest)
+	api.POST("/friend-requests/:id/cancel", middleware.RequireAnyRole([]string{"user"}), h.CancelFriendRequest)
+}
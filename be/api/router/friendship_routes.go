@@ -10,7 +10,10 @@ import (
 
 func registerFriendshipRoutes(api *gin.RouterGroup, h *handler.FriendshipHandler, db *gorm.DB) {
 	api.Use(middleware.ValidateAccessToken())
-	api.POST("/friendship", middleware.RequireAnyRole([]string{"user"}), h.CreateFriendship)
+	api.POST("/friendship", middleware.RequireAnyRole([]string{"admin"}), h.CreateFriendship)
+	api.DELETE("/friendship", middleware.RequireAnyRole([]string{"user"}), h.DeleteFriendship)
 	api.GET("/friendship/friends", middleware.RequireAnyRole([]string{"admin", "user"}), h.RetrieveFriendsList)
 	api.GET("/friendship/common-friends", middleware.RequireAnyRole([]string{"admin", "user"}), h.RetrieveCommonFriends)
+	api.GET("/friendship/suggestions", middleware.RequireAnyRole([]string{"admin", "user"}), h.RetrieveFriendSuggestions)
+	api.GET("/friendship/path", middleware.RequireAnyRole([]string{"admin", "user"}), h.RetrieveFriendshipPath)
 }
@@ -11,13 +11,24 @@ import (
 
 func SetupRoutes(r *gin.Engine, handlers *handler.Handlers, db *gorm.DB) {
 	r.Use(middleware.CORSMiddleware())
+	// Streaming routes are long-lived, so they are registered before the
+	// request timeout is installed.
+	streamApi := r.Group("/api")
+	registerStreamRoutes(streamApi, handlers.StreamHandler, handlers.WebSocketHandler, db)
 	r.Use(middleware.TimeoutMiddleware(5 * time.Second))
 	api := r.Group("/api")
 	authApi := r.Group("/api")
 	registerUserRoutes(api, handlers.User, db)
 	registerFriendshipRoutes(api, handlers.Friendship, db)
+	registerFriendRequestRoutes(api, handlers.FriendRequest, db)
 	registerSubscriptionRoutes(api, handlers.Subscription, db)
 	registerBlockRoutes(api, handlers.BlockRelationship, db)
+	registerMuteRoutes(api, handlers.Mute, db)
 	registerNotificationRoutes(api, handlers.NotificationHandler, db)
+	registerWebhookRoutes(api, handlers.WebhookHandler, db)
+	registerDigestRoutes(api, handlers.DigestHandler, db)
+	registerNotificationPreferenceRoutes(api, handlers.NotificationPreferenceHandler, db)
 	registerAuthRoutes(authApi, handlers.AuthHandler, db)
+	wellKnown := r.Group("/.well-known")
+	registerJwksRoutes(wellKnown, handlers.JwksHandler, db)
 }
@@ -0,0 +1,12 @@
+package api
+
+import (
+	"BE_Friends_Management/api/handler"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func registerJwksRoutes(wellKnown *gin.RouterGroup, h *handler.JwksHandler, db *gorm.DB) {
+	wellKnown.GET("/jwks.json", h.GetJwks)
+}
@@ -0,0 +1,16 @@
+package api
+
+import (
+	"BE_Friends_Management/api/handler"
+	"BE_Friends_Management/api/middleware"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func registerMuteRoutes(api *gin.RouterGroup, h *handler.MuteHandler, db *gorm.DB) {
+	api.Use(middleware.ValidateAccessToken())
+	api.POST("/mute", middleware.RequireAnyRole([]string{"user"}), h.CreateMute)
+	api.DELETE("/mute", middleware.RequireAnyRole([]string{"admin", "user"}), h.DeleteMute)
+	api.GET("/mute", middleware.RequireAnyRole([]string{"admin", "user"}), h.RetrieveMutedUsers)
+}
@@ -0,0 +1,15 @@
+package api
+
+import (
+	"BE_Friends_Management/api/handler"
+	"BE_Friends_Management/api/middleware"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func registerNotificationPreferenceRoutes(api *gin.RouterGroup, h *handler.NotificationPreferenceHandler, db *gorm.DB) {
+	api.Use(middleware.ValidateAccessToken())
+	api.GET("/me/notification-preferences", middleware.RequireAnyRole([]string{"admin", "user"}), h.GetNotificationPreference)
+	api.PUT("/me/notification-preferences", middleware.RequireAnyRole([]string{"admin", "user"}), h.UpdateNotificationPreference)
+}
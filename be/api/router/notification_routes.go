@@ -10,4 +10,8 @@ import (
 func registerNotificationRoutes(api *gin.RouterGroup, h *handler.NotificationHandler, db *gorm.DB) {
 	api.Use(middleware.ValidateAccessToken())
 	api.POST("/update-recipients", middleware.RequireAnyRole([]string{"admin", "user"}), h.GetUpdateRecipients)
+	api.POST("/updates", middleware.RequireAnyRole([]string{"admin", "user"}), h.PublishUpdate)
+	api.GET("/feed", middleware.RequireAnyRole([]string{"admin", "user"}), h.GetFeed)
+	api.POST("/feed/:id/read", middleware.RequireAnyRole([]string{"admin", "user"}), h.MarkFeedItemRead)
+	api.GET("/me/mentions", middleware.RequireAnyRole([]string{"admin", "user"}), h.GetMentions)
 }
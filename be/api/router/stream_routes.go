@@ -0,0 +1,15 @@
+package api
+
+import (
+	"BE_Friends_Management/api/handler"
+	"BE_Friends_Management/api/middleware"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func registerStreamRoutes(api *gin.RouterGroup, h *handler.StreamHandler, ws *handler.WebSocketHandler, db *gorm.DB) {
+	api.Use(middleware.ValidateAccessToken())
+	api.GET("/stream", middleware.RequireAnyRole([]string{"admin", "user"}), h.Stream)
+	api.GET("/ws", middleware.RequireAnyRole([]string{"admin", "user"}), ws.Serve)
+}
@@ -10,4 +10,7 @@ import (
 func registerSubscriptionRoutes(api *gin.RouterGroup, h *handler.SubscriptionHandler, db *gorm.DB) {
 	api.Use(middleware.ValidateAccessToken())
 	api.POST("/subscription", middleware.RequireAnyRole([]string{"user"}), h.CreateSubscription)
+	api.DELETE("/subscription", middleware.RequireAnyRole([]string{"admin", "user"}), h.DeleteSubscription)
+	api.GET("/subscription/following", middleware.RequireAnyRole([]string{"admin", "user"}), h.RetrieveFollowing)
+	api.GET("/subscription/followers", middleware.RequireAnyRole([]string{"admin", "user"}), h.RetrieveFollowers)
 }
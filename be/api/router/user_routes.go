@@ -13,4 +13,6 @@ func registerUserRoutes(api *gin.RouterGroup, h *handler.UserHandler, db *gorm.D
 	api.GET("/users/:id", middleware.RequireAnyRole([]string{"admin"}), h.GetUserById)
 	api.DELETE("/users/:id", middleware.RequireAnyRole([]string{"admin"}), h.DeleteUserById)
 	api.PUT("users/:id", middleware.RequireAnyRole([]string{"admin"}), h.UpdateUser)
+	api.PUT("/me/email-opt-out", middleware.RequireAnyRole([]string{"admin", "user"}), h.SetEmailOptOut)
+	api.PUT("/me/handle", middleware.RequireAnyRole([]string{"admin", "user"}), h.SetHandle)
 }
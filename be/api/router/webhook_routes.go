@@ -0,0 +1,17 @@
+package api
+
+import (
+	"BE_Friends_Management/api/handler"
+	"BE_Friends_Management/api/middleware"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func registerWebhookRoutes(api *gin.RouterGroup, h *handler.WebhookHandler, db *gorm.DB) {
+	api.Use(middleware.ValidateAccessToken())
+	api.POST("/webhooks", middleware.RequireAnyRole([]string{"admin"}), h.CreateWebhook)
+	api.GET("/webhooks", middleware.RequireAnyRole([]string{"admin"}), h.GetWebhooks)
+	api.DELETE("/webhooks/:id", middleware.RequireAnyRole([]string{"admin"}), h.DeleteWebhook)
+	api.GET("/webhooks/:id/deliveries", middleware.RequireAnyRole([]string{"admin"}), h.GetWebhookDeliveries)
+}
@@ -15,6 +15,26 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "JSON Web Key Set other services verify access tokens with, matching the kid header of a token to the kid of a key. Keys stay listed while tokens they signed may still be valid. Empty when tokens are signed with a shared secret.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Get the public keys of access tokens",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JwksResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/auth/login": {
             "post": {
                 "description": "Login",
@@ -71,6 +91,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.LogoutRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Access token to revoke along with the refresh token",
+                        "name": "Authorization",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -83,6 +109,43 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/auth/logout-all": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Revoke every refresh token and access token of the authenticated user, including the one making the request.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Logout everywhere",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessNoData"
+                        }
+                    }
+                }
+            }
+        },
         "/api/auth/refresh": {
             "post": {
                 "description": "Refresh Access Token",
@@ -157,13 +220,67 @@ const docTemplate = `{
             }
         },
         "/api/block": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Retrieve the users blocked by an email address, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "BlockRelationship"
+                ],
+                "summary": "Retrieve blocked users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email address",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of blocks to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithBlockedUsers"
+                        }
+                    }
+                }
+            },
             "post": {
                 "security": [
                     {
                         "JWT": []
                     }
                 ],
-                "description": "Create new block relationship",
+                "description": "Create new block relationship, for a duration (e.g. 30m, 8h) or until unblocked. An expired block is lifted automatically.",
                 "consumes": [
                     "application/json"
                 ],
@@ -176,7 +293,7 @@ const docTemplate = `{
                 "summary": "Create new block relationship",
                 "parameters": [
                     {
-                        "description": "Requestor's email and target's email",
+                        "description": "Requestor's email, target's email and optional duration",
                         "name": "request",
                         "in": "body",
                         "required": true,
@@ -200,16 +317,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/friendship": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "JWT": []
                     }
                 ],
-                "description": "Create new friendship",
+                "description": "Lift a block. Only the requestor of the block or an admin can lift it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -217,17 +332,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Friendship"
+                    "BlockRelationship"
                 ],
-                "summary": "Create new friendship",
+                "summary": "Delete block relationship",
                 "parameters": [
                     {
-                        "description": "List of 2 friend emails",
+                        "description": "Requestor's email and target's email",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateFriendshipRequest"
+                            "$ref": "#/definitions/dto.DeleteBlockRequest"
                         }
                     },
                     {
@@ -248,38 +363,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/friendship/common-friends": {
+        "/api/feed": {
             "get": {
                 "security": [
                     {
                         "JWT": []
                     }
                 ],
-                "description": "Retrieve common friends list between two email addresses",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the updates delivered to the authenticated user, newest first, with read flags.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Friendship"
+                    "Notification"
                 ],
-                "summary": "Retrieve common friends list between two email addresses",
+                "summary": "Get feed",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Email address of user 1",
-                        "name": "email1",
-                        "in": "query",
-                        "required": true
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Email address of user 2",
-                        "name": "email2",
-                        "in": "query",
-                        "required": true
+                        "description": "Cursor returned as next_cursor by the previous page",
+                        "name": "after",
+                        "in": "query"
                     },
                     {
                         "type": "string",
@@ -293,36 +403,33 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ApiResponseSuccessWithFriendsList"
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithFeed"
                         }
                     }
                 }
             }
         },
-        "/api/friendship/friends": {
-            "get": {
+        "/api/feed/{id}/read": {
+            "post": {
                 "security": [
                     {
                         "JWT": []
                     }
                 ],
-                "description": "Retrieve friends list for an email address",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mark an update of the authenticated user's feed as read.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Friendship"
+                    "Notification"
                 ],
-                "summary": "Retrieve friends list for an email address",
+                "summary": "Mark feed item as read",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Email address",
-                        "name": "email",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "Feed item ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
@@ -337,20 +444,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ApiResponseSuccessWithFriendsList"
+                            "$ref": "#/definitions/dto.ApiResponseSuccessNoData"
                         }
                     }
                 }
             }
         },
-        "/api/subscription": {
+        "/api/friend-requests": {
             "post": {
                 "security": [
                     {
                         "JWT": []
                     }
                 ],
-                "description": "Create new subscription",
+                "description": "Send a friend request from requestor to target. The friendship is only created once the target accepts.",
                 "consumes": [
                     "application/json"
                 ],
@@ -358,9 +465,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Subscription"
+                    "FriendRequest"
                 ],
-                "summary": "Create new subscription",
+                "summary": "Send friend request",
                 "parameters": [
                     {
                         "description": "Requestor's email and target's email",
@@ -368,7 +475,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateSubscriptionRequest"
+                            "$ref": "#/definitions/dto.SendFriendRequestRequest"
                         }
                     },
                     {
@@ -383,20 +490,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ApiResponseSuccessNoData"
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithFriendRequest"
                         }
                     }
                 }
             }
         },
-        "/api/update-recipients": {
-            "post": {
+        "/api/friend-requests/incoming": {
+            "get": {
                 "security": [
                     {
                         "JWT": []
                     }
                 ],
-                "description": "Get all email addresses that can receive updates from an email address.",
+                "description": "Retrieve pending friend requests sent to an email address",
                 "consumes": [
                     "application/json"
                 ],
@@ -404,18 +511,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notification"
+                    "FriendRequest"
                 ],
-                "summary": "Get update recipients",
+                "summary": "Retrieve incoming friend requests",
                 "parameters": [
                     {
-                        "description": "Sender email and update text",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.GetUpdateRecipientsRequest"
-                        }
+                        "type": "string",
+                        "description": "Email address",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
@@ -429,20 +534,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ApiResponseSuccessNoData"
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithFriendRequests"
                         }
                     }
                 }
             }
         },
-        "/api/users": {
+        "/api/friend-requests/outgoing": {
             "get": {
                 "security": [
                     {
                         "JWT": []
                     }
                 ],
-                "description": "Get all user",
+                "description": "Retrieve pending friend requests sent by an email address",
                 "consumes": [
                     "application/json"
                 ],
@@ -450,10 +555,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users Management"
+                    "FriendRequest"
                 ],
-                "summary": "Get all user",
+                "summary": "Retrieve outgoing friend requests",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email address",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "Authorization",
@@ -466,20 +578,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ApiResponseSuccessStruct"
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithFriendRequests"
                         }
                     }
                 }
             }
         },
-        "/api/users/{id}": {
-            "get": {
+        "/api/friend-requests/{id}/accept": {
+            "post": {
                 "security": [
                     {
                         "JWT": []
                     }
                 ],
-                "description": "Get user by ID",
+                "description": "Accept a pending friend request and create the friendship. Only the target can accept.",
                 "consumes": [
                     "application/json"
                 ],
@@ -487,13 +599,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users Management"
+                    "FriendRequest"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Accept friend request",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Friend request ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -510,50 +622,38 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ApiResponseSuccessStruct"
+                            "$ref": "#/definitions/dto.ApiResponseSuccessNoData"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/friend-requests/{id}/cancel": {
+            "post": {
                 "security": [
                     {
                         "JWT": []
                     }
                 ],
-                "description": "Update user",
+                "description": "Cancel a pending friend request. Only the requestor can cancel.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users Management"
+                    "FriendRequest"
                 ],
-                "summary": "Update user",
+                "summary": "Cancel friend request",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "id",
+                        "description": "Friend request ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "string",
-                        "description": "User's New Email",
-                        "name": "email",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "User's New Password",
-                        "name": "password",
-                        "in": "formData",
-                        "required": true
-                    },
                     {
                         "type": "string",
                         "description": "Authorization",
@@ -566,18 +666,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ApiResponseSuccessStruct"
+                            "$ref": "#/definitions/dto.ApiResponseSuccessNoData"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/friend-requests/{id}/reject": {
+            "post": {
                 "security": [
                     {
                         "JWT": []
                     }
                 ],
-                "description": "Delete user",
+                "description": "Reject a pending friend request. Only the target can reject.",
                 "consumes": [
                     "application/json"
                 ],
@@ -585,13 +687,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users Management"
+                    "FriendRequest"
                 ],
-                "summary": "Delete user",
+                "summary": "Reject friend request",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Friend request ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -608,82 +710,2429 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ApiResponseSuccessStruct"
+                            "$ref": "#/definitions/dto.ApiResponseSuccessNoData"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.ApiResponseSuccessNoData": {
-            "type": "object",
-            "properties": {
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "dto.ApiResponseSuccessStruct": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "string",
-                    "example": "null"
-                },
-                "message": {
-                    "type": "string",
-                    "example": "Success"
-                }
-            }
         },
-        "dto.ApiResponseSuccessWithFriendsList": {
-            "type": "object",
-            "properties": {
-                "count": {
+        "/api/friendship": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Directly create a friendship between two email addresses. Users must go through friend requests instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Friendship"
+                ],
+                "summary": "Create new friendship",
+                "parameters": [
+                    {
+                        "description": "List of 2 friend emails",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateFriendshipRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessNoData"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Delete friendship between two email addresses, optionally removing their mutual subscriptions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Friendship"
+                ],
+                "summary": "Delete friendship",
+                "parameters": [
+                    {
+                        "description": "List of 2 friend emails and whether to remove mutual subscriptions",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeleteFriendshipRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessNoData"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friendship/common-friends": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Retrieve common friends list between two email addresses",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Friendship"
+                ],
+                "summary": "Retrieve common friends list between two email addresses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email address of user 1",
+                        "name": "email1",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Email address of user 2",
+                        "name": "email2",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned as next_cursor by the previous page",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithFriendsList"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friendship/friends": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Retrieve friends list for an email address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Friendship"
+                ],
+                "summary": "Retrieve friends list for an email address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email address",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned as next_cursor by the previous page",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithFriendsList"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friendship/path": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Retrieve the chain of emails connecting two users through friendships, skipping users involved in a block with either of them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Friendship"
+                ],
+                "summary": "Retrieve the shortest friendship path between two email addresses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email address of the start user",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Email address of the end user",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of friendship hops",
+                        "name": "max_depth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithFriendshipPath"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/friendship/suggestions": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Suggest friends of friends ranked by number of mutual friends, excluding existing friends and blocked users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Friendship"
+                ],
+                "summary": "Retrieve friend suggestions for an email address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email address",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of suggestions to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithFriendSuggestions"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/me/digest": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "How often the authenticated user gets a digest email of missed updates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Digest"
+                ],
+                "summary": "Get digest preference",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithDigestPreference"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Set digest emails of missed updates to off, daily or weekly",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Digest"
+                ],
+                "summary": "Update digest preference",
+                "parameters": [
+                    {
+                        "description": "Digest frequency: off, daily or weekly",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateDigestPreferenceRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithDigestPreference"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/me/email-opt-out": {
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Turn notification emails for the authenticated user off, or back on.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users Management"
+                ],
+                "summary": "Opt out of notification emails",
+                "parameters": [
+                    {
+                        "description": "Whether to opt out",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmailOptOutRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessNoData"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/me/handle": {
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Set the handle others use to @mention the authenticated user: 3 to 30 letters, digits or underscores, case-insensitive. An empty handle clears it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users Management"
+                ],
+                "summary": "Set handle",
+                "parameters": [
+                    {
+                        "description": "New handle",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetHandleRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/me/mentions": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Get the updates that mentioned the authenticated user by @handle or email, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification"
+                ],
+                "summary": "Get mentions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned as next_cursor by the previous page",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithMentions"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/me/notification-preferences": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Channels, event types and quiet hours the authenticated user is notified with",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification preferences"
+                ],
+                "summary": "Get notification preferences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithNotificationPreference"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Replace the channels (in_app, email, webhook), event types (friend_update, subscription_update, mention, friend_request) and quiet hours the authenticated user is notified with",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification preferences"
+                ],
+                "summary": "Update notification preferences",
+                "parameters": [
+                    {
+                        "description": "Channels, event types and optional quiet hours",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateNotificationPreferenceRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithNotificationPreference"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/me/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Get the sessions of the authenticated user, one per active refresh token, newest first. The user agent and IP address are the ones of the login that started the session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Get my sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithSessions"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/me/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Revoke a session of the authenticated user. Refresh tokens rotated out of the same login are revoked with it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Revoke one of my sessions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessNoData"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/mute": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Retrieve the users currently muted by an email address, newest first. Expired mutes are left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Mute"
+                ],
+                "summary": "Retrieve muted users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email address",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of mutes to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithMutedUsers"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Silently stop receiving the target's updates, for a duration (e.g. 30m, 8h) or until unmuted. Friendships and subscriptions are left alone and the target is not told. Muting again replaces the expiry.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Mute"
+                ],
+                "summary": "Mute a user",
+                "parameters": [
+                    {
+                        "description": "Requestor's email, target's email and optional duration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateMuteRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessNoData"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Lift a mute. Only the requestor of the mute or an admin can lift it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Mute"
+                ],
+                "summary": "Unmute a user",
+                "parameters": [
+                    {
+                        "description": "Requestor's email and target's email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeleteMuteRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessNoData"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stream": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Server-Sent Events stream of updates delivered to the authenticated user. Each event id is a feed item id; reconnecting with Last-Event-ID replays the missed items.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Notification"
+                ],
+                "summary": "Stream updates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/subscription": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Create new subscription",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Create new subscription",
+                "parameters": [
+                    {
+                        "description": "Requestor's email and target's email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateSubscriptionRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessNoData"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Unsubscribe requestor from target's updates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Delete subscription",
+                "parameters": [
+                    {
+                        "description": "Requestor's email and target's email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeleteSubscriptionRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessNoData"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/subscription/followers": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Retrieve the email addresses subscribed to an email address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Retrieve followers list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email address",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithSubscriptionList"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/subscription/following": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Retrieve the email addresses an email address has subscribed to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Retrieve following list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Email address",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithSubscriptionList"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/update-recipients": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Get the users an update from an email address would notify now, according to their notification preferences. Every recipient still gets the update in their feed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification"
+                ],
+                "summary": "Get update recipients",
+                "parameters": [
+                    {
+                        "description": "Sender email and update text",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetUpdateRecipientsRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned as next_cursor by the previous page",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithRecipients"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/updates": {
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Publish an update and deliver it to the recipients computed at publish time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification"
+                ],
+                "summary": "Publish update",
+                "parameters": [
+                    {
+                        "description": "Sender email and update text",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PublishUpdateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithUpdate"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Get all user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users Management"
+                ],
+                "summary": "Get all user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned as next_cursor by the previous page",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Get user by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users Management"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessStruct"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Update user",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users Management"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User's New Email",
+                        "name": "email",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User's New Password",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessStruct"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Delete user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users Management"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Get the sessions of any user, one per active refresh token, newest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users Management"
+                ],
+                "summary": "Get a user's sessions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithSessions"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Revoke every refresh token and access token of a user, e.g. when the account is compromised.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users Management"
+                ],
+                "summary": "Force logout of a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessNoData"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "List every webhook subscription",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhook subscriptions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithWebhooks"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Register a URL that receives the given event types. Every delivery is signed with the secret as X-Webhook-Signature: sha256=\u003chex HMAC-SHA256 of the body\u003e.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Create webhook subscription",
+                "parameters": [
+                    {
+                        "description": "Target URL, signing secret and event types",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateWebhookRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithWebhook"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "Delete a webhook subscription along with its delivery log",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessNoData"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "List the delivery attempts of a webhook subscription, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get webhook delivery log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ApiResponseSuccessWithWebhookDeliveries"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/ws": {
+            "get": {
+                "security": [
+                    {
+                        "JWT": []
+                    }
+                ],
+                "description": "WebSocket delivering update, friend_request, block and unblock events to the authenticated user as {\"type\",\"id\",\"data\"} messages. Clients acknowledge with {\"type\":\"ack\",\"event\":\"update\",\"id\":\u003cfeed item id\u003e}, which marks the feed item as read.",
+                "tags": [
+                    "Notification"
+                ],
+                "summary": "WebSocket gateway",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.ApiResponseSuccessNoData": {
+            "type": "object",
+            "properties": {
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ApiResponseSuccessStruct": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "string",
+                    "example": "null"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success"
+                }
+            }
+        },
+        "dto.ApiResponseSuccessWithBlockedUsers": {
+            "type": "object",
+            "properties": {
+                "blocked_users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BlockedUserResponse"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ApiResponseSuccessWithDigestPreference": {
+            "type": "object",
+            "properties": {
+                "digest_preference": {
+                    "$ref": "#/definitions/dto.DigestPreferenceResponse"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ApiResponseSuccessWithFeed": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FeedItemResponse"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "unread_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ApiResponseSuccessWithFriendRequest": {
+            "type": "object",
+            "properties": {
+                "friend_request": {
+                    "$ref": "#/definitions/dto.FriendRequestResponse"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ApiResponseSuccessWithFriendRequests": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "friend_requests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FriendRequestResponse"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ApiResponseSuccessWithFriendSuggestions": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FriendSuggestionResponse"
+                    }
+                }
+            }
+        },
+        "dto.ApiResponseSuccessWithFriendsList": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "friends": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ApiResponseSuccessWithFriendshipPath": {
+            "type": "object",
+            "properties": {
+                "degree": {
+                    "type": "integer"
+                },
+                "path": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ApiResponseSuccessWithMentions": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "mentions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MentionResponse"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ApiResponseSuccessWithMutedUsers": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "muted_users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MutedUserResponse"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ApiResponseSuccessWithNotificationPreference": {
+            "type": "object",
+            "properties": {
+                "notification_preference": {
+                    "$ref": "#/definitions/dto.NotificationPreferenceResponse"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ApiResponseSuccessWithRecipients": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "recipients": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ApiResponseSuccessWithSessions": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SessionResponse"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ApiResponseSuccessWithSubscriptionList": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "emails": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ApiResponseSuccessWithUpdate": {
+            "type": "object",
+            "properties": {
+                "success": {
+                    "type": "boolean"
+                },
+                "update": {
+                    "$ref": "#/definitions/dto.UpdateResponse"
+                }
+            }
+        },
+        "dto.ApiResponseSuccessWithWebhook": {
+            "type": "object",
+            "properties": {
+                "success": {
+                    "type": "boolean"
+                },
+                "webhook": {
+                    "$ref": "#/definitions/dto.WebhookResponse"
+                }
+            }
+        },
+        "dto.ApiResponseSuccessWithWebhookDeliveries": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WebhookDeliveryResponse"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ApiResponseSuccessWithWebhooks": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "webhooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WebhookResponse"
+                    }
+                }
+            }
+        },
+        "dto.BlockedUserResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateBlockRequest": {
+            "type": "object",
+            "required": [
+                "requestor",
+                "target"
+            ],
+            "properties": {
+                "duration": {
+                    "type": "string"
+                },
+                "requestor": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateFriendshipRequest": {
+            "type": "object",
+            "required": [
+                "friends"
+            ],
+            "properties": {
+                "friends": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.CreateMuteRequest": {
+            "type": "object",
+            "required": [
+                "requestor",
+                "target"
+            ],
+            "properties": {
+                "duration": {
+                    "type": "string"
+                },
+                "requestor": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "requestor",
+                "target"
+            ],
+            "properties": {
+                "requestor": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "event_types",
+                "secret",
+                "url"
+            ],
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DeleteBlockRequest": {
+            "type": "object",
+            "required": [
+                "requestor",
+                "target"
+            ],
+            "properties": {
+                "requestor": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DeleteFriendshipRequest": {
+            "type": "object",
+            "required": [
+                "friends"
+            ],
+            "properties": {
+                "friends": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "remove_subscriptions": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.DeleteMuteRequest": {
+            "type": "object",
+            "required": [
+                "requestor",
+                "target"
+            ],
+            "properties": {
+                "requestor": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DeleteSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "requestor",
+                "target"
+            ],
+            "properties": {
+                "requestor": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DigestPreferenceResponse": {
+            "type": "object",
+            "properties": {
+                "frequency": {
+                    "type": "string"
+                },
+                "last_sent_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.EmailOptOutRequest": {
+            "type": "object",
+            "required": [
+                "opt_out"
+            ],
+            "properties": {
+                "opt_out": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.FeedItemResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "read": {
+                    "type": "boolean"
+                },
+                "read_at": {
+                    "type": "string"
+                },
+                "sender": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "update_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.FriendRequestResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "requestor": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.FriendSuggestionResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "mutual_friends": {
                     "type": "integer"
+                }
+            }
+        },
+        "dto.GetUpdateRecipientsRequest": {
+            "type": "object",
+            "required": [
+                "sender",
+                "text"
+            ],
+            "properties": {
+                "sender": {
+                    "type": "string"
                 },
-                "friends": {
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.JwkResponse": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.JwksResponse": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.JwkResponse"
+                    }
+                }
+            }
+        },
+        "dto.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LogoutRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MentionResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "sender": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "update_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.MutedUserResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.NotificationPreferenceResponse": {
+            "type": "object",
+            "properties": {
+                "channels": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "success": {
-                    "type": "boolean"
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "quiet_hours": {
+                    "$ref": "#/definitions/dto.QuietHoursResponse"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "dto.CreateBlockRequest": {
+        "dto.PublishUpdateRequest": {
             "type": "object",
             "required": [
-                "requestor",
-                "target"
+                "sender",
+                "text"
             ],
             "properties": {
-                "requestor": {
+                "sender": {
                     "type": "string"
                 },
-                "target": {
+                "text": {
                     "type": "string"
                 }
             }
         },
-        "dto.CreateFriendshipRequest": {
+        "dto.QuietHoursRequest": {
             "type": "object",
             "required": [
-                "friends"
+                "end",
+                "start"
             ],
             "properties": {
-                "friends": {
+                "end": {
+                    "type": "string"
+                },
+                "event_types": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
+                },
+                "start": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
                 }
             }
         },
-        "dto.CreateSubscriptionRequest": {
+        "dto.QuietHoursResponse": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "start": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RefreshRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SendFriendRequestRequest": {
             "type": "object",
             "required": [
                 "requestor",
@@ -698,54 +3147,143 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.GetUpdateRecipientsRequest": {
+        "dto.SessionResponse": {
             "type": "object",
-            "required": [
-                "sender",
-                "text"
-            ],
             "properties": {
-                "sender": {
+                "created_at": {
                     "type": "string"
                 },
-                "text": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "user_agent": {
                     "type": "string"
                 }
             }
         },
-        "dto.LoginRequest": {
+        "dto.SetHandleRequest": {
             "type": "object",
             "required": [
-                "email",
-                "password"
+                "handle"
             ],
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "password": {
+                "handle": {
                     "type": "string"
                 }
             }
         },
-        "dto.LogoutRequest": {
+        "dto.UpdateDigestPreferenceRequest": {
             "type": "object",
             "required": [
-                "refresh_token"
+                "frequency"
             ],
             "properties": {
-                "refresh_token": {
+                "frequency": {
                     "type": "string"
                 }
             }
         },
-        "dto.RefreshRequest": {
+        "dto.UpdateNotificationPreferenceRequest": {
             "type": "object",
             "required": [
-                "refresh_token"
+                "channels",
+                "event_types"
             ],
             "properties": {
-                "refresh_token": {
+                "channels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "quiet_hours": {
+                    "$ref": "#/definitions/dto.QuietHoursRequest"
+                }
+            }
+        },
+        "dto.UpdateResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "recipient_count": {
+                    "type": "integer"
+                },
+                "sender": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.WebhookDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "response_status": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "url": {
                     "type": "string"
                 }
             }
@@ -1,14 +1,26 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
+	"os/signal"
+	"syscall"
 
 	"BE_Friends_Management/api/handler"
+	"BE_Friends_Management/api/middleware"
 	api "BE_Friends_Management/api/router"
 	"BE_Friends_Management/cmd/server/docs"
 	"BE_Friends_Management/config"
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/eventbus"
+	"BE_Friends_Management/internal/keyring"
+	"BE_Friends_Management/internal/realtime"
 	"BE_Friends_Management/internal/repository"
+	"BE_Friends_Management/internal/revocation"
 	"BE_Friends_Management/internal/service"
+	"BE_Friends_Management/pkg/utils"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -25,16 +37,60 @@ func main() {
 	config.LoadEnv()
 	db := config.ConnectToDB()
 	repos := repository.NewRepository(db)
+	hub := realtime.NewHub(constant.StreamBufferSize)
+	bus := eventbus.NewBus()
+	denylist := revocation.NewDenylist(repos.Revocation)
+	if err := denylist.Sync(); err != nil {
+		log.Fatal("failed to load access token revocations:", err)
+	}
+	middleware.SetAccessTokenDenylist(denylist)
+
+	// Access tokens are signed with HS256 and AccessSecret unless a key
+	// ring is configured.
+	var ring *keyring.KeyRing
+	if config.JwtKeyDir != "" {
+		var err error
+		ring, err = keyring.Load(config.JwtKeyDir, config.JwtSigningKeyId)
+		if err != nil {
+			log.Fatal("failed to load JWT signing keys:", err)
+		}
+		utils.SetAccessKeyRing(ring)
+	}
 
-	services := service.NewService(repos)
-	handlers := handler.NewHandlers(services)
+	services := service.NewService(repos, hub, bus, denylist)
+	handlers := handler.NewHandlers(services, hub, ring)
 
 	r := gin.Default()
 	api.SetupRoutes(r, handlers, db)
 	docs.SwaggerInfo.Host = config.BASE_URL_BACKEND_FOR_SWAGGER
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	if err := r.Run(config.Port); err != nil {
-		log.Fatal("failed to run server:", err)
+	server := &http.Server{Addr: config.Port, Handler: r}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("failed to run server:", err)
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	go denylist.Run(ctx)
+	go services.Outbox.Run(ctx)
+	go services.Webhook.Run(ctx)
+	go services.BlockRelationship.Run(ctx)
+	go services.Mute.Run(ctx)
+	if config.SmtpHost != "" {
+		go services.Email.Run(ctx)
+		go services.Digest.Run(ctx)
+	}
+	<-ctx.Done()
+
+	// Closing the hub ends the streams and WebSockets, which Shutdown does
+	// not wait for on its own.
+	hub.Close()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), constant.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Fatal("failed to shut down server:", err)
 	}
 }
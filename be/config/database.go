@@ -85,10 +85,32 @@ func ConnectToDB() *gorm.DB {
 	$$;
 	`
 	db.Exec(createRoleEnumSQL)
-	err = db.AutoMigrate(&entity.User{}, &entity.Friendship{}, &entity.Subscription{}, &entity.BlockRelationship{}, &entity.UserToken{})
+	err = db.AutoMigrate(&entity.User{}, &entity.Friendship{}, &entity.FriendRequest{}, &entity.Subscription{}, &entity.BlockRelationship{}, &entity.UserToken{}, &entity.Update{}, &entity.UpdateDelivery{}, &entity.WebhookSubscription{}, &entity.WebhookDelivery{}, &entity.OutboxEvent{}, &entity.EmailOptOut{}, &entity.DigestPreference{}, &entity.NotificationPreference{}, &entity.Mute{}, &entity.Mention{}, &entity.AccessTokenRevocation{}, &entity.EmailMessage{})
 	if err != nil {
 		log.Fatal("Error migrate to database. Error:", err)
 	}
+	// Refresh tokens used to be stored in plaintext. A JWT always contains a
+	// dot and a hex encoded hash never does, so this only hashes old rows.
+	hashRefreshTokensSQL := `
+	UPDATE user_tokens
+	SET refresh_token = encode(sha256(convert_to(refresh_token, 'UTF8')), 'hex')
+	WHERE refresh_token LIKE '%.%';
+	`
+	err = db.Exec(hashRefreshTokensSQL).Error
+	if err != nil {
+		log.Fatal("Error hashing refresh tokens. Error:", err)
+	}
+	// At most one request may be pending between two users, whichever of
+	// them sent it.
+	pendingFriendRequestIndexSQL := `
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_friend_requests_pending
+	ON friend_requests (LEAST(requestor_id, target_id), GREATEST(requestor_id, target_id))
+	WHERE status = 'pending';
+	`
+	err = db.Exec(pendingFriendRequestIndexSQL).Error
+	if err != nil {
+		log.Fatal("Error creating the pending friend request index. Error:", err)
+	}
 	for _, user := range users {
 		var existing entity.User
 		db.Where("email = ?", user.Email).FirstOrCreate(&existing, user)
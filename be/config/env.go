@@ -12,7 +12,13 @@ var (
 	AccessSecret                 string
 	RefreshSecret                string
 	PasswordSecret               string
+	JwtKeyDir                    string
+	JwtSigningKeyId              string
 	SmtpPasswd                   string
+	SmtpHost                     string
+	SmtpPort                     string
+	SmtpUsername                 string
+	SmtpFrom                     string
 	BASE_URL_BACKEND             string
 	DB_DNS                       string
 	BASE_URL_BACKEND_FOR_SWAGGER string
@@ -27,7 +33,13 @@ func LoadEnv() {
 	AccessSecret = os.Getenv("AccessSecret")
 	RefreshSecret = os.Getenv("refreshSecret")
 	PasswordSecret = os.Getenv("PasswordSecret")
+	JwtKeyDir = os.Getenv("JWT_KEY_DIR")
+	JwtSigningKeyId = os.Getenv("JWT_SIGNING_KEY_ID")
 	SmtpPasswd = os.Getenv("SMTP_PASSWORD")
+	SmtpHost = os.Getenv("SMTP_HOST")
+	SmtpPort = os.Getenv("SMTP_PORT")
+	SmtpUsername = os.Getenv("SMTP_USERNAME")
+	SmtpFrom = os.Getenv("SMTP_FROM")
 	BASE_URL_BACKEND_FOR_SWAGGER = os.Getenv("BASE_URL_BACKEND_FOR_SWAGGER")
 	BASE_URL_BACKEND = os.Getenv("BASE_URL_BACKEND")
 	DB_DNS = os.Getenv("DATABASE_URL")
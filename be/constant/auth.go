@@ -0,0 +1,11 @@
+package constant
+
+import "time"
+
+const (
+	// MaxUserAgentLength matches the size of the user_tokens.user_agent column.
+	MaxUserAgentLength = 512
+	// RevocationSyncInterval bounds how long another replica keeps accepting
+	// an access token revoked elsewhere.
+	RevocationSyncInterval = 5 * time.Second
+)
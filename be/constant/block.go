@@ -0,0 +1,8 @@
+package constant
+
+import "time"
+
+const (
+	BlockSweepInterval  = time.Minute
+	BlockSweepBatchSize = 100
+)
@@ -0,0 +1,17 @@
+package constant
+
+import "time"
+
+const (
+	DigestPollInterval = 5 * time.Minute
+	DigestBatchSize    = 50
+	DigestMaxUpdates   = 50
+	DigestDailyPeriod  = 24 * time.Hour
+	DigestWeeklyPeriod = 7 * 24 * time.Hour
+	// A digest that fails to send is retried after DigestInitialBackoff,
+	// doubling up to DigestMaxBackoff.
+	DigestInitialBackoff = 15 * time.Minute
+	DigestMaxBackoff     = 24 * time.Hour
+	// DigestAdvisoryLockKey serialises digest runs across replicas.
+	DigestAdvisoryLockKey = 160016
+)
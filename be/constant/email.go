@@ -0,0 +1,15 @@
+package constant
+
+import "time"
+
+const (
+	SmtpTimeout         = 10 * time.Second
+	EmailPollInterval   = 5 * time.Second
+	EmailBatchSize      = 50
+	EmailMaxAttempts    = 8
+	EmailInitialBackoff = 30 * time.Second
+	EmailMaxBackoff     = time.Hour
+	// A claimed batch is sent one email after another, so its lease
+	// outlasts every send of the batch timing out.
+	EmailClaimLease = EmailBatchSize*SmtpTimeout + time.Minute
+)
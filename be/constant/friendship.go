@@ -0,0 +1,6 @@
+package constant
+
+const (
+	DefaultPathMaxDepth = 4
+	MaxPathMaxDepth     = 6
+)
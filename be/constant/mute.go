@@ -0,0 +1,8 @@
+package constant
+
+import "time"
+
+const (
+	MuteSweepInterval  = time.Minute
+	MuteSweepBatchSize = 100
+)
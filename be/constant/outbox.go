@@ -0,0 +1,11 @@
+package constant
+
+import "time"
+
+const (
+	OutboxPollInterval = time.Second
+	OutboxBatchSize    = 100
+	OutboxClaimLease   = time.Minute
+	OutboxRetryDelay   = 30 * time.Second
+	OutboxMaxAttempts  = 10
+)
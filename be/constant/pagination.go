@@ -0,0 +1,6 @@
+package constant
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
@@ -0,0 +1,12 @@
+package constant
+
+import "time"
+
+const (
+	StreamBufferSize        = 64
+	StreamReplayLimit       = 100
+	StreamHeartbeatInterval = 15 * time.Second
+	WebSocketWriteTimeout   = 10 * time.Second
+	WebSocketMaxMessageSize = 4096
+	ShutdownTimeout         = 10 * time.Second
+)
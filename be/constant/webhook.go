@@ -0,0 +1,15 @@
+package constant
+
+import "time"
+
+const (
+	WebhookPollInterval   = 5 * time.Second
+	WebhookBatchSize      = 50
+	WebhookMaxAttempts    = 8
+	WebhookInitialBackoff = 30 * time.Second
+	WebhookMaxBackoff     = time.Hour
+	WebhookRequestTimeout = 10 * time.Second
+	// A claimed batch is sent one delivery after another, so its lease
+	// outlasts every request of the batch timing out.
+	WebhookClaimLease = WebhookBatchSize*WebhookRequestTimeout + time.Minute
+)
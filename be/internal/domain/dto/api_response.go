@@ -19,20 +19,124 @@ type ApiResponseSuccessNoData struct {
 	Success bool `json:"success"`
 }
 
+type ApiResponseSuccessWithCursor[T any] struct {
+	Msg        string `json:"message"`
+	Data       T      `json:"data"`
+	NextCursor string `json:"next_cursor"`
+	Count      int64  `json:"count"`
+}
+
 type ApiResponseSuccessStruct struct {
 	Message string  `json:"message" example:"Success"`
 	Data    *string `json:"data" example:"null"`
 }
 
 type ApiResponseSuccessWithFriendsList struct {
+	Success    bool     `json:"success"`
+	Friends    []string `json:"friends"`
+	NextCursor string   `json:"next_cursor"`
+	Count      int64    `json:"count"`
+}
+
+type ApiResponseSuccessWithFriendSuggestions struct {
+	Success     bool                       `json:"success"`
+	Suggestions []FriendSuggestionResponse `json:"suggestions"`
+	Count       int64                      `json:"count"`
+}
+
+type ApiResponseSuccessWithFriendshipPath struct {
+	Success bool     `json:"success"`
+	Path    []string `json:"path"`
+	Degree  int      `json:"degree"`
+}
+
+type ApiResponseSuccessWithFriendRequest struct {
+	Success       bool                  `json:"success"`
+	FriendRequest FriendRequestResponse `json:"friend_request"`
+}
+
+type ApiResponseSuccessWithFriendRequests struct {
+	Success        bool                    `json:"success"`
+	FriendRequests []FriendRequestResponse `json:"friend_requests"`
+	Count          int64                   `json:"count"`
+}
+
+type ApiResponseSuccessWithBlockedUsers struct {
+	Success      bool                  `json:"success"`
+	BlockedUsers []BlockedUserResponse `json:"blocked_users"`
+	Count        int64                 `json:"count"`
+}
+
+type ApiResponseSuccessWithMutedUsers struct {
+	Success    bool                `json:"success"`
+	MutedUsers []MutedUserResponse `json:"muted_users"`
+	Count      int64               `json:"count"`
+}
+
+type ApiResponseSuccessWithSessions struct {
+	Success  bool              `json:"success"`
+	Sessions []SessionResponse `json:"sessions"`
+	Count    int64             `json:"count"`
+}
+
+type ApiResponseSuccessWithSubscriptionList struct {
 	Success bool     `json:"success"`
-	Friends []string `json:"friends"`
+	Emails  []string `json:"emails"`
 	Count   int64    `json:"count"`
 }
 
 type ApiResponseSuccessWithRecipients struct {
 	Success    bool     `json:"success"`
 	Recipients []string `json:"recipients"`
+	NextCursor string   `json:"next_cursor"`
+	Count      int64    `json:"count"`
+}
+
+type ApiResponseSuccessWithUpdate struct {
+	Success bool           `json:"success"`
+	Update  UpdateResponse `json:"update"`
+}
+
+type ApiResponseSuccessWithFeed struct {
+	Success     bool               `json:"success"`
+	Items       []FeedItemResponse `json:"items"`
+	NextCursor  string             `json:"next_cursor"`
+	Count       int64              `json:"count"`
+	UnreadCount int64              `json:"unread_count"`
+}
+
+type ApiResponseSuccessWithMentions struct {
+	Success    bool              `json:"success"`
+	Mentions   []MentionResponse `json:"mentions"`
+	NextCursor string            `json:"next_cursor"`
+	Count      int64             `json:"count"`
+}
+
+type ApiResponseSuccessWithWebhook struct {
+	Success bool            `json:"success"`
+	Webhook WebhookResponse `json:"webhook"`
+}
+
+type ApiResponseSuccessWithDigestPreference struct {
+	Success          bool                     `json:"success"`
+	DigestPreference DigestPreferenceResponse `json:"digest_preference"`
+}
+
+type ApiResponseSuccessWithNotificationPreference struct {
+	Success                bool                           `json:"success"`
+	NotificationPreference NotificationPreferenceResponse `json:"notification_preference"`
+}
+
+type ApiResponseSuccessWithWebhooks struct {
+	Success  bool              `json:"success"`
+	Webhooks []WebhookResponse `json:"webhooks"`
+	Count    int64             `json:"count"`
+}
+
+type ApiResponseSuccessWithWebhookDeliveries struct {
+	Success    bool                      `json:"success"`
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+	Count      int64                     `json:"count"`
 }
 
 type ApiResponseSuccessWithTokens struct {
@@ -1,6 +1,25 @@
 package dto
 
+import "time"
+
 type CreateBlockRequest struct {
 	Requestor string `json:"requestor" binding:"required"`
 	Target    string `json:"target" binding:"required"`
+	Duration  string `json:"duration"`
+}
+
+type DeleteBlockRequest struct {
+	Requestor string `json:"requestor" binding:"required"`
+	Target    string `json:"target" binding:"required"`
+}
+
+type BlockedUserResponse struct {
+	Email     string     `json:"email"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type BlockEventResponse struct {
+	Requestor string `json:"requestor"`
+	Target    string `json:"target"`
 }
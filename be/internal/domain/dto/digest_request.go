@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+type UpdateDigestPreferenceRequest struct {
+	Frequency string `json:"frequency" binding:"required"`
+}
+
+type DigestPreferenceResponse struct {
+	Frequency  string     `json:"frequency"`
+	LastSentAt *time.Time `json:"last_sent_at"`
+}
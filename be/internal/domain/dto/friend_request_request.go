@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+type SendFriendRequestRequest struct {
+	Requestor string `json:"requestor" binding:"required"`
+	Target    string `json:"target" binding:"required"`
+}
+
+type FriendRequestResponse struct {
+	Id        int64     `json:"id"`
+	Requestor string    `json:"requestor"`
+	Target    string    `json:"target"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -3,3 +3,13 @@ package dto
 type CreateFriendshipRequest struct {
 	Friends []string `json:"friends" binding:"required,len=2"`
 }
+
+type DeleteFriendshipRequest struct {
+	Friends             []string `json:"friends" binding:"required,len=2"`
+	RemoveSubscriptions bool     `json:"remove_subscriptions"`
+}
+
+type FriendSuggestionResponse struct {
+	Email         string `json:"email"`
+	MutualFriends int64  `json:"mutual_friends"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+// JwkResponse is a public key in JSON Web Key form (RFC 7517). N and E are
+// set for RSA keys, Crv and X for Ed25519 keys.
+type JwkResponse struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JwksResponse struct {
+	Keys []JwkResponse `json:"keys"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+type CreateMuteRequest struct {
+	Requestor string `json:"requestor" binding:"required"`
+	Target    string `json:"target" binding:"required"`
+	Duration  string `json:"duration"`
+}
+
+type DeleteMuteRequest struct {
+	Requestor string `json:"requestor" binding:"required"`
+	Target    string `json:"target" binding:"required"`
+}
+
+type MutedUserResponse struct {
+	Email     string     `json:"email"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+type QuietHoursRequest struct {
+	Start      string   `json:"start" binding:"required"`
+	End        string   `json:"end" binding:"required"`
+	Timezone   string   `json:"timezone"`
+	EventTypes []string `json:"event_types"`
+}
+
+type UpdateNotificationPreferenceRequest struct {
+	Channels   []string           `json:"channels" binding:"required"`
+	EventTypes []string           `json:"event_types" binding:"required"`
+	QuietHours *QuietHoursRequest `json:"quiet_hours"`
+}
+
+type QuietHoursResponse struct {
+	Start      string   `json:"start"`
+	End        string   `json:"end"`
+	Timezone   string   `json:"timezone"`
+	EventTypes []string `json:"event_types"`
+}
+
+type NotificationPreferenceResponse struct {
+	Channels   []string            `json:"channels"`
+	EventTypes []string            `json:"event_types"`
+	QuietHours *QuietHoursResponse `json:"quiet_hours"`
+	UpdatedAt  *time.Time          `json:"updated_at"`
+}
@@ -1,6 +1,39 @@
 package dto
 
+import "time"
+
 type GetUpdateRecipientsRequest struct {
 	Sender string `json:"sender" binding:"required"`
 	Text   string `json:"text" binding:"required"`
 }
+
+type PublishUpdateRequest struct {
+	Sender string `json:"sender" binding:"required"`
+	Text   string `json:"text" binding:"required"`
+}
+
+type UpdateResponse struct {
+	Id             int64     `json:"id"`
+	Sender         string    `json:"sender"`
+	Text           string    `json:"text"`
+	CreatedAt      time.Time `json:"created_at"`
+	RecipientCount int64     `json:"recipient_count"`
+}
+
+type FeedItemResponse struct {
+	Id        int64      `json:"id"`
+	UpdateId  int64      `json:"update_id"`
+	Sender    string     `json:"sender"`
+	Text      string     `json:"text"`
+	CreatedAt time.Time  `json:"created_at"`
+	Read      bool       `json:"read"`
+	ReadAt    *time.Time `json:"read_at"`
+}
+
+type MentionResponse struct {
+	Id        int64     `json:"id"`
+	UpdateId  int64     `json:"update_id"`
+	Sender    string    `json:"sender"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+type SessionResponse struct {
+	Id        int64     `json:"id"`
+	UserAgent string    `json:"user_agent"`
+	IpAddress string    `json:"ip_address"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
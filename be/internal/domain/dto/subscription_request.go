@@ -4,3 +4,8 @@ type CreateSubscriptionRequest struct {
 	Requestor string `json:"requestor" binding:"required"`
 	Target    string `json:"target" binding:"required"`
 }
+
+type DeleteSubscriptionRequest struct {
+	Requestor string `json:"requestor" binding:"required"`
+	Target    string `json:"target" binding:"required"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+// EmailOptOutRequest uses a pointer so that an explicit false passes the
+// required check.
+type EmailOptOutRequest struct {
+	OptOut *bool `json:"opt_out" binding:"required"`
+}
+
+// SetHandleRequest uses a pointer so that an empty handle, which clears it,
+// passes the required check.
+type SetHandleRequest struct {
+	Handle *string `json:"handle" binding:"required"`
+}
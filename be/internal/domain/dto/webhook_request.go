@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+type CreateWebhookRequest struct {
+	Url        string   `json:"url" binding:"required"`
+	Secret     string   `json:"secret" binding:"required"`
+	EventTypes []string `json:"event_types" binding:"required"`
+}
+
+type WebhookResponse struct {
+	Id         int64     `json:"id"`
+	Url        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	Id             int64      `json:"id"`
+	EventId        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	ResponseStatus int        `json:"response_status"`
+	LastError      string     `json:"last_error"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
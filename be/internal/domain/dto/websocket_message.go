@@ -0,0 +1,14 @@
+package dto
+
+type WebSocketInboundMessage struct {
+	Type  string `json:"type"`
+	Event string `json:"event"`
+	Id    int64  `json:"id"`
+}
+
+type WebSocketOutboundMessage struct {
+	Type  string `json:"type"`
+	Id    int64  `json:"id,omitempty"`
+	Data  any    `json:"data,omitempty"`
+	Error string `json:"error,omitempty"`
+}
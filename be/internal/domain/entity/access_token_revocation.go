@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+type AccessTokenRevocation struct {
+	Id        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	Jti       *string   `gorm:"type:varchar(64);uniqueIndex" json:"jti"`
+	UserId    *int64    `gorm:"index" json:"user_id"`
+	RevokedAt time.Time `gorm:"not null" json:"revoked_at"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+}
@@ -3,8 +3,9 @@ package entity
 import "time"
 
 type BlockRelationship struct {
-	RequestorId int64 `gorm:"primaryKey" json:"requestor_id"`
-	TargetId    int64 `gorm:"primaryKey" json:"target_id"`
+	RequestorId int64      `gorm:"primaryKey" json:"requestor_id"`
+	TargetId    int64      `gorm:"primaryKey" json:"target_id"`
+	ExpiresAt   *time.Time `gorm:"index" json:"expires_at"`
 	CreatedAt   time.Time
 
 	Requestor *User `gorm:"foreignKey:RequestorId;references:Id"`
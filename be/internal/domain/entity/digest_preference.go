@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+const (
+	DigestOff    = "off"
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+var DigestFrequencies = []string{DigestOff, DigestDaily, DigestWeekly}
+
+type DigestPreference struct {
+	UserId              int64      `gorm:"primaryKey" json:"user_id"`
+	Frequency           string     `gorm:"type:varchar(16);not null;index" json:"frequency"`
+	WatermarkDeliveryId int64      `gorm:"not null;default:0" json:"watermark_delivery_id"`
+	LastSentAt          *time.Time `json:"last_sent_at"`
+	FailedAttempts      int        `gorm:"not null;default:0" json:"failed_attempts"`
+	NextAttemptAt       *time.Time `json:"next_attempt_at"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+
+	User *User `gorm:"foreignKey:UserId;references:Id;constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+const (
+	EmailMessagePending = "pending"
+	EmailMessageSent    = "sent"
+	EmailMessageFailed  = "failed"
+)
+
+type EmailMessage struct {
+	Id            int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	EventId       string     `gorm:"type:varchar(64);not null;uniqueIndex:idx_email_message_event" json:"event_id"`
+	Recipient     string     `gorm:"type:varchar(255);not null;uniqueIndex:idx_email_message_event" json:"recipient"`
+	Subject       string     `gorm:"type:text;not null" json:"subject"`
+	Text          string     `gorm:"type:text;not null" json:"text"`
+	HTML          string     `gorm:"column:html;type:text;not null" json:"html"`
+	Status        string     `gorm:"type:varchar(16);not null;index:idx_email_message_due" json:"status"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"not null;index:idx_email_message_due" json:"next_attempt_at"`
+	LastError     string     `gorm:"type:text" json:"last_error"`
+	SentAt        *time.Time `json:"sent_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
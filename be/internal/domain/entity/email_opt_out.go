@@ -0,0 +1,10 @@
+package entity
+
+import "time"
+
+type EmailOptOut struct {
+	UserId    int64     `gorm:"primaryKey" json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	User *User `gorm:"foreignKey:UserId;references:Id;constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,64 @@
+package entity
+
+import "time"
+
+const (
+	EventFriendshipCreated   = "friendship.created"
+	EventFriendshipDeleted   = "friendship.deleted"
+	EventSubscriptionCreated = "subscription.created"
+	EventSubscriptionDeleted = "subscription.deleted"
+	EventBlockCreated        = "block.created"
+	EventBlockDeleted        = "block.deleted"
+	EventUpdatePublished     = "update.published"
+	EventFriendRequestSent   = "friend_request.sent"
+	EventNotificationSent    = "notification.sent"
+)
+
+var EventTypes = []string{
+	EventFriendshipCreated,
+	EventFriendshipDeleted,
+	EventSubscriptionCreated,
+	EventSubscriptionDeleted,
+	EventBlockCreated,
+	EventBlockDeleted,
+	EventUpdatePublished,
+	EventFriendRequestSent,
+	EventNotificationSent,
+}
+
+type Event struct {
+	Id        string    `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+type FriendshipEventData struct {
+	Friends []string `json:"friends"`
+}
+
+type RelationshipEventData struct {
+	Requestor string `json:"requestor"`
+	Target    string `json:"target"`
+}
+
+type FriendRequestEventData struct {
+	Id        int64  `json:"id"`
+	Requestor string `json:"requestor"`
+	Target    string `json:"target"`
+}
+
+type UpdateEventData struct {
+	Id             int64     `json:"id"`
+	Sender         string    `json:"sender"`
+	Text           string    `json:"text"`
+	CreatedAt      time.Time `json:"created_at"`
+	RecipientCount int64     `json:"recipient_count"`
+}
+
+type NotificationEventData struct {
+	Recipient       string   `json:"recipient"`
+	Reasons         []string `json:"reasons"`
+	UpdateId        int64    `json:"update_id,omitempty"`
+	FriendRequestId int64    `json:"friend_request_id,omitempty"`
+}
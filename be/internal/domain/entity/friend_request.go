@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+const (
+	FriendRequestPending   = "pending"
+	FriendRequestAccepted  = "accepted"
+	FriendRequestRejected  = "rejected"
+	FriendRequestCancelled = "cancelled"
+)
+
+type FriendRequest struct {
+	Id          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	RequestorId int64     `gorm:"not null;index" json:"requestor_id"`
+	TargetId    int64     `gorm:"not null;index" json:"target_id"`
+	Status      string    `gorm:"type:varchar(16);not null;default:pending" json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	Requestor *User `gorm:"foreignKey:RequestorId;references:Id"`
+	Target    *User `gorm:"foreignKey:TargetId;references:Id"`
+}
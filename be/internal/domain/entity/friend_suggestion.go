@@ -0,0 +1,7 @@
+package entity
+
+type FriendSuggestion struct {
+	UserId        int64  `json:"user_id"`
+	Email         string `json:"email"`
+	MutualFriends int64  `json:"mutual_friends"`
+}
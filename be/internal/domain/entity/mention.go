@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+type Mention struct {
+	Id        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UpdateId  int64     `gorm:"not null;uniqueIndex:idx_mention_update_user" json:"update_id"`
+	UserId    int64     `gorm:"not null;uniqueIndex:idx_mention_update_user;index" json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Update *Update `gorm:"foreignKey:UpdateId;references:Id;constraint:OnDelete:CASCADE"`
+	User   *User   `gorm:"foreignKey:UserId;references:Id;constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+type Mute struct {
+	RequestorId int64      `gorm:"primaryKey" json:"requestor_id"`
+	TargetId    int64      `gorm:"primaryKey;index" json:"target_id"`
+	ExpiresAt   *time.Time `gorm:"index" json:"expires_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+
+	Requestor *User `gorm:"foreignKey:RequestorId;references:Id;constraint:OnDelete:CASCADE"`
+	Target    *User `gorm:"foreignKey:TargetId;references:Id;constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	ChannelInApp   = "in_app"
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+var NotificationChannels = []string{ChannelInApp, ChannelEmail, ChannelWebhook}
+
+// Notification types describe why a user is notified.
+const (
+	NotificationFriendUpdate       = "friend_update"
+	NotificationSubscriptionUpdate = "subscription_update"
+	NotificationMention            = "mention"
+	NotificationFriendRequest      = "friend_request"
+)
+
+var NotificationTypes = []string{
+	NotificationFriendUpdate,
+	NotificationSubscriptionUpdate,
+	NotificationMention,
+	NotificationFriendRequest,
+}
+
+type NotificationPreference struct {
+	UserId               int64          `gorm:"primaryKey" json:"user_id"`
+	Channels             pq.StringArray `gorm:"type:text[];not null" json:"channels"`
+	EventTypes           pq.StringArray `gorm:"type:text[];not null" json:"event_types"`
+	QuietHoursStart      string         `gorm:"type:varchar(5);not null;default:''" json:"quiet_hours_start"`
+	QuietHoursEnd        string         `gorm:"type:varchar(5);not null;default:''" json:"quiet_hours_end"`
+	QuietHoursTimezone   string         `gorm:"type:varchar(64);not null;default:''" json:"quiet_hours_timezone"`
+	QuietHoursEventTypes pq.StringArray `gorm:"type:text[];not null" json:"quiet_hours_event_types"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+
+	User *User `gorm:"foreignKey:UserId;references:Id;constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type OutboxEvent struct {
+	Id             int64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	EventId        string         `gorm:"type:varchar(64);not null;uniqueIndex" json:"event_id"`
+	EventType      string         `gorm:"type:varchar(64);not null" json:"event_type"`
+	Payload        string         `gorm:"type:jsonb;not null" json:"payload"`
+	Attempts       int            `gorm:"not null;default:0" json:"attempts"`
+	DeliveredSinks pq.StringArray `gorm:"type:text[];not null;default:'{}'" json:"delivered_sinks"`
+	LastError      string         `gorm:"type:text" json:"last_error"`
+	AvailableAt    time.Time      `gorm:"not null;index:idx_outbox_event_pending" json:"available_at"`
+	ProcessedAt    *time.Time     `gorm:"index:idx_outbox_event_pending" json:"processed_at"`
+	FailedAt       *time.Time     `gorm:"index:idx_outbox_event_pending" json:"failed_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
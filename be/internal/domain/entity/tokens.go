@@ -5,7 +5,10 @@ import "time"
 type UserToken struct {
 	Id           int64     `gorm:"primaryKey;autoIncrement" json:"id"`
 	UserId       int64     `gorm:"type:varchar(256);not null" json:"email"`
-	RefreshToken string    `gorm:"type:varchar(256);not null" json:"-"`
+	RefreshToken string    `gorm:"type:varchar(256);not null;index" json:"-"`
+	FamilyId     string    `gorm:"type:varchar(64);not null;default:'';index" json:"family_id"`
+	UserAgent    string    `gorm:"type:varchar(512);not null;default:''" json:"user_agent"`
+	IpAddress    string    `gorm:"type:varchar(64);not null;default:''" json:"ip_address"`
 	CreatedAt    time.Time `json:"created_at"`
 	ExpiresAt    time.Time `json:"expires_at"`
 	IsRevoked    bool      `json:"is_revoked"`
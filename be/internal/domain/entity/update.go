@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+type Update struct {
+	Id        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	SenderId  int64     `gorm:"not null;index" json:"sender_id"`
+	Text      string    `gorm:"type:text;not null" json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Sender *User `gorm:"foreignKey:SenderId;references:Id"`
+}
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type UpdateDelivery struct {
+	Id          int64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	UpdateId    int64          `gorm:"not null;uniqueIndex:idx_update_delivery_recipient" json:"update_id"`
+	RecipientId int64          `gorm:"not null;uniqueIndex:idx_update_delivery_recipient;index" json:"recipient_id"`
+	Reasons     pq.StringArray `gorm:"type:text[];not null;default:'{}'" json:"reasons"`
+	ReadAt      *time.Time     `json:"read_at"`
+	CreatedAt   time.Time      `json:"created_at"`
+
+	Update    *Update `gorm:"foreignKey:UpdateId;references:Id"`
+	Recipient *User   `gorm:"foreignKey:RecipientId;references:Id"`
+}
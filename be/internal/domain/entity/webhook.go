@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+type WebhookSubscription struct {
+	Id         int64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	Url        string         `gorm:"type:text;not null" json:"url"`
+	Secret     string         `gorm:"type:varchar(256);not null" json:"-"`
+	EventTypes pq.StringArray `gorm:"type:text[];not null" json:"event_types"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	Id             int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	SubscriptionId int64      `gorm:"not null;uniqueIndex:idx_webhook_delivery_event;index" json:"subscription_id"`
+	EventId        string     `gorm:"type:varchar(64);not null;uniqueIndex:idx_webhook_delivery_event" json:"event_id"`
+	EventType      string     `gorm:"type:varchar(64);not null" json:"event_type"`
+	Payload        string     `gorm:"type:jsonb;not null" json:"payload"`
+	Status         string     `gorm:"type:varchar(16);not null;index:idx_webhook_delivery_due" json:"status"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time  `gorm:"not null;index:idx_webhook_delivery_due" json:"next_attempt_at"`
+	ResponseStatus int        `json:"response_status"`
+	LastError      string     `gorm:"type:text" json:"last_error"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	Subscription *WebhookSubscription `gorm:"foreignKey:SubscriptionId;references:Id;constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,44 @@
+package eventbus
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"errors"
+	"sync"
+)
+
+// Handler consumes a domain event. Events are delivered at least once, so
+// handlers should use the event id to drop duplicates.
+type Handler func(event entity.Event) error
+
+// Bus is an in-process dispatcher of domain events to the handlers
+// subscribed to their type.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+func (b *Bus) Subscribe(eventType string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Publish runs every handler of the event type in subscription order and
+// returns their combined errors. A failing handler does not stop the others.
+func (b *Bus) Publish(event entity.Event) error {
+	b.mu.RLock()
+	handlers := b.handlers[event.Type]
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,54 @@
+package eventbus
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus_Publish(t *testing.T) {
+	t.Run("delivers to handlers of the event type", func(t *testing.T) {
+		bus := NewBus()
+		var received []string
+		bus.Subscribe(entity.EventFriendshipCreated, func(event entity.Event) error {
+			received = append(received, "first:"+event.Id)
+			return nil
+		})
+		bus.Subscribe(entity.EventFriendshipCreated, func(event entity.Event) error {
+			received = append(received, "second:"+event.Id)
+			return nil
+		})
+		bus.Subscribe(entity.EventBlockCreated, func(event entity.Event) error {
+			received = append(received, "block:"+event.Id)
+			return nil
+		})
+
+		err := bus.Publish(entity.Event{Id: "evt_1", Type: entity.EventFriendshipCreated})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"first:evt_1", "second:evt_1"}, received)
+	})
+
+	t.Run("no handlers", func(t *testing.T) {
+		bus := NewBus()
+		assert.NoError(t, bus.Publish(entity.Event{Id: "evt_1", Type: entity.EventBlockDeleted}))
+	})
+
+	t.Run("failing handler does not stop the others", func(t *testing.T) {
+		bus := NewBus()
+		handlerErr := errors.New("handler error")
+		called := false
+		bus.Subscribe(entity.EventBlockCreated, func(event entity.Event) error {
+			return handlerErr
+		})
+		bus.Subscribe(entity.EventBlockCreated, func(event entity.Event) error {
+			called = true
+			return nil
+		})
+
+		err := bus.Publish(entity.Event{Id: "evt_1", Type: entity.EventBlockCreated})
+		assert.ErrorIs(t, err, handlerErr)
+		assert.True(t, called)
+	})
+}
@@ -0,0 +1,133 @@
+package keyring
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+
+	minRSAKeyBits = 2048
+)
+
+var (
+	ErrNoKeys             = errors.New("no signing keys found")
+	ErrSigningKeyNotFound = errors.New("signing key not found")
+	ErrUnsupportedKey     = errors.New("unsupported key type, expected RSA or Ed25519")
+)
+
+// Key is a private key of the ring. Id is sent as the kid header of the
+// tokens it signs.
+type Key struct {
+	Id        string
+	Algorithm string
+	Private   crypto.Signer
+	Public    crypto.PublicKey
+}
+
+// KeyRing holds every key tokens may be verified with and the one new tokens
+// are signed with. Keeping the previous key in the ring after rotating lets
+// the tokens it signed live out their lifetime.
+type KeyRing struct {
+	keys    map[string]*Key
+	signing *Key
+}
+
+// Load reads every <kid>.pem file of dir, each holding an RSA or Ed25519
+// private key in PKCS#8 or, for RSA, PKCS#1 form. New tokens are signed with
+// signingKeyId, or with the greatest kid when it is empty, so naming the
+// files by date rotates to the newest key.
+func Load(dir, signingKeyId string) (*KeyRing, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, ErrNoKeys
+	}
+	sort.Strings(paths)
+	ring := &KeyRing{keys: make(map[string]*Key, len(paths))}
+	for _, path := range paths {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := parseKey(strings.TrimSuffix(filepath.Base(path), ".pem"), raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		ring.keys[key.Id] = key
+		if signingKeyId == "" || key.Id == signingKeyId {
+			ring.signing = key
+		}
+	}
+	if ring.signing == nil {
+		return nil, fmt.Errorf("%w: %s", ErrSigningKeyNotFound, signingKeyId)
+	}
+	return ring, nil
+}
+
+// SigningKey returns the key new tokens are signed with.
+func (r *KeyRing) SigningKey() *Key {
+	return r.signing
+}
+
+// Key returns the key of the given kid.
+func (r *KeyRing) Key(id string) (*Key, bool) {
+	key, ok := r.keys[id]
+	return key, ok
+}
+
+// Keys returns every key of the ring ordered by kid.
+func (r *KeyRing) Keys() []*Key {
+	keys := make([]*Key, 0, len(r.keys))
+	for _, key := range r.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Id < keys[j].Id
+	})
+	return keys
+}
+
+func parseKey(id string, raw []byte) (*Key, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	var private any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		private, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		private, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unexpected PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch private := private.(type) {
+	case *rsa.PrivateKey:
+		if private.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA key of %d bits is shorter than %d", private.N.BitLen(), minRSAKeyBits)
+		}
+		return &Key{Id: id, Algorithm: AlgorithmRS256, Private: private, Public: &private.PublicKey}, nil
+	case ed25519.PrivateKey:
+		return &Key{Id: id, Algorithm: AlgorithmEdDSA, Private: private, Public: private.Public()}, nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
@@ -0,0 +1,100 @@
+package keyring
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeEd25519Key(t *testing.T, dir, kid string) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	raw, err := x509.MarshalPKCS8PrivateKey(private)
+	assert.NoError(t, err)
+	writePEM(t, dir, kid, "PRIVATE KEY", raw)
+}
+
+func writeRSAKey(t *testing.T, dir, kid string, bits int) {
+	private, err := rsa.GenerateKey(rand.Reader, bits)
+	assert.NoError(t, err)
+	writePEM(t, dir, kid, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(private))
+}
+
+func writePEM(t *testing.T, dir, kid, blockType string, raw []byte) {
+	encoded := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: raw})
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, kid+".pem"), encoded, 0o600))
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("signs with the greatest kid by default", func(t *testing.T) {
+		dir := t.TempDir()
+		writeRSAKey(t, dir, "2026-09-01", 2048)
+		writeEd25519Key(t, dir, "2026-10-01")
+
+		ring, err := Load(dir, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2026-10-01", ring.SigningKey().Id)
+		assert.Equal(t, AlgorithmEdDSA, ring.SigningKey().Algorithm)
+		previous, ok := ring.Key("2026-09-01")
+		assert.True(t, ok)
+		assert.Equal(t, AlgorithmRS256, previous.Algorithm)
+		assert.Len(t, ring.Keys(), 2)
+		assert.Equal(t, "2026-09-01", ring.Keys()[0].Id)
+	})
+
+	t.Run("signs with the configured kid", func(t *testing.T) {
+		dir := t.TempDir()
+		writeRSAKey(t, dir, "2026-09-01", 2048)
+		writeEd25519Key(t, dir, "2026-10-01")
+
+		ring, err := Load(dir, "2026-09-01")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2026-09-01", ring.SigningKey().Id)
+	})
+
+	t.Run("unknown signing kid", func(t *testing.T) {
+		dir := t.TempDir()
+		writeEd25519Key(t, dir, "2026-10-01")
+
+		ring, err := Load(dir, "2026-11-01")
+
+		assert.ErrorIs(t, err, ErrSigningKeyNotFound)
+		assert.Nil(t, ring)
+	})
+
+	t.Run("empty directory", func(t *testing.T) {
+		ring, err := Load(t.TempDir(), "")
+
+		assert.ErrorIs(t, err, ErrNoKeys)
+		assert.Nil(t, ring)
+	})
+
+	t.Run("short RSA key", func(t *testing.T) {
+		dir := t.TempDir()
+		writeRSAKey(t, dir, "weak", 1024)
+
+		ring, err := Load(dir, "")
+
+		assert.Error(t, err)
+		assert.Nil(t, ring)
+	})
+
+	t.Run("not a PEM file", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "broken.pem"), []byte("not a key"), 0o600))
+
+		ring, err := Load(dir, "")
+
+		assert.Error(t, err)
+		assert.Nil(t, ring)
+	})
+}
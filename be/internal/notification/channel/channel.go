@@ -0,0 +1,16 @@
+package channel
+
+// Message is a rendered notification addressed to a single recipient.
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Channel delivers notifications to users outside the application, e.g. by
+// email.
+type Channel interface {
+	Name() string
+	Send(message Message) error
+}
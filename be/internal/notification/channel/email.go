@@ -0,0 +1,157 @@
+package channel
+
+import (
+	"BE_Friends_Management/constant"
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"time"
+)
+
+// Sender hands a fully encoded message to a mail transport.
+type Sender interface {
+	Send(from string, to []string, message []byte) error
+}
+
+type smtpSender struct {
+	host string
+	addr string
+	auth smtp.Auth
+}
+
+// NewSMTPSender returns a Sender for the SMTP server at host:port. The
+// connection is upgraded with STARTTLS when the server offers it, and PLAIN
+// authentication is used when username is set.
+func NewSMTPSender(host, port, username, password string) Sender {
+	sender := &smtpSender{host: host, addr: net.JoinHostPort(host, port)}
+	if username != "" {
+		sender.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return sender
+}
+
+func (sender *smtpSender) Send(from string, to []string, message []byte) error {
+	conn, err := net.DialTimeout("tcp", sender.addr, constant.SmtpTimeout)
+	if err != nil {
+		return err
+	}
+	// net/smtp has no timeouts of its own, so a stalled server would block
+	// the outbox relay forever.
+	err = conn.SetDeadline(time.Now().Add(constant.SmtpTimeout))
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	client, err := smtp.NewClient(conn, sender.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		err = client.StartTLS(&tls.Config{ServerName: sender.host})
+		if err != nil {
+			return err
+		}
+	}
+	if sender.auth != nil {
+		err = client.Auth(sender.auth)
+		if err != nil {
+			return err
+		}
+	}
+	err = client.Mail(from)
+	if err != nil {
+		return err
+	}
+	for _, recipient := range to {
+		err = client.Rcpt(recipient)
+		if err != nil {
+			return err
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(message)
+	if err != nil {
+		return err
+	}
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+type emailChannel struct {
+	sender Sender
+	from   string
+}
+
+func NewEmailChannel(sender Sender, from string) Channel {
+	return &emailChannel{sender: sender, from: from}
+}
+
+func (channel *emailChannel) Name() string {
+	return "email"
+}
+
+func (channel *emailChannel) Send(message Message) error {
+	body, err := encodeEmail(channel.from, message)
+	if err != nil {
+		return err
+	}
+	return channel.sender.Send(channel.from, []string{message.To}, body)
+}
+
+// encodeEmail builds a multipart/alternative message carrying both the text
+// and the HTML version, so clients without HTML support still show the text.
+func encodeEmail(from string, message Message) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := multipart.NewWriter(&buffer)
+	fmt.Fprintf(&buffer, "From: %s\r\n", from)
+	fmt.Fprintf(&buffer, "To: %s\r\n", message.To)
+	fmt.Fprintf(&buffer, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&buffer, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buffer, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buffer, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", writer.Boundary())
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", message.Text},
+		{"text/html; charset=utf-8", message.HTML},
+	}
+	for _, part := range parts {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoder := quotedprintable.NewWriter(partWriter)
+		_, err = encoder.Write([]byte(part.content))
+		if err != nil {
+			return nil, err
+		}
+		err = encoder.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	err := writer.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,210 @@
+package channel
+
+import (
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubMail struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+// smtpStub is a minimal in-process SMTP server that accepts every message
+// except those addressed to rejectRecipient.
+type smtpStub struct {
+	listener        net.Listener
+	rejectRecipient string
+
+	mu    sync.Mutex
+	auth  []string
+	mails []stubMail
+}
+
+func newSmtpStub(t *testing.T) *smtpStub {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	stub := &smtpStub{listener: listener}
+	go stub.serve()
+	t.Cleanup(func() { listener.Close() })
+	return stub
+}
+
+func (stub *smtpStub) hostPort() (string, string) {
+	host, port, _ := net.SplitHostPort(stub.listener.Addr().String())
+	return host, port
+}
+
+func (stub *smtpStub) serve() {
+	for {
+		conn, err := stub.listener.Accept()
+		if err != nil {
+			return
+		}
+		go stub.handle(conn)
+	}
+}
+
+func (stub *smtpStub) handle(conn net.Conn) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 localhost ESMTP stub")
+	var current stubMail
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch command {
+		case "EHLO", "HELO":
+			text.PrintfLine("250-localhost")
+			text.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			fields := strings.Fields(line)
+			credentials, _ := base64.StdEncoding.DecodeString(fields[len(fields)-1])
+			stub.mu.Lock()
+			stub.auth = append(stub.auth, string(credentials))
+			stub.mu.Unlock()
+			text.PrintfLine("235 2.7.0 Authentication successful")
+		case "MAIL":
+			current = stubMail{From: extractAddress(line)}
+			text.PrintfLine("250 OK")
+		case "RCPT":
+			recipient := extractAddress(line)
+			if recipient == stub.rejectRecipient {
+				text.PrintfLine("550 mailbox unavailable")
+				continue
+			}
+			current.To = append(current.To, recipient)
+			text.PrintfLine("250 OK")
+		case "DATA":
+			text.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			current.Data = data
+			stub.mu.Lock()
+			stub.mails = append(stub.mails, current)
+			stub.mu.Unlock()
+			text.PrintfLine("250 OK")
+		case "QUIT":
+			text.PrintfLine("221 Bye")
+			return
+		default:
+			text.PrintfLine("502 command not implemented")
+		}
+	}
+}
+
+func (stub *smtpStub) credentials() []string {
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+	return append([]string(nil), stub.auth...)
+}
+
+func (stub *smtpStub) received() []stubMail {
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+	return append([]stubMail(nil), stub.mails...)
+}
+
+func extractAddress(line string) string {
+	start := strings.Index(line, "<")
+	end := strings.Index(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
+
+func TestEmailChannel_Send(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		stub := newSmtpStub(t)
+		host, port := stub.hostPort()
+		channel := NewEmailChannel(NewSMTPSender(host, port, "mailer", "secret"), "noreply@example.com")
+
+		err := channel.Send(Message{
+			To:      "user1@example.com",
+			Subject: "Héllo",
+			Text:    "plain body",
+			HTML:    "<p>html body</p>",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "email", channel.Name())
+		assert.Equal(t, []string{"\x00mailer\x00secret"}, stub.credentials())
+
+		mails := stub.received()
+		assert.Len(t, mails, 1)
+		assert.Equal(t, "noreply@example.com", mails[0].From)
+		assert.Equal(t, []string{"user1@example.com"}, mails[0].To)
+
+		message, err := mail.ReadMessage(strings.NewReader(string(mails[0].Data)))
+		assert.NoError(t, err)
+		subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+		assert.NoError(t, err)
+		assert.Equal(t, "Héllo", subject)
+		assert.Equal(t, "user1@example.com", message.Header.Get("To"))
+
+		mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+		assert.NoError(t, err)
+		assert.Equal(t, "multipart/alternative", mediaType)
+		reader := multipart.NewReader(message.Body, params["boundary"])
+		var bodies []string
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+			body, err := io.ReadAll(part)
+			assert.NoError(t, err)
+			bodies = append(bodies, string(body))
+		}
+		assert.Equal(t, []string{"plain body", "<p>html body</p>"}, bodies)
+	})
+
+	t.Run("WithoutAuth", func(t *testing.T) {
+		stub := newSmtpStub(t)
+		host, port := stub.hostPort()
+		channel := NewEmailChannel(NewSMTPSender(host, port, "", ""), "noreply@example.com")
+
+		err := channel.Send(Message{To: "user1@example.com", Subject: "Hi", Text: "text", HTML: "html"})
+		assert.NoError(t, err)
+		assert.Empty(t, stub.credentials())
+		assert.Len(t, stub.received(), 1)
+	})
+
+	t.Run("RecipientRejected", func(t *testing.T) {
+		stub := newSmtpStub(t)
+		stub.rejectRecipient = "unknown@example.com"
+		host, port := stub.hostPort()
+		channel := NewEmailChannel(NewSMTPSender(host, port, "", ""), "noreply@example.com")
+
+		err := channel.Send(Message{To: "unknown@example.com", Subject: "Hi", Text: "text", HTML: "html"})
+		assert.ErrorContains(t, err, "550")
+		assert.Empty(t, stub.received())
+	})
+
+	t.Run("ServerUnavailable", func(t *testing.T) {
+		stub := newSmtpStub(t)
+		host, port := stub.hostPort()
+		stub.listener.Close()
+		channel := NewEmailChannel(NewSMTPSender(host, port, "", ""), "noreply@example.com")
+
+		err := channel.Send(Message{To: "user1@example.com", Subject: "Hi", Text: "text", HTML: "html"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,96 @@
+package channel
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	htmlTemplate "html/template"
+	"strings"
+	textTemplate "text/template"
+	"time"
+)
+
+const (
+	TemplateNewUpdate        = "new_update"
+	TemplateNewFriendRequest = "new_friend_request"
+	TemplateMentioned        = "mentioned"
+	TemplateDigest           = "digest"
+)
+
+var ErrUnknownTemplate = errors.New("unknown email template")
+
+// UpdateTemplateData feeds TemplateNewUpdate and TemplateMentioned.
+type UpdateTemplateData struct {
+	Sender string
+	Text   string
+}
+
+// FriendRequestTemplateData feeds TemplateNewFriendRequest.
+type FriendRequestTemplateData struct {
+	Requestor string
+}
+
+// DigestTemplateData feeds TemplateDigest. HasMore is set when the digest
+// was cut short and the rest is only in the feed.
+type DigestTemplateData struct {
+	Frequency string
+	Updates   []DigestUpdate
+	HasMore   bool
+}
+
+type DigestUpdate struct {
+	Sender    string
+	Text      string
+	CreatedAt time.Time
+}
+
+//go:embed templates
+var templateFiles embed.FS
+
+type emailTemplate struct {
+	text *textTemplate.Template
+	html *htmlTemplate.Template
+}
+
+// Every template has a text and an HTML file. The text file also defines the
+// "subject" block.
+var templates = map[string]emailTemplate{
+	TemplateNewUpdate:        mustParseTemplate(TemplateNewUpdate),
+	TemplateNewFriendRequest: mustParseTemplate(TemplateNewFriendRequest),
+	TemplateMentioned:        mustParseTemplate(TemplateMentioned),
+	TemplateDigest:           mustParseTemplate(TemplateDigest),
+}
+
+func mustParseTemplate(name string) emailTemplate {
+	return emailTemplate{
+		text: textTemplate.Must(textTemplate.ParseFS(templateFiles, "templates/"+name+".txt")),
+		html: htmlTemplate.Must(htmlTemplate.ParseFS(templateFiles, "templates/"+name+".html")),
+	}
+}
+
+// Render fills the named template with data. The returned message has no
+// recipient yet.
+func Render(name string, data any) (Message, error) {
+	tmpl, ok := templates[name]
+	if !ok {
+		return Message{}, ErrUnknownTemplate
+	}
+	var subject, text, html bytes.Buffer
+	err := tmpl.text.ExecuteTemplate(&subject, "subject", data)
+	if err != nil {
+		return Message{}, err
+	}
+	err = tmpl.text.Execute(&text, data)
+	if err != nil {
+		return Message{}, err
+	}
+	err = tmpl.html.Execute(&html, data)
+	if err != nil {
+		return Message{}, err
+	}
+	return Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
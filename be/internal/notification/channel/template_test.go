@@ -0,0 +1,55 @@
+package channel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	t.Run("NewUpdate", func(t *testing.T) {
+		message, err := Render(TemplateNewUpdate, UpdateTemplateData{Sender: "user1@example.com", Text: "Hello <world>"})
+		assert.NoError(t, err)
+		assert.Equal(t, "New update from user1@example.com", message.Subject)
+		assert.Equal(t, "user1@example.com posted an update:\n\nHello <world>\n", message.Text)
+		assert.Contains(t, message.HTML, "<blockquote>Hello &lt;world&gt;</blockquote>")
+	})
+
+	t.Run("Mentioned", func(t *testing.T) {
+		message, err := Render(TemplateMentioned, UpdateTemplateData{Sender: "user1@example.com", Text: "Hi user2@example.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, "user1@example.com mentioned you", message.Subject)
+		assert.Contains(t, message.Text, "Hi user2@example.com")
+		assert.Contains(t, message.HTML, "mentioned you in an update")
+	})
+
+	t.Run("NewFriendRequest", func(t *testing.T) {
+		message, err := Render(TemplateNewFriendRequest, FriendRequestTemplateData{Requestor: "user1@example.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, "user1@example.com sent you a friend request", message.Subject)
+		assert.Contains(t, message.Text, "user1@example.com wants to be your friend.")
+		assert.Contains(t, message.HTML, "<strong>user1@example.com</strong>")
+	})
+
+	t.Run("Digest", func(t *testing.T) {
+		createdAt := time.Date(2025, 8, 7, 10, 0, 0, 0, time.UTC)
+		message, err := Render(TemplateDigest, DigestTemplateData{
+			Frequency: "daily",
+			Updates: []DigestUpdate{
+				{Sender: "user1@example.com", Text: "First", CreatedAt: createdAt},
+				{Sender: "user2@example.com", Text: "Second", CreatedAt: createdAt},
+			},
+			HasMore: true,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "Your daily digest: 2 new updates", message.Subject)
+		assert.Equal(t, "Here is what you missed:\n\nuser1@example.com (Aug 7, 10:00 UTC):\nFirst\n\nuser2@example.com (Aug 7, 10:00 UTC):\nSecond\n\nThere are more updates in your feed.\n", message.Text)
+		assert.Contains(t, message.HTML, "<blockquote>Second</blockquote>")
+	})
+
+	t.Run("UnknownTemplate", func(t *testing.T) {
+		_, err := Render("unknown", nil)
+		assert.ErrorIs(t, err, ErrUnknownTemplate)
+	})
+}
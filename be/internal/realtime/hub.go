@@ -0,0 +1,120 @@
+package realtime
+
+import (
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	EventUpdate        = "update"
+	EventFriendRequest = "friend_request"
+	EventBlock         = "block"
+	EventUnblock       = "unblock"
+)
+
+// Event is a message pushed to the live connections of a user. Id is the id
+// of the underlying row so clients can resume from the last event they saw.
+type Event struct {
+	Id      int64
+	Type    string
+	Payload any
+}
+
+type Publisher interface {
+	Publish(userId int64, event Event)
+}
+
+// Subscription is one live connection of a user. Events is closed when the
+// subscription is removed from the hub, either by Unsubscribe, because the
+// consumer fell behind or because the hub was closed.
+type Subscription struct {
+	UserId int64
+	Events <-chan Event
+	events chan Event
+}
+
+// Hub is an in-process pub/sub hub fanning events out to the connections of
+// each user.
+type Hub struct {
+	mu          sync.Mutex
+	bufferSize  int
+	closed      bool
+	subscribers map[int64]map[*Subscription]struct{}
+}
+
+func NewHub(bufferSize int) *Hub {
+	return &Hub{
+		bufferSize:  bufferSize,
+		subscribers: make(map[int64]map[*Subscription]struct{}),
+	}
+}
+
+func (h *Hub) Subscribe(userId int64) *Subscription {
+	events := make(chan Event, h.bufferSize)
+	subscription := &Subscription{UserId: userId, Events: events, events: events}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(events)
+		return subscription
+	}
+	if h.subscribers[userId] == nil {
+		h.subscribers[userId] = make(map[*Subscription]struct{})
+	}
+	h.subscribers[userId][subscription] = struct{}{}
+	return subscription
+}
+
+func (h *Hub) Unsubscribe(subscription *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(subscription)
+}
+
+// Publish never blocks: a subscription whose buffer is full is dropped so
+// the client reconnects and resumes instead of stalling every publisher.
+func (h *Hub) Publish(userId int64, event Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for subscription := range h.subscribers[userId] {
+		select {
+		case subscription.events <- event:
+		default:
+			log.Warn("Dropping slow realtime subscriber of user ", userId)
+			h.remove(subscription)
+		}
+	}
+}
+
+// Close ends every live subscription and refuses new ones, letting the
+// connections drain before the server shuts down.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for _, subscriptions := range h.subscribers {
+		for subscription := range subscriptions {
+			h.remove(subscription)
+		}
+	}
+}
+
+func (h *Hub) SubscriberCount(userId int64) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers[userId])
+}
+
+func (h *Hub) remove(subscription *Subscription) {
+	subscriptions := h.subscribers[subscription.UserId]
+	if _, ok := subscriptions[subscription]; !ok {
+		return
+	}
+	delete(subscriptions, subscription)
+	if len(subscriptions) == 0 {
+		delete(h.subscribers, subscription.UserId)
+	}
+	close(subscription.events)
+}
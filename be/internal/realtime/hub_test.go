@@ -0,0 +1,74 @@
+package realtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHub_Publish(t *testing.T) {
+	t.Run("delivers to every subscription of the user", func(t *testing.T) {
+		hub := NewHub(4)
+		first := hub.Subscribe(1)
+		second := hub.Subscribe(1)
+		other := hub.Subscribe(2)
+
+		hub.Publish(1, Event{Id: 7, Type: EventUpdate})
+
+		assert.Equal(t, int64(7), (<-first.Events).Id)
+		assert.Equal(t, int64(7), (<-second.Events).Id)
+		assert.Len(t, other.Events, 0)
+	})
+
+	t.Run("no subscribers", func(t *testing.T) {
+		hub := NewHub(4)
+		hub.Publish(1, Event{Id: 7, Type: EventUpdate})
+		assert.Equal(t, 0, hub.SubscriberCount(1))
+	})
+
+	t.Run("drops slow subscriber", func(t *testing.T) {
+		hub := NewHub(1)
+		subscription := hub.Subscribe(1)
+
+		hub.Publish(1, Event{Id: 1, Type: EventUpdate})
+		hub.Publish(1, Event{Id: 2, Type: EventUpdate})
+
+		event, ok := <-subscription.Events
+		assert.True(t, ok)
+		assert.Equal(t, int64(1), event.Id)
+		_, ok = <-subscription.Events
+		assert.False(t, ok)
+		assert.Equal(t, 0, hub.SubscriberCount(1))
+	})
+}
+
+func TestHub_Unsubscribe(t *testing.T) {
+	hub := NewHub(4)
+	subscription := hub.Subscribe(1)
+
+	hub.Unsubscribe(subscription)
+	hub.Unsubscribe(subscription)
+
+	_, ok := <-subscription.Events
+	assert.False(t, ok)
+	assert.Equal(t, 0, hub.SubscriberCount(1))
+}
+
+func TestHub_Close(t *testing.T) {
+	hub := NewHub(4)
+	first := hub.Subscribe(1)
+	second := hub.Subscribe(2)
+
+	hub.Close()
+
+	_, ok := <-first.Events
+	assert.False(t, ok)
+	_, ok = <-second.Events
+	assert.False(t, ok)
+
+	late := hub.Subscribe(1)
+	_, ok = <-late.Events
+	assert.False(t, ok)
+	assert.Equal(t, 0, hub.SubscriberCount(1))
+	hub.Publish(1, Event{Id: 1, Type: EventUpdate})
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"BE_Friends_Management/internal/domain/entity"
+	"BE_Friends_Management/pkg/utils"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -14,21 +16,74 @@ func NewAuthRepository(db *gorm.DB) AuthRepository {
 	return &PostgreSQLAuthRepository{db: db}
 }
 
+// CreateToken stores the hash of token.RefreshToken rather than the token
+// itself. The caller's token keeps the raw value and gets the generated id.
 func (r *PostgreSQLAuthRepository) CreateToken(token *entity.UserToken) error {
-	result := r.db.Create(token)
-	return result.Error
+	record := *token
+	record.RefreshToken = utils.HashRefreshToken(token.RefreshToken)
+	result := r.db.Create(&record)
+	if result.Error != nil {
+		return result.Error
+	}
+	token.Id = record.Id
+	token.CreatedAt = record.CreatedAt
+	return nil
 }
 
 func (r *PostgreSQLAuthRepository) FindByRefreshToken(refreshToken string) (*entity.UserToken, error) {
 	var userToken = entity.UserToken{}
-	err := r.db.Model(&entity.UserToken{}).Where("refresh_token = ?", refreshToken).First(&userToken).Error
+	err := r.db.Model(&entity.UserToken{}).Where("refresh_token = ?", utils.HashRefreshToken(refreshToken)).First(&userToken).Error
+	if err != nil {
+		return nil, err
+	}
+	return &userToken, nil
+}
+
+// SetRefreshTokenIsRevoked revokes the token and reports whether this call
+// revoked it, so that only one of two concurrent rotations wins.
+func (r *PostgreSQLAuthRepository) SetRefreshTokenIsRevoked(refreshToken string) (bool, error) {
+	result := r.db.Model(&entity.UserToken{}).Where("refresh_token = ? AND is_revoked = ?", utils.HashRefreshToken(refreshToken), false).Update("is_revoked", true)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *PostgreSQLAuthRepository) RevokeTokenFamily(familyId string) error {
+	err := r.db.Model(&entity.UserToken{}).Where("family_id = ? AND is_revoked = ?", familyId, false).Update("is_revoked", true).Error
+	return err
+}
+
+// GetActiveTokensByUserId returns the user's tokens that are neither revoked
+// nor expired, newest first. Each of them is one live session.
+func (r *PostgreSQLAuthRepository) GetActiveTokensByUserId(userId int64, now time.Time) ([]*entity.UserToken, error) {
+	var userTokens []*entity.UserToken
+	err := r.db.Model(&entity.UserToken{}).
+		Where("user_id = ? AND is_revoked = ? AND expires_at > ?", userId, false, now).
+		Order("created_at DESC").
+		Order("id DESC").
+		Find(&userTokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return userTokens, nil
+}
+
+func (r *PostgreSQLAuthRepository) GetTokenById(userId, tokenId int64) (*entity.UserToken, error) {
+	var userToken = entity.UserToken{}
+	err := r.db.Model(&entity.UserToken{}).Where("id = ? AND user_id = ?", tokenId, userId).First(&userToken).Error
 	if err != nil {
 		return nil, err
 	}
 	return &userToken, nil
 }
 
-func (r *PostgreSQLAuthRepository) SetRefreshTokenIsRevoked(refreshToken string) error {
-	err := r.db.Model(&entity.UserToken{}).Where("refresh_token = ?", refreshToken).Update("is_revoked", true).Error
+func (r *PostgreSQLAuthRepository) RevokeTokenById(tokenId int64) error {
+	err := r.db.Model(&entity.UserToken{}).Where("id = ? AND is_revoked = ?", tokenId, false).Update("is_revoked", true).Error
+	return err
+}
+
+func (r *PostgreSQLAuthRepository) RevokeUserTokens(userId int64) error {
+	err := r.db.Model(&entity.UserToken{}).Where("user_id = ? AND is_revoked = ?", userId, false).Update("is_revoked", true).Error
 	return err
 }
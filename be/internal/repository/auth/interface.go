@@ -2,6 +2,7 @@ package repository
 
 import (
 	"BE_Friends_Management/internal/domain/entity"
+	"time"
 )
 
 //go:generate mockgen -source=interface.go -destination=../mock/mock_auth_repository.go
@@ -9,5 +10,10 @@ import (
 type AuthRepository interface {
 	CreateToken(token *entity.UserToken) error
 	FindByRefreshToken(refreshToken string) (*entity.UserToken, error)
-	SetRefreshTokenIsRevoked(refreshToken string) error
+	SetRefreshTokenIsRevoked(refreshToken string) (bool, error)
+	RevokeTokenFamily(familyId string) error
+	GetActiveTokensByUserId(userId int64, now time.Time) ([]*entity.UserToken, error)
+	GetTokenById(userId, tokenId int64) (*entity.UserToken, error)
+	RevokeTokenById(tokenId int64) error
+	RevokeUserTokens(userId int64) error
 }
@@ -0,0 +1,306 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"BE_Friends_Management/pkg/utils"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestPostgreSQLAuthRepository_CreateToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewAuthRepository(gormDB)
+
+	t.Run("stores the hashed token", func(t *testing.T) {
+		token := &entity.UserToken{UserId: 1, RefreshToken: "raw.refresh.token", FamilyId: "family-1", UserAgent: "curl/8.0", IpAddress: "10.0.0.1", ExpiresAt: time.Now()}
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "user_tokens"`).
+			WithArgs(int64(1), utils.HashRefreshToken("raw.refresh.token"), "family-1", "curl/8.0", "10.0.0.1", sqlmock.AnyArg(), sqlmock.AnyArg(), false).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+		mock.ExpectCommit()
+
+		err := repo.CreateToken(token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), token.Id)
+		assert.Equal(t, "raw.refresh.token", token.RefreshToken)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		token := &entity.UserToken{UserId: 1, RefreshToken: "raw.refresh.token", FamilyId: "family-1", ExpiresAt: time.Now()}
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "user_tokens"`).WillReturnError(errors.New("insert failed"))
+		mock.ExpectRollback()
+
+		err := repo.CreateToken(token)
+
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), token.Id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLAuthRepository_FindByRefreshToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewAuthRepository(gormDB)
+
+	t.Run("queries by hash", func(t *testing.T) {
+		hash := utils.HashRefreshToken("raw.refresh.token")
+		rows := sqlmock.NewRows([]string{"id", "user_id", "refresh_token", "family_id", "is_revoked"}).AddRow(5, 1, hash, "family-1", false)
+		mock.ExpectQuery(`SELECT \* FROM "user_tokens" WHERE refresh_token = \$1`).
+			WithArgs(hash, 1).
+			WillReturnRows(rows)
+
+		userToken, err := repo.FindByRefreshToken("raw.refresh.token")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), userToken.Id)
+		assert.Equal(t, "family-1", userToken.FamilyId)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "user_tokens" WHERE refresh_token = \$1`).
+			WithArgs(utils.HashRefreshToken("unknown"), 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		userToken, err := repo.FindByRefreshToken("unknown")
+
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		assert.Nil(t, userToken)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLAuthRepository_SetRefreshTokenIsRevoked(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewAuthRepository(gormDB)
+
+	t.Run("revoked by this call", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "user_tokens" SET "is_revoked"=\$1 WHERE refresh_token = \$2 AND is_revoked = \$3`).
+			WithArgs(true, utils.HashRefreshToken("raw.refresh.token"), false).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		revoked, err := repo.SetRefreshTokenIsRevoked("raw.refresh.token")
+
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already revoked", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "user_tokens" SET "is_revoked"=\$1 WHERE refresh_token = \$2 AND is_revoked = \$3`).
+			WithArgs(true, utils.HashRefreshToken("raw.refresh.token"), false).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		revoked, err := repo.SetRefreshTokenIsRevoked("raw.refresh.token")
+
+		assert.NoError(t, err)
+		assert.False(t, revoked)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLAuthRepository_RevokeTokenFamily(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewAuthRepository(gormDB)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "user_tokens" SET "is_revoked"=\$1 WHERE family_id = \$2 AND is_revoked = \$3`).
+			WithArgs(true, "family-1", false).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		err := repo.RevokeTokenFamily("family-1")
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLAuthRepository_GetActiveTokensByUserId(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewAuthRepository(gormDB)
+
+	t.Run("success", func(t *testing.T) {
+		now := time.Now()
+		rows := sqlmock.NewRows([]string{"id", "user_id", "user_agent", "ip_address"}).
+			AddRow(4, 1, "curl/8.0", "10.0.0.1").
+			AddRow(3, 1, "Mozilla/5.0", "10.0.0.2")
+		mock.ExpectQuery(`SELECT \* FROM "user_tokens" WHERE user_id = \$1 AND is_revoked = \$2 AND expires_at > \$3 ORDER BY created_at DESC,id DESC`).
+			WithArgs(int64(1), false, now).
+			WillReturnRows(rows)
+
+		userTokens, err := repo.GetActiveTokensByUserId(1, now)
+
+		assert.NoError(t, err)
+		assert.Len(t, userTokens, 2)
+		assert.Equal(t, int64(4), userTokens[0].Id)
+		assert.Equal(t, "curl/8.0", userTokens[0].UserAgent)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		now := time.Now()
+		mock.ExpectQuery(`SELECT \* FROM "user_tokens"`).WillReturnError(errors.New("query failed"))
+
+		userTokens, err := repo.GetActiveTokensByUserId(1, now)
+
+		assert.Error(t, err)
+		assert.Nil(t, userTokens)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLAuthRepository_GetTokenById(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewAuthRepository(gormDB)
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "user_id", "family_id"}).AddRow(3, 1, "family-1")
+		mock.ExpectQuery(`SELECT \* FROM "user_tokens" WHERE id = \$1 AND user_id = \$2`).
+			WithArgs(int64(3), int64(1), 1).
+			WillReturnRows(rows)
+
+		userToken, err := repo.GetTokenById(1, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "family-1", userToken.FamilyId)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "user_tokens" WHERE id = \$1 AND user_id = \$2`).
+			WithArgs(int64(3), int64(2), 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		userToken, err := repo.GetTokenById(2, 3)
+
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		assert.Nil(t, userToken)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLAuthRepository_RevokeTokenById(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewAuthRepository(gormDB)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "user_tokens" SET "is_revoked"=\$1 WHERE id = \$2 AND is_revoked = \$3`).
+			WithArgs(true, int64(3), false).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.RevokeTokenById(3)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLAuthRepository_RevokeUserTokens(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewAuthRepository(gormDB)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "user_tokens" SET "is_revoked"=\$1 WHERE user_id = \$2 AND is_revoked = \$3`).
+			WithArgs(true, int64(1), false).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		err := repo.RevokeUserTokens(1)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "user_tokens"`).WillReturnError(errors.New("update failed"))
+		mock.ExpectRollback()
+
+		err := repo.RevokeUserTokens(1)
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -2,10 +2,15 @@ package repository
 
 import (
 	"BE_Friends_Management/internal/domain/entity"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// activeBlock matches the blocks that have not expired at the given time.
+const activeBlock = "expires_at IS NULL OR expires_at > ?"
+
 type PostgreSQLBlockRelationshipRepository struct {
 	db *gorm.DB
 }
@@ -18,15 +23,28 @@ func (r *PostgreSQLBlockRelationshipRepository) GetDB() *gorm.DB {
 	return r.db
 }
 
-func (r *PostgreSQLBlockRelationshipRepository) CreateBlockRelationship(tx *gorm.DB, requestorId, targetId int64) error {
-	newBlockRelationship := entity.BlockRelationship{RequestorId: requestorId, TargetId: targetId}
+// CreateBlockRelationship creates the block, replacing an expired one the
+// sweeper has not deleted yet. An active block is a duplicate key error.
+func (r *PostgreSQLBlockRelationshipRepository) CreateBlockRelationship(tx *gorm.DB, requestorId, targetId int64, expiresAt *time.Time) error {
+	newBlockRelationship := entity.BlockRelationship{RequestorId: requestorId, TargetId: targetId, ExpiresAt: expiresAt}
 	err := tx.Model(&entity.BlockRelationship{}).Create(&newBlockRelationship).Error
 	return err
 }
 
+// DeleteExpiredBlockRelationship deletes the block of target by requestor if
+// it expired but was not swept yet, and reports whether there was one.
+func (r *PostgreSQLBlockRelationshipRepository) DeleteExpiredBlockRelationship(tx *gorm.DB, requestorId, targetId int64, now time.Time) (bool, error) {
+	result := tx.Where("requestor_id = ? AND target_id = ? AND expires_at <= ?", requestorId, targetId, now).
+		Delete(&entity.BlockRelationship{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
 func (r *PostgreSQLBlockRelationshipRepository) GetBlockRelationship(requestorId, targetId int64) (*entity.BlockRelationship, error) {
 	blockRelationship := entity.BlockRelationship{RequestorId: requestorId, TargetId: targetId}
-	err := r.db.Model(&entity.BlockRelationship{}).First(&blockRelationship).Error
+	err := r.db.Model(&entity.BlockRelationship{}).Where(activeBlock, time.Now()).First(&blockRelationship).Error
 	if err != nil {
 		return nil, err
 	}
@@ -35,15 +53,66 @@ func (r *PostgreSQLBlockRelationshipRepository) GetBlockRelationship(requestorId
 
 func (r *PostgreSQLBlockRelationshipRepository) GetBlockRequestorIds(targetId int64) ([]int64, error) {
 	var requestorIds []int64
-	err := r.db.Model(&entity.BlockRelationship{}).Where("target_id = ?", targetId).Pluck("requestor_id", &requestorIds).Error
+	err := r.db.Model(&entity.BlockRelationship{}).Where("target_id = ?", targetId).Where(activeBlock, time.Now()).Pluck("requestor_id", &requestorIds).Error
 	if err != nil {
 		return nil, err
 	}
 	return requestorIds, nil
 }
 
-func (r *PostgreSQLBlockRelationshipRepository) DeleteBlockRelationship(requestorId, targetId int64) error {
+func (r *PostgreSQLBlockRelationshipRepository) GetBlockedTargetIds(requestorId int64) ([]int64, error) {
+	var targetIds []int64
+	err := r.db.Model(&entity.BlockRelationship{}).Where("requestor_id = ?", requestorId).Where(activeBlock, time.Now()).Pluck("target_id", &targetIds).Error
+	if err != nil {
+		return nil, err
+	}
+	return targetIds, nil
+}
+
+func (r *PostgreSQLBlockRelationshipRepository) DeleteBlockRelationship(tx *gorm.DB, requestorId, targetId int64) error {
 	blockRelationship := entity.BlockRelationship{RequestorId: requestorId, TargetId: targetId}
-	err := r.db.Delete(&blockRelationship).Error
+	err := tx.Delete(&blockRelationship).Error
 	return err
 }
+
+func (r *PostgreSQLBlockRelationshipRepository) GetBlockRelationshipsByRequestor(requestorId int64, limit, offset int) ([]*entity.BlockRelationship, error) {
+	var blockRelationships []*entity.BlockRelationship
+	err := r.db.Model(&entity.BlockRelationship{}).
+		Preload("Target").
+		Where("requestor_id = ?", requestorId).
+		Where(activeBlock, time.Now()).
+		Order("created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&blockRelationships).Error
+	if err != nil {
+		return nil, err
+	}
+	return blockRelationships, nil
+}
+
+func (r *PostgreSQLBlockRelationshipRepository) CountBlockRelationshipsByRequestor(requestorId int64) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.BlockRelationship{}).Where("requestor_id = ?", requestorId).Where(activeBlock, time.Now()).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetExpiredBlockRelationships locks a batch of blocks that expired by now,
+// with their users. Concurrent sweepers skip the locked rows.
+func (r *PostgreSQLBlockRelationshipRepository) GetExpiredBlockRelationships(tx *gorm.DB, now time.Time, limit int) ([]*entity.BlockRelationship, error) {
+	var blockRelationships []*entity.BlockRelationship
+	err := tx.Model(&entity.BlockRelationship{}).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Preload("Requestor").
+		Preload("Target").
+		Where("expires_at <= ?", now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&blockRelationships).Error
+	if err != nil {
+		return nil, err
+	}
+	return blockRelationships, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"BE_Friends_Management/internal/domain/entity"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -10,8 +11,13 @@ import (
 
 type BlockRelationshipRepository interface {
 	GetDB() *gorm.DB
-	CreateBlockRelationship(tx *gorm.DB, requestorId, targetId int64) error
+	CreateBlockRelationship(tx *gorm.DB, requestorId, targetId int64, expiresAt *time.Time) error
+	DeleteExpiredBlockRelationship(tx *gorm.DB, requestorId, targetId int64, now time.Time) (bool, error)
 	GetBlockRelationship(requestorId, targetId int64) (*entity.BlockRelationship, error)
 	GetBlockRequestorIds(targetId int64) ([]int64, error)
-	DeleteBlockRelationship(requestorId, targetId int64) error
+	GetBlockedTargetIds(requestorId int64) ([]int64, error)
+	DeleteBlockRelationship(tx *gorm.DB, requestorId, targetId int64) error
+	GetBlockRelationshipsByRequestor(requestorId int64, limit, offset int) ([]*entity.BlockRelationship, error)
+	CountBlockRelationshipsByRequestor(requestorId int64) (int64, error)
+	GetExpiredBlockRelationships(tx *gorm.DB, now time.Time, limit int) ([]*entity.BlockRelationship, error)
 }
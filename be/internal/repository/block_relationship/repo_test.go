@@ -3,6 +3,7 @@ package repository
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/lib/pq"
@@ -27,11 +28,11 @@ func TestPostgreSQLBlockRelationshipRepository_CreateBlockRelationship(t *testin
 		requestorId := int64(1)
 		targetId := int64(2)
 		mock.ExpectBegin()
-		mock.ExpectExec(`INSERT INTO "block_relationships"`).WithArgs(requestorId, targetId, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`INSERT INTO "block_relationships"`).WithArgs(requestorId, targetId, nil, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
 		tx := gormDB.Begin()
-		err := repo.CreateBlockRelationship(tx, requestorId, targetId)
+		err := repo.CreateBlockRelationship(tx, requestorId, targetId, nil)
 		tx.Commit()
 		assert.NoError(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
@@ -42,11 +43,11 @@ func TestPostgreSQLBlockRelationshipRepository_CreateBlockRelationship(t *testin
 		targetId := int64(4)
 
 		mock.ExpectBegin()
-		mock.ExpectExec(`INSERT INTO "block_relationships"`).WithArgs(requestorId, targetId, sqlmock.AnyArg()).WillReturnError(assert.AnError)
+		mock.ExpectExec(`INSERT INTO "block_relationships"`).WithArgs(requestorId, targetId, nil, sqlmock.AnyArg()).WillReturnError(assert.AnError)
 		mock.ExpectRollback()
 
 		tx := gormDB.Begin()
-		err := repo.CreateBlockRelationship(tx, requestorId, targetId)
+		err := repo.CreateBlockRelationship(tx, requestorId, targetId, nil)
 		tx.Rollback()
 		assert.Error(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
@@ -57,29 +58,46 @@ func TestPostgreSQLBlockRelationshipRepository_CreateBlockRelationship(t *testin
 
 		mock.ExpectBegin()
 		mock.ExpectExec(`INSERT INTO "block_relationships"`).
-			WithArgs(requestorId, targetId, sqlmock.AnyArg()).
+			WithArgs(requestorId, targetId, nil, sqlmock.AnyArg()).
 			WillReturnError(&pq.Error{Code: "23505", Message: "duplicate key value violates unique constraint"})
 		mock.ExpectRollback()
 
 		tx := gormDB.Begin()
-		err := repo.CreateBlockRelationship(tx, requestorId, targetId)
+		err := repo.CreateBlockRelationship(tx, requestorId, targetId, nil)
 		tx.Rollback()
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "duplicate key")
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
+	t.Run("temporary block stores expiry", func(t *testing.T) {
+		requestorId := int64(1)
+		targetId := int64(2)
+		expiresAt := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "block_relationships"`).
+			WithArgs(requestorId, targetId, expiresAt, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		tx := gormDB.Begin()
+		err := repo.CreateBlockRelationship(tx, requestorId, targetId, &expiresAt)
+		tx.Commit()
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 	t.Run("invalid user ids", func(t *testing.T) {
 		requestorId := int64(-1)
 		targetId := int64(0)
 
 		mock.ExpectBegin()
 		mock.ExpectExec(`INSERT INTO "block_relationships"`).
-			WithArgs(requestorId, targetId, sqlmock.AnyArg()).
+			WithArgs(requestorId, targetId, nil, sqlmock.AnyArg()).
 			WillReturnError(errors.New("invalid input"))
 		mock.ExpectRollback()
 
 		tx := gormDB.Begin()
-		err := repo.CreateBlockRelationship(tx, requestorId, targetId)
+		err := repo.CreateBlockRelationship(tx, requestorId, targetId, nil)
 		tx.Rollback()
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid input")
@@ -105,7 +123,7 @@ func TestPostgreSQLBlockRelationshipRepository_GetBlockRelationship(t *testing.T
 		targetId := int64(2)
 		rows := sqlmock.NewRows([]string{"user_id1", "user_id2"}).AddRow(requestorId, targetId)
 		mock.ExpectQuery(`SELECT \* FROM "block_relationships"`).
-			WithArgs(requestorId, targetId, requestorId).
+			WithArgs(sqlmock.AnyArg(), requestorId, targetId, 1).
 			WillReturnRows(rows)
 
 		blockRelationship, err := repo.GetBlockRelationship(requestorId, targetId)
@@ -120,7 +138,7 @@ func TestPostgreSQLBlockRelationshipRepository_GetBlockRelationship(t *testing.T
 		requestorId := int64(1)
 		targetId := int64(2)
 		mock.ExpectQuery(`SELECT \* FROM "block_relationships"`).
-			WithArgs(requestorId, targetId, requestorId).
+			WithArgs(sqlmock.AnyArg(), requestorId, targetId, 1).
 			WillReturnError(gorm.ErrRecordNotFound)
 
 		blockRelationship, err := repo.GetBlockRelationship(requestorId, targetId)
@@ -134,7 +152,7 @@ func TestPostgreSQLBlockRelationshipRepository_GetBlockRelationship(t *testing.T
 		requestorId := int64(1)
 		targetId := int64(2)
 		mock.ExpectQuery(`SELECT \* FROM "block_relationships"`).
-			WithArgs(requestorId, targetId, requestorId).
+			WithArgs(sqlmock.AnyArg(), requestorId, targetId, 1).
 			WillReturnError(gorm.ErrInvalidDB)
 
 		blockRelationship, err := repo.GetBlockRelationship(requestorId, targetId)
@@ -167,8 +185,8 @@ func TestPostgreSQLBlockRelationshipRepository_GetBlockRequestorIds(t *testing.T
 			AddRow(3).
 			AddRow(4)
 
-		mock.ExpectQuery(`SELECT "requestor_id" FROM "block_relationships" WHERE target_id = \$1`).
-			WithArgs(targetId).
+		mock.ExpectQuery(`SELECT "requestor_id" FROM "block_relationships" WHERE target_id = \$1 AND \(expires_at IS NULL OR expires_at > \$2\)`).
+			WithArgs(targetId, sqlmock.AnyArg()).
 			WillReturnRows(rows)
 
 		blockRequestors, err := repo.GetBlockRequestorIds(targetId)
@@ -183,8 +201,8 @@ func TestPostgreSQLBlockRelationshipRepository_GetBlockRequestorIds(t *testing.T
 
 		rows := sqlmock.NewRows([]string{"case"})
 
-		mock.ExpectQuery(`SELECT "requestor_id" FROM "block_relationships" WHERE target_id = \$1`).
-			WithArgs(targetId).
+		mock.ExpectQuery(`SELECT "requestor_id" FROM "block_relationships" WHERE target_id = \$1 AND \(expires_at IS NULL OR expires_at > \$2\)`).
+			WithArgs(targetId, sqlmock.AnyArg()).
 			WillReturnRows(rows)
 
 		blockRequestors, err := repo.GetBlockRequestorIds(targetId)
@@ -197,8 +215,8 @@ func TestPostgreSQLBlockRelationshipRepository_GetBlockRequestorIds(t *testing.T
 	t.Run("database error", func(t *testing.T) {
 		targetId := int64(1)
 
-		mock.ExpectQuery(`SELECT "requestor_id" FROM "block_relationships" WHERE target_id = \$1`).
-			WithArgs(targetId).
+		mock.ExpectQuery(`SELECT "requestor_id" FROM "block_relationships" WHERE target_id = \$1 AND \(expires_at IS NULL OR expires_at > \$2\)`).
+			WithArgs(targetId, sqlmock.AnyArg()).
 			WillReturnError(gorm.ErrInvalidDB)
 
 		blockRequestors, err := repo.GetBlockRequestorIds(targetId)
@@ -229,7 +247,7 @@ func TestPostgreSQLBlockRelationshipRepository_DeleteBlockRelationship(t *testin
 		mock.ExpectExec(`DELETE FROM "block_relationships"`).WithArgs(requestorId, targetId).WillReturnResult(sqlmock.NewResult(0, 1))
 		mock.ExpectCommit()
 
-		err := repo.DeleteBlockRelationship(requestorId, targetId)
+		err := repo.DeleteBlockRelationship(gormDB, requestorId, targetId)
 
 		assert.NoError(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
@@ -244,10 +262,217 @@ func TestPostgreSQLBlockRelationshipRepository_DeleteBlockRelationship(t *testin
 			WillReturnError(gorm.ErrInvalidTransaction)
 		mock.ExpectRollback()
 
-		err := repo.DeleteBlockRelationship(requestorId, targetId)
+		err := repo.DeleteBlockRelationship(gormDB, requestorId, targetId)
 
 		assert.Error(t, err)
 		assert.Equal(t, gorm.ErrInvalidTransaction, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestPostgreSQLBlockRelationshipRepository_DeleteExpiredBlockRelationship(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewBlockRelationshipRepository(gormDB)
+	now := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("deletes an unswept expired block", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "block_relationships" WHERE requestor_id = \$1 AND target_id = \$2 AND expires_at <= \$3`).
+			WithArgs(int64(1), int64(2), now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		deleted, err := repo.DeleteExpiredBlockRelationship(gormDB, 1, 2, now)
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no expired block", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "block_relationships"`).
+			WithArgs(int64(1), int64(2), now).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		deleted, err := repo.DeleteExpiredBlockRelationship(gormDB, 1, 2, now)
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "block_relationships"`).WillReturnError(gorm.ErrInvalidDB)
+		mock.ExpectRollback()
+
+		deleted, err := repo.DeleteExpiredBlockRelationship(gormDB, 1, 2, now)
+		assert.Equal(t, gorm.ErrInvalidDB, err)
+		assert.False(t, deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLBlockRelationshipRepository_GetBlockRelationshipsByRequestor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewBlockRelationshipRepository(gormDB)
+
+	t.Run("successful retrieval with targets", func(t *testing.T) {
+		requestorId := int64(1)
+		createdAt := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(`SELECT \* FROM "block_relationships" WHERE requestor_id = \$1 AND \(expires_at IS NULL OR expires_at > \$2\) ORDER BY created_at DESC LIMIT \$3 OFFSET \$4`).
+			WithArgs(requestorId, sqlmock.AnyArg(), 10, 20).
+			WillReturnRows(sqlmock.NewRows([]string{"requestor_id", "target_id", "created_at"}).AddRow(1, 2, createdAt))
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE "users"."id" = \$1`).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(2, "user2@example.com"))
+
+		blockRelationships, err := repo.GetBlockRelationshipsByRequestor(requestorId, 10, 20)
+
+		assert.NoError(t, err)
+		assert.Len(t, blockRelationships, 1)
+		assert.Equal(t, "user2@example.com", blockRelationships[0].Target.Email)
+		assert.Equal(t, createdAt, blockRelationships[0].CreatedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		requestorId := int64(1)
+		mock.ExpectQuery(`SELECT \* FROM "block_relationships" WHERE requestor_id = \$1 AND \(expires_at IS NULL OR expires_at > \$2\) ORDER BY created_at DESC LIMIT \$3`).
+			WithArgs(requestorId, sqlmock.AnyArg(), 10).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		blockRelationships, err := repo.GetBlockRelationshipsByRequestor(requestorId, 10, 0)
+
+		assert.Nil(t, blockRelationships)
+		assert.Equal(t, gorm.ErrInvalidDB, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLBlockRelationshipRepository_CountBlockRelationshipsByRequestor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewBlockRelationshipRepository(gormDB)
+
+	t.Run("successful count", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "block_relationships" WHERE requestor_id = \$1 AND \(expires_at IS NULL OR expires_at > \$2\)`).
+			WithArgs(int64(1), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		count, err := repo.CountBlockRelationshipsByRequestor(1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLBlockRelationshipRepository_GetBlockedTargetIds(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewBlockRelationshipRepository(gormDB)
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		requestorId := int64(1)
+		rows := sqlmock.NewRows([]string{"target_id"}).AddRow(2).AddRow(3)
+		mock.ExpectQuery(`SELECT "target_id" FROM "block_relationships" WHERE requestor_id = \$1 AND \(expires_at IS NULL OR expires_at > \$2\)`).
+			WithArgs(requestorId, sqlmock.AnyArg()).
+			WillReturnRows(rows)
+
+		targetIds, err := repo.GetBlockedTargetIds(requestorId)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{2, 3}, targetIds)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		requestorId := int64(1)
+		mock.ExpectQuery(`SELECT "target_id" FROM "block_relationships" WHERE requestor_id = \$1 AND \(expires_at IS NULL OR expires_at > \$2\)`).
+			WithArgs(requestorId, sqlmock.AnyArg()).
+			WillReturnError(errors.New("db error"))
+
+		targetIds, err := repo.GetBlockedTargetIds(requestorId)
+
+		assert.Error(t, err)
+		assert.Nil(t, targetIds)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLBlockRelationshipRepository_GetExpiredBlockRelationships(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewBlockRelationshipRepository(gormDB)
+	now := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("successful retrieval with users", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "block_relationships" WHERE expires_at <= \$1 ORDER BY expires_at ASC LIMIT \$2 FOR UPDATE SKIP LOCKED`).
+			WithArgs(now, 100).
+			WillReturnRows(sqlmock.NewRows([]string{"requestor_id", "target_id", "expires_at"}).AddRow(1, 2, now))
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE "users"."id" = \$1`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(1, "user1@example.com"))
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE "users"."id" = \$1`).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(2, "user2@example.com"))
+
+		blockRelationships, err := repo.GetExpiredBlockRelationships(gormDB, now, 100)
+
+		assert.NoError(t, err)
+		assert.Len(t, blockRelationships, 1)
+		assert.Equal(t, "user1@example.com", blockRelationships[0].Requestor.Email)
+		assert.Equal(t, "user2@example.com", blockRelationships[0].Target.Email)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "block_relationships" WHERE expires_at <= \$1`).
+			WithArgs(now, 100).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		blockRelationships, err := repo.GetExpiredBlockRelationships(gormDB, now, 100)
+
+		assert.Nil(t, blockRelationships)
+		assert.Equal(t, gorm.ErrInvalidDB, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PostgreSQLDigestRepository struct {
+	db *gorm.DB
+}
+
+func NewDigestRepository(db *gorm.DB) DigestRepository {
+	return &PostgreSQLDigestRepository{db: db}
+}
+
+func (r *PostgreSQLDigestRepository) GetDB() *gorm.DB {
+	return r.db
+}
+
+func (r *PostgreSQLDigestRepository) GetPreference(userId int64) (*entity.DigestPreference, error) {
+	var preference = entity.DigestPreference{}
+	err := r.db.Model(&entity.DigestPreference{}).Where("user_id = ?", userId).First(&preference).Error
+	if err != nil {
+		return nil, err
+	}
+	return &preference, nil
+}
+
+func (r *PostgreSQLDigestRepository) CreatePreference(preference *entity.DigestPreference) error {
+	return r.db.Create(preference).Error
+}
+
+func (r *PostgreSQLDigestRepository) UpdatePreference(preference *entity.DigestPreference) error {
+	result := r.db.Model(&entity.DigestPreference{}).
+		Where("user_id = ?", preference.UserId).
+		Updates(map[string]interface{}{
+			"frequency":             preference.Frequency,
+			"watermark_delivery_id": preference.WatermarkDeliveryId,
+			"last_sent_at":          preference.LastSentAt,
+			"updated_at":            time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// TryAdvisoryLock takes a session-level Postgres advisory lock without
+// waiting. It reports false when another session holds the lock. conn must be
+// pinned to a single connection, see gorm.DB.Connection, and the lock must be
+// released with AdvisoryUnlock on that same connection.
+func (r *PostgreSQLDigestRepository) TryAdvisoryLock(conn *gorm.DB, key int64) (bool, error) {
+	var locked bool
+	err := conn.Raw("SELECT pg_try_advisory_lock(?)", key).Scan(&locked).Error
+	if err != nil {
+		return false, err
+	}
+	return locked, nil
+}
+
+func (r *PostgreSQLDigestRepository) AdvisoryUnlock(conn *gorm.DB, key int64) error {
+	return conn.Exec("SELECT pg_advisory_unlock(?)", key).Error
+}
+
+// GetDuePreferences returns the daily digests last sent before dailyBefore
+// and the weekly ones last sent before weeklyBefore, with their users.
+// Digests waiting to be retried after a failure are left out until their
+// next attempt is due.
+func (r *PostgreSQLDigestRepository) GetDuePreferences(conn *gorm.DB, dailyBefore, weeklyBefore, now time.Time, limit int) ([]*entity.DigestPreference, error) {
+	var preferences []*entity.DigestPreference
+	err := conn.Model(&entity.DigestPreference{}).
+		Preload("User").
+		Where("(frequency = ? AND (last_sent_at IS NULL OR last_sent_at <= ?)) OR (frequency = ? AND (last_sent_at IS NULL OR last_sent_at <= ?))",
+			entity.DigestDaily, dailyBefore, entity.DigestWeekly, weeklyBefore).
+		Where("next_attempt_at IS NULL OR next_attempt_at <= ?", now).
+		Order("user_id ASC").
+		Limit(limit).
+		Find(&preferences).Error
+	if err != nil {
+		return nil, err
+	}
+	return preferences, nil
+}
+
+func (r *PostgreSQLDigestRepository) MarkDigestSent(conn *gorm.DB, userId, watermarkDeliveryId int64, sentAt time.Time) error {
+	err := conn.Model(&entity.DigestPreference{}).
+		Where("user_id = ?", userId).
+		Updates(map[string]interface{}{
+			"watermark_delivery_id": watermarkDeliveryId,
+			"last_sent_at":          sentAt,
+			"failed_attempts":       0,
+			"next_attempt_at":       nil,
+			"updated_at":            time.Now(),
+		}).Error
+	return err
+}
+
+func (r *PostgreSQLDigestRepository) MarkDigestFailed(conn *gorm.DB, userId int64, failedAttempts int, nextAttemptAt time.Time) error {
+	err := conn.Model(&entity.DigestPreference{}).
+		Where("user_id = ?", userId).
+		Updates(map[string]interface{}{
+			"failed_attempts": failedAttempts,
+			"next_attempt_at": nextAttemptAt,
+			"updated_at":      time.Now(),
+		}).Error
+	return err
+}
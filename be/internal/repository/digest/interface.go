@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=interface.go -destination=../mock/mock_digest_repository.go
+
+type DigestRepository interface {
+	GetDB() *gorm.DB
+	GetPreference(userId int64) (*entity.DigestPreference, error)
+	CreatePreference(preference *entity.DigestPreference) error
+	UpdatePreference(preference *entity.DigestPreference) error
+	TryAdvisoryLock(conn *gorm.DB, key int64) (bool, error)
+	AdvisoryUnlock(conn *gorm.DB, key int64) error
+	GetDuePreferences(conn *gorm.DB, dailyBefore, weeklyBefore, now time.Time, limit int) ([]*entity.DigestPreference, error)
+	MarkDigestSent(conn *gorm.DB, userId, watermarkDeliveryId int64, sentAt time.Time) error
+	MarkDigestFailed(conn *gorm.DB, userId int64, failedAttempts int, nextAttemptAt time.Time) error
+}
@@ -0,0 +1,308 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestPostgreSQLDigestRepository_GetPreference(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewDigestRepository(gormDB)
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "digest_preferences" WHERE user_id = \$1 ORDER BY "digest_preferences"."user_id" LIMIT \$2`).
+			WithArgs(int64(1), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "frequency", "watermark_delivery_id"}).AddRow(1, entity.DigestDaily, 5))
+
+		preference, err := repo.GetPreference(1)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.DigestDaily, preference.Frequency)
+		assert.Equal(t, int64(5), preference.WatermarkDeliveryId)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "digest_preferences" WHERE user_id = \$1`).
+			WithArgs(int64(2), 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		preference, err := repo.GetPreference(2)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		assert.Nil(t, preference)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLDigestRepository_CreatePreference(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewDigestRepository(gormDB)
+
+	t.Run("successful creation", func(t *testing.T) {
+		sentAt := time.Now()
+		preference := &entity.DigestPreference{UserId: 1, Frequency: entity.DigestWeekly, WatermarkDeliveryId: 9, LastSentAt: &sentAt}
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "digest_preferences"`).
+			WithArgs(entity.DigestWeekly, int64(9), sqlmock.AnyArg(), 0, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(1))
+		mock.ExpectCommit()
+
+		err := repo.CreatePreference(preference)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		preference := &entity.DigestPreference{UserId: 1, Frequency: entity.DigestWeekly}
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "digest_preferences"`).
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		err := repo.CreatePreference(preference)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLDigestRepository_UpdatePreference(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewDigestRepository(gormDB)
+
+	t.Run("successful update", func(t *testing.T) {
+		sentAt := time.Date(2025, 8, 7, 10, 0, 0, 0, time.UTC)
+		preference := &entity.DigestPreference{UserId: 1, Frequency: entity.DigestDaily, WatermarkDeliveryId: 9, LastSentAt: &sentAt}
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "digest_preferences" SET "frequency"=\$1,"last_sent_at"=\$2,"updated_at"=\$3,"watermark_delivery_id"=\$4 WHERE user_id = \$5`).
+			WithArgs(entity.DigestDaily, sentAt, sqlmock.AnyArg(), int64(9), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.UpdatePreference(preference)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "digest_preferences"`).
+			WithArgs(entity.DigestOff, nil, sqlmock.AnyArg(), int64(0), int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := repo.UpdatePreference(&entity.DigestPreference{UserId: 2, Frequency: entity.DigestOff})
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLDigestRepository_TryAdvisoryLock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewDigestRepository(gormDB)
+
+	t.Run("lock acquired", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT pg_try_advisory_lock\(\$1\)`).
+			WithArgs(int64(42)).
+			WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(true))
+
+		locked, err := repo.TryAdvisoryLock(gormDB, 42)
+		assert.NoError(t, err)
+		assert.True(t, locked)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("lock held elsewhere", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT pg_try_advisory_lock\(\$1\)`).
+			WithArgs(int64(42)).
+			WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(false))
+
+		locked, err := repo.TryAdvisoryLock(gormDB, 42)
+		assert.NoError(t, err)
+		assert.False(t, locked)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT pg_try_advisory_lock\(\$1\)`).
+			WithArgs(int64(42)).
+			WillReturnError(assert.AnError)
+
+		locked, err := repo.TryAdvisoryLock(gormDB, 42)
+		assert.Error(t, err)
+		assert.False(t, locked)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLDigestRepository_AdvisoryUnlock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewDigestRepository(gormDB)
+
+	mock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).
+		WithArgs(int64(42)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.AdvisoryUnlock(gormDB, 42)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgreSQLDigestRepository_GetDuePreferences(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewDigestRepository(gormDB)
+	dailyBefore := time.Date(2025, 8, 7, 10, 0, 0, 0, time.UTC)
+	weeklyBefore := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 8, 8, 10, 0, 0, 0, time.UTC)
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "digest_preferences" WHERE \(\(frequency = \$1 AND \(last_sent_at IS NULL OR last_sent_at <= \$2\)\) OR \(frequency = \$3 AND \(last_sent_at IS NULL OR last_sent_at <= \$4\)\)\) AND \(next_attempt_at IS NULL OR next_attempt_at <= \$5\) ORDER BY user_id ASC LIMIT \$6`).
+			WithArgs(entity.DigestDaily, dailyBefore, entity.DigestWeekly, weeklyBefore, now, 50).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "frequency"}).AddRow(1, entity.DigestDaily).AddRow(2, entity.DigestWeekly))
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE "users"."id" IN \(\$1,\$2\)`).
+			WithArgs(int64(1), int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(1, "user1@example.com").AddRow(2, "user2@example.com"))
+
+		preferences, err := repo.GetDuePreferences(gormDB, dailyBefore, weeklyBefore, now, 50)
+		assert.NoError(t, err)
+		assert.Len(t, preferences, 2)
+		assert.Equal(t, "user2@example.com", preferences[1].User.Email)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "digest_preferences"`).
+			WillReturnError(assert.AnError)
+
+		preferences, err := repo.GetDuePreferences(gormDB, dailyBefore, weeklyBefore, now, 50)
+		assert.Error(t, err)
+		assert.Nil(t, preferences)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLDigestRepository_MarkDigestSent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewDigestRepository(gormDB)
+	sentAt := time.Date(2025, 8, 7, 10, 0, 0, 0, time.UTC)
+
+	t.Run("successful update", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "digest_preferences" SET "failed_attempts"=\$1,"last_sent_at"=\$2,"next_attempt_at"=\$3,"updated_at"=\$4,"watermark_delivery_id"=\$5 WHERE user_id = \$6`).
+			WithArgs(0, sentAt, nil, sqlmock.AnyArg(), int64(15), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.MarkDigestSent(gormDB, 1, 15, sentAt)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "digest_preferences"`).
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		err := repo.MarkDigestSent(gormDB, 1, 15, sentAt)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLDigestRepository_MarkDigestFailed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewDigestRepository(gormDB)
+	nextAttemptAt := time.Date(2025, 8, 7, 10, 30, 0, 0, time.UTC)
+
+	t.Run("successful update", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "digest_preferences" SET "failed_attempts"=\$1,"next_attempt_at"=\$2,"updated_at"=\$3 WHERE user_id = \$4`).
+			WithArgs(2, nextAttemptAt, sqlmock.AnyArg(), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.MarkDigestFailed(gormDB, 1, 2, nextAttemptAt)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "digest_preferences"`).
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		err := repo.MarkDigestFailed(gormDB, 1, 2, nextAttemptAt)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
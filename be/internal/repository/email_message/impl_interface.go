@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgreSQLEmailMessageRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailMessageRepository(db *gorm.DB) EmailMessageRepository {
+	return &PostgreSQLEmailMessageRepository{db: db}
+}
+
+// EnqueueMessages queues the messages for sending. A message already queued
+// for the same event and recipient is skipped, so the outbox relay can retry
+// an event without emailing anyone twice.
+func (r *PostgreSQLEmailMessageRepository) EnqueueMessages(messages []*entity.EmailMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "event_id"}, {Name: "recipient"}},
+		DoNothing: true,
+	}).Create(&messages).Error
+	return err
+}
+
+// ClaimDueMessages locks a batch of due messages and pushes their next
+// attempt to leaseUntil, so concurrent senders skip them while they are in
+// flight and a crashed sender's batch is retried after the lease.
+func (r *PostgreSQLEmailMessageRepository) ClaimDueMessages(now, leaseUntil time.Time, limit int) ([]*entity.EmailMessage, error) {
+	var messages []*entity.EmailMessage
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.EmailMessage{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", entity.EmailMessagePending, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&messages).Error
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+		messageIds := make([]int64, 0, len(messages))
+		for _, message := range messages {
+			messageIds = append(messageIds, message.Id)
+		}
+		return tx.Model(&entity.EmailMessage{}).
+			Where("id IN ?", messageIds).
+			Update("next_attempt_at", leaseUntil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (r *PostgreSQLEmailMessageRepository) UpdateMessageResult(message *entity.EmailMessage) error {
+	err := r.db.Model(&entity.EmailMessage{}).
+		Where("id = ?", message.Id).
+		Updates(map[string]interface{}{
+			"status":          message.Status,
+			"attempts":        message.Attempts,
+			"next_attempt_at": message.NextAttemptAt,
+			"last_error":      message.LastError,
+			"sent_at":         message.SentAt,
+			"updated_at":      time.Now(),
+		}).Error
+	return err
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"time"
+)
+
+//go:generate mockgen -source=interface.go -destination=../mock/mock_email_message_repository.go
+
+type EmailMessageRepository interface {
+	EnqueueMessages(messages []*entity.EmailMessage) error
+	ClaimDueMessages(now, leaseUntil time.Time, limit int) ([]*entity.EmailMessage, error)
+	UpdateMessageResult(message *entity.EmailMessage) error
+}
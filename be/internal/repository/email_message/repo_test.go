@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newEmailMessageTestRepository(t *testing.T) (EmailMessageRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	return NewEmailMessageRepository(gormDB), mock
+}
+
+func TestPostgreSQLEmailMessageRepository_EnqueueMessages(t *testing.T) {
+	repo, mock := newEmailMessageTestRepository(t)
+	now := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("skips messages already queued", func(t *testing.T) {
+		message := &entity.EmailMessage{
+			EventId:       "evt_1",
+			Recipient:     "user2@example.com",
+			Subject:       "New update from user1@example.com",
+			Text:          "Hello",
+			HTML:          "<p>Hello</p>",
+			Status:        entity.EmailMessagePending,
+			NextAttemptAt: now,
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "email_messages" .* ON CONFLICT \("event_id","recipient"\) DO NOTHING RETURNING "id"`).
+			WithArgs("evt_1", "user2@example.com", "New update from user1@example.com", "Hello", "<p>Hello</p>", entity.EmailMessagePending, 0, now, "", nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectCommit()
+
+		err := repo.EnqueueMessages([]*entity.EmailMessage{message})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nothing to queue", func(t *testing.T) {
+		err := repo.EnqueueMessages(nil)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLEmailMessageRepository_ClaimDueMessages(t *testing.T) {
+	repo, mock := newEmailMessageTestRepository(t)
+	now := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+	leaseUntil := now.Add(time.Minute)
+
+	t.Run("claims due messages", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "email_messages" WHERE status = \$1 AND next_attempt_at <= \$2 ORDER BY next_attempt_at ASC LIMIT \$3 FOR UPDATE SKIP LOCKED`).
+			WithArgs(entity.EmailMessagePending, now, 50).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "recipient", "status"}).
+				AddRow(1, "evt_1", "user2@example.com", entity.EmailMessagePending).
+				AddRow(2, "evt_1", "user3@example.com", entity.EmailMessagePending))
+		mock.ExpectExec(`UPDATE "email_messages" SET "next_attempt_at"=\$1,"updated_at"=\$2 WHERE id IN \(\$3,\$4\)`).
+			WithArgs(leaseUntil, sqlmock.AnyArg(), int64(1), int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		messages, err := repo.ClaimDueMessages(now, leaseUntil, 50)
+		assert.NoError(t, err)
+		assert.Len(t, messages, 2)
+		assert.Equal(t, "user3@example.com", messages[1].Recipient)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nothing due", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "email_messages"`).
+			WithArgs(entity.EmailMessagePending, now, 50).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectCommit()
+
+		messages, err := repo.ClaimDueMessages(now, leaseUntil, 50)
+		assert.NoError(t, err)
+		assert.Empty(t, messages)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "email_messages"`).
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		messages, err := repo.ClaimDueMessages(now, leaseUntil, 50)
+		assert.Error(t, err)
+		assert.Nil(t, messages)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLEmailMessageRepository_UpdateMessageResult(t *testing.T) {
+	repo, mock := newEmailMessageTestRepository(t)
+
+	t.Run("successful update", func(t *testing.T) {
+		nextAttemptAt := time.Date(2025, 8, 1, 10, 0, 30, 0, time.UTC)
+		message := &entity.EmailMessage{
+			Id:            1,
+			Status:        entity.EmailMessagePending,
+			Attempts:      1,
+			NextAttemptAt: nextAttemptAt,
+			LastError:     "smtp error",
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "email_messages" SET .* WHERE id = \$7`).
+			WithArgs(1, "smtp error", nextAttemptAt, nil, entity.EmailMessagePending, sqlmock.AnyArg(), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.UpdateMessageResult(message)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type PostgreSQLFriendRequestRepository struct {
+	db *gorm.DB
+}
+
+func NewFriendRequestRepository(db *gorm.DB) FriendRequestRepository {
+	return &PostgreSQLFriendRequestRepository{db: db}
+}
+
+func (r *PostgreSQLFriendRequestRepository) GetDB() *gorm.DB {
+	return r.db
+}
+
+func (r *PostgreSQLFriendRequestRepository) CreateFriendRequest(tx *gorm.DB, friendRequest *entity.FriendRequest) error {
+	err := tx.Model(&entity.FriendRequest{}).Create(friendRequest).Error
+	return err
+}
+
+func (r *PostgreSQLFriendRequestRepository) GetFriendRequestById(requestId int64) (*entity.FriendRequest, error) {
+	var friendRequest = entity.FriendRequest{}
+	err := r.db.Model(&entity.FriendRequest{}).
+		Preload("Requestor").Preload("Target").
+		Where("id = ?", requestId).
+		First(&friendRequest).Error
+	if err != nil {
+		return nil, err
+	}
+	return &friendRequest, nil
+}
+
+func (r *PostgreSQLFriendRequestRepository) GetPendingFriendRequest(requestorId, targetId int64) (*entity.FriendRequest, error) {
+	var friendRequest = entity.FriendRequest{}
+	err := r.db.Model(&entity.FriendRequest{}).
+		Where("requestor_id = ? AND target_id = ? AND status = ?", requestorId, targetId, entity.FriendRequestPending).
+		First(&friendRequest).Error
+	if err != nil {
+		return nil, err
+	}
+	return &friendRequest, nil
+}
+
+func (r *PostgreSQLFriendRequestRepository) GetIncomingFriendRequests(targetId int64) ([]*entity.FriendRequest, error) {
+	var friendRequests []*entity.FriendRequest
+	err := r.db.Model(&entity.FriendRequest{}).
+		Preload("Requestor").Preload("Target").
+		Where("target_id = ? AND status = ?", targetId, entity.FriendRequestPending).
+		Order("created_at DESC").
+		Find(&friendRequests).Error
+	if err != nil {
+		return nil, err
+	}
+	return friendRequests, nil
+}
+
+func (r *PostgreSQLFriendRequestRepository) GetOutgoingFriendRequests(requestorId int64) ([]*entity.FriendRequest, error) {
+	var friendRequests []*entity.FriendRequest
+	err := r.db.Model(&entity.FriendRequest{}).
+		Preload("Requestor").Preload("Target").
+		Where("requestor_id = ? AND status = ?", requestorId, entity.FriendRequestPending).
+		Order("created_at DESC").
+		Find(&friendRequests).Error
+	if err != nil {
+		return nil, err
+	}
+	return friendRequests, nil
+}
+
+func (r *PostgreSQLFriendRequestRepository) UpdateFriendRequestStatus(tx *gorm.DB, requestId int64, status string) error {
+	result := tx.Model(&entity.FriendRequest{}).
+		Where("id = ? AND status = ?", requestId, entity.FriendRequestPending).
+		Update("status", status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=interface.go -destination=../mock/mock_friend_request_repository.go
+
+type FriendRequestRepository interface {
+	GetDB() *gorm.DB
+	CreateFriendRequest(tx *gorm.DB, friendRequest *entity.FriendRequest) error
+	GetFriendRequestById(requestId int64) (*entity.FriendRequest, error)
+	GetPendingFriendRequest(requestorId, targetId int64) (*entity.FriendRequest, error)
+	GetIncomingFriendRequests(targetId int64) ([]*entity.FriendRequest, error)
+	GetOutgoingFriendRequests(requestorId int64) ([]*entity.FriendRequest, error)
+	UpdateFriendRequestStatus(tx *gorm.DB, requestId int64, status string) error
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestPostgreSQLFriendRequestRepository_CreateFriendRequest(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewFriendRequestRepository(gormDB)
+
+	t.Run("successful creation", func(t *testing.T) {
+		friendRequest := &entity.FriendRequest{RequestorId: 1, TargetId: 2, Status: entity.FriendRequestPending}
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "friend_requests"`).
+			WithArgs(int64(1), int64(2), entity.FriendRequestPending, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+		mock.ExpectCommit()
+
+		err := repo.CreateFriendRequest(gormDB, friendRequest)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), friendRequest.Id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		friendRequest := &entity.FriendRequest{RequestorId: 1, TargetId: 2, Status: entity.FriendRequestPending}
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "friend_requests"`).
+			WithArgs(int64(1), int64(2), entity.FriendRequestPending, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		err := repo.CreateFriendRequest(gormDB, friendRequest)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLFriendRequestRepository_GetPendingFriendRequest(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewFriendRequestRepository(gormDB)
+
+	t.Run("successful getting pending friend request", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "requestor_id", "target_id", "status"}).AddRow(10, 1, 2, entity.FriendRequestPending)
+		mock.ExpectQuery(`SELECT \* FROM "friend_requests" WHERE requestor_id = \$1 AND target_id = \$2 AND status = \$3`).
+			WithArgs(int64(1), int64(2), entity.FriendRequestPending, 1).
+			WillReturnRows(rows)
+
+		friendRequest, err := repo.GetPendingFriendRequest(1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), friendRequest.Id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no pending friend request", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "friend_requests" WHERE requestor_id = \$1 AND target_id = \$2 AND status = \$3`).
+			WithArgs(int64(1), int64(2), entity.FriendRequestPending, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		friendRequest, err := repo.GetPendingFriendRequest(1, 2)
+		assert.Nil(t, friendRequest)
+		assert.Equal(t, gorm.ErrRecordNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLFriendRequestRepository_GetIncomingFriendRequests(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewFriendRequestRepository(gormDB)
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		createdAt := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(`SELECT \* FROM "friend_requests" WHERE target_id = \$1 AND status = \$2 ORDER BY created_at DESC`).
+			WithArgs(int64(2), entity.FriendRequestPending).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "requestor_id", "target_id", "status", "created_at"}).
+				AddRow(10, 1, 2, entity.FriendRequestPending, createdAt))
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE "users"."id" = \$1`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(1, "user1@example.com"))
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE "users"."id" = \$1`).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(2, "user2@example.com"))
+
+		friendRequests, err := repo.GetIncomingFriendRequests(2)
+		assert.NoError(t, err)
+		assert.Len(t, friendRequests, 1)
+		assert.Equal(t, "user1@example.com", friendRequests[0].Requestor.Email)
+		assert.Equal(t, "user2@example.com", friendRequests[0].Target.Email)
+		assert.Equal(t, createdAt, friendRequests[0].CreatedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "friend_requests" WHERE target_id = \$1 AND status = \$2 ORDER BY created_at DESC`).
+			WithArgs(int64(2), entity.FriendRequestPending).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		friendRequests, err := repo.GetIncomingFriendRequests(2)
+		assert.Nil(t, friendRequests)
+		assert.Equal(t, gorm.ErrInvalidDB, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLFriendRequestRepository_UpdateFriendRequestStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewFriendRequestRepository(gormDB)
+
+	t.Run("successful update", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "friend_requests" SET "status"=\$1,"updated_at"=\$2 WHERE id = \$3 AND status = \$4`).
+			WithArgs(entity.FriendRequestAccepted, sqlmock.AnyArg(), int64(10), entity.FriendRequestPending).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.UpdateFriendRequestStatus(gormDB, 10, entity.FriendRequestAccepted)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("request no longer pending", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "friend_requests" SET "status"=\$1,"updated_at"=\$2 WHERE id = \$3 AND status = \$4`).
+			WithArgs(entity.FriendRequestAccepted, sqlmock.AnyArg(), int64(10), entity.FriendRequestPending).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := repo.UpdateFriendRequestStatus(gormDB, 10, entity.FriendRequestAccepted)
+		assert.Equal(t, gorm.ErrRecordNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"BE_Friends_Management/internal/domain/entity"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -18,9 +19,9 @@ func (r *PostgreSQLFriendshipRepository) GetDB() *gorm.DB {
 	return r.db
 }
 
-func (r *PostgreSQLFriendshipRepository) CreateFriendship(userId1, userId2 int64) error {
+func (r *PostgreSQLFriendshipRepository) CreateFriendship(tx *gorm.DB, userId1, userId2 int64) error {
 	newFriendship := entity.Friendship{UserId1: userId1, UserId2: userId2}
-	err := r.db.Model(&entity.Friendship{}).Create(&newFriendship).Error
+	err := tx.Model(&entity.Friendship{}).Create(&newFriendship).Error
 	return err
 }
 
@@ -36,6 +37,108 @@ func (r *PostgreSQLFriendshipRepository) RetrieveFriendIds(userId int64) ([]int6
 	return friends, nil
 }
 
+func (r *PostgreSQLFriendshipRepository) RetrieveFriendshipsOfUsers(userIds []int64) ([]*entity.Friendship, error) {
+	var friendships []*entity.Friendship
+	err := r.db.Model(&entity.Friendship{}).
+		Where("user_id1 IN ? OR user_id2 IN ?", userIds, userIds).
+		Find(&friendships).Error
+	if err != nil {
+		return nil, err
+	}
+	return friendships, nil
+}
+
+func (r *PostgreSQLFriendshipRepository) CountFriendIds(userId int64) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.Friendship{}).
+		Where("user_id1 = ? OR user_id2 = ?", userId, userId).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *PostgreSQLFriendshipRepository) RetrieveFriends(userId int64, afterId int64, limit int) ([]*entity.User, error) {
+	var friends []*entity.User
+	err := r.db.Model(&entity.User{}).
+		Joins("JOIN friendships ON (friendships.user_id1 = ? AND friendships.user_id2 = users.id) OR (friendships.user_id2 = ? AND friendships.user_id1 = users.id)", userId, userId).
+		Where("users.id > ?", afterId).
+		Order("users.id").
+		Limit(limit).
+		Find(&friends).Error
+	if err != nil {
+		return nil, err
+	}
+	return friends, nil
+}
+
+func (r *PostgreSQLFriendshipRepository) RetrieveCommonFriends(userId1, userId2 int64, afterId int64, limit int) ([]*entity.User, error) {
+	var commonFriends []*entity.User
+	err := r.db.Model(&entity.User{}).
+		Where("id IN (?) AND id IN (?)", r.friendIdsQuery(userId1), r.friendIdsQuery(userId2)).
+		Where("id > ?", afterId).
+		Order("id").
+		Limit(limit).
+		Find(&commonFriends).Error
+	if err != nil {
+		return nil, err
+	}
+	return commonFriends, nil
+}
+
+func (r *PostgreSQLFriendshipRepository) CountCommonFriends(userId1, userId2 int64) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.User{}).
+		Where("id IN (?) AND id IN (?)", r.friendIdsQuery(userId1), r.friendIdsQuery(userId2)).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *PostgreSQLFriendshipRepository) RetrieveFriendSuggestions(userId int64, limit, offset int) ([]*entity.FriendSuggestion, error) {
+	var suggestions []*entity.FriendSuggestion
+	err := r.db.Raw(`
+		WITH my_friends AS (
+			SELECT CASE WHEN user_id1 = @user THEN user_id2 ELSE user_id1 END AS friend_id
+			FROM friendships
+			WHERE user_id1 = @user OR user_id2 = @user
+		), candidates AS (
+			SELECT CASE WHEN f.user_id1 = m.friend_id THEN f.user_id2 ELSE f.user_id1 END AS candidate_id
+			FROM friendships f
+			JOIN my_friends m ON f.user_id1 = m.friend_id OR f.user_id2 = m.friend_id
+		)
+		SELECT users.id AS user_id, users.email AS email, COUNT(*) AS mutual_friends
+		FROM candidates
+		JOIN users ON users.id = candidates.candidate_id
+		WHERE users.id <> @user
+			AND users.id NOT IN (SELECT friend_id FROM my_friends)
+			AND users.id NOT IN (
+				SELECT target_id FROM block_relationships
+				WHERE requestor_id = @user AND (expires_at IS NULL OR expires_at > @now)
+				UNION
+				SELECT requestor_id FROM block_relationships
+				WHERE target_id = @user AND (expires_at IS NULL OR expires_at > @now)
+			)
+		GROUP BY users.id, users.email
+		ORDER BY mutual_friends DESC, users.id
+		LIMIT @limit OFFSET @offset`,
+		map[string]interface{}{"user": userId, "now": time.Now(), "limit": limit, "offset": offset}).
+		Scan(&suggestions).Error
+	if err != nil {
+		return nil, err
+	}
+	return suggestions, nil
+}
+
+func (r *PostgreSQLFriendshipRepository) friendIdsQuery(userId int64) *gorm.DB {
+	return r.db.Model(&entity.Friendship{}).
+		Select("CASE WHEN user_id1 = ? THEN user_id2 ELSE user_id1 END", userId).
+		Where("user_id1 = ? OR user_id2 = ?", userId, userId)
+}
+
 func (r *PostgreSQLFriendshipRepository) GetFriendship(userId1, userId2 int64) (*entity.Friendship, error) {
 	friendship := entity.Friendship{UserId1: userId1, UserId2: userId2}
 	err := r.db.Model(&entity.Friendship{}).First(&friendship).Error
@@ -44,3 +147,9 @@ func (r *PostgreSQLFriendshipRepository) GetFriendship(userId1, userId2 int64) (
 	}
 	return &friendship, nil
 }
+
+func (r *PostgreSQLFriendshipRepository) DeleteFriendship(tx *gorm.DB, userId1, userId2 int64) error {
+	deleteFriendship := entity.Friendship{UserId1: userId1, UserId2: userId2}
+	err := tx.Model(&entity.Friendship{}).Delete(&deleteFriendship).Error
+	return err
+}
@@ -10,7 +10,14 @@ import (
 
 type FriendshipRepository interface {
 	GetDB() *gorm.DB
-	CreateFriendship(userId1, userId2 int64) error
+	CreateFriendship(tx *gorm.DB, userId1, userId2 int64) error
 	RetrieveFriendIds(userId int64) ([]int64, error)
+	RetrieveFriendshipsOfUsers(userIds []int64) ([]*entity.Friendship, error)
+	CountFriendIds(userId int64) (int64, error)
+	RetrieveFriends(userId int64, afterId int64, limit int) ([]*entity.User, error)
+	RetrieveCommonFriends(userId1, userId2 int64, afterId int64, limit int) ([]*entity.User, error)
+	CountCommonFriends(userId1, userId2 int64) (int64, error)
+	RetrieveFriendSuggestions(userId int64, limit, offset int) ([]*entity.FriendSuggestion, error)
 	GetFriendship(userId1, userId2 int64) (*entity.Friendship, error)
+	DeleteFriendship(tx *gorm.DB, userId1, userId2 int64) error
 }
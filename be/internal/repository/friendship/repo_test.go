@@ -30,7 +30,7 @@ func TestPostgreSQLFriendshipRepository_CreateFriendship(t *testing.T) {
 		mock.ExpectExec(`INSERT INTO "friendships"`).WithArgs(userId1, userId2, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
-		err := repo.CreateFriendship(userId1, userId2)
+		err := repo.CreateFriendship(gormDB, userId1, userId2)
 		assert.NoError(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
@@ -43,7 +43,7 @@ func TestPostgreSQLFriendshipRepository_CreateFriendship(t *testing.T) {
 		mock.ExpectExec(`INSERT INTO "friendships"`).WithArgs(userId1, userId2, sqlmock.AnyArg()).WillReturnError(assert.AnError)
 		mock.ExpectRollback()
 
-		err := repo.CreateFriendship(userId1, userId2)
+		err := repo.CreateFriendship(gormDB, userId1, userId2)
 		assert.Error(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
@@ -57,7 +57,7 @@ func TestPostgreSQLFriendshipRepository_CreateFriendship(t *testing.T) {
 			WillReturnError(&pq.Error{Code: "23505", Message: "duplicate key value violates unique constraint"})
 		mock.ExpectRollback()
 
-		err := repo.CreateFriendship(userId1, userId2)
+		err := repo.CreateFriendship(gormDB, userId1, userId2)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "duplicate key")
 		assert.NoError(t, mock.ExpectationsWereMet())
@@ -72,7 +72,7 @@ func TestPostgreSQLFriendshipRepository_CreateFriendship(t *testing.T) {
 			WillReturnError(errors.New("invalid input"))
 		mock.ExpectRollback()
 
-		err := repo.CreateFriendship(userId1, userId2)
+		err := repo.CreateFriendship(gormDB, userId1, userId2)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid input")
 		assert.NoError(t, mock.ExpectationsWereMet())
@@ -144,6 +144,203 @@ func TestPostgreSQLFriendshipRepository_RetrieveFriendIds(t *testing.T) {
 	})
 }
 
+func TestPostgreSQLFriendshipRepository_RetrieveFriends(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewFriendshipRepository(gormDB)
+
+	t.Run("successful retrieval after cursor", func(t *testing.T) {
+		userId := int64(1)
+		rows := sqlmock.NewRows([]string{"id", "email"}).
+			AddRow(3, "user3@example.com").
+			AddRow(5, "user5@example.com")
+		mock.ExpectQuery(`SELECT "users"."id","users"."email",.* FROM "users" JOIN friendships ON \(friendships.user_id1 = \$1 AND friendships.user_id2 = users.id\) OR \(friendships.user_id2 = \$2 AND friendships.user_id1 = users.id\) WHERE users.id > \$3 ORDER BY users.id LIMIT \$4`).
+			WithArgs(userId, userId, int64(2), 2).
+			WillReturnRows(rows)
+
+		friends, err := repo.RetrieveFriends(userId, 2, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, friends, 2)
+		assert.Equal(t, int64(3), friends[0].Id)
+		assert.Equal(t, "user5@example.com", friends[1].Email)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`FROM "users" JOIN friendships`).
+			WillReturnError(assert.AnError)
+
+		friends, err := repo.RetrieveFriends(1, 0, 20)
+
+		assert.Error(t, err)
+		assert.Nil(t, friends)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLFriendshipRepository_RetrieveCommonFriends(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewFriendshipRepository(gormDB)
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "email"}).AddRow(3, "user3@example.com")
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE \(id IN \(SELECT CASE WHEN user_id1 = \$1 THEN user_id2 ELSE user_id1 END FROM "friendships" WHERE user_id1 = \$2 OR user_id2 = \$3\) AND id IN \(SELECT CASE WHEN user_id1 = \$4 THEN user_id2 ELSE user_id1 END FROM "friendships" WHERE user_id1 = \$5 OR user_id2 = \$6\)\) AND id > \$7 ORDER BY id LIMIT \$8`).
+			WithArgs(int64(1), int64(1), int64(1), int64(2), int64(2), int64(2), int64(0), 20).
+			WillReturnRows(rows)
+
+		commonFriends, err := repo.RetrieveCommonFriends(1, 2, 0, 20)
+
+		assert.NoError(t, err)
+		assert.Len(t, commonFriends, 1)
+		assert.Equal(t, int64(3), commonFriends[0].Id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE \(id IN`).
+			WillReturnError(assert.AnError)
+
+		commonFriends, err := repo.RetrieveCommonFriends(1, 2, 0, 20)
+
+		assert.Error(t, err)
+		assert.Nil(t, commonFriends)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLFriendshipRepository_CountCommonFriends(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewFriendshipRepository(gormDB)
+
+	t.Run("successful count", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"count"}).AddRow(2)
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "users" WHERE id IN \(SELECT CASE WHEN user_id1 = \$1 THEN user_id2 ELSE user_id1 END FROM "friendships" WHERE user_id1 = \$2 OR user_id2 = \$3\) AND id IN \(SELECT CASE WHEN user_id1 = \$4 THEN user_id2 ELSE user_id1 END FROM "friendships" WHERE user_id1 = \$5 OR user_id2 = \$6\)`).
+			WithArgs(int64(1), int64(1), int64(1), int64(2), int64(2), int64(2)).
+			WillReturnRows(rows)
+
+		count, err := repo.CountCommonFriends(1, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "users"`).
+			WillReturnError(assert.AnError)
+
+		count, err := repo.CountCommonFriends(1, 2)
+
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLFriendshipRepository_RetrieveFriendshipsOfUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewFriendshipRepository(gormDB)
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"user_id1", "user_id2"}).
+			AddRow(1, 2).
+			AddRow(2, 5)
+		mock.ExpectQuery(`SELECT \* FROM "friendships" WHERE user_id1 IN \(\$1,\$2\) OR user_id2 IN \(\$3,\$4\)`).
+			WithArgs(int64(1), int64(5), int64(1), int64(5)).
+			WillReturnRows(rows)
+
+		friendships, err := repo.RetrieveFriendshipsOfUsers([]int64{1, 5})
+
+		assert.NoError(t, err)
+		assert.Len(t, friendships, 2)
+		assert.Equal(t, int64(2), friendships[1].UserId1)
+		assert.Equal(t, int64(5), friendships[1].UserId2)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "friendships" WHERE user_id1 IN`).
+			WillReturnError(assert.AnError)
+
+		friendships, err := repo.RetrieveFriendshipsOfUsers([]int64{1})
+
+		assert.Error(t, err)
+		assert.Nil(t, friendships)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLFriendshipRepository_CountFriendIds(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewFriendshipRepository(gormDB)
+
+	t.Run("successful count", func(t *testing.T) {
+		userId := int64(1)
+		rows := sqlmock.NewRows([]string{"count"}).AddRow(4)
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "friendships" WHERE user_id1 = \$1 OR user_id2 = \$2`).
+			WithArgs(userId, userId).
+			WillReturnRows(rows)
+
+		count, err := repo.CountFriendIds(userId)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		userId := int64(1)
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "friendships"`).
+			WillReturnError(assert.AnError)
+
+		count, err := repo.CountFriendIds(userId)
+
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestPostgreSQLFriendshipRepository_GetFriendship(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
@@ -201,3 +398,90 @@ func TestPostgreSQLFriendshipRepository_GetFriendship(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestPostgreSQLFriendshipRepository_DeleteFriendship(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewFriendshipRepository(gormDB)
+
+	t.Run("successful deletion", func(t *testing.T) {
+		userId1 := int64(1)
+		userId2 := int64(2)
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "friendships"`).WithArgs(userId1, userId2).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		tx := gormDB.Begin()
+		err := repo.DeleteFriendship(tx, userId1, userId2)
+		tx.Commit()
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("deletion error", func(t *testing.T) {
+		userId1 := int64(1)
+		userId2 := int64(2)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "friendships"`).
+			WithArgs(userId1, userId2).
+			WillReturnError(gorm.ErrInvalidTransaction)
+		mock.ExpectRollback()
+
+		tx := gormDB.Begin()
+		err := repo.DeleteFriendship(tx, userId1, userId2)
+		tx.Rollback()
+
+		assert.Error(t, err)
+		assert.Equal(t, gorm.ErrInvalidTransaction, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLFriendshipRepository_RetrieveFriendSuggestions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewFriendshipRepository(gormDB)
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		userId := int64(1)
+		rows := sqlmock.NewRows([]string{"user_id", "email", "mutual_friends"}).
+			AddRow(7, "user7@example.com", 3).
+			AddRow(4, "user4@example.com", 1)
+		mock.ExpectQuery(`WITH my_friends AS \(.*\) SELECT users.id AS user_id, users.email AS email, COUNT\(\*\) AS mutual_friends FROM candidates .* WHERE requestor_id = \$\d+ AND \(expires_at IS NULL OR expires_at > \$\d+\) .* WHERE target_id = \$\d+ AND \(expires_at IS NULL OR expires_at > \$\d+\) .* ORDER BY mutual_friends DESC, users.id\s+LIMIT \$\d+ OFFSET \$\d+`).
+			WillReturnRows(rows)
+
+		suggestions, err := repo.RetrieveFriendSuggestions(userId, 20, 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, suggestions, 2)
+		assert.Equal(t, int64(7), suggestions[0].UserId)
+		assert.Equal(t, "user7@example.com", suggestions[0].Email)
+		assert.Equal(t, int64(3), suggestions[0].MutualFriends)
+		assert.Equal(t, int64(1), suggestions[1].MutualFriends)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`WITH my_friends AS`).
+			WillReturnError(assert.AnError)
+
+		suggestions, err := repo.RetrieveFriendSuggestions(1, 20, 0)
+
+		assert.Error(t, err)
+		assert.Nil(t, suggestions)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -3,27 +3,54 @@ package repository
 import (
 	auth "BE_Friends_Management/internal/repository/auth"
 	block_relationship "BE_Friends_Management/internal/repository/block_relationship"
+	digest "BE_Friends_Management/internal/repository/digest"
+	email_message "BE_Friends_Management/internal/repository/email_message"
+	friend_request "BE_Friends_Management/internal/repository/friend_request"
 	friendship "BE_Friends_Management/internal/repository/friendship"
+	mute "BE_Friends_Management/internal/repository/mute"
+	notification_preference "BE_Friends_Management/internal/repository/notification_preference"
+	outbox "BE_Friends_Management/internal/repository/outbox"
+	revocation "BE_Friends_Management/internal/repository/revocation"
 	subscription "BE_Friends_Management/internal/repository/subscription"
+	update "BE_Friends_Management/internal/repository/update"
 	user "BE_Friends_Management/internal/repository/users"
+	webhook "BE_Friends_Management/internal/repository/webhook"
 
 	"gorm.io/gorm"
 )
 
 type Repository struct {
-	User              user.UserRepository
-	Friendship        friendship.FriendshipRepository
-	Subscription      subscription.SubscriptionRepository
-	BlockRelationship block_relationship.BlockRelationshipRepository
-	Auth              auth.AuthRepository
+	User                   user.UserRepository
+	Friendship             friendship.FriendshipRepository
+	FriendRequest          friend_request.FriendRequestRepository
+	Subscription           subscription.SubscriptionRepository
+	BlockRelationship      block_relationship.BlockRelationshipRepository
+	Mute                   mute.MuteRepository
+	Update                 update.UpdateRepository
+	Auth                   auth.AuthRepository
+	Webhook                webhook.WebhookRepository
+	Outbox                 outbox.OutboxRepository
+	Digest                 digest.DigestRepository
+	NotificationPreference notification_preference.NotificationPreferenceRepository
+	Revocation             revocation.RevocationRepository
+	EmailMessage           email_message.EmailMessageRepository
 }
 
 func NewRepository(db *gorm.DB) *Repository {
 	return &Repository{
-		User:              user.NewUserRepository(db),
-		Friendship:        friendship.NewFriendshipRepository(db),
-		Subscription:      subscription.NewSubscriptionRepository(db),
-		BlockRelationship: block_relationship.NewBlockRelationshipRepository(db),
-		Auth:              auth.NewAuthRepository(db),
+		User:                   user.NewUserRepository(db),
+		Friendship:             friendship.NewFriendshipRepository(db),
+		FriendRequest:          friend_request.NewFriendRequestRepository(db),
+		Subscription:           subscription.NewSubscriptionRepository(db),
+		BlockRelationship:      block_relationship.NewBlockRelationshipRepository(db),
+		Mute:                   mute.NewMuteRepository(db),
+		Update:                 update.NewUpdateRepository(db),
+		Auth:                   auth.NewAuthRepository(db),
+		Webhook:                webhook.NewWebhookRepository(db),
+		Outbox:                 outbox.NewOutboxRepository(db),
+		Digest:                 digest.NewDigestRepository(db),
+		NotificationPreference: notification_preference.NewNotificationPreferenceRepository(db),
+		Revocation:             revocation.NewRevocationRepository(db),
+		EmailMessage:           email_message.NewEmailMessageRepository(db),
 	}
 }
@@ -7,6 +7,7 @@ package mock_repository
 import (
 	entity "BE_Friends_Management/internal/domain/entity"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -63,14 +64,87 @@ func (mr *MockAuthRepositoryMockRecorder) FindByRefreshToken(refreshToken interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByRefreshToken", reflect.TypeOf((*MockAuthRepository)(nil).FindByRefreshToken), refreshToken)
 }
 
-// SetRefreshTokenIsRevoked mocks base method.
-func (m *MockAuthRepository) SetRefreshTokenIsRevoked(refreshToken string) error {
+// GetActiveTokensByUserId mocks base method.
+func (m *MockAuthRepository) GetActiveTokensByUserId(userId int64, now time.Time) ([]*entity.UserToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetRefreshTokenIsRevoked", refreshToken)
+	ret := m.ctrl.Call(m, "GetActiveTokensByUserId", userId, now)
+	ret0, _ := ret[0].([]*entity.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveTokensByUserId indicates an expected call of GetActiveTokensByUserId.
+func (mr *MockAuthRepositoryMockRecorder) GetActiveTokensByUserId(userId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveTokensByUserId", reflect.TypeOf((*MockAuthRepository)(nil).GetActiveTokensByUserId), userId, now)
+}
+
+// GetTokenById mocks base method.
+func (m *MockAuthRepository) GetTokenById(userId, tokenId int64) (*entity.UserToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokenById", userId, tokenId)
+	ret0, _ := ret[0].(*entity.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokenById indicates an expected call of GetTokenById.
+func (mr *MockAuthRepositoryMockRecorder) GetTokenById(userId, tokenId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenById", reflect.TypeOf((*MockAuthRepository)(nil).GetTokenById), userId, tokenId)
+}
+
+// RevokeTokenById mocks base method.
+func (m *MockAuthRepository) RevokeTokenById(tokenId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeTokenById", tokenId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// RevokeTokenById indicates an expected call of RevokeTokenById.
+func (mr *MockAuthRepositoryMockRecorder) RevokeTokenById(tokenId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeTokenById", reflect.TypeOf((*MockAuthRepository)(nil).RevokeTokenById), tokenId)
+}
+
+// RevokeTokenFamily mocks base method.
+func (m *MockAuthRepository) RevokeTokenFamily(familyId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeTokenFamily", familyId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeTokenFamily indicates an expected call of RevokeTokenFamily.
+func (mr *MockAuthRepositoryMockRecorder) RevokeTokenFamily(familyId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeTokenFamily", reflect.TypeOf((*MockAuthRepository)(nil).RevokeTokenFamily), familyId)
+}
+
+// RevokeUserTokens mocks base method.
+func (m *MockAuthRepository) RevokeUserTokens(userId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserTokens", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserTokens indicates an expected call of RevokeUserTokens.
+func (mr *MockAuthRepositoryMockRecorder) RevokeUserTokens(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserTokens", reflect.TypeOf((*MockAuthRepository)(nil).RevokeUserTokens), userId)
+}
+
+// SetRefreshTokenIsRevoked mocks base method.
+func (m *MockAuthRepository) SetRefreshTokenIsRevoked(refreshToken string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRefreshTokenIsRevoked", refreshToken)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // SetRefreshTokenIsRevoked indicates an expected call of SetRefreshTokenIsRevoked.
 func (mr *MockAuthRepositoryMockRecorder) SetRefreshTokenIsRevoked(refreshToken interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
@@ -7,6 +7,7 @@ package mock_repository
 import (
 	entity "BE_Friends_Management/internal/domain/entity"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	gorm "gorm.io/gorm"
@@ -35,32 +36,62 @@ func (m *MockBlockRelationshipRepository) EXPECT() *MockBlockRelationshipReposit
 	return m.recorder
 }
 
+// CountBlockRelationshipsByRequestor mocks base method.
+func (m *MockBlockRelationshipRepository) CountBlockRelationshipsByRequestor(requestorId int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBlockRelationshipsByRequestor", requestorId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBlockRelationshipsByRequestor indicates an expected call of CountBlockRelationshipsByRequestor.
+func (mr *MockBlockRelationshipRepositoryMockRecorder) CountBlockRelationshipsByRequestor(requestorId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBlockRelationshipsByRequestor", reflect.TypeOf((*MockBlockRelationshipRepository)(nil).CountBlockRelationshipsByRequestor), requestorId)
+}
+
 // CreateBlockRelationship mocks base method.
-func (m *MockBlockRelationshipRepository) CreateBlockRelationship(tx *gorm.DB, requestorId, targetId int64) error {
+func (m *MockBlockRelationshipRepository) CreateBlockRelationship(tx *gorm.DB, requestorId, targetId int64, expiresAt *time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateBlockRelationship", tx, requestorId, targetId)
+	ret := m.ctrl.Call(m, "CreateBlockRelationship", tx, requestorId, targetId, expiresAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateBlockRelationship indicates an expected call of CreateBlockRelationship.
-func (mr *MockBlockRelationshipRepositoryMockRecorder) CreateBlockRelationship(tx, requestorId, targetId interface{}) *gomock.Call {
+func (mr *MockBlockRelationshipRepositoryMockRecorder) CreateBlockRelationship(tx, requestorId, targetId, expiresAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBlockRelationship", reflect.TypeOf((*MockBlockRelationshipRepository)(nil).CreateBlockRelationship), tx, requestorId, targetId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBlockRelationship", reflect.TypeOf((*MockBlockRelationshipRepository)(nil).CreateBlockRelationship), tx, requestorId, targetId, expiresAt)
 }
 
 // DeleteBlockRelationship mocks base method.
-func (m *MockBlockRelationshipRepository) DeleteBlockRelationship(requestorId, targetId int64) error {
+func (m *MockBlockRelationshipRepository) DeleteBlockRelationship(tx *gorm.DB, requestorId, targetId int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteBlockRelationship", requestorId, targetId)
+	ret := m.ctrl.Call(m, "DeleteBlockRelationship", tx, requestorId, targetId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteBlockRelationship indicates an expected call of DeleteBlockRelationship.
-func (mr *MockBlockRelationshipRepositoryMockRecorder) DeleteBlockRelationship(requestorId, targetId interface{}) *gomock.Call {
+func (mr *MockBlockRelationshipRepositoryMockRecorder) DeleteBlockRelationship(tx, requestorId, targetId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlockRelationship", reflect.TypeOf((*MockBlockRelationshipRepository)(nil).DeleteBlockRelationship), requestorId, targetId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlockRelationship", reflect.TypeOf((*MockBlockRelationshipRepository)(nil).DeleteBlockRelationship), tx, requestorId, targetId)
+}
+
+// DeleteExpiredBlockRelationship mocks base method.
+func (m *MockBlockRelationshipRepository) DeleteExpiredBlockRelationship(tx *gorm.DB, requestorId, targetId int64, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredBlockRelationship", tx, requestorId, targetId, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredBlockRelationship indicates an expected call of DeleteExpiredBlockRelationship.
+func (mr *MockBlockRelationshipRepositoryMockRecorder) DeleteExpiredBlockRelationship(tx, requestorId, targetId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredBlockRelationship", reflect.TypeOf((*MockBlockRelationshipRepository)(nil).DeleteExpiredBlockRelationship), tx, requestorId, targetId, now)
 }
 
 // GetBlockRelationship mocks base method.
@@ -78,6 +109,21 @@ func (mr *MockBlockRelationshipRepositoryMockRecorder) GetBlockRelationship(requ
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockRelationship", reflect.TypeOf((*MockBlockRelationshipRepository)(nil).GetBlockRelationship), requestorId, targetId)
 }
 
+// GetBlockRelationshipsByRequestor mocks base method.
+func (m *MockBlockRelationshipRepository) GetBlockRelationshipsByRequestor(requestorId int64, limit, offset int) ([]*entity.BlockRelationship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockRelationshipsByRequestor", requestorId, limit, offset)
+	ret0, _ := ret[0].([]*entity.BlockRelationship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockRelationshipsByRequestor indicates an expected call of GetBlockRelationshipsByRequestor.
+func (mr *MockBlockRelationshipRepositoryMockRecorder) GetBlockRelationshipsByRequestor(requestorId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockRelationshipsByRequestor", reflect.TypeOf((*MockBlockRelationshipRepository)(nil).GetBlockRelationshipsByRequestor), requestorId, limit, offset)
+}
+
 // GetBlockRequestorIds mocks base method.
 func (m *MockBlockRelationshipRepository) GetBlockRequestorIds(targetId int64) ([]int64, error) {
 	m.ctrl.T.Helper()
@@ -93,6 +139,21 @@ func (mr *MockBlockRelationshipRepositoryMockRecorder) GetBlockRequestorIds(targ
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockRequestorIds", reflect.TypeOf((*MockBlockRelationshipRepository)(nil).GetBlockRequestorIds), targetId)
 }
 
+// GetBlockedTargetIds mocks base method.
+func (m *MockBlockRelationshipRepository) GetBlockedTargetIds(requestorId int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockedTargetIds", requestorId)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockedTargetIds indicates an expected call of GetBlockedTargetIds.
+func (mr *MockBlockRelationshipRepositoryMockRecorder) GetBlockedTargetIds(requestorId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockedTargetIds", reflect.TypeOf((*MockBlockRelationshipRepository)(nil).GetBlockedTargetIds), requestorId)
+}
+
 // GetDB mocks base method.
 func (m *MockBlockRelationshipRepository) GetDB() *gorm.DB {
 	m.ctrl.T.Helper()
@@ -106,3 +167,18 @@ func (mr *MockBlockRelationshipRepositoryMockRecorder) GetDB() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDB", reflect.TypeOf((*MockBlockRelationshipRepository)(nil).GetDB))
 }
+
+// GetExpiredBlockRelationships mocks base method.
+func (m *MockBlockRelationshipRepository) GetExpiredBlockRelationships(tx *gorm.DB, now time.Time, limit int) ([]*entity.BlockRelationship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredBlockRelationships", tx, now, limit)
+	ret0, _ := ret[0].([]*entity.BlockRelationship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredBlockRelationships indicates an expected call of GetExpiredBlockRelationships.
+func (mr *MockBlockRelationshipRepositoryMockRecorder) GetExpiredBlockRelationships(tx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredBlockRelationships", reflect.TypeOf((*MockBlockRelationshipRepository)(nil).GetExpiredBlockRelationships), tx, now, limit)
+}
@@ -0,0 +1,166 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	entity "BE_Friends_Management/internal/domain/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockDigestRepository is a mock of DigestRepository interface.
+type MockDigestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDigestRepositoryMockRecorder
+}
+
+// MockDigestRepositoryMockRecorder is the mock recorder for MockDigestRepository.
+type MockDigestRepositoryMockRecorder struct {
+	mock *MockDigestRepository
+}
+
+// NewMockDigestRepository creates a new mock instance.
+func NewMockDigestRepository(ctrl *gomock.Controller) *MockDigestRepository {
+	mock := &MockDigestRepository{ctrl: ctrl}
+	mock.recorder = &MockDigestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDigestRepository) EXPECT() *MockDigestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AdvisoryUnlock mocks base method.
+func (m *MockDigestRepository) AdvisoryUnlock(conn *gorm.DB, key int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvisoryUnlock", conn, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdvisoryUnlock indicates an expected call of AdvisoryUnlock.
+func (mr *MockDigestRepositoryMockRecorder) AdvisoryUnlock(conn, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvisoryUnlock", reflect.TypeOf((*MockDigestRepository)(nil).AdvisoryUnlock), conn, key)
+}
+
+// CreatePreference mocks base method.
+func (m *MockDigestRepository) CreatePreference(preference *entity.DigestPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePreference", preference)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePreference indicates an expected call of CreatePreference.
+func (mr *MockDigestRepositoryMockRecorder) CreatePreference(preference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePreference", reflect.TypeOf((*MockDigestRepository)(nil).CreatePreference), preference)
+}
+
+// GetDB mocks base method.
+func (m *MockDigestRepository) GetDB() *gorm.DB {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDB")
+	ret0, _ := ret[0].(*gorm.DB)
+	return ret0
+}
+
+// GetDB indicates an expected call of GetDB.
+func (mr *MockDigestRepositoryMockRecorder) GetDB() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDB", reflect.TypeOf((*MockDigestRepository)(nil).GetDB))
+}
+
+// GetDuePreferences mocks base method.
+func (m *MockDigestRepository) GetDuePreferences(conn *gorm.DB, dailyBefore, weeklyBefore, now time.Time, limit int) ([]*entity.DigestPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDuePreferences", conn, dailyBefore, weeklyBefore, now, limit)
+	ret0, _ := ret[0].([]*entity.DigestPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDuePreferences indicates an expected call of GetDuePreferences.
+func (mr *MockDigestRepositoryMockRecorder) GetDuePreferences(conn, dailyBefore, weeklyBefore, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuePreferences", reflect.TypeOf((*MockDigestRepository)(nil).GetDuePreferences), conn, dailyBefore, weeklyBefore, now, limit)
+}
+
+// GetPreference mocks base method.
+func (m *MockDigestRepository) GetPreference(userId int64) (*entity.DigestPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreference", userId)
+	ret0, _ := ret[0].(*entity.DigestPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreference indicates an expected call of GetPreference.
+func (mr *MockDigestRepositoryMockRecorder) GetPreference(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreference", reflect.TypeOf((*MockDigestRepository)(nil).GetPreference), userId)
+}
+
+// MarkDigestFailed mocks base method.
+func (m *MockDigestRepository) MarkDigestFailed(conn *gorm.DB, userId int64, failedAttempts int, nextAttemptAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDigestFailed", conn, userId, failedAttempts, nextAttemptAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDigestFailed indicates an expected call of MarkDigestFailed.
+func (mr *MockDigestRepositoryMockRecorder) MarkDigestFailed(conn, userId, failedAttempts, nextAttemptAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDigestFailed", reflect.TypeOf((*MockDigestRepository)(nil).MarkDigestFailed), conn, userId, failedAttempts, nextAttemptAt)
+}
+
+// MarkDigestSent mocks base method.
+func (m *MockDigestRepository) MarkDigestSent(conn *gorm.DB, userId, watermarkDeliveryId int64, sentAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDigestSent", conn, userId, watermarkDeliveryId, sentAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDigestSent indicates an expected call of MarkDigestSent.
+func (mr *MockDigestRepositoryMockRecorder) MarkDigestSent(conn, userId, watermarkDeliveryId, sentAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDigestSent", reflect.TypeOf((*MockDigestRepository)(nil).MarkDigestSent), conn, userId, watermarkDeliveryId, sentAt)
+}
+
+// TryAdvisoryLock mocks base method.
+func (m *MockDigestRepository) TryAdvisoryLock(conn *gorm.DB, key int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryAdvisoryLock", conn, key)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TryAdvisoryLock indicates an expected call of TryAdvisoryLock.
+func (mr *MockDigestRepositoryMockRecorder) TryAdvisoryLock(conn, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryAdvisoryLock", reflect.TypeOf((*MockDigestRepository)(nil).TryAdvisoryLock), conn, key)
+}
+
+// UpdatePreference mocks base method.
+func (m *MockDigestRepository) UpdatePreference(preference *entity.DigestPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreference", preference)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePreference indicates an expected call of UpdatePreference.
+func (mr *MockDigestRepositoryMockRecorder) UpdatePreference(preference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreference", reflect.TypeOf((*MockDigestRepository)(nil).UpdatePreference), preference)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	entity "BE_Friends_Management/internal/domain/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEmailMessageRepository is a mock of EmailMessageRepository interface.
+type MockEmailMessageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailMessageRepositoryMockRecorder
+}
+
+// MockEmailMessageRepositoryMockRecorder is the mock recorder for MockEmailMessageRepository.
+type MockEmailMessageRepositoryMockRecorder struct {
+	mock *MockEmailMessageRepository
+}
+
+// NewMockEmailMessageRepository creates a new mock instance.
+func NewMockEmailMessageRepository(ctrl *gomock.Controller) *MockEmailMessageRepository {
+	mock := &MockEmailMessageRepository{ctrl: ctrl}
+	mock.recorder = &MockEmailMessageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailMessageRepository) EXPECT() *MockEmailMessageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDueMessages mocks base method.
+func (m *MockEmailMessageRepository) ClaimDueMessages(now, leaseUntil time.Time, limit int) ([]*entity.EmailMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueMessages", now, leaseUntil, limit)
+	ret0, _ := ret[0].([]*entity.EmailMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueMessages indicates an expected call of ClaimDueMessages.
+func (mr *MockEmailMessageRepositoryMockRecorder) ClaimDueMessages(now, leaseUntil, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueMessages", reflect.TypeOf((*MockEmailMessageRepository)(nil).ClaimDueMessages), now, leaseUntil, limit)
+}
+
+// EnqueueMessages mocks base method.
+func (m *MockEmailMessageRepository) EnqueueMessages(messages []*entity.EmailMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueMessages", messages)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueMessages indicates an expected call of EnqueueMessages.
+func (mr *MockEmailMessageRepositoryMockRecorder) EnqueueMessages(messages interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueMessages", reflect.TypeOf((*MockEmailMessageRepository)(nil).EnqueueMessages), messages)
+}
+
+// UpdateMessageResult mocks base method.
+func (m *MockEmailMessageRepository) UpdateMessageResult(message *entity.EmailMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMessageResult", message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMessageResult indicates an expected call of UpdateMessageResult.
+func (mr *MockEmailMessageRepositoryMockRecorder) UpdateMessageResult(message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMessageResult", reflect.TypeOf((*MockEmailMessageRepository)(nil).UpdateMessageResult), message)
+}
@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	entity "BE_Friends_Management/internal/domain/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockFriendRequestRepository is a mock of FriendRequestRepository interface.
+type MockFriendRequestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFriendRequestRepositoryMockRecorder
+}
+
+// MockFriendRequestRepositoryMockRecorder is the mock recorder for MockFriendRequestRepository.
+type MockFriendRequestRepositoryMockRecorder struct {
+	mock *MockFriendRequestRepository
+}
+
+// NewMockFriendRequestRepository creates a new mock instance.
+func NewMockFriendRequestRepository(ctrl *gomock.Controller) *MockFriendRequestRepository {
+	mock := &MockFriendRequestRepository{ctrl: ctrl}
+	mock.recorder = &MockFriendRequestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFriendRequestRepository) EXPECT() *MockFriendRequestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateFriendRequest mocks base method.
+func (m *MockFriendRequestRepository) CreateFriendRequest(tx *gorm.DB, friendRequest *entity.FriendRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFriendRequest", tx, friendRequest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFriendRequest indicates an expected call of CreateFriendRequest.
+func (mr *MockFriendRequestRepositoryMockRecorder) CreateFriendRequest(tx, friendRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFriendRequest", reflect.TypeOf((*MockFriendRequestRepository)(nil).CreateFriendRequest), tx, friendRequest)
+}
+
+// GetDB mocks base method.
+func (m *MockFriendRequestRepository) GetDB() *gorm.DB {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDB")
+	ret0, _ := ret[0].(*gorm.DB)
+	return ret0
+}
+
+// GetDB indicates an expected call of GetDB.
+func (mr *MockFriendRequestRepositoryMockRecorder) GetDB() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDB", reflect.TypeOf((*MockFriendRequestRepository)(nil).GetDB))
+}
+
+// GetFriendRequestById mocks base method.
+func (m *MockFriendRequestRepository) GetFriendRequestById(requestId int64) (*entity.FriendRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFriendRequestById", requestId)
+	ret0, _ := ret[0].(*entity.FriendRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFriendRequestById indicates an expected call of GetFriendRequestById.
+func (mr *MockFriendRequestRepositoryMockRecorder) GetFriendRequestById(requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFriendRequestById", reflect.TypeOf((*MockFriendRequestRepository)(nil).GetFriendRequestById), requestId)
+}
+
+// GetIncomingFriendRequests mocks base method.
+func (m *MockFriendRequestRepository) GetIncomingFriendRequests(targetId int64) ([]*entity.FriendRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncomingFriendRequests", targetId)
+	ret0, _ := ret[0].([]*entity.FriendRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncomingFriendRequests indicates an expected call of GetIncomingFriendRequests.
+func (mr *MockFriendRequestRepositoryMockRecorder) GetIncomingFriendRequests(targetId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncomingFriendRequests", reflect.TypeOf((*MockFriendRequestRepository)(nil).GetIncomingFriendRequests), targetId)
+}
+
+// GetOutgoingFriendRequests mocks base method.
+func (m *MockFriendRequestRepository) GetOutgoingFriendRequests(requestorId int64) ([]*entity.FriendRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutgoingFriendRequests", requestorId)
+	ret0, _ := ret[0].([]*entity.FriendRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutgoingFriendRequests indicates an expected call of GetOutgoingFriendRequests.
+func (mr *MockFriendRequestRepositoryMockRecorder) GetOutgoingFriendRequests(requestorId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingFriendRequests", reflect.TypeOf((*MockFriendRequestRepository)(nil).GetOutgoingFriendRequests), requestorId)
+}
+
+// GetPendingFriendRequest mocks base method.
+func (m *MockFriendRequestRepository) GetPendingFriendRequest(requestorId, targetId int64) (*entity.FriendRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingFriendRequest", requestorId, targetId)
+	ret0, _ := ret[0].(*entity.FriendRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingFriendRequest indicates an expected call of GetPendingFriendRequest.
+func (mr *MockFriendRequestRepositoryMockRecorder) GetPendingFriendRequest(requestorId, targetId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingFriendRequest", reflect.TypeOf((*MockFriendRequestRepository)(nil).GetPendingFriendRequest), requestorId, targetId)
+}
+
+// UpdateFriendRequestStatus mocks base method.
+func (m *MockFriendRequestRepository) UpdateFriendRequestStatus(tx *gorm.DB, requestId int64, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFriendRequestStatus", tx, requestId, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFriendRequestStatus indicates an expected call of UpdateFriendRequestStatus.
+func (mr *MockFriendRequestRepositoryMockRecorder) UpdateFriendRequestStatus(tx, requestId, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFriendRequestStatus", reflect.TypeOf((*MockFriendRequestRepository)(nil).UpdateFriendRequestStatus), tx, requestId, status)
+}
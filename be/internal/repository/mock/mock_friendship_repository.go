@@ -35,18 +35,62 @@ func (m *MockFriendshipRepository) EXPECT() *MockFriendshipRepositoryMockRecorde
 	return m.recorder
 }
 
+// CountCommonFriends mocks base method.
+func (m *MockFriendshipRepository) CountCommonFriends(userId1, userId2 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCommonFriends", userId1, userId2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCommonFriends indicates an expected call of CountCommonFriends.
+func (mr *MockFriendshipRepositoryMockRecorder) CountCommonFriends(userId1, userId2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCommonFriends", reflect.TypeOf((*MockFriendshipRepository)(nil).CountCommonFriends), userId1, userId2)
+}
+
+// CountFriendIds mocks base method.
+func (m *MockFriendshipRepository) CountFriendIds(userId int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFriendIds", userId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFriendIds indicates an expected call of CountFriendIds.
+func (mr *MockFriendshipRepositoryMockRecorder) CountFriendIds(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFriendIds", reflect.TypeOf((*MockFriendshipRepository)(nil).CountFriendIds), userId)
+}
+
 // CreateFriendship mocks base method.
-func (m *MockFriendshipRepository) CreateFriendship(userId1, userId2 int64) error {
+func (m *MockFriendshipRepository) CreateFriendship(tx *gorm.DB, userId1, userId2 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateFriendship", userId1, userId2)
+	ret := m.ctrl.Call(m, "CreateFriendship", tx, userId1, userId2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateFriendship indicates an expected call of CreateFriendship.
-func (mr *MockFriendshipRepositoryMockRecorder) CreateFriendship(userId1, userId2 interface{}) *gomock.Call {
+func (mr *MockFriendshipRepositoryMockRecorder) CreateFriendship(tx, userId1, userId2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFriendship", reflect.TypeOf((*MockFriendshipRepository)(nil).CreateFriendship), tx, userId1, userId2)
+}
+
+// DeleteFriendship mocks base method.
+func (m *MockFriendshipRepository) DeleteFriendship(tx *gorm.DB, userId1, userId2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFriendship", tx, userId1, userId2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFriendship indicates an expected call of DeleteFriendship.
+func (mr *MockFriendshipRepositoryMockRecorder) DeleteFriendship(tx, userId1, userId2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFriendship", reflect.TypeOf((*MockFriendshipRepository)(nil).CreateFriendship), userId1, userId2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFriendship", reflect.TypeOf((*MockFriendshipRepository)(nil).DeleteFriendship), tx, userId1, userId2)
 }
 
 // GetDB mocks base method.
@@ -78,6 +122,21 @@ func (mr *MockFriendshipRepositoryMockRecorder) GetFriendship(userId1, userId2 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFriendship", reflect.TypeOf((*MockFriendshipRepository)(nil).GetFriendship), userId1, userId2)
 }
 
+// RetrieveCommonFriends mocks base method.
+func (m *MockFriendshipRepository) RetrieveCommonFriends(userId1, userId2, afterId int64, limit int) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveCommonFriends", userId1, userId2, afterId, limit)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrieveCommonFriends indicates an expected call of RetrieveCommonFriends.
+func (mr *MockFriendshipRepositoryMockRecorder) RetrieveCommonFriends(userId1, userId2, afterId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveCommonFriends", reflect.TypeOf((*MockFriendshipRepository)(nil).RetrieveCommonFriends), userId1, userId2, afterId, limit)
+}
+
 // RetrieveFriendIds mocks base method.
 func (m *MockFriendshipRepository) RetrieveFriendIds(userId int64) ([]int64, error) {
 	m.ctrl.T.Helper()
@@ -92,3 +151,48 @@ func (mr *MockFriendshipRepositoryMockRecorder) RetrieveFriendIds(userId interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveFriendIds", reflect.TypeOf((*MockFriendshipRepository)(nil).RetrieveFriendIds), userId)
 }
+
+// RetrieveFriendSuggestions mocks base method.
+func (m *MockFriendshipRepository) RetrieveFriendSuggestions(userId int64, limit, offset int) ([]*entity.FriendSuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveFriendSuggestions", userId, limit, offset)
+	ret0, _ := ret[0].([]*entity.FriendSuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrieveFriendSuggestions indicates an expected call of RetrieveFriendSuggestions.
+func (mr *MockFriendshipRepositoryMockRecorder) RetrieveFriendSuggestions(userId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveFriendSuggestions", reflect.TypeOf((*MockFriendshipRepository)(nil).RetrieveFriendSuggestions), userId, limit, offset)
+}
+
+// RetrieveFriends mocks base method.
+func (m *MockFriendshipRepository) RetrieveFriends(userId, afterId int64, limit int) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveFriends", userId, afterId, limit)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrieveFriends indicates an expected call of RetrieveFriends.
+func (mr *MockFriendshipRepositoryMockRecorder) RetrieveFriends(userId, afterId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveFriends", reflect.TypeOf((*MockFriendshipRepository)(nil).RetrieveFriends), userId, afterId, limit)
+}
+
+// RetrieveFriendshipsOfUsers mocks base method.
+func (m *MockFriendshipRepository) RetrieveFriendshipsOfUsers(userIds []int64) ([]*entity.Friendship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveFriendshipsOfUsers", userIds)
+	ret0, _ := ret[0].([]*entity.Friendship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrieveFriendshipsOfUsers indicates an expected call of RetrieveFriendshipsOfUsers.
+func (mr *MockFriendshipRepositoryMockRecorder) RetrieveFriendshipsOfUsers(userIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveFriendshipsOfUsers", reflect.TypeOf((*MockFriendshipRepository)(nil).RetrieveFriendshipsOfUsers), userIds)
+}
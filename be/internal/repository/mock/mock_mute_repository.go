@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	entity "BE_Friends_Management/internal/domain/entity"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockMuteRepository is a mock of MuteRepository interface.
+type MockMuteRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMuteRepositoryMockRecorder
+}
+
+// MockMuteRepositoryMockRecorder is the mock recorder for MockMuteRepository.
+type MockMuteRepositoryMockRecorder struct {
+	mock *MockMuteRepository
+}
+
+// NewMockMuteRepository creates a new mock instance.
+func NewMockMuteRepository(ctrl *gomock.Controller) *MockMuteRepository {
+	mock := &MockMuteRepository{ctrl: ctrl}
+	mock.recorder = &MockMuteRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMuteRepository) EXPECT() *MockMuteRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountMutesByRequestor mocks base method.
+func (m *MockMuteRepository) CountMutesByRequestor(requestorId int64, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMutesByRequestor", requestorId, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMutesByRequestor indicates an expected call of CountMutesByRequestor.
+func (mr *MockMuteRepositoryMockRecorder) CountMutesByRequestor(requestorId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMutesByRequestor", reflect.TypeOf((*MockMuteRepository)(nil).CountMutesByRequestor), requestorId, now)
+}
+
+// DeleteExpiredMutes mocks base method.
+func (m *MockMuteRepository) DeleteExpiredMutes(ctx context.Context, now time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredMutes", ctx, now, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredMutes indicates an expected call of DeleteExpiredMutes.
+func (mr *MockMuteRepositoryMockRecorder) DeleteExpiredMutes(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredMutes", reflect.TypeOf((*MockMuteRepository)(nil).DeleteExpiredMutes), ctx, now, limit)
+}
+
+// DeleteMute mocks base method.
+func (m *MockMuteRepository) DeleteMute(requestorId, targetId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMute", requestorId, targetId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMute indicates an expected call of DeleteMute.
+func (mr *MockMuteRepositoryMockRecorder) DeleteMute(requestorId, targetId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMute", reflect.TypeOf((*MockMuteRepository)(nil).DeleteMute), requestorId, targetId)
+}
+
+// GetDB mocks base method.
+func (m *MockMuteRepository) GetDB() *gorm.DB {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDB")
+	ret0, _ := ret[0].(*gorm.DB)
+	return ret0
+}
+
+// GetDB indicates an expected call of GetDB.
+func (mr *MockMuteRepositoryMockRecorder) GetDB() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDB", reflect.TypeOf((*MockMuteRepository)(nil).GetDB))
+}
+
+// GetMute mocks base method.
+func (m *MockMuteRepository) GetMute(requestorId, targetId int64, now time.Time) (*entity.Mute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMute", requestorId, targetId, now)
+	ret0, _ := ret[0].(*entity.Mute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMute indicates an expected call of GetMute.
+func (mr *MockMuteRepositoryMockRecorder) GetMute(requestorId, targetId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMute", reflect.TypeOf((*MockMuteRepository)(nil).GetMute), requestorId, targetId, now)
+}
+
+// GetMutesByRequestor mocks base method.
+func (m *MockMuteRepository) GetMutesByRequestor(requestorId int64, now time.Time, limit, offset int) ([]*entity.Mute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMutesByRequestor", requestorId, now, limit, offset)
+	ret0, _ := ret[0].([]*entity.Mute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMutesByRequestor indicates an expected call of GetMutesByRequestor.
+func (mr *MockMuteRepositoryMockRecorder) GetMutesByRequestor(requestorId, now, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMutesByRequestor", reflect.TypeOf((*MockMuteRepository)(nil).GetMutesByRequestor), requestorId, now, limit, offset)
+}
+
+// SaveMute mocks base method.
+func (m *MockMuteRepository) SaveMute(mute *entity.Mute) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMute", mute)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveMute indicates an expected call of SaveMute.
+func (mr *MockMuteRepositoryMockRecorder) SaveMute(mute interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMute", reflect.TypeOf((*MockMuteRepository)(nil).SaveMute), mute)
+}
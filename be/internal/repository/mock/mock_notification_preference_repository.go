@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	entity "BE_Friends_Management/internal/domain/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockNotificationPreferenceRepository is a mock of NotificationPreferenceRepository interface.
+type MockNotificationPreferenceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationPreferenceRepositoryMockRecorder
+}
+
+// MockNotificationPreferenceRepositoryMockRecorder is the mock recorder for MockNotificationPreferenceRepository.
+type MockNotificationPreferenceRepositoryMockRecorder struct {
+	mock *MockNotificationPreferenceRepository
+}
+
+// NewMockNotificationPreferenceRepository creates a new mock instance.
+func NewMockNotificationPreferenceRepository(ctrl *gomock.Controller) *MockNotificationPreferenceRepository {
+	mock := &MockNotificationPreferenceRepository{ctrl: ctrl}
+	mock.recorder = &MockNotificationPreferenceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationPreferenceRepository) EXPECT() *MockNotificationPreferenceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetDB mocks base method.
+func (m *MockNotificationPreferenceRepository) GetDB() *gorm.DB {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDB")
+	ret0, _ := ret[0].(*gorm.DB)
+	return ret0
+}
+
+// GetDB indicates an expected call of GetDB.
+func (mr *MockNotificationPreferenceRepositoryMockRecorder) GetDB() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDB", reflect.TypeOf((*MockNotificationPreferenceRepository)(nil).GetDB))
+}
+
+// GetPreference mocks base method.
+func (m *MockNotificationPreferenceRepository) GetPreference(userId int64) (*entity.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreference", userId)
+	ret0, _ := ret[0].(*entity.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreference indicates an expected call of GetPreference.
+func (mr *MockNotificationPreferenceRepositoryMockRecorder) GetPreference(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreference", reflect.TypeOf((*MockNotificationPreferenceRepository)(nil).GetPreference), userId)
+}
+
+// GetPreferences mocks base method.
+func (m *MockNotificationPreferenceRepository) GetPreferences(userIds []int64) (map[int64]*entity.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", userIds)
+	ret0, _ := ret[0].(map[int64]*entity.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockNotificationPreferenceRepositoryMockRecorder) GetPreferences(userIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockNotificationPreferenceRepository)(nil).GetPreferences), userIds)
+}
+
+// SavePreference mocks base method.
+func (m *MockNotificationPreferenceRepository) SavePreference(preference *entity.NotificationPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePreference", preference)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePreference indicates an expected call of SavePreference.
+func (mr *MockNotificationPreferenceRepositoryMockRecorder) SavePreference(preference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePreference", reflect.TypeOf((*MockNotificationPreferenceRepository)(nil).SavePreference), preference)
+}
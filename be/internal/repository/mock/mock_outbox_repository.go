@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	entity "BE_Friends_Management/internal/domain/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimPendingEvents mocks base method.
+func (m *MockOutboxRepository) ClaimPendingEvents(now, leaseUntil time.Time, limit int) ([]*entity.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPendingEvents", now, leaseUntil, limit)
+	ret0, _ := ret[0].([]*entity.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPendingEvents indicates an expected call of ClaimPendingEvents.
+func (mr *MockOutboxRepositoryMockRecorder) ClaimPendingEvents(now, leaseUntil, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPendingEvents", reflect.TypeOf((*MockOutboxRepository)(nil).ClaimPendingEvents), now, leaseUntil, limit)
+}
+
+// CreateEvent mocks base method.
+func (m *MockOutboxRepository) CreateEvent(tx *gorm.DB, event *entity.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEvent", tx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEvent indicates an expected call of CreateEvent.
+func (mr *MockOutboxRepositoryMockRecorder) CreateEvent(tx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockOutboxRepository)(nil).CreateEvent), tx, event)
+}
+
+// GetDB mocks base method.
+func (m *MockOutboxRepository) GetDB() *gorm.DB {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDB")
+	ret0, _ := ret[0].(*gorm.DB)
+	return ret0
+}
+
+// GetDB indicates an expected call of GetDB.
+func (mr *MockOutboxRepositoryMockRecorder) GetDB() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDB", reflect.TypeOf((*MockOutboxRepository)(nil).GetDB))
+}
+
+// MarkEventAbandoned mocks base method.
+func (m *MockOutboxRepository) MarkEventAbandoned(eventId int64, deliveredSinks []string, lastError string, failedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventAbandoned", eventId, deliveredSinks, lastError, failedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventAbandoned indicates an expected call of MarkEventAbandoned.
+func (mr *MockOutboxRepositoryMockRecorder) MarkEventAbandoned(eventId, deliveredSinks, lastError, failedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventAbandoned", reflect.TypeOf((*MockOutboxRepository)(nil).MarkEventAbandoned), eventId, deliveredSinks, lastError, failedAt)
+}
+
+// MarkEventFailed mocks base method.
+func (m *MockOutboxRepository) MarkEventFailed(eventId int64, deliveredSinks []string, lastError string, availableAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventFailed", eventId, deliveredSinks, lastError, availableAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventFailed indicates an expected call of MarkEventFailed.
+func (mr *MockOutboxRepositoryMockRecorder) MarkEventFailed(eventId, deliveredSinks, lastError, availableAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventFailed", reflect.TypeOf((*MockOutboxRepository)(nil).MarkEventFailed), eventId, deliveredSinks, lastError, availableAt)
+}
+
+// MarkEventProcessed mocks base method.
+func (m *MockOutboxRepository) MarkEventProcessed(eventId int64, processedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventProcessed", eventId, processedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventProcessed indicates an expected call of MarkEventProcessed.
+func (mr *MockOutboxRepositoryMockRecorder) MarkEventProcessed(eventId, processedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventProcessed", reflect.TypeOf((*MockOutboxRepository)(nil).MarkEventProcessed), eventId, processedAt)
+}
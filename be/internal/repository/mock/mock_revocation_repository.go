@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	entity "BE_Friends_Management/internal/domain/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRevocationRepository is a mock of RevocationRepository interface.
+type MockRevocationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevocationRepositoryMockRecorder
+}
+
+// MockRevocationRepositoryMockRecorder is the mock recorder for MockRevocationRepository.
+type MockRevocationRepositoryMockRecorder struct {
+	mock *MockRevocationRepository
+}
+
+// NewMockRevocationRepository creates a new mock instance.
+func NewMockRevocationRepository(ctrl *gomock.Controller) *MockRevocationRepository {
+	mock := &MockRevocationRepository{ctrl: ctrl}
+	mock.recorder = &MockRevocationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevocationRepository) EXPECT() *MockRevocationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateRevocation mocks base method.
+func (m *MockRevocationRepository) CreateRevocation(revocation *entity.AccessTokenRevocation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRevocation", revocation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRevocation indicates an expected call of CreateRevocation.
+func (mr *MockRevocationRepositoryMockRecorder) CreateRevocation(revocation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRevocation", reflect.TypeOf((*MockRevocationRepository)(nil).CreateRevocation), revocation)
+}
+
+// DeleteExpiredRevocations mocks base method.
+func (m *MockRevocationRepository) DeleteExpiredRevocations(now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredRevocations", now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredRevocations indicates an expected call of DeleteExpiredRevocations.
+func (mr *MockRevocationRepositoryMockRecorder) DeleteExpiredRevocations(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredRevocations", reflect.TypeOf((*MockRevocationRepository)(nil).DeleteExpiredRevocations), now)
+}
+
+// GetActiveRevocations mocks base method.
+func (m *MockRevocationRepository) GetActiveRevocations(now time.Time) ([]*entity.AccessTokenRevocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveRevocations", now)
+	ret0, _ := ret[0].([]*entity.AccessTokenRevocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveRevocations indicates an expected call of GetActiveRevocations.
+func (mr *MockRevocationRepositoryMockRecorder) GetActiveRevocations(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveRevocations", reflect.TypeOf((*MockRevocationRepository)(nil).GetActiveRevocations), now)
+}
@@ -36,17 +36,17 @@ func (m *MockSubscriptionRepository) EXPECT() *MockSubscriptionRepositoryMockRec
 }
 
 // CreateSubscription mocks base method.
-func (m *MockSubscriptionRepository) CreateSubscription(requestorId, targetId int64) error {
+func (m *MockSubscriptionRepository) CreateSubscription(tx *gorm.DB, requestorId, targetId int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSubscription", requestorId, targetId)
+	ret := m.ctrl.Call(m, "CreateSubscription", tx, requestorId, targetId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateSubscription indicates an expected call of CreateSubscription.
-func (mr *MockSubscriptionRepositoryMockRecorder) CreateSubscription(requestorId, targetId interface{}) *gomock.Call {
+func (mr *MockSubscriptionRepositoryMockRecorder) CreateSubscription(tx, requestorId, targetId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockSubscriptionRepository)(nil).CreateSubscription), requestorId, targetId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockSubscriptionRepository)(nil).CreateSubscription), tx, requestorId, targetId)
 }
 
 // DeleteSubscription mocks base method.
@@ -78,6 +78,21 @@ func (mr *MockSubscriptionRepositoryMockRecorder) GetAllSubscriberIds(targetId i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSubscriberIds", reflect.TypeOf((*MockSubscriptionRepository)(nil).GetAllSubscriberIds), targetId)
 }
 
+// GetAllSubscriptionTargetIds mocks base method.
+func (m *MockSubscriptionRepository) GetAllSubscriptionTargetIds(requestorId int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllSubscriptionTargetIds", requestorId)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllSubscriptionTargetIds indicates an expected call of GetAllSubscriptionTargetIds.
+func (mr *MockSubscriptionRepositoryMockRecorder) GetAllSubscriptionTargetIds(requestorId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSubscriptionTargetIds", reflect.TypeOf((*MockSubscriptionRepository)(nil).GetAllSubscriptionTargetIds), requestorId)
+}
+
 // GetDB mocks base method.
 func (m *MockSubscriptionRepository) GetDB() *gorm.DB {
 	m.ctrl.T.Helper()
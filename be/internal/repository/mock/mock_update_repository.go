@@ -0,0 +1,258 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	entity "BE_Friends_Management/internal/domain/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockUpdateRepository is a mock of UpdateRepository interface.
+type MockUpdateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUpdateRepositoryMockRecorder
+}
+
+// MockUpdateRepositoryMockRecorder is the mock recorder for MockUpdateRepository.
+type MockUpdateRepositoryMockRecorder struct {
+	mock *MockUpdateRepository
+}
+
+// NewMockUpdateRepository creates a new mock instance.
+func NewMockUpdateRepository(ctrl *gomock.Controller) *MockUpdateRepository {
+	mock := &MockUpdateRepository{ctrl: ctrl}
+	mock.recorder = &MockUpdateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUpdateRepository) EXPECT() *MockUpdateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountFeed mocks base method.
+func (m *MockUpdateRepository) CountFeed(recipientId int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFeed", recipientId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFeed indicates an expected call of CountFeed.
+func (mr *MockUpdateRepositoryMockRecorder) CountFeed(recipientId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFeed", reflect.TypeOf((*MockUpdateRepository)(nil).CountFeed), recipientId)
+}
+
+// CountMentions mocks base method.
+func (m *MockUpdateRepository) CountMentions(userId int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMentions", userId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMentions indicates an expected call of CountMentions.
+func (mr *MockUpdateRepositoryMockRecorder) CountMentions(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMentions", reflect.TypeOf((*MockUpdateRepository)(nil).CountMentions), userId)
+}
+
+// CountUnreadFeed mocks base method.
+func (m *MockUpdateRepository) CountUnreadFeed(recipientId int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnreadFeed", recipientId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnreadFeed indicates an expected call of CountUnreadFeed.
+func (mr *MockUpdateRepositoryMockRecorder) CountUnreadFeed(recipientId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadFeed", reflect.TypeOf((*MockUpdateRepository)(nil).CountUnreadFeed), recipientId)
+}
+
+// CountUpdateRecipients mocks base method.
+func (m *MockUpdateRepository) CountUpdateRecipients(senderId int64, mentionedIds []int64, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUpdateRecipients", senderId, mentionedIds, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUpdateRecipients indicates an expected call of CountUpdateRecipients.
+func (mr *MockUpdateRepositoryMockRecorder) CountUpdateRecipients(senderId, mentionedIds, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUpdateRecipients", reflect.TypeOf((*MockUpdateRepository)(nil).CountUpdateRecipients), senderId, mentionedIds, now)
+}
+
+// CreateMentions mocks base method.
+func (m *MockUpdateRepository) CreateMentions(tx *gorm.DB, updateId int64, userIds []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMentions", tx, updateId, userIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMentions indicates an expected call of CreateMentions.
+func (mr *MockUpdateRepositoryMockRecorder) CreateMentions(tx, updateId, userIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMentions", reflect.TypeOf((*MockUpdateRepository)(nil).CreateMentions), tx, updateId, userIds)
+}
+
+// CreateUpdate mocks base method.
+func (m *MockUpdateRepository) CreateUpdate(tx *gorm.DB, update *entity.Update) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUpdate", tx, update)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUpdate indicates an expected call of CreateUpdate.
+func (mr *MockUpdateRepositoryMockRecorder) CreateUpdate(tx, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUpdate", reflect.TypeOf((*MockUpdateRepository)(nil).CreateUpdate), tx, update)
+}
+
+// CreateUpdateDeliveries mocks base method.
+func (m *MockUpdateRepository) CreateUpdateDeliveries(tx *gorm.DB, updateId, senderId int64, mentionedIds []int64, now time.Time) ([]*entity.UpdateDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUpdateDeliveries", tx, updateId, senderId, mentionedIds, now)
+	ret0, _ := ret[0].([]*entity.UpdateDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUpdateDeliveries indicates an expected call of CreateUpdateDeliveries.
+func (mr *MockUpdateRepositoryMockRecorder) CreateUpdateDeliveries(tx, updateId, senderId, mentionedIds, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUpdateDeliveries", reflect.TypeOf((*MockUpdateRepository)(nil).CreateUpdateDeliveries), tx, updateId, senderId, mentionedIds, now)
+}
+
+// GetDB mocks base method.
+func (m *MockUpdateRepository) GetDB() *gorm.DB {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDB")
+	ret0, _ := ret[0].(*gorm.DB)
+	return ret0
+}
+
+// GetDB indicates an expected call of GetDB.
+func (mr *MockUpdateRepositoryMockRecorder) GetDB() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDB", reflect.TypeOf((*MockUpdateRepository)(nil).GetDB))
+}
+
+// GetFeed mocks base method.
+func (m *MockUpdateRepository) GetFeed(recipientId, beforeId int64, limit int) ([]*entity.UpdateDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", recipientId, beforeId, limit)
+	ret0, _ := ret[0].([]*entity.UpdateDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *MockUpdateRepositoryMockRecorder) GetFeed(recipientId, beforeId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockUpdateRepository)(nil).GetFeed), recipientId, beforeId, limit)
+}
+
+// GetFeedSince mocks base method.
+func (m *MockUpdateRepository) GetFeedSince(recipientId, afterId int64, limit int) ([]*entity.UpdateDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeedSince", recipientId, afterId, limit)
+	ret0, _ := ret[0].([]*entity.UpdateDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeedSince indicates an expected call of GetFeedSince.
+func (mr *MockUpdateRepositoryMockRecorder) GetFeedSince(recipientId, afterId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeedSince", reflect.TypeOf((*MockUpdateRepository)(nil).GetFeedSince), recipientId, afterId, limit)
+}
+
+// GetLatestDeliveryId mocks base method.
+func (m *MockUpdateRepository) GetLatestDeliveryId(recipientId int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestDeliveryId", recipientId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestDeliveryId indicates an expected call of GetLatestDeliveryId.
+func (mr *MockUpdateRepositoryMockRecorder) GetLatestDeliveryId(recipientId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestDeliveryId", reflect.TypeOf((*MockUpdateRepository)(nil).GetLatestDeliveryId), recipientId)
+}
+
+// GetMentions mocks base method.
+func (m *MockUpdateRepository) GetMentions(userId, beforeId int64, limit int) ([]*entity.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMentions", userId, beforeId, limit)
+	ret0, _ := ret[0].([]*entity.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMentions indicates an expected call of GetMentions.
+func (mr *MockUpdateRepositoryMockRecorder) GetMentions(userId, beforeId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMentions", reflect.TypeOf((*MockUpdateRepository)(nil).GetMentions), userId, beforeId, limit)
+}
+
+// GetUpdateDeliveries mocks base method.
+func (m *MockUpdateRepository) GetUpdateDeliveries(updateId int64) ([]*entity.UpdateDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpdateDeliveries", updateId)
+	ret0, _ := ret[0].([]*entity.UpdateDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpdateDeliveries indicates an expected call of GetUpdateDeliveries.
+func (mr *MockUpdateRepositoryMockRecorder) GetUpdateDeliveries(updateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpdateDeliveries", reflect.TypeOf((*MockUpdateRepository)(nil).GetUpdateDeliveries), updateId)
+}
+
+// GetUpdateRecipientsPage mocks base method.
+func (m *MockUpdateRepository) GetUpdateRecipientsPage(senderId int64, mentionedIds []int64, now time.Time, afterId int64, limit int) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpdateRecipientsPage", senderId, mentionedIds, now, afterId, limit)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpdateRecipientsPage indicates an expected call of GetUpdateRecipientsPage.
+func (mr *MockUpdateRepositoryMockRecorder) GetUpdateRecipientsPage(senderId, mentionedIds, now, afterId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpdateRecipientsPage", reflect.TypeOf((*MockUpdateRepository)(nil).GetUpdateRecipientsPage), senderId, mentionedIds, now, afterId, limit)
+}
+
+// MarkDeliveryRead mocks base method.
+func (m *MockUpdateRepository) MarkDeliveryRead(recipientId, deliveryId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDeliveryRead", recipientId, deliveryId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDeliveryRead indicates an expected call of MarkDeliveryRead.
+func (mr *MockUpdateRepositoryMockRecorder) MarkDeliveryRead(recipientId, deliveryId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDeliveryRead", reflect.TypeOf((*MockUpdateRepository)(nil).MarkDeliveryRead), recipientId, deliveryId)
+}
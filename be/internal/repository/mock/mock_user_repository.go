@@ -35,6 +35,21 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountUsers mocks base method.
+func (m *MockUserRepository) CountUsers() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsers")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsers indicates an expected call of CountUsers.
+func (mr *MockUserRepositoryMockRecorder) CountUsers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockUserRepository)(nil).CountUsers))
+}
+
 // CreateUser mocks base method.
 func (m *MockUserRepository) CreateUser(user *entity.User) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -65,18 +80,18 @@ func (mr *MockUserRepositoryMockRecorder) DeleteUserById(userId interface{}) *go
 }
 
 // GetAllUser mocks base method.
-func (m *MockUserRepository) GetAllUser() ([]*entity.User, error) {
+func (m *MockUserRepository) GetAllUser(afterId int64, limit int) ([]*entity.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllUser")
+	ret := m.ctrl.Call(m, "GetAllUser", afterId, limit)
 	ret0, _ := ret[0].([]*entity.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllUser indicates an expected call of GetAllUser.
-func (mr *MockUserRepositoryMockRecorder) GetAllUser() *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) GetAllUser(afterId, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUser", reflect.TypeOf((*MockUserRepository)(nil).GetAllUser))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUser", reflect.TypeOf((*MockUserRepository)(nil).GetAllUser), afterId, limit)
 }
 
 // GetDB mocks base method.
@@ -93,6 +108,21 @@ func (mr *MockUserRepositoryMockRecorder) GetDB() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDB", reflect.TypeOf((*MockUserRepository)(nil).GetDB))
 }
 
+// GetEmailOptOutUserIds mocks base method.
+func (m *MockUserRepository) GetEmailOptOutUserIds(userIds []int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailOptOutUserIds", userIds)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmailOptOutUserIds indicates an expected call of GetEmailOptOutUserIds.
+func (mr *MockUserRepositoryMockRecorder) GetEmailOptOutUserIds(userIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailOptOutUserIds", reflect.TypeOf((*MockUserRepository)(nil).GetEmailOptOutUserIds), userIds)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockUserRepository) GetUserByEmail(email string) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -153,6 +183,49 @@ func (mr *MockUserRepositoryMockRecorder) GetUsersFromIds(userIds interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersFromIds", reflect.TypeOf((*MockUserRepository)(nil).GetUsersFromIds), userIds)
 }
 
+// GetUsersFromMentions mocks base method.
+func (m *MockUserRepository) GetUsersFromMentions(handles, emails []string) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersFromMentions", handles, emails)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersFromMentions indicates an expected call of GetUsersFromMentions.
+func (mr *MockUserRepositoryMockRecorder) GetUsersFromMentions(handles, emails interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersFromMentions", reflect.TypeOf((*MockUserRepository)(nil).GetUsersFromMentions), handles, emails)
+}
+
+// SetEmailOptOut mocks base method.
+func (m *MockUserRepository) SetEmailOptOut(userId int64, optOut bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEmailOptOut", userId, optOut)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEmailOptOut indicates an expected call of SetEmailOptOut.
+func (mr *MockUserRepositoryMockRecorder) SetEmailOptOut(userId, optOut interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEmailOptOut", reflect.TypeOf((*MockUserRepository)(nil).SetEmailOptOut), userId, optOut)
+}
+
+// SetHandle mocks base method.
+func (m *MockUserRepository) SetHandle(userId int64, handle *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHandle", userId, handle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHandle indicates an expected call of SetHandle.
+func (mr *MockUserRepositoryMockRecorder) SetHandle(userId, handle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHandle", reflect.TypeOf((*MockUserRepository)(nil).SetHandle), userId, handle)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUserRepository) UpdateUser(user *entity.User) (*entity.User, error) {
 	m.ctrl.T.Helper()
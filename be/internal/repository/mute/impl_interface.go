@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// activeMute matches the mutes that have not expired at the given time.
+const activeMute = "expires_at IS NULL OR expires_at > ?"
+
+type PostgreSQLMuteRepository struct {
+	db *gorm.DB
+}
+
+func NewMuteRepository(db *gorm.DB) MuteRepository {
+	return &PostgreSQLMuteRepository{db: db}
+}
+
+func (r *PostgreSQLMuteRepository) GetDB() *gorm.DB {
+	return r.db
+}
+
+// SaveMute creates the mute, or replaces the expiry of an existing one.
+func (r *PostgreSQLMuteRepository) SaveMute(mute *entity.Mute) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "requestor_id"}, {Name: "target_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"expires_at", "created_at"}),
+	}).Create(mute).Error
+}
+
+func (r *PostgreSQLMuteRepository) GetMute(requestorId, targetId int64, now time.Time) (*entity.Mute, error) {
+	var mute = entity.Mute{}
+	err := r.db.Model(&entity.Mute{}).
+		Where("requestor_id = ? AND target_id = ?", requestorId, targetId).
+		Where(activeMute, now).
+		First(&mute).Error
+	if err != nil {
+		return nil, err
+	}
+	return &mute, nil
+}
+
+func (r *PostgreSQLMuteRepository) DeleteMute(requestorId, targetId int64) error {
+	result := r.db.Where("requestor_id = ? AND target_id = ?", requestorId, targetId).Delete(&entity.Mute{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *PostgreSQLMuteRepository) GetMutesByRequestor(requestorId int64, now time.Time, limit, offset int) ([]*entity.Mute, error) {
+	var mutes []*entity.Mute
+	err := r.db.Model(&entity.Mute{}).
+		Preload("Target").
+		Where("requestor_id = ?", requestorId).
+		Where(activeMute, now).
+		Order("created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&mutes).Error
+	if err != nil {
+		return nil, err
+	}
+	return mutes, nil
+}
+
+func (r *PostgreSQLMuteRepository) CountMutesByRequestor(requestorId int64, now time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.Mute{}).
+		Where("requestor_id = ?", requestorId).
+		Where(activeMute, now).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeleteExpiredMutes deletes a batch of mutes that expired by now and returns
+// how many were deleted.
+func (r *PostgreSQLMuteRepository) DeleteExpiredMutes(ctx context.Context, now time.Time, limit int) (int64, error) {
+	expired := r.db.Model(&entity.Mute{}).
+		Select("requestor_id, target_id").
+		Where("expires_at <= ?", now).
+		Limit(limit)
+	result := r.db.WithContext(ctx).
+		Where("(requestor_id, target_id) IN (?)", expired).
+		Delete(&entity.Mute{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=interface.go -destination=../mock/mock_mute_repository.go
+
+type MuteRepository interface {
+	GetDB() *gorm.DB
+	SaveMute(mute *entity.Mute) error
+	GetMute(requestorId, targetId int64, now time.Time) (*entity.Mute, error)
+	DeleteMute(requestorId, targetId int64) error
+	GetMutesByRequestor(requestorId int64, now time.Time, limit, offset int) ([]*entity.Mute, error)
+	CountMutesByRequestor(requestorId int64, now time.Time) (int64, error)
+	DeleteExpiredMutes(ctx context.Context, now time.Time, limit int) (int64, error)
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newMockRepository(t *testing.T) (MuteRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	return NewMuteRepository(gormDB), mock
+}
+
+func TestPostgreSQLMuteRepository_SaveMute(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	t.Run("upserts the expiry", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour)
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "mutes" .* ON CONFLICT \("requestor_id","target_id"\) DO UPDATE SET "expires_at"="excluded"."expires_at","created_at"="excluded"."created_at"`).
+			WithArgs(int64(1), int64(2), &expiresAt, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.SaveMute(&entity.Mute{RequestorId: 1, TargetId: 2, ExpiresAt: &expiresAt})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "mutes"`).WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		err := repo.SaveMute(&entity.Mute{RequestorId: 1, TargetId: 2})
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLMuteRepository_GetMute(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	now := time.Now()
+
+	t.Run("active mute", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "mutes" WHERE \(requestor_id = \$1 AND target_id = \$2\) AND \(expires_at IS NULL OR expires_at > \$3\)`).
+			WithArgs(int64(1), int64(2), now, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"requestor_id", "target_id", "expires_at"}).AddRow(1, 2, nil))
+
+		mute, err := repo.GetMute(1, 2, now)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), mute.TargetId)
+		assert.Nil(t, mute.ExpiresAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "mutes"`).
+			WithArgs(int64(1), int64(3), now, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		mute, err := repo.GetMute(1, 3, now)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		assert.Nil(t, mute)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLMuteRepository_DeleteMute(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	t.Run("successful deletion", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "mutes" WHERE requestor_id = \$1 AND target_id = \$2`).
+			WithArgs(int64(1), int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.DeleteMute(1, 2)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "mutes"`).
+			WithArgs(int64(1), int64(3)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := repo.DeleteMute(1, 3)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLMuteRepository_GetMutesByRequestor(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	now := time.Now()
+
+	t.Run("newest first with targets", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "mutes" WHERE requestor_id = \$1 AND \(expires_at IS NULL OR expires_at > \$2\) ORDER BY created_at DESC LIMIT \$3`).
+			WithArgs(int64(1), now, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"requestor_id", "target_id"}).AddRow(1, 2))
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE "users"."id" = \$1`).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(2, "user2@example.com"))
+
+		mutes, err := repo.GetMutesByRequestor(1, now, 10, 0)
+		assert.NoError(t, err)
+		assert.Len(t, mutes, 1)
+		assert.Equal(t, "user2@example.com", mutes[0].Target.Email)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLMuteRepository_CountMutesByRequestor(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	now := time.Now()
+
+	t.Run("counts active mutes", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "mutes" WHERE requestor_id = \$1 AND \(expires_at IS NULL OR expires_at > \$2\)`).
+			WithArgs(int64(1), now).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		count, err := repo.CountMutesByRequestor(1, now)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLMuteRepository_DeleteExpiredMutes(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	now := time.Now()
+
+	t.Run("deletes a batch of expired mutes", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "mutes" WHERE \(requestor_id, target_id\) IN \(SELECT requestor_id, target_id FROM "mutes" WHERE expires_at <= \$1 LIMIT \$2\)`).
+			WithArgs(now, 100).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		swept, err := repo.DeleteExpiredMutes(context.Background(), now, 100)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), swept)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgreSQLNotificationPreferenceRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationPreferenceRepository(db *gorm.DB) NotificationPreferenceRepository {
+	return &PostgreSQLNotificationPreferenceRepository{db: db}
+}
+
+func (r *PostgreSQLNotificationPreferenceRepository) GetDB() *gorm.DB {
+	return r.db
+}
+
+func (r *PostgreSQLNotificationPreferenceRepository) GetPreference(userId int64) (*entity.NotificationPreference, error) {
+	var preference = entity.NotificationPreference{}
+	err := r.db.Model(&entity.NotificationPreference{}).Where("user_id = ?", userId).First(&preference).Error
+	if err != nil {
+		return nil, err
+	}
+	return &preference, nil
+}
+
+// GetPreferences returns the saved preferences of the given users keyed by
+// user id. Users without one are missing from the map.
+func (r *PostgreSQLNotificationPreferenceRepository) GetPreferences(userIds []int64) (map[int64]*entity.NotificationPreference, error) {
+	preferencesByUser := make(map[int64]*entity.NotificationPreference)
+	if len(userIds) == 0 {
+		return preferencesByUser, nil
+	}
+	var preferences []*entity.NotificationPreference
+	err := r.db.Model(&entity.NotificationPreference{}).Where("user_id IN ?", userIds).Find(&preferences).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, preference := range preferences {
+		preferencesByUser[preference.UserId] = preference
+	}
+	return preferencesByUser, nil
+}
+
+func (r *PostgreSQLNotificationPreferenceRepository) SavePreference(preference *entity.NotificationPreference) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		UpdateAll: true,
+	}).Create(preference).Error
+}
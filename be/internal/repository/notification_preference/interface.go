@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=interface.go -destination=../mock/mock_notification_preference_repository.go
+
+type NotificationPreferenceRepository interface {
+	GetDB() *gorm.DB
+	GetPreference(userId int64) (*entity.NotificationPreference, error)
+	GetPreferences(userIds []int64) (map[int64]*entity.NotificationPreference, error)
+	SavePreference(preference *entity.NotificationPreference) error
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestPostgreSQLNotificationPreferenceRepository_GetPreference(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewNotificationPreferenceRepository(gormDB)
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "notification_preferences" WHERE user_id = \$1 ORDER BY "notification_preferences"."user_id" LIMIT \$2`).
+			WithArgs(int64(1), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "channels", "event_types", "quiet_hours_start", "quiet_hours_end"}).
+				AddRow(1, "{email}", "{mention,friend_request}", "22:00", "07:00"))
+
+		preference, err := repo.GetPreference(1)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{entity.ChannelEmail}, []string(preference.Channels))
+		assert.Equal(t, []string{entity.NotificationMention, entity.NotificationFriendRequest}, []string(preference.EventTypes))
+		assert.Equal(t, "22:00", preference.QuietHoursStart)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "notification_preferences" WHERE user_id = \$1`).
+			WithArgs(int64(2), 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		preference, err := repo.GetPreference(2)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		assert.Nil(t, preference)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLNotificationPreferenceRepository_GetPreferences(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewNotificationPreferenceRepository(gormDB)
+
+	t.Run("keyed by user", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "notification_preferences" WHERE user_id IN \(\$1,\$2\)`).
+			WithArgs(int64(1), int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "channels", "event_types"}).
+				AddRow(2, "{in_app}", "{mention}"))
+
+		preferences, err := repo.GetPreferences([]int64{1, 2})
+		assert.NoError(t, err)
+		assert.Len(t, preferences, 1)
+		assert.Equal(t, []string{entity.ChannelInApp}, []string(preferences[2].Channels))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no users", func(t *testing.T) {
+		preferences, err := repo.GetPreferences(nil)
+		assert.NoError(t, err)
+		assert.Empty(t, preferences)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "notification_preferences" WHERE user_id IN \(\$1\)`).
+			WithArgs(int64(1)).
+			WillReturnError(assert.AnError)
+
+		preferences, err := repo.GetPreferences([]int64{1})
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, preferences)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLNotificationPreferenceRepository_SavePreference(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewNotificationPreferenceRepository(gormDB)
+
+	t.Run("upserts", func(t *testing.T) {
+		preference := &entity.NotificationPreference{
+			UserId:     1,
+			Channels:   []string{entity.ChannelEmail},
+			EventTypes: []string{entity.NotificationMention},
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "notification_preferences" .* ON CONFLICT \("user_id"\) DO UPDATE SET`).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(1))
+		mock.ExpectCommit()
+
+		err := repo.SavePreference(preference)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		preference := &entity.NotificationPreference{UserId: 1}
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "notification_preferences"`).
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		err := repo.SavePreference(preference)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
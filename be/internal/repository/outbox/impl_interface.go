@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgreSQLOutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &PostgreSQLOutboxRepository{db: db}
+}
+
+func (r *PostgreSQLOutboxRepository) GetDB() *gorm.DB {
+	return r.db
+}
+
+func (r *PostgreSQLOutboxRepository) CreateEvent(tx *gorm.DB, event *entity.OutboxEvent) error {
+	err := tx.Model(&entity.OutboxEvent{}).Create(event).Error
+	return err
+}
+
+// ClaimPendingEvents locks a batch of unprocessed, not abandoned events in insertion order
+// and pushes their availability to leaseUntil, so concurrent relays skip them
+// and a crashed relay's batch is picked up again once the lease expires.
+func (r *PostgreSQLOutboxRepository) ClaimPendingEvents(now, leaseUntil time.Time, limit int) ([]*entity.OutboxEvent, error) {
+	var events []*entity.OutboxEvent
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.OutboxEvent{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("processed_at IS NULL AND failed_at IS NULL AND available_at <= ?", now).
+			Order("id ASC").
+			Limit(limit).
+			Find(&events).Error
+		if err != nil || len(events) == 0 {
+			return err
+		}
+		eventIds := make([]int64, 0, len(events))
+		for _, event := range events {
+			eventIds = append(eventIds, event.Id)
+		}
+		return tx.Model(&entity.OutboxEvent{}).
+			Where("id IN ?", eventIds).
+			Update("available_at", leaseUntil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *PostgreSQLOutboxRepository) MarkEventProcessed(eventId int64, processedAt time.Time) error {
+	err := r.db.Model(&entity.OutboxEvent{}).
+		Where("id = ?", eventId).
+		Updates(map[string]interface{}{
+			"processed_at": processedAt,
+			"last_error":   "",
+		}).Error
+	return err
+}
+
+// MarkEventFailed schedules a retry and keeps the sinks that accepted the
+// event, so the retry skips them.
+func (r *PostgreSQLOutboxRepository) MarkEventFailed(eventId int64, deliveredSinks []string, lastError string, availableAt time.Time) error {
+	err := r.db.Model(&entity.OutboxEvent{}).
+		Where("id = ?", eventId).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"delivered_sinks": pq.StringArray(deliveredSinks),
+			"last_error":      lastError,
+			"available_at":    availableAt,
+		}).Error
+	return err
+}
+
+// MarkEventAbandoned records the last failure of an event that ran out of
+// attempts, so it is never claimed again.
+func (r *PostgreSQLOutboxRepository) MarkEventAbandoned(eventId int64, deliveredSinks []string, lastError string, failedAt time.Time) error {
+	err := r.db.Model(&entity.OutboxEvent{}).
+		Where("id = ?", eventId).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"delivered_sinks": pq.StringArray(deliveredSinks),
+			"last_error":      lastError,
+			"failed_at":       failedAt,
+		}).Error
+	return err
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=interface.go -destination=../mock/mock_outbox_repository.go
+
+type OutboxRepository interface {
+	GetDB() *gorm.DB
+	CreateEvent(tx *gorm.DB, event *entity.OutboxEvent) error
+	ClaimPendingEvents(now, leaseUntil time.Time, limit int) ([]*entity.OutboxEvent, error)
+	MarkEventProcessed(eventId int64, processedAt time.Time) error
+	MarkEventFailed(eventId int64, deliveredSinks []string, lastError string, availableAt time.Time) error
+	MarkEventAbandoned(eventId int64, deliveredSinks []string, lastError string, failedAt time.Time) error
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newOutboxTestRepository(t *testing.T) (OutboxRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	return NewOutboxRepository(gormDB), mock
+}
+
+func TestPostgreSQLOutboxRepository_CreateEvent(t *testing.T) {
+	repo, mock := newOutboxTestRepository(t)
+
+	t.Run("successful creation", func(t *testing.T) {
+		event := &entity.OutboxEvent{
+			EventId:     "evt_1",
+			EventType:   entity.EventFriendshipCreated,
+			Payload:     `{"friends":["a@example.com","b@example.com"]}`,
+			AvailableAt: time.Now(),
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "outbox_events"`).
+			WithArgs("evt_1", entity.EventFriendshipCreated, event.Payload, 0, "", sqlmock.AnyArg(), nil, nil, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+		mock.ExpectCommit()
+
+		err := repo.CreateEvent(repo.GetDB(), event)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), event.Id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLOutboxRepository_ClaimPendingEvents(t *testing.T) {
+	repo, mock := newOutboxTestRepository(t)
+	now := time.Now()
+	leaseUntil := now.Add(time.Minute)
+
+	t.Run("claims a batch", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "outbox_events" WHERE processed_at IS NULL AND failed_at IS NULL AND available_at <= \$1 ORDER BY id ASC LIMIT \$2 FOR UPDATE SKIP LOCKED`).
+			WithArgs(now, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "event_type", "payload"}).
+				AddRow(1, "evt_1", entity.EventFriendshipCreated, `{}`).
+				AddRow(2, "evt_2", entity.EventBlockCreated, `{}`))
+		mock.ExpectExec(`UPDATE "outbox_events" SET "available_at"=\$1 WHERE id IN \(\$2,\$3\)`).
+			WithArgs(leaseUntil, int64(1), int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		events, err := repo.ClaimPendingEvents(now, leaseUntil, 10)
+		assert.NoError(t, err)
+		assert.Len(t, events, 2)
+		assert.Equal(t, "evt_1", events[0].EventId)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nothing pending", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "outbox_events"`).
+			WithArgs(now, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectCommit()
+
+		events, err := repo.ClaimPendingEvents(now, leaseUntil, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "outbox_events"`).
+			WithArgs(now, 10).
+			WillReturnError(errors.New("database error"))
+		mock.ExpectRollback()
+
+		events, err := repo.ClaimPendingEvents(now, leaseUntil, 10)
+		assert.Error(t, err)
+		assert.Nil(t, events)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLOutboxRepository_MarkEventProcessed(t *testing.T) {
+	repo, mock := newOutboxTestRepository(t)
+	processedAt := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "outbox_events" SET "last_error"=\$1,"processed_at"=\$2 WHERE id = \$3`).
+		WithArgs("", processedAt, int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.MarkEventProcessed(1, processedAt)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgreSQLOutboxRepository_MarkEventFailed(t *testing.T) {
+	repo, mock := newOutboxTestRepository(t)
+	availableAt := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "outbox_events" SET "attempts"=attempts \+ 1,"available_at"=\$1,"delivered_sinks"=\$2,"last_error"=\$3 WHERE id = \$4`).
+		WithArgs(availableAt, pq.StringArray{"bus"}, "webhook: database error", int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.MarkEventFailed(1, []string{"bus"}, "webhook: database error", availableAt)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgreSQLOutboxRepository_MarkEventAbandoned(t *testing.T) {
+	repo, mock := newOutboxTestRepository(t)
+	failedAt := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "outbox_events" SET "attempts"=attempts \+ 1,"delivered_sinks"=\$1,"failed_at"=\$2,"last_error"=\$3 WHERE id = \$4`).
+		WithArgs(pq.StringArray{"bus"}, failedAt, "webhook: database error", int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.MarkEventAbandoned(1, []string{"bus"}, "webhook: database error", failedAt)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
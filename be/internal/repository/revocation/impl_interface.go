@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgreSQLRevocationRepository struct {
+	db *gorm.DB
+}
+
+func NewRevocationRepository(db *gorm.DB) RevocationRepository {
+	return &PostgreSQLRevocationRepository{db: db}
+}
+
+// CreateRevocation stores the revocation. Revoking a token twice is a no-op.
+func (r *PostgreSQLRevocationRepository) CreateRevocation(revocation *entity.AccessTokenRevocation) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(revocation).Error
+}
+
+func (r *PostgreSQLRevocationRepository) GetActiveRevocations(now time.Time) ([]*entity.AccessTokenRevocation, error) {
+	var revocations []*entity.AccessTokenRevocation
+	err := r.db.Model(&entity.AccessTokenRevocation{}).
+		Where("expires_at > ?", now).
+		Order("id ASC").
+		Find(&revocations).Error
+	if err != nil {
+		return nil, err
+	}
+	return revocations, nil
+}
+
+func (r *PostgreSQLRevocationRepository) DeleteExpiredRevocations(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at <= ?", now).Delete(&entity.AccessTokenRevocation{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"time"
+)
+
+//go:generate mockgen -source=interface.go -destination=../mock/mock_revocation_repository.go
+
+type RevocationRepository interface {
+	CreateRevocation(revocation *entity.AccessTokenRevocation) error
+	GetActiveRevocations(now time.Time) ([]*entity.AccessTokenRevocation, error)
+	DeleteExpiredRevocations(now time.Time) (int64, error)
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestPostgreSQLRevocationRepository_CreateRevocation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewRevocationRepository(gormDB)
+
+	t.Run("success", func(t *testing.T) {
+		jti := "jti-1"
+		now := time.Now()
+		revocation := &entity.AccessTokenRevocation{Jti: &jti, RevokedAt: now, ExpiresAt: now.Add(time.Hour)}
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "access_token_revocations" .* ON CONFLICT DO NOTHING RETURNING "id"`).
+			WithArgs(&jti, nil, now, now.Add(time.Hour)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectCommit()
+
+		err := repo.CreateRevocation(revocation)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		userId := int64(1)
+		revocation := &entity.AccessTokenRevocation{UserId: &userId, RevokedAt: time.Now(), ExpiresAt: time.Now()}
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "access_token_revocations"`).WillReturnError(errors.New("insert failed"))
+		mock.ExpectRollback()
+
+		err := repo.CreateRevocation(revocation)
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLRevocationRepository_GetActiveRevocations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewRevocationRepository(gormDB)
+
+	t.Run("success", func(t *testing.T) {
+		now := time.Now()
+		rows := sqlmock.NewRows([]string{"id", "jti", "user_id", "revoked_at", "expires_at"}).
+			AddRow(1, "jti-1", nil, now, now.Add(time.Hour)).
+			AddRow(2, nil, 3, now, now.Add(time.Hour))
+		mock.ExpectQuery(`SELECT \* FROM "access_token_revocations" WHERE expires_at > \$1 ORDER BY id ASC`).
+			WithArgs(now).
+			WillReturnRows(rows)
+
+		revocations, err := repo.GetActiveRevocations(now)
+
+		assert.NoError(t, err)
+		assert.Len(t, revocations, 2)
+		assert.Equal(t, "jti-1", *revocations[0].Jti)
+		assert.Nil(t, revocations[0].UserId)
+		assert.Equal(t, int64(3), *revocations[1].UserId)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "access_token_revocations"`).WillReturnError(errors.New("query failed"))
+
+		revocations, err := repo.GetActiveRevocations(time.Now())
+
+		assert.Error(t, err)
+		assert.Nil(t, revocations)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLRevocationRepository_DeleteExpiredRevocations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewRevocationRepository(gormDB)
+
+	t.Run("success", func(t *testing.T) {
+		now := time.Now()
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "access_token_revocations" WHERE expires_at <= \$1`).
+			WithArgs(now).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		deleted, err := repo.DeleteExpiredRevocations(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -18,9 +18,9 @@ func (r *PostgreSQLSubscriptionRepository) GetDB() *gorm.DB {
 	return r.db
 }
 
-func (r *PostgreSQLSubscriptionRepository) CreateSubscription(requestorId, targetId int64) error {
+func (r *PostgreSQLSubscriptionRepository) CreateSubscription(tx *gorm.DB, requestorId, targetId int64) error {
 	newSubscription := entity.Subscription{RequestorId: requestorId, TargetId: targetId}
-	err := r.db.Model(&entity.Subscription{}).Create(&newSubscription).Error
+	err := tx.Model(&entity.Subscription{}).Create(&newSubscription).Error
 	return err
 }
 
@@ -47,3 +47,12 @@ func (r *PostgreSQLSubscriptionRepository) GetAllSubscriberIds(targetId int64) (
 	}
 	return subscriberIds, nil
 }
+
+func (r *PostgreSQLSubscriptionRepository) GetAllSubscriptionTargetIds(requestorId int64) ([]int64, error) {
+	var targetIds []int64
+	err := r.db.Model(&entity.Subscription{}).Where("requestor_id = ?", requestorId).Pluck("target_id", &targetIds).Error
+	if err != nil {
+		return nil, err
+	}
+	return targetIds, nil
+}
@@ -10,8 +10,9 @@ import (
 
 type SubscriptionRepository interface {
 	GetDB() *gorm.DB
-	CreateSubscription(requestorId, targetId int64) error
+	CreateSubscription(tx *gorm.DB, requestorId, targetId int64) error
 	DeleteSubscription(tx *gorm.DB, requestorId, targetId int64) error
 	GetSubscription(requestorId, targetId int64) (*entity.Subscription, error)
 	GetAllSubscriberIds(targetId int64) ([]int64, error)
+	GetAllSubscriptionTargetIds(requestorId int64) ([]int64, error)
 }
@@ -30,7 +30,7 @@ func TestPostgreSQLSubscriptionRepository_CreateSubscription(t *testing.T) {
 		mock.ExpectExec(`INSERT INTO "subscriptions"`).WithArgs(requestorId, targetId, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
-		err := repo.CreateSubscription(requestorId, targetId)
+		err := repo.CreateSubscription(gormDB, requestorId, targetId)
 		assert.NoError(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
@@ -43,7 +43,7 @@ func TestPostgreSQLSubscriptionRepository_CreateSubscription(t *testing.T) {
 		mock.ExpectExec(`INSERT INTO "subscriptions"`).WithArgs(requestorId, targetId, sqlmock.AnyArg()).WillReturnError(assert.AnError)
 		mock.ExpectRollback()
 
-		err := repo.CreateSubscription(requestorId, targetId)
+		err := repo.CreateSubscription(gormDB, requestorId, targetId)
 		assert.Error(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
@@ -57,7 +57,7 @@ func TestPostgreSQLSubscriptionRepository_CreateSubscription(t *testing.T) {
 			WillReturnError(&pq.Error{Code: "23505", Message: "duplicate key value violates unique constraint"})
 		mock.ExpectRollback()
 
-		err := repo.CreateSubscription(requestorId, targetId)
+		err := repo.CreateSubscription(gormDB, requestorId, targetId)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "duplicate key")
 		assert.NoError(t, mock.ExpectationsWereMet())
@@ -72,7 +72,7 @@ func TestPostgreSQLSubscriptionRepository_CreateSubscription(t *testing.T) {
 			WillReturnError(errors.New("invalid input"))
 		mock.ExpectRollback()
 
-		err := repo.CreateSubscription(requestorId, targetId)
+		err := repo.CreateSubscription(gormDB, requestorId, targetId)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid input")
 		assert.NoError(t, mock.ExpectationsWereMet())
@@ -242,3 +242,47 @@ func TestPostgreSQLSubscriptionRepository_GetAllSubscriberIds(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestPostgreSQLSubscriptionRepository_GetAllSubscriptionTargetIds(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewSubscriptionRepository(gormDB)
+
+	t.Run("successful retrieval with targets", func(t *testing.T) {
+		requestorId := int64(1)
+		rows := sqlmock.NewRows([]string{"target_id"}).
+			AddRow(2).
+			AddRow(3)
+
+		mock.ExpectQuery(`SELECT "target_id" FROM "subscriptions" WHERE requestor_id = \$1`).
+			WithArgs(requestorId).
+			WillReturnRows(rows)
+
+		targets, err := repo.GetAllSubscriptionTargetIds(requestorId)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{2, 3}, targets)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		requestorId := int64(1)
+
+		mock.ExpectQuery(`SELECT "target_id" FROM "subscriptions" WHERE requestor_id = \$1`).
+			WithArgs(requestorId).
+			WillReturnError(gorm.ErrInvalidDB)
+
+		targets, err := repo.GetAllSubscriptionTargetIds(requestorId)
+
+		assert.Nil(t, targets)
+		assert.Equal(t, gorm.ErrInvalidDB, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const deliveryBatchSize = 500
+
+type PostgreSQLUpdateRepository struct {
+	db *gorm.DB
+}
+
+func NewUpdateRepository(db *gorm.DB) UpdateRepository {
+	return &PostgreSQLUpdateRepository{db: db}
+}
+
+func (r *PostgreSQLUpdateRepository) GetDB() *gorm.DB {
+	return r.db
+}
+
+func (r *PostgreSQLUpdateRepository) CreateUpdate(tx *gorm.DB, update *entity.Update) error {
+	err := tx.Model(&entity.Update{}).Create(update).Error
+	return err
+}
+
+// updateRecipients selects the sender's friends, subscribers and mentioned
+// users, minus everyone who blocked or muted the sender, along with the
+// reasons each of them gets the update for. Listing the recipients and
+// delivering the update both read it, so they can never disagree.
+const updateRecipients = `
+	SELECT recipient_id, array_agg(reason ORDER BY rank) AS reasons
+	FROM (
+		SELECT user_id2 AS recipient_id, CAST(@friendUpdate AS text) AS reason, 1 AS rank FROM friendships WHERE user_id1 = @sender
+		UNION ALL
+		SELECT user_id1, CAST(@friendUpdate AS text), 1 FROM friendships WHERE user_id2 = @sender
+		UNION ALL
+		SELECT requestor_id, CAST(@subscriptionUpdate AS text), 2 FROM subscriptions WHERE target_id = @sender
+		UNION ALL
+		SELECT id, CAST(@mention AS text), 3 FROM users WHERE id IN @mentioned
+	) AS candidates
+	WHERE NOT EXISTS (
+		SELECT 1 FROM block_relationships
+		WHERE requestor_id = candidates.recipient_id AND target_id = @sender AND (expires_at IS NULL OR expires_at > @now)
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM mutes
+		WHERE requestor_id = candidates.recipient_id AND target_id = @sender AND (expires_at IS NULL OR expires_at > @now)
+	)
+	GROUP BY recipient_id`
+
+// notifiedRecipient keeps the recipients whose notification preferences let
+// them be notified at @now, on at least one channel, for one of the reasons
+// they get the update for. It follows utils.WantsAnyNotification, so a page
+// of recipients is filtered without loading their preferences; users without
+// preferences get everything. Quiet hours compare "15:04" clock strings.
+const notifiedRecipient = `NOT EXISTS (
+		SELECT 1 FROM notification_preferences AS preference
+		CROSS JOIN LATERAL (
+			SELECT to_char(CAST(@now AS timestamptz) AT TIME ZONE COALESCE(NULLIF(preference.quiet_hours_timezone, ''), 'UTC'), 'HH24:MI') AS clock
+		) AS local
+		WHERE preference.user_id = recipients.recipient_id
+		AND NOT (
+			cardinality(preference.channels) > 0
+			AND EXISTS (
+				SELECT 1 FROM unnest(recipients.reasons) AS reason
+				WHERE reason = ANY(preference.event_types)
+				AND NOT (
+					preference.quiet_hours_start <> '' AND preference.quiet_hours_end <> ''
+					AND (cardinality(preference.quiet_hours_event_types) = 0 OR reason = ANY(preference.quiet_hours_event_types))
+					AND CASE WHEN preference.quiet_hours_start <= preference.quiet_hours_end
+						THEN local.clock >= preference.quiet_hours_start AND local.clock < preference.quiet_hours_end
+						ELSE local.clock >= preference.quiet_hours_start OR local.clock < preference.quiet_hours_end
+					END
+				)
+			)
+		)
+	)`
+
+// GetUpdateRecipientsPage returns the recipients the update would notify at
+// now, by id.
+func (r *PostgreSQLUpdateRepository) GetUpdateRecipientsPage(senderId int64, mentionedIds []int64, now time.Time, afterId int64, limit int) ([]*entity.User, error) {
+	var users []*entity.User
+	args := updateRecipientsArgs(senderId, mentionedIds, now)
+	args["after"] = afterId
+	args["limit"] = limit
+	err := r.db.Raw(`SELECT users.* FROM users
+		JOIN (`+updateRecipients+`) AS recipients ON recipients.recipient_id = users.id
+		WHERE users.id > @after AND `+notifiedRecipient+`
+		ORDER BY users.id
+		LIMIT @limit`, args).
+		Scan(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *PostgreSQLUpdateRepository) CountUpdateRecipients(senderId int64, mentionedIds []int64, now time.Time) (int64, error) {
+	var count int64
+	err := r.db.Raw(`SELECT COUNT(*) FROM (`+updateRecipients+`) AS recipients WHERE `+notifiedRecipient, updateRecipientsArgs(senderId, mentionedIds, now)).
+		Scan(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CreateUpdateDeliveries puts the update in the feed of every recipient,
+// ordered by recipient id, along with the reasons they get it for. The feed
+// ignores notification preferences, which only decide who is notified.
+func (r *PostgreSQLUpdateRepository) CreateUpdateDeliveries(tx *gorm.DB, updateId, senderId int64, mentionedIds []int64, now time.Time) ([]*entity.UpdateDelivery, error) {
+	var deliveries []*entity.UpdateDelivery
+	args := updateRecipientsArgs(senderId, mentionedIds, now)
+	args["update"] = updateId
+	err := tx.Raw(`INSERT INTO update_deliveries (update_id, recipient_id, reasons, created_at)
+		SELECT @update, recipient_id, reasons, @now FROM (`+updateRecipients+`) AS recipients
+		ORDER BY recipient_id
+		RETURNING *`, args).
+		Scan(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func updateRecipientsArgs(senderId int64, mentionedIds []int64, now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"sender":             senderId,
+		"mentioned":          mentionedIds,
+		"now":                now,
+		"friendUpdate":       entity.NotificationFriendUpdate,
+		"subscriptionUpdate": entity.NotificationSubscriptionUpdate,
+		"mention":            entity.NotificationMention,
+	}
+}
+
+// GetFeed returns the deliveries of a recipient newest first. A beforeId of 0
+// starts from the most recent delivery.
+func (r *PostgreSQLUpdateRepository) GetFeed(recipientId, beforeId int64, limit int) ([]*entity.UpdateDelivery, error) {
+	var deliveries []*entity.UpdateDelivery
+	query := r.db.Model(&entity.UpdateDelivery{}).
+		Preload("Update.Sender").
+		Where("recipient_id = ?", recipientId)
+	if beforeId > 0 {
+		query = query.Where("id < ?", beforeId)
+	}
+	err := query.Order("id DESC").Limit(limit).Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// GetFeedSince returns the deliveries of a recipient created after afterId,
+// oldest first, so a reconnecting stream can replay what it missed in order.
+func (r *PostgreSQLUpdateRepository) GetFeedSince(recipientId, afterId int64, limit int) ([]*entity.UpdateDelivery, error) {
+	var deliveries []*entity.UpdateDelivery
+	err := r.db.Model(&entity.UpdateDelivery{}).
+		Preload("Update.Sender").
+		Where("recipient_id = ? AND id > ?", recipientId, afterId).
+		Order("id ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *PostgreSQLUpdateRepository) CountFeed(recipientId int64) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.UpdateDelivery{}).
+		Where("recipient_id = ?", recipientId).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *PostgreSQLUpdateRepository) CountUnreadFeed(recipientId int64) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.UpdateDelivery{}).
+		Where("recipient_id = ? AND read_at IS NULL", recipientId).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkDeliveryRead stamps read_at on the first read only, so re-reading an
+// item keeps its original timestamp.
+func (r *PostgreSQLUpdateRepository) MarkDeliveryRead(recipientId, deliveryId int64) error {
+	var delivery = entity.UpdateDelivery{}
+	err := r.db.Model(&entity.UpdateDelivery{}).
+		Where("id = ? AND recipient_id = ?", deliveryId, recipientId).
+		First(&delivery).Error
+	if err != nil {
+		return err
+	}
+	if delivery.ReadAt != nil {
+		return nil
+	}
+	err = r.db.Model(&entity.UpdateDelivery{}).
+		Where("id = ? AND read_at IS NULL", deliveryId).
+		Update("read_at", time.Now()).Error
+	return err
+}
+
+// GetUpdateDeliveries returns every delivery of the update with its
+// recipient, ordered by recipient id.
+func (r *PostgreSQLUpdateRepository) GetUpdateDeliveries(updateId int64) ([]*entity.UpdateDelivery, error) {
+	var deliveries []*entity.UpdateDelivery
+	err := r.db.Model(&entity.UpdateDelivery{}).
+		Preload("Recipient").
+		Where("update_id = ?", updateId).
+		Order("recipient_id").
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// GetLatestDeliveryId returns the id of the newest feed item of the
+// recipient, or 0 when the feed is empty.
+func (r *PostgreSQLUpdateRepository) GetLatestDeliveryId(recipientId int64) (int64, error) {
+	var latestId int64
+	err := r.db.Model(&entity.UpdateDelivery{}).
+		Where("recipient_id = ?", recipientId).
+		Select("COALESCE(MAX(id), 0)").
+		Scan(&latestId).Error
+	if err != nil {
+		return 0, err
+	}
+	return latestId, nil
+}
+
+func (r *PostgreSQLUpdateRepository) CreateMentions(tx *gorm.DB, updateId int64, userIds []int64) error {
+	if len(userIds) == 0 {
+		return nil
+	}
+	mentions := make([]*entity.Mention, len(userIds))
+	for i, userId := range userIds {
+		mentions[i] = &entity.Mention{UpdateId: updateId, UserId: userId}
+	}
+	return tx.Model(&entity.Mention{}).CreateInBatches(mentions, deliveryBatchSize).Error
+}
+
+// GetMentions returns the mentions of a user newest first. A beforeId of 0
+// starts from the most recent mention.
+func (r *PostgreSQLUpdateRepository) GetMentions(userId, beforeId int64, limit int) ([]*entity.Mention, error) {
+	var mentions []*entity.Mention
+	query := r.db.Model(&entity.Mention{}).
+		Preload("Update.Sender").
+		Where("user_id = ?", userId)
+	if beforeId > 0 {
+		query = query.Where("id < ?", beforeId)
+	}
+	err := query.Order("id DESC").Limit(limit).Find(&mentions).Error
+	if err != nil {
+		return nil, err
+	}
+	return mentions, nil
+}
+
+func (r *PostgreSQLUpdateRepository) CountMentions(userId int64) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.Mention{}).
+		Where("user_id = ?", userId).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=interface.go -destination=../mock/mock_update_repository.go
+
+type UpdateRepository interface {
+	GetDB() *gorm.DB
+	CreateUpdate(tx *gorm.DB, update *entity.Update) error
+	GetUpdateRecipientsPage(senderId int64, mentionedIds []int64, now time.Time, afterId int64, limit int) ([]*entity.User, error)
+	CountUpdateRecipients(senderId int64, mentionedIds []int64, now time.Time) (int64, error)
+	CreateUpdateDeliveries(tx *gorm.DB, updateId, senderId int64, mentionedIds []int64, now time.Time) ([]*entity.UpdateDelivery, error)
+	GetFeed(recipientId, beforeId int64, limit int) ([]*entity.UpdateDelivery, error)
+	GetFeedSince(recipientId, afterId int64, limit int) ([]*entity.UpdateDelivery, error)
+	CountFeed(recipientId int64) (int64, error)
+	CountUnreadFeed(recipientId int64) (int64, error)
+	MarkDeliveryRead(recipientId, deliveryId int64) error
+	GetUpdateDeliveries(updateId int64) ([]*entity.UpdateDelivery, error)
+	GetLatestDeliveryId(recipientId int64) (int64, error)
+	CreateMentions(tx *gorm.DB, updateId int64, userIds []int64) error
+	GetMentions(userId, beforeId int64, limit int) ([]*entity.Mention, error)
+	CountMentions(userId int64) (int64, error)
+}
@@ -0,0 +1,553 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestPostgreSQLUpdateRepository_CreateUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewUpdateRepository(gormDB)
+
+	t.Run("successful creation", func(t *testing.T) {
+		update := &entity.Update{SenderId: 1, Text: "Hello"}
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "updates"`).
+			WithArgs(int64(1), "Hello", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+		mock.ExpectCommit()
+
+		err := repo.CreateUpdate(gormDB, update)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), update.Id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		update := &entity.Update{SenderId: 1, Text: "Hello"}
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "updates"`).
+			WithArgs(int64(1), "Hello", sqlmock.AnyArg()).
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		err := repo.CreateUpdate(gormDB, update)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLUpdateRepository_GetUpdateRecipientsPage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewUpdateRepository(gormDB)
+	now := time.Now()
+
+	t.Run("successful retrieval after cursor", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "email"}).
+			AddRow(3, "user3@example.com").
+			AddRow(4, "user4@example.com")
+		mock.ExpectQuery(`SELECT users.\* FROM users JOIN \( SELECT recipient_id, array_agg\(reason ORDER BY rank\) AS reasons FROM \( SELECT user_id2 AS recipient_id, CAST\(\$1 AS text\) AS reason, 1 AS rank FROM friendships WHERE user_id1 = \$2 UNION ALL SELECT user_id1, CAST\(\$3 AS text\), 1 FROM friendships WHERE user_id2 = \$4 UNION ALL SELECT requestor_id, CAST\(\$5 AS text\), 2 FROM subscriptions WHERE target_id = \$6 UNION ALL SELECT id, CAST\(\$7 AS text\), 3 FROM users WHERE id IN \(\$8,\$9\) \) AS candidates WHERE NOT EXISTS \( SELECT 1 FROM block_relationships WHERE requestor_id = candidates.recipient_id AND target_id = \$10 AND \(expires_at IS NULL OR expires_at > \$11\) \) AND NOT EXISTS \( SELECT 1 FROM mutes WHERE requestor_id = candidates.recipient_id AND target_id = \$12 AND \(expires_at IS NULL OR expires_at > \$13\) \) GROUP BY recipient_id\) AS recipients ON recipients.recipient_id = users.id WHERE users.id > \$14 AND NOT EXISTS \( SELECT 1 FROM notification_preferences AS preference CROSS JOIN LATERAL \( SELECT to_char\(CAST\(\$15 AS timestamptz\) AT TIME ZONE .*local.clock < preference.quiet_hours_end END \) \) \) \) ORDER BY users.id LIMIT \$16`).
+			WithArgs(entity.NotificationFriendUpdate, int64(1), entity.NotificationFriendUpdate, int64(1), entity.NotificationSubscriptionUpdate, int64(1), entity.NotificationMention, int64(4), int64(5), int64(1), now, int64(1), now, int64(2), now, 2).
+			WillReturnRows(rows)
+
+		users, err := repo.GetUpdateRecipientsPage(1, []int64{4, 5}, now, 2, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, users, 2)
+		assert.Equal(t, int64(3), users[0].Id)
+		assert.Equal(t, int64(4), users[1].Id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no mentions", func(t *testing.T) {
+		mock.ExpectQuery(`FROM users WHERE id IN \(NULL\).* WHERE users.id > \$12 AND NOT EXISTS .* ORDER BY users.id LIMIT \$14`).
+			WithArgs(entity.NotificationFriendUpdate, int64(1), entity.NotificationFriendUpdate, int64(1), entity.NotificationSubscriptionUpdate, int64(1), entity.NotificationMention, int64(1), now, int64(1), now, int64(0), now, 20).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(2, "user2@example.com"))
+
+		users, err := repo.GetUpdateRecipientsPage(1, nil, now, 0, 20)
+
+		assert.NoError(t, err)
+		assert.Len(t, users, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT users.\* FROM users`).WillReturnError(assert.AnError)
+
+		users, err := repo.GetUpdateRecipientsPage(1, nil, now, 0, 20)
+
+		assert.Error(t, err)
+		assert.Nil(t, users)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLUpdateRepository_CountUpdateRecipients(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewUpdateRepository(gormDB)
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM \( SELECT recipient_id, array_agg.*GROUP BY recipient_id\) AS recipients WHERE NOT EXISTS \( SELECT 1 FROM notification_preferences .*\)$`).
+			WithArgs(entity.NotificationFriendUpdate, int64(1), entity.NotificationFriendUpdate, int64(1), entity.NotificationSubscriptionUpdate, int64(1), entity.NotificationMention, int64(4), int64(1), now, int64(1), now, now).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		count, err := repo.CountUpdateRecipients(1, []int64{4}, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT COUNT\(\*\)`).WillReturnError(assert.AnError)
+
+		count, err := repo.CountUpdateRecipients(1, nil, now)
+
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLUpdateRepository_CreateUpdateDeliveries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewUpdateRepository(gormDB)
+	now := time.Now()
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectQuery(`INSERT INTO update_deliveries \(update_id, recipient_id, reasons, created_at\) SELECT \$1, recipient_id, reasons, \$2 FROM \( SELECT recipient_id, array_agg.*GROUP BY recipient_id\) AS recipients ORDER BY recipient_id RETURNING \*`).
+			WithArgs(int64(7), now, entity.NotificationFriendUpdate, int64(1), entity.NotificationFriendUpdate, int64(1), entity.NotificationSubscriptionUpdate, int64(1), entity.NotificationMention, int64(3), int64(1), now, int64(1), now).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "update_id", "recipient_id", "reasons"}).
+				AddRow(1, 7, 2, "{friend_update}").
+				AddRow(2, 7, 3, "{subscription_update,mention}"))
+
+		deliveries, err := repo.CreateUpdateDeliveries(gormDB, 7, 1, []int64{3}, now)
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 2)
+		assert.Equal(t, int64(1), deliveries[0].Id)
+		assert.Equal(t, int64(2), deliveries[0].RecipientId)
+		assert.Equal(t, int64(3), deliveries[1].RecipientId)
+		assert.Equal(t, pq.StringArray{entity.NotificationSubscriptionUpdate, entity.NotificationMention}, deliveries[1].Reasons)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`INSERT INTO update_deliveries`).
+			WillReturnError(assert.AnError)
+
+		deliveries, err := repo.CreateUpdateDeliveries(gormDB, 7, 1, nil, now)
+		assert.Error(t, err)
+		assert.Nil(t, deliveries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+func TestPostgreSQLUpdateRepository_GetFeed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewUpdateRepository(gormDB)
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		readAt := time.Date(2025, 8, 2, 10, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(`SELECT \* FROM "update_deliveries" WHERE recipient_id = \$1 AND id < \$2 ORDER BY id DESC LIMIT \$3`).
+			WithArgs(int64(2), int64(10), 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "update_id", "recipient_id", "read_at"}).
+				AddRow(9, 5, 2, nil).
+				AddRow(8, 4, 2, readAt))
+		mock.ExpectQuery(`SELECT \* FROM "updates" WHERE "updates"."id" IN \(\$1,\$2\)`).
+			WithArgs(int64(5), int64(4)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "sender_id", "text"}).
+				AddRow(5, 1, "Second").
+				AddRow(4, 1, "First"))
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE "users"."id" = \$1`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(1, "sender@example.com"))
+
+		deliveries, err := repo.GetFeed(2, 10, 2)
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 2)
+		assert.Equal(t, "Second", deliveries[0].Update.Text)
+		assert.Equal(t, "sender@example.com", deliveries[0].Update.Sender.Email)
+		assert.Nil(t, deliveries[0].ReadAt)
+		assert.Equal(t, readAt, *deliveries[1].ReadAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("first page", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "update_deliveries" WHERE recipient_id = \$1 ORDER BY id DESC LIMIT \$2`).
+			WithArgs(int64(2), 20).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "update_id", "recipient_id"}))
+
+		deliveries, err := repo.GetFeed(2, 0, 20)
+		assert.NoError(t, err)
+		assert.Empty(t, deliveries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "update_deliveries" WHERE recipient_id = \$1 ORDER BY id DESC LIMIT \$2`).
+			WithArgs(int64(2), 20).
+			WillReturnError(assert.AnError)
+
+		deliveries, err := repo.GetFeed(2, 0, 20)
+		assert.Error(t, err)
+		assert.Nil(t, deliveries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLUpdateRepository_GetFeedSince(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewUpdateRepository(gormDB)
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "update_deliveries" WHERE recipient_id = \$1 AND id > \$2 ORDER BY id ASC LIMIT \$3`).
+			WithArgs(int64(2), int64(7), 100).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "update_id", "recipient_id"}).AddRow(8, 4, 2))
+		mock.ExpectQuery(`SELECT \* FROM "updates" WHERE "updates"."id" = \$1`).
+			WithArgs(int64(4)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "sender_id", "text"}).AddRow(4, 1, "Missed"))
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE "users"."id" = \$1`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(1, "sender@example.com"))
+
+		deliveries, err := repo.GetFeedSince(2, 7, 100)
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 1)
+		assert.Equal(t, "Missed", deliveries[0].Update.Text)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "update_deliveries" WHERE recipient_id = \$1 AND id > \$2 ORDER BY id ASC LIMIT \$3`).
+			WithArgs(int64(2), int64(7), 100).
+			WillReturnError(assert.AnError)
+
+		deliveries, err := repo.GetFeedSince(2, 7, 100)
+		assert.Error(t, err)
+		assert.Nil(t, deliveries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLUpdateRepository_CountFeed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewUpdateRepository(gormDB)
+
+	t.Run("all deliveries", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "update_deliveries" WHERE recipient_id = \$1`).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		count, err := repo.CountFeed(2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unread deliveries", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "update_deliveries" WHERE recipient_id = \$1 AND read_at IS NULL`).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		count, err := repo.CountUnreadFeed(2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLUpdateRepository_MarkDeliveryRead(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewUpdateRepository(gormDB)
+
+	t.Run("successful update", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "update_deliveries" WHERE id = \$1 AND recipient_id = \$2`).
+			WithArgs(int64(9), int64(2), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "update_id", "recipient_id", "read_at"}).AddRow(9, 5, 2, nil))
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "update_deliveries" SET "read_at"=\$1 WHERE id = \$2 AND read_at IS NULL`).
+			WithArgs(sqlmock.AnyArg(), int64(9)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.MarkDeliveryRead(2, 9)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "update_deliveries" WHERE id = \$1 AND recipient_id = \$2`).
+			WithArgs(int64(9), int64(2), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "update_id", "recipient_id", "read_at"}).AddRow(9, 5, 2, time.Now()))
+
+		err := repo.MarkDeliveryRead(2, 9)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "update_deliveries" WHERE id = \$1 AND recipient_id = \$2`).
+			WithArgs(int64(9), int64(3), 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		err := repo.MarkDeliveryRead(3, 9)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLUpdateRepository_GetUpdateDeliveries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewUpdateRepository(gormDB)
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "update_deliveries" WHERE update_id = \$1 ORDER BY recipient_id`).
+			WithArgs(int64(4)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "update_id", "recipient_id", "reasons"}).
+				AddRow(1, 4, 2, "{friend_update}").
+				AddRow(2, 4, 3, "{mention}"))
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE "users"."id" IN \(\$1,\$2\)`).
+			WithArgs(int64(2), int64(3)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(2, "user2@example.com").AddRow(3, "user3@example.com"))
+
+		deliveries, err := repo.GetUpdateDeliveries(4)
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 2)
+		assert.Equal(t, "user3@example.com", deliveries[1].Recipient.Email)
+		assert.Equal(t, pq.StringArray{entity.NotificationMention}, deliveries[1].Reasons)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "update_deliveries"`).
+			WithArgs(int64(4)).
+			WillReturnError(assert.AnError)
+
+		deliveries, err := repo.GetUpdateDeliveries(4)
+		assert.Error(t, err)
+		assert.Nil(t, deliveries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLUpdateRepository_GetLatestDeliveryId(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewUpdateRepository(gormDB)
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT COALESCE\(MAX\(id\), 0\) FROM "update_deliveries" WHERE recipient_id = \$1`).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(12))
+
+		latestId, err := repo.GetLatestDeliveryId(2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), latestId)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT COALESCE\(MAX\(id\), 0\) FROM "update_deliveries"`).
+			WithArgs(int64(2)).
+			WillReturnError(assert.AnError)
+
+		latestId, err := repo.GetLatestDeliveryId(2)
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), latestId)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLUpdateRepository_CreateMentions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewUpdateRepository(gormDB)
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "mentions" \("update_id","user_id","created_at"\) VALUES \(\$1,\$2,\$3\),\(\$4,\$5,\$6\)`).
+			WithArgs(int64(7), int64(2), sqlmock.AnyArg(), int64(7), int64(3), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+		mock.ExpectCommit()
+
+		err := repo.CreateMentions(gormDB, 7, []int64{2, 3})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no mentions", func(t *testing.T) {
+		err := repo.CreateMentions(gormDB, 7, nil)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "mentions"`).
+			WithArgs(int64(7), int64(2), sqlmock.AnyArg()).
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		err := repo.CreateMentions(gormDB, 7, []int64{2})
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLUpdateRepository_GetMentions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewUpdateRepository(gormDB)
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "mentions" WHERE user_id = \$1 AND id < \$2 ORDER BY id DESC LIMIT \$3`).
+			WithArgs(int64(2), int64(10), 20).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "update_id", "user_id"}).AddRow(9, 5, 2))
+		mock.ExpectQuery(`SELECT \* FROM "updates" WHERE "updates"."id" = \$1`).
+			WithArgs(int64(5)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "sender_id", "text"}).AddRow(5, 1, "Hi @bob"))
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE "users"."id" = \$1`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(1, "sender@example.com"))
+
+		mentions, err := repo.GetMentions(2, 10, 20)
+		assert.NoError(t, err)
+		assert.Len(t, mentions, 1)
+		assert.Equal(t, "Hi @bob", mentions[0].Update.Text)
+		assert.Equal(t, "sender@example.com", mentions[0].Update.Sender.Email)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "mentions" WHERE user_id = \$1 ORDER BY id DESC LIMIT \$2`).
+			WithArgs(int64(2), 20).
+			WillReturnError(assert.AnError)
+
+		mentions, err := repo.GetMentions(2, 0, 20)
+		assert.Error(t, err)
+		assert.Nil(t, mentions)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLUpdateRepository_CountMentions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewUpdateRepository(gormDB)
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "mentions" WHERE user_id = \$1`).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+	count, err := repo.CountMentions(2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,6 +4,7 @@ import (
 	"BE_Friends_Management/internal/domain/entity"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostgreSQLUserRepository struct {
@@ -18,15 +19,24 @@ func (r *PostgreSQLUserRepository) GetDB() *gorm.DB {
 	return r.db
 }
 
-func (r *PostgreSQLUserRepository) GetAllUser() ([]*entity.User, error) {
+func (r *PostgreSQLUserRepository) GetAllUser(afterId int64, limit int) ([]*entity.User, error) {
 	var users = []*entity.User{}
-	result := r.db.Model(&entity.User{}).Find(&users)
+	result := r.db.Model(&entity.User{}).Where("id > ?", afterId).Order("id").Limit(limit).Find(&users)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return users, nil
 }
 
+func (r *PostgreSQLUserRepository) CountUsers() (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.User{}).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 func (r *PostgreSQLUserRepository) GetUserById(userId int64) (*entity.User, error) {
 	var user = entity.User{}
 	result := r.db.Model(&entity.User{}).Where("id = ?", userId).First(&user)
@@ -54,6 +64,17 @@ func (r *PostgreSQLUserRepository) GetUsersFromEmails(emails []string) ([]*entit
 	return users, nil
 }
 
+// GetUsersFromMentions returns the users with one of the lower-cased handles
+// or emails. Emails are compared case-insensitively.
+func (r *PostgreSQLUserRepository) GetUsersFromMentions(handles, emails []string) ([]*entity.User, error) {
+	var users []*entity.User
+	result := r.db.Model(&entity.User{}).Where("handle IN ? OR LOWER(email) IN ?", handles, emails).Order("id").Find(&users)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return users, nil
+}
+
 func (r *PostgreSQLUserRepository) GetUserByEmail(email string) (*entity.User, error) {
 	var user = entity.User{}
 	result := r.db.Model(&entity.User{}).Where("email = ?", email).First(&user)
@@ -88,3 +109,34 @@ func (r *PostgreSQLUserRepository) UpdateUser(user *entity.User) (*entity.User,
 	}
 	return &updatedUser, nil
 }
+
+// SetHandle sets the handle of the user, or clears it when handle is nil. A
+// handle taken by another user is a duplicate key error.
+func (r *PostgreSQLUserRepository) SetHandle(userId int64, handle *string) error {
+	result := r.db.Model(&entity.User{}).Where("id = ?", userId).Update("handle", handle)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *PostgreSQLUserRepository) SetEmailOptOut(userId int64, optOut bool) error {
+	if !optOut {
+		return r.db.Where("user_id = ?", userId).Delete(&entity.EmailOptOut{}).Error
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.EmailOptOut{UserId: userId}).Error
+}
+
+// GetEmailOptOutUserIds returns the subset of userIds that opted out of
+// notification emails.
+func (r *PostgreSQLUserRepository) GetEmailOptOutUserIds(userIds []int64) ([]int64, error) {
+	var optOutUserIds []int64
+	err := r.db.Model(&entity.EmailOptOut{}).Where("user_id IN ?", userIds).Pluck("user_id", &optOutUserIds).Error
+	if err != nil {
+		return nil, err
+	}
+	return optOutUserIds, nil
+}
@@ -11,11 +11,16 @@ import (
 type UserRepository interface {
 	GetDB() *gorm.DB
 	CreateUser(user *entity.User) (*entity.User, error)
-	GetAllUser() ([]*entity.User, error)
+	GetAllUser(afterId int64, limit int) ([]*entity.User, error)
+	CountUsers() (int64, error)
 	GetUserById(userId int64) (*entity.User, error)
 	GetUserByEmail(email string) (*entity.User, error)
 	GetUsersFromIds(userIds []int64) ([]*entity.User, error)
 	GetUsersFromEmails(emails []string) ([]*entity.User, error)
+	GetUsersFromMentions(handles, emails []string) ([]*entity.User, error)
+	SetHandle(userId int64, handle *string) error
 	UpdateUser(user *entity.User) (*entity.User, error)
 	DeleteUserById(userId int64) error
+	SetEmailOptOut(userId int64, optOut bool) error
+	GetEmailOptOutUserIds(userIds []int64) ([]int64, error)
 }
@@ -31,7 +31,7 @@ func TestPostgreSQLUserRepository_CreateUser(t *testing.T) {
 		user := &entity.User{Email: userEmail, Password: userPassword, Role: userRole}
 		rows := sqlmock.NewRows([]string{"id", "email", "password", "role"}).AddRow(userId, userEmail, userPassword, userRole)
 		mock.ExpectBegin()
-		mock.ExpectQuery(`INSERT INTO "users"`).WithArgs(userEmail, userPassword, userRole, sqlmock.AnyArg()).WillReturnRows(rows)
+		mock.ExpectQuery(`INSERT INTO "users"`).WithArgs(userEmail, nil, userPassword, userRole, sqlmock.AnyArg()).WillReturnRows(rows)
 		mock.ExpectCommit()
 
 		createdUser, err := repo.CreateUser(user)
@@ -49,7 +49,7 @@ func TestPostgreSQLUserRepository_CreateUser(t *testing.T) {
 		userRole := "user"
 		user := &entity.User{Email: userEmail, Password: userPassword, Role: userRole}
 		mock.ExpectBegin()
-		mock.ExpectQuery(`INSERT INTO "users"`).WithArgs(userEmail, userPassword, userRole, sqlmock.AnyArg()).WillReturnError(assert.AnError)
+		mock.ExpectQuery(`INSERT INTO "users"`).WithArgs(userEmail, nil, userPassword, userRole, sqlmock.AnyArg()).WillReturnError(assert.AnError)
 		mock.ExpectRollback()
 
 		createdUser, err := repo.CreateUser(user)
@@ -64,7 +64,7 @@ func TestPostgreSQLUserRepository_CreateUser(t *testing.T) {
 		userRole := "user"
 		user := &entity.User{Email: userEmail, Password: userPassword, Role: userRole}
 		mock.ExpectBegin()
-		mock.ExpectQuery(`INSERT INTO "users"`).WithArgs(userEmail, userPassword, userRole, sqlmock.AnyArg()).WillReturnError(&pq.Error{Code: "23505", Message: "duplicate key value violates unique constraint"})
+		mock.ExpectQuery(`INSERT INTO "users"`).WithArgs(userEmail, nil, userPassword, userRole, sqlmock.AnyArg()).WillReturnError(&pq.Error{Code: "23505", Message: "duplicate key value violates unique constraint"})
 		mock.ExpectRollback()
 
 		createdUser, err := repo.CreateUser(user)
@@ -94,9 +94,9 @@ func TestPostgreSQLUserRepository_GetAllUser(t *testing.T) {
 			AddRow(1, "user1@example.com", "123", "user", createdAt1).
 			AddRow(2, "user2@example.com", "123", "user", createdAt2)
 
-		mock.ExpectQuery(`SELECT \* FROM "users"`).WillReturnRows(rows)
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE id > \$1 ORDER BY id LIMIT \$2`).WithArgs(int64(0), 20).WillReturnRows(rows)
 
-		users, err := repo.GetAllUser()
+		users, err := repo.GetAllUser(0, 20)
 
 		assert.NoError(t, err)
 		assert.Len(t, users, 2)
@@ -116,9 +116,9 @@ func TestPostgreSQLUserRepository_GetAllUser(t *testing.T) {
 	t.Run("successful retrieval with no users", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "email", "password", "role", "created_at"})
 
-		mock.ExpectQuery(`SELECT \* FROM "users"`).WillReturnRows(rows)
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE id > \$1 ORDER BY id LIMIT \$2`).WithArgs(int64(0), 20).WillReturnRows(rows)
 
-		users, err := repo.GetAllUser()
+		users, err := repo.GetAllUser(0, 20)
 
 		assert.NoError(t, err)
 		assert.Len(t, users, 0)
@@ -127,9 +127,9 @@ func TestPostgreSQLUserRepository_GetAllUser(t *testing.T) {
 	})
 
 	t.Run("error retrieval", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT \* FROM "users"`).WillReturnError(assert.AnError)
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE id > \$1 ORDER BY id LIMIT \$2`).WithArgs(int64(0), 20).WillReturnError(assert.AnError)
 
-		users, err := repo.GetAllUser()
+		users, err := repo.GetAllUser(0, 20)
 
 		assert.Error(t, err)
 		assert.Nil(t, users)
@@ -137,6 +137,40 @@ func TestPostgreSQLUserRepository_GetAllUser(t *testing.T) {
 	})
 }
 
+func TestPostgreSQLUserRepository_CountUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewUserRepository(gormDB)
+
+	t.Run("successful count", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"count"}).AddRow(3)
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "users"`).WillReturnRows(rows)
+
+		count, err := repo.CountUsers()
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("error count", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "users"`).WillReturnError(assert.AnError)
+
+		count, err := repo.CountUsers()
+
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestPostgreSQLUserRepository_GetUserById(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
@@ -530,3 +564,188 @@ func TestPostgreSQLUserRepository_DeleteUserById(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestPostgreSQLUserRepository_SetEmailOptOut(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewUserRepository(gormDB)
+
+	t.Run("opt out", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "email_opt_outs" .* ON CONFLICT DO NOTHING`).
+			WithArgs(sqlmock.AnyArg(), int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(1))
+		mock.ExpectCommit()
+
+		err := repo.SetEmailOptOut(1, true)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("opt back in", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "email_opt_outs" WHERE user_id = \$1`).
+			WithArgs(int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.SetEmailOptOut(1, false)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "email_opt_outs"`).
+			WithArgs(sqlmock.AnyArg(), int64(1)).
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		err := repo.SetEmailOptOut(1, true)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLUserRepository_GetEmailOptOutUserIds(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewUserRepository(gormDB)
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT "user_id" FROM "email_opt_outs" WHERE user_id IN \(\$1,\$2,\$3\)`).
+			WithArgs(int64(1), int64(2), int64(3)).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(2))
+
+		optOutUserIds, err := repo.GetEmailOptOutUserIds([]int64{1, 2, 3})
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{2}, optOutUserIds)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT "user_id" FROM "email_opt_outs"`).
+			WithArgs(int64(1)).
+			WillReturnError(assert.AnError)
+
+		optOutUserIds, err := repo.GetEmailOptOutUserIds([]int64{1})
+		assert.Error(t, err)
+		assert.Nil(t, optOutUserIds)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLUserRepository_GetUsersFromMentions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewUserRepository(gormDB)
+
+	t.Run("successful retrieval by handle and email", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE handle IN \(\$1\) OR LOWER\(email\) IN \(\$2\) ORDER BY id`).
+			WithArgs("alice", "bob@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email", "handle"}).
+				AddRow(1, "alice@example.com", "alice").
+				AddRow(2, "Bob@Example.com", nil))
+
+		users, err := repo.GetUsersFromMentions([]string{"alice"}, []string{"bob@example.com"})
+		assert.NoError(t, err)
+		assert.Len(t, users, 2)
+		assert.Equal(t, "alice", *users[0].Handle)
+		assert.Nil(t, users[1].Handle)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "users"`).
+			WithArgs("alice", "bob@example.com").
+			WillReturnError(gorm.ErrInvalidDB)
+
+		users, err := repo.GetUsersFromMentions([]string{"alice"}, []string{"bob@example.com"})
+		assert.Nil(t, users)
+		assert.Equal(t, gorm.ErrInvalidDB, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLUserRepository_SetHandle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	repo := NewUserRepository(gormDB)
+	handle := "alice"
+
+	t.Run("successful update", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "users" SET "handle"=\$1 WHERE id = \$2`).
+			WithArgs(handle, int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.SetHandle(1, &handle)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("clear handle", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "users" SET "handle"=\$1 WHERE id = \$2`).
+			WithArgs(nil, int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.SetHandle(1, nil)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "users" SET "handle"=\$1 WHERE id = \$2`).
+			WithArgs(handle, int64(9)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := repo.SetHandle(9, &handle)
+		assert.Equal(t, gorm.ErrRecordNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("handle taken", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "users"`).
+			WithArgs(handle, int64(2)).
+			WillReturnError(&pq.Error{Code: "23505", Message: "duplicate key value violates unique constraint"})
+		mock.ExpectRollback()
+
+		err := repo.SetHandle(2, &handle)
+		assert.Contains(t, err.Error(), "duplicate key")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
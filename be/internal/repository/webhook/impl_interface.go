@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgreSQLWebhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &PostgreSQLWebhookRepository{db: db}
+}
+
+func (r *PostgreSQLWebhookRepository) GetDB() *gorm.DB {
+	return r.db
+}
+
+func (r *PostgreSQLWebhookRepository) CreateSubscription(subscription *entity.WebhookSubscription) error {
+	err := r.db.Model(&entity.WebhookSubscription{}).Create(subscription).Error
+	return err
+}
+
+func (r *PostgreSQLWebhookRepository) GetSubscriptions() ([]*entity.WebhookSubscription, error) {
+	var subscriptions []*entity.WebhookSubscription
+	err := r.db.Model(&entity.WebhookSubscription{}).Order("id ASC").Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *PostgreSQLWebhookRepository) GetSubscriptionById(subscriptionId int64) (*entity.WebhookSubscription, error) {
+	var subscription = entity.WebhookSubscription{}
+	err := r.db.Model(&entity.WebhookSubscription{}).Where("id = ?", subscriptionId).First(&subscription).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// DeleteSubscription also drops the delivery log of the subscription through
+// the cascading foreign key.
+func (r *PostgreSQLWebhookRepository) DeleteSubscription(subscriptionId int64) error {
+	result := r.db.Where("id = ?", subscriptionId).Delete(&entity.WebhookSubscription{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// EnqueueDeliveries queues the event for every subscription listening to its
+// type. Re-enqueueing the same event id is a no-op.
+func (r *PostgreSQLWebhookRepository) EnqueueDeliveries(tx *gorm.DB, eventId, eventType, payload string) error {
+	query := `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, created_at, updated_at)
+		SELECT id, @eventId, @eventType, @payload, @status, 0, NOW(), NOW(), NOW()
+		FROM webhook_subscriptions
+		WHERE @eventType = ANY(event_types)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING`
+	err := tx.Exec(query, map[string]interface{}{
+		"eventId":   eventId,
+		"eventType": eventType,
+		"payload":   payload,
+		"status":    entity.WebhookDeliveryPending,
+	}).Error
+	return err
+}
+
+// ClaimDueDeliveries locks a batch of due deliveries and pushes their next
+// attempt to leaseUntil, so concurrent dispatchers skip them while they are
+// in flight and a crashed dispatcher's batch is retried after the lease.
+func (r *PostgreSQLWebhookRepository) ClaimDueDeliveries(now, leaseUntil time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	var deliveryIds []int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.WebhookDelivery{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", entity.WebhookDeliveryPending, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Pluck("id", &deliveryIds).Error
+		if err != nil || len(deliveryIds) == 0 {
+			return err
+		}
+		return tx.Model(&entity.WebhookDelivery{}).
+			Where("id IN ?", deliveryIds).
+			Update("next_attempt_at", leaseUntil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	var deliveries []*entity.WebhookDelivery
+	if len(deliveryIds) == 0 {
+		return deliveries, nil
+	}
+	err = r.db.Model(&entity.WebhookDelivery{}).
+		Preload("Subscription").
+		Where("id IN ?", deliveryIds).
+		Order("id ASC").
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *PostgreSQLWebhookRepository) UpdateDeliveryResult(delivery *entity.WebhookDelivery) error {
+	err := r.db.Model(&entity.WebhookDelivery{}).
+		Where("id = ?", delivery.Id).
+		Updates(map[string]interface{}{
+			"status":          delivery.Status,
+			"attempts":        delivery.Attempts,
+			"next_attempt_at": delivery.NextAttemptAt,
+			"response_status": delivery.ResponseStatus,
+			"last_error":      delivery.LastError,
+			"delivered_at":    delivery.DeliveredAt,
+			"updated_at":      time.Now(),
+		}).Error
+	return err
+}
+
+func (r *PostgreSQLWebhookRepository) GetDeliveries(subscriptionId int64, limit, offset int) ([]*entity.WebhookDelivery, error) {
+	var deliveries []*entity.WebhookDelivery
+	err := r.db.Model(&entity.WebhookDelivery{}).
+		Where("subscription_id = ?", subscriptionId).
+		Order("id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *PostgreSQLWebhookRepository) CountDeliveries(subscriptionId int64) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.WebhookDelivery{}).
+		Where("subscription_id = ?", subscriptionId).
+		Count(&count).Error
+	return count, err
+}
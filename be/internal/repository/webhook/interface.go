@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=interface.go -destination=../mock/mock_webhook_repository.go
+
+type WebhookRepository interface {
+	GetDB() *gorm.DB
+	CreateSubscription(subscription *entity.WebhookSubscription) error
+	GetSubscriptions() ([]*entity.WebhookSubscription, error)
+	GetSubscriptionById(subscriptionId int64) (*entity.WebhookSubscription, error)
+	DeleteSubscription(subscriptionId int64) error
+	EnqueueDeliveries(tx *gorm.DB, eventId, eventType, payload string) error
+	ClaimDueDeliveries(now, leaseUntil time.Time, limit int) ([]*entity.WebhookDelivery, error)
+	UpdateDeliveryResult(delivery *entity.WebhookDelivery) error
+	GetDeliveries(subscriptionId int64, limit, offset int) ([]*entity.WebhookDelivery, error)
+	CountDeliveries(subscriptionId int64) (int64, error)
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newWebhookTestRepository(t *testing.T) (WebhookRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	return NewWebhookRepository(gormDB), mock
+}
+
+func TestPostgreSQLWebhookRepository_CreateSubscription(t *testing.T) {
+	repo, mock := newWebhookTestRepository(t)
+
+	t.Run("successful creation", func(t *testing.T) {
+		subscription := &entity.WebhookSubscription{
+			Url:        "https://example.com/hook",
+			Secret:     "secret",
+			EventTypes: pq.StringArray{entity.EventFriendshipCreated},
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "webhook_subscriptions" \("url","secret","event_types","created_at"\)`).
+			WithArgs("https://example.com/hook", "secret", sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+		mock.ExpectCommit()
+
+		err := repo.CreateSubscription(subscription)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), subscription.Id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLWebhookRepository_GetSubscriptionById(t *testing.T) {
+	repo, mock := newWebhookTestRepository(t)
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "webhook_subscriptions" WHERE id = \$1`).
+			WithArgs(int64(3), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "url", "secret", "event_types"}).
+				AddRow(3, "https://example.com/hook", "secret", "{friendship.created,block.created}"))
+
+		subscription, err := repo.GetSubscriptionById(3)
+		assert.NoError(t, err)
+		assert.Equal(t, pq.StringArray{entity.EventFriendshipCreated, entity.EventBlockCreated}, subscription.EventTypes)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "webhook_subscriptions" WHERE id = \$1`).
+			WithArgs(int64(4), 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		subscription, err := repo.GetSubscriptionById(4)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		assert.Nil(t, subscription)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLWebhookRepository_DeleteSubscription(t *testing.T) {
+	repo, mock := newWebhookTestRepository(t)
+
+	t.Run("successful deletion", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "webhook_subscriptions" WHERE id = \$1`).
+			WithArgs(int64(3)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.DeleteSubscription(3)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "webhook_subscriptions" WHERE id = \$1`).
+			WithArgs(int64(4)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := repo.DeleteSubscription(4)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLWebhookRepository_EnqueueDeliveries(t *testing.T) {
+	repo, mock := newWebhookTestRepository(t)
+
+	t.Run("successful enqueue", func(t *testing.T) {
+		mock.ExpectExec(`INSERT INTO webhook_deliveries .* SELECT id, \$1, \$2, \$3, \$4, 0, NOW\(\), NOW\(\), NOW\(\) FROM webhook_subscriptions WHERE \$5 = ANY\(event_types\) ON CONFLICT \(subscription_id, event_id\) DO NOTHING`).
+			WithArgs("evt_1", entity.EventBlockCreated, `{"id":"evt_1"}`, entity.WebhookDeliveryPending, entity.EventBlockCreated).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := repo.EnqueueDeliveries(repo.GetDB(), "evt_1", entity.EventBlockCreated, `{"id":"evt_1"}`)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectExec(`INSERT INTO webhook_deliveries`).
+			WillReturnError(assert.AnError)
+
+		err := repo.EnqueueDeliveries(repo.GetDB(), "evt_1", entity.EventBlockCreated, `{"id":"evt_1"}`)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLWebhookRepository_ClaimDueDeliveries(t *testing.T) {
+	repo, mock := newWebhookTestRepository(t)
+	now := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+	leaseUntil := now.Add(time.Minute)
+
+	t.Run("claims and loads due deliveries", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT "id" FROM "webhook_deliveries" WHERE status = \$1 AND next_attempt_at <= \$2 ORDER BY next_attempt_at ASC LIMIT \$3 FOR UPDATE SKIP LOCKED`).
+			WithArgs(entity.WebhookDeliveryPending, now, 50).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+		mock.ExpectExec(`UPDATE "webhook_deliveries" SET "next_attempt_at"=\$1,"updated_at"=\$2 WHERE id IN \(\$3,\$4\)`).
+			WithArgs(leaseUntil, sqlmock.AnyArg(), int64(1), int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+		mock.ExpectQuery(`SELECT \* FROM "webhook_deliveries" WHERE id IN \(\$1,\$2\) ORDER BY id ASC`).
+			WithArgs(int64(1), int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "subscription_id", "event_id", "status"}).
+				AddRow(1, 3, "evt_1", entity.WebhookDeliveryPending).
+				AddRow(2, 3, "evt_2", entity.WebhookDeliveryPending))
+		mock.ExpectQuery(`SELECT \* FROM "webhook_subscriptions" WHERE "webhook_subscriptions"."id" = \$1`).
+			WithArgs(int64(3)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "url", "secret"}).AddRow(3, "https://example.com/hook", "secret"))
+
+		deliveries, err := repo.ClaimDueDeliveries(now, leaseUntil, 50)
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 2)
+		assert.Equal(t, "https://example.com/hook", deliveries[1].Subscription.Url)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nothing due", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT "id" FROM "webhook_deliveries"`).
+			WithArgs(entity.WebhookDeliveryPending, now, 50).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectCommit()
+
+		deliveries, err := repo.ClaimDueDeliveries(now, leaseUntil, 50)
+		assert.NoError(t, err)
+		assert.Empty(t, deliveries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT "id" FROM "webhook_deliveries"`).
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		deliveries, err := repo.ClaimDueDeliveries(now, leaseUntil, 50)
+		assert.Error(t, err)
+		assert.Nil(t, deliveries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLWebhookRepository_UpdateDeliveryResult(t *testing.T) {
+	repo, mock := newWebhookTestRepository(t)
+
+	t.Run("successful update", func(t *testing.T) {
+		nextAttemptAt := time.Date(2025, 8, 1, 10, 0, 30, 0, time.UTC)
+		delivery := &entity.WebhookDelivery{
+			Id:             1,
+			Status:         entity.WebhookDeliveryPending,
+			Attempts:       1,
+			NextAttemptAt:  nextAttemptAt,
+			ResponseStatus: 500,
+			LastError:      "unexpected status 500",
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "webhook_deliveries" SET .* WHERE id = \$8`).
+			WithArgs(1, nil, "unexpected status 500", nextAttemptAt, 500, entity.WebhookDeliveryPending, sqlmock.AnyArg(), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.UpdateDeliveryResult(delivery)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPostgreSQLWebhookRepository_GetDeliveries(t *testing.T) {
+	repo, mock := newWebhookTestRepository(t)
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "webhook_deliveries" WHERE subscription_id = \$1 ORDER BY id DESC LIMIT \$2 OFFSET \$3`).
+			WithArgs(int64(3), 20, 20).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "subscription_id", "event_id", "status"}).
+				AddRow(2, 3, "evt_2", entity.WebhookDeliverySucceeded))
+
+		deliveries, err := repo.GetDeliveries(3, 20, 20)
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 1)
+		assert.Equal(t, entity.WebhookDeliverySucceeded, deliveries[0].Status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("count", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "webhook_deliveries" WHERE subscription_id = \$1`).
+			WithArgs(int64(3)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(21))
+
+		count, err := repo.CountDeliveries(3)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(21), count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
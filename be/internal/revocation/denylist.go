@@ -0,0 +1,160 @@
+package revocation
+
+import (
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/entity"
+	revocationRepository "BE_Friends_Management/internal/repository/revocation"
+	"BE_Friends_Management/pkg/utils"
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type userRevocation struct {
+	revokedAt time.Time
+	expiresAt time.Time
+}
+
+// Denylist keeps the revoked access tokens in memory, so that checking a
+// token on every request costs no query. Revocations are persisted first and
+// Run loads the ones made by other replicas.
+type Denylist struct {
+	repo  revocationRepository.RevocationRepository
+	mu    sync.RWMutex
+	jtis  map[string]time.Time
+	users map[int64]userRevocation
+}
+
+func NewDenylist(repo revocationRepository.RevocationRepository) *Denylist {
+	return &Denylist{
+		repo:  repo,
+		jtis:  make(map[string]time.Time),
+		users: make(map[int64]userRevocation),
+	}
+}
+
+// RevokeToken revokes a single access token until it expires.
+func (d *Denylist) RevokeToken(jti string, expiresAt time.Time) error {
+	err := d.repo.CreateRevocation(&entity.AccessTokenRevocation{
+		Jti:       &jti,
+		RevokedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.addToken(jti, expiresAt)
+	return nil
+}
+
+// RevokeUser revokes every access token issued to the user so far, until the
+// last of them expires. Tokens issued afterwards, e.g. by logging in again,
+// are not affected.
+func (d *Denylist) RevokeUser(userId int64) error {
+	now := time.Now()
+	revocation := &entity.AccessTokenRevocation{
+		UserId:    &userId,
+		RevokedAt: now,
+		ExpiresAt: now.Add(utils.AccessTokenExpiredTime),
+	}
+	err := d.repo.CreateRevocation(revocation)
+	if err != nil {
+		return err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.addUser(userId, revocation.RevokedAt, revocation.ExpiresAt)
+	return nil
+}
+
+// IsRevoked reports whether the access token has been revoked. User
+// revocations are compared in milliseconds, and a token issued within the
+// millisecond of the revocation is revoked. Tokens issued before iat_ms
+// existed are compared in seconds and those issued before jti and iat
+// existed are revoked along with their user.
+func (d *Denylist) IsRevoked(claims *utils.Claims) bool {
+	now := time.Now()
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if expiresAt, ok := d.jtis[claims.ID]; ok && claims.ID != "" && expiresAt.After(now) {
+		return true
+	}
+	revoked, ok := d.users[claims.UserId]
+	if !ok || !revoked.expiresAt.After(now) {
+		return false
+	}
+	if claims.IssuedAtMs > 0 {
+		return claims.IssuedAtMs <= revoked.revokedAt.UnixMilli()
+	}
+	return claims.IssuedAt == nil || claims.IssuedAt.Unix() <= revoked.revokedAt.Unix()
+}
+
+// Sync loads the revocations made by every replica and drops the expired
+// ones, from memory and from the database.
+func (d *Denylist) Sync() error {
+	now := time.Now()
+	revocations, err := d.repo.GetActiveRevocations(now)
+	if err != nil {
+		return err
+	}
+	d.mu.Lock()
+	// Revocations are only ever added, so merging cannot lose one made by
+	// this replica while the query ran.
+	for _, revocation := range revocations {
+		if revocation.Jti != nil {
+			d.addToken(*revocation.Jti, revocation.ExpiresAt)
+		}
+		if revocation.UserId != nil {
+			d.addUser(*revocation.UserId, revocation.RevokedAt, revocation.ExpiresAt)
+		}
+	}
+	for jti, expiresAt := range d.jtis {
+		if !expiresAt.After(now) {
+			delete(d.jtis, jti)
+		}
+	}
+	for userId, revoked := range d.users {
+		if !revoked.expiresAt.After(now) {
+			delete(d.users, userId)
+		}
+	}
+	d.mu.Unlock()
+	_, err = d.repo.DeleteExpiredRevocations(now)
+	return err
+}
+
+// Run syncs the denylist right away and then every RevocationSyncInterval
+// until ctx is done.
+func (d *Denylist) Run(ctx context.Context) {
+	ticker := time.NewTicker(constant.RevocationSyncInterval)
+	defer ticker.Stop()
+	for {
+		if err := d.Sync(); err != nil {
+			log.Error("Happened error when syncing access token revocations. Error: ", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Denylist) addToken(jti string, expiresAt time.Time) {
+	if expiresAt.After(d.jtis[jti]) {
+		d.jtis[jti] = expiresAt
+	}
+}
+
+// addUser keeps the latest revocation of the user, which covers the earlier
+// ones.
+func (d *Denylist) addUser(userId int64, revokedAt, expiresAt time.Time) {
+	revoked, ok := d.users[userId]
+	if !ok || revokedAt.After(revoked.revokedAt) {
+		d.users[userId] = userRevocation{revokedAt: revokedAt, expiresAt: expiresAt}
+	}
+}
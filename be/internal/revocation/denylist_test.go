@@ -0,0 +1,170 @@
+package revocation
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	mock "BE_Friends_Management/internal/repository/mock"
+	"BE_Friends_Management/pkg/utils"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newClaims(userId int64, jti string, issuedAt time.Time) *utils.Claims {
+	return &utils.Claims{
+		UserId:     userId,
+		IssuedAtMs: issuedAt.UnixMilli(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(issuedAt.Add(time.Hour)),
+		},
+	}
+}
+
+func TestDenylist_RevokeToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRevocationRepository(ctrl)
+
+	t.Run("revokes only that token", func(t *testing.T) {
+		denylist := NewDenylist(mockRepo)
+		mockRepo.EXPECT().CreateRevocation(gomock.Any()).Return(nil)
+
+		err := denylist.RevokeToken("jti-1", time.Now().Add(time.Hour))
+
+		assert.NoError(t, err)
+		assert.True(t, denylist.IsRevoked(newClaims(1, "jti-1", time.Now())))
+		assert.False(t, denylist.IsRevoked(newClaims(1, "jti-2", time.Now())))
+	})
+
+	t.Run("not revoked when it cannot be persisted", func(t *testing.T) {
+		denylist := NewDenylist(mockRepo)
+		mockRepo.EXPECT().CreateRevocation(gomock.Any()).Return(errors.New("database error"))
+
+		err := denylist.RevokeToken("jti-1", time.Now().Add(time.Hour))
+
+		assert.Error(t, err)
+		assert.False(t, denylist.IsRevoked(newClaims(1, "jti-1", time.Now())))
+	})
+}
+
+func TestDenylist_RevokeUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRevocationRepository(ctrl)
+
+	t.Run("revokes the tokens issued before", func(t *testing.T) {
+		denylist := NewDenylist(mockRepo)
+		mockRepo.EXPECT().CreateRevocation(gomock.Any()).Return(nil)
+
+		err := denylist.RevokeUser(1)
+
+		assert.NoError(t, err)
+		assert.True(t, denylist.IsRevoked(newClaims(1, "jti-1", time.Now().Add(-time.Minute))))
+		assert.False(t, denylist.IsRevoked(newClaims(1, "jti-2", time.Now().Add(time.Minute))))
+		assert.False(t, denylist.IsRevoked(newClaims(2, "jti-3", time.Now().Add(-time.Minute))))
+	})
+
+	t.Run("tells apart tokens issued a millisecond before and after", func(t *testing.T) {
+		denylist := NewDenylist(mockRepo)
+		var revokedAt time.Time
+		mockRepo.EXPECT().CreateRevocation(gomock.Any()).DoAndReturn(func(revocation *entity.AccessTokenRevocation) error {
+			revokedAt = revocation.RevokedAt
+			return nil
+		})
+
+		err := denylist.RevokeUser(1)
+
+		assert.NoError(t, err)
+		assert.False(t, denylist.IsRevoked(newClaims(1, "jti-1", revokedAt.Add(time.Millisecond))))
+		assert.True(t, denylist.IsRevoked(newClaims(1, "jti-2", revokedAt.Add(-time.Millisecond))))
+	})
+
+	t.Run("compares synced revocations in milliseconds", func(t *testing.T) {
+		denylist := NewDenylist(mockRepo)
+		revokedAt := time.Date(2026, 1, 1, 10, 0, 0, 500_000_000, time.UTC)
+		denylist.addUser(1, revokedAt, time.Now().Add(time.Hour))
+
+		assert.False(t, denylist.IsRevoked(newClaims(1, "jti-1", revokedAt.Add(100*time.Millisecond))))
+		assert.True(t, denylist.IsRevoked(newClaims(1, "jti-2", revokedAt.Add(-100*time.Millisecond))))
+	})
+
+	t.Run("compares tokens without iat_ms in seconds", func(t *testing.T) {
+		denylist := NewDenylist(mockRepo)
+		revokedAt := time.Date(2026, 1, 1, 10, 0, 0, 500_000_000, time.UTC)
+		denylist.addUser(1, revokedAt, time.Now().Add(time.Hour))
+		sameSecond := newClaims(1, "jti-1", revokedAt.Add(100*time.Millisecond))
+		sameSecond.IssuedAtMs = 0
+		nextSecond := newClaims(1, "jti-2", revokedAt.Add(time.Second))
+		nextSecond.IssuedAtMs = 0
+
+		assert.True(t, denylist.IsRevoked(sameSecond))
+		assert.False(t, denylist.IsRevoked(nextSecond))
+	})
+
+	t.Run("revokes tokens without iat", func(t *testing.T) {
+		denylist := NewDenylist(mockRepo)
+		mockRepo.EXPECT().CreateRevocation(gomock.Any()).Return(nil)
+
+		err := denylist.RevokeUser(1)
+
+		assert.NoError(t, err)
+		assert.True(t, denylist.IsRevoked(&utils.Claims{UserId: 1}))
+	})
+}
+
+func TestDenylist_Sync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRevocationRepository(ctrl)
+
+	t.Run("loads revocations of other replicas", func(t *testing.T) {
+		denylist := NewDenylist(mockRepo)
+		jti := "jti-1"
+		userId := int64(2)
+		now := time.Now()
+		mockRepo.EXPECT().GetActiveRevocations(gomock.Any()).Return([]*entity.AccessTokenRevocation{
+			{Id: 1, Jti: &jti, RevokedAt: now, ExpiresAt: now.Add(time.Hour)},
+			{Id: 2, UserId: &userId, RevokedAt: now, ExpiresAt: now.Add(time.Hour)},
+		}, nil)
+		mockRepo.EXPECT().DeleteExpiredRevocations(gomock.Any()).Return(int64(0), nil)
+
+		err := denylist.Sync()
+
+		assert.NoError(t, err)
+		assert.True(t, denylist.IsRevoked(newClaims(1, "jti-1", now)))
+		assert.True(t, denylist.IsRevoked(newClaims(2, "jti-2", now.Add(-time.Minute))))
+	})
+
+	t.Run("drops expired revocations", func(t *testing.T) {
+		denylist := NewDenylist(mockRepo)
+		mockRepo.EXPECT().CreateRevocation(gomock.Any()).Return(nil)
+		assert.NoError(t, denylist.RevokeToken("jti-1", time.Now().Add(-time.Second)))
+		mockRepo.EXPECT().GetActiveRevocations(gomock.Any()).Return(nil, nil)
+		mockRepo.EXPECT().DeleteExpiredRevocations(gomock.Any()).Return(int64(1), nil)
+
+		err := denylist.Sync()
+
+		assert.NoError(t, err)
+		assert.Empty(t, denylist.jtis)
+	})
+
+	t.Run("repository error keeps the revocations", func(t *testing.T) {
+		denylist := NewDenylist(mockRepo)
+		mockRepo.EXPECT().CreateRevocation(gomock.Any()).Return(nil)
+		assert.NoError(t, denylist.RevokeToken("jti-1", time.Now().Add(time.Hour)))
+		mockRepo.EXPECT().GetActiveRevocations(gomock.Any()).Return(nil, errors.New("database error"))
+
+		err := denylist.Sync()
+
+		assert.Error(t, err)
+		assert.True(t, denylist.IsRevoked(newClaims(1, "jti-1", time.Now())))
+	})
+}
@@ -12,13 +12,18 @@ var (
 	ErrRefreshTokenIsRevoked = errors.New("refresh token is revoked")
 	ErrRefreshTokenExpires   = errors.New("refresh token has expired")
 	ErrInvalidSigningMethod  = errors.New("unexpected signing method")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrSessionNotFound       = errors.New("session not found")
 )
 
 //go:generate mockgen -source=interface.go -destination=../mock/mock_auth_service.go
 
 type AuthService interface {
 	RegisterUser(email, password string) (*entity.User, error)
-	Login(email, password string) (string, string, error)
+	Login(email, password, userAgent, ipAddress string) (string, string, error)
 	RefreshAccessToken(rawRefreshToken string) (string, string, error)
-	Logout(rawRefreshToken string) error
+	Logout(rawRefreshToken, rawAccessToken string) error
+	GetSessions(userId int64) ([]*entity.UserToken, error)
+	RevokeSession(userId, sessionId int64) error
+	LogoutAll(userId int64) error
 }
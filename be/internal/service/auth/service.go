@@ -1,14 +1,17 @@
 package service
 
 import (
+	"BE_Friends_Management/constant"
 	"BE_Friends_Management/internal/domain/entity"
 	authRepository "BE_Friends_Management/internal/repository/auth"
 	usersRepository "BE_Friends_Management/internal/repository/users"
+	"BE_Friends_Management/internal/revocation"
 	"BE_Friends_Management/pkg/utils"
 	"errors"
 	"strings"
 	"time"
 
+	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 
 	"golang.org/x/crypto/bcrypt"
@@ -17,10 +20,11 @@ import (
 type authService struct {
 	repo     authRepository.AuthRepository
 	userRepo usersRepository.UserRepository
+	denylist *revocation.Denylist
 }
 
-func NewAuthService(repo authRepository.AuthRepository, userRepo usersRepository.UserRepository) AuthService {
-	return &authService{repo: repo, userRepo: userRepo}
+func NewAuthService(repo authRepository.AuthRepository, userRepo usersRepository.UserRepository, denylist *revocation.Denylist) AuthService {
+	return &authService{repo: repo, userRepo: userRepo, denylist: denylist}
 }
 
 func (service *authService) RegisterUser(email, password string) (*entity.User, error) {
@@ -39,7 +43,7 @@ func (service *authService) RegisterUser(email, password string) (*entity.User,
 	return newUser, nil
 }
 
-func (service *authService) Login(email, password string) (string, string, error) {
+func (service *authService) Login(email, password, userAgent, ipAddress string) (string, string, error) {
 	user, err := service.userRepo.GetUserByEmail(email)
 	if err != nil {
 		return "", "", ErrInvalidLoginRequest
@@ -61,6 +65,9 @@ func (service *authService) Login(email, password string) (string, string, error
 	tokenRecord := &entity.UserToken{
 		UserId:       user.Id,
 		RefreshToken: refreshToken,
+		FamilyId:     utils.NewTokenFamilyId(),
+		UserAgent:    truncateUserAgent(userAgent),
+		IpAddress:    ipAddress,
 		ExpiresAt:    refreshTokenExpiredTime,
 		IsRevoked:    false,
 	}
@@ -71,6 +78,11 @@ func (service *authService) Login(email, password string) (string, string, error
 	return accessToken, refreshToken, nil
 }
 
+// RefreshAccessToken rotates the refresh token: it is revoked and replaced
+// by a new member of its family. A revoked token being presented again means
+// it leaked, so the whole family is revoked and both the thief and the user
+// have to log in again. The role is read again from the user, who may have
+// been deleted since the login.
 func (service *authService) RefreshAccessToken(rawRefreshToken string) (string, string, error) {
 	userToken, err := service.repo.FindByRefreshToken(rawRefreshToken)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -80,7 +92,7 @@ func (service *authService) RefreshAccessToken(rawRefreshToken string) (string,
 		return "", "", err
 	}
 	if userToken.IsRevoked {
-		return "", "", ErrRefreshTokenIsRevoked
+		return "", "", service.revokeReusedTokenFamily(userToken)
 	}
 	claims, err := utils.ParseRefreshToken(rawRefreshToken)
 	if errors.Is(err, utils.ErrInvalidRefreshToken) {
@@ -95,22 +107,41 @@ func (service *authService) RefreshAccessToken(rawRefreshToken string) (string,
 	if claims.ExpiresAt.Time.Before(time.Now()) {
 		return "", "", ErrRefreshTokenExpires
 	}
-	accessToken, err := utils.GenerateAccessToken(userToken.UserId, claims.Role, time.Now().Add(utils.AccessTokenExpiredTime))
+	user, err := service.userRepo.GetUserById(userToken.UserId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", "", ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return "", "", err
+	}
+	revoked, err := service.repo.SetRefreshTokenIsRevoked(rawRefreshToken)
 	if err != nil {
 		return "", "", err
 	}
-	err = service.repo.SetRefreshTokenIsRevoked(rawRefreshToken)
+	if !revoked {
+		// A concurrent request rotated the same token first.
+		return "", "", service.revokeReusedTokenFamily(userToken)
+	}
+	accessToken, err := utils.GenerateAccessToken(user.Id, user.Role, time.Now().Add(utils.AccessTokenExpiredTime))
 	if err != nil {
 		return "", "", err
 	}
 	refreshTokenExpiredTime := time.Now().Add(utils.RefreshTokenExpiredTime)
-	refreshToken, err := utils.GenerateRefreshToken(userToken.Id, claims.Role, refreshTokenExpiredTime)
+	refreshToken, err := utils.GenerateRefreshToken(user.Id, user.Role, refreshTokenExpiredTime)
 	if err != nil {
 		return "", "", err
 	}
+	familyId := userToken.FamilyId
+	if familyId == "" {
+		// Tokens issued before families existed start one when rotated.
+		familyId = utils.NewTokenFamilyId()
+	}
 	tokenRecord := &entity.UserToken{
-		UserId:       userToken.Id,
+		UserId:       userToken.UserId,
 		RefreshToken: refreshToken,
+		FamilyId:     familyId,
+		UserAgent:    userToken.UserAgent,
+		IpAddress:    userToken.IpAddress,
 		ExpiresAt:    refreshTokenExpiredTime,
 		IsRevoked:    false,
 	}
@@ -121,7 +152,9 @@ func (service *authService) RefreshAccessToken(rawRefreshToken string) (string,
 	return accessToken, refreshToken, nil
 }
 
-func (service *authService) Logout(rawRefreshToken string) error {
+// Logout revokes the refresh token and, when given, the access token sent
+// along with it so that it stops working at once.
+func (service *authService) Logout(rawRefreshToken, rawAccessToken string) error {
 	userToken, err := service.repo.FindByRefreshToken(rawRefreshToken)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return ErrInvalidRefreshToken
@@ -130,7 +163,7 @@ func (service *authService) Logout(rawRefreshToken string) error {
 		return err
 	}
 	if userToken.IsRevoked {
-		return ErrRefreshTokenIsRevoked
+		return service.revokeReusedTokenFamily(userToken)
 	}
 	claims, err := utils.ParseRefreshToken(rawRefreshToken)
 	if errors.Is(err, utils.ErrInvalidRefreshToken) {
@@ -145,9 +178,94 @@ func (service *authService) Logout(rawRefreshToken string) error {
 	if claims.ExpiresAt.Time.Before(time.Now()) {
 		return ErrRefreshTokenExpires
 	}
-	err = service.repo.SetRefreshTokenIsRevoked(rawRefreshToken)
+	revoked, err := service.repo.SetRefreshTokenIsRevoked(rawRefreshToken)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return service.revokeReusedTokenFamily(userToken)
+	}
+	if rawAccessToken == "" {
+		return nil
+	}
+	accessClaims, err := utils.ParseAccessToken(rawAccessToken)
+	if err != nil || accessClaims.UserId != userToken.UserId || accessClaims.ID == "" {
+		// An unusable access token has nothing left to revoke.
+		return nil
+	}
+	return service.denylist.RevokeToken(accessClaims.ID, accessClaims.ExpiresAt.Time)
+}
+
+// revokeReusedTokenFamily logs the reuse of a revoked refresh token as a
+// security event and revokes every token of its family. It returns
+// ErrRefreshTokenIsRevoked unless the family could not be revoked.
+func (service *authService) revokeReusedTokenFamily(userToken *entity.UserToken) error {
+	log.WithFields(log.Fields{
+		"security_event": "refresh_token_reuse",
+		"user_id":        userToken.UserId,
+		"token_id":       userToken.Id,
+		"family_id":      userToken.FamilyId,
+	}).Warn("Revoked refresh token was reused, revoking its token family")
+	if userToken.FamilyId == "" {
+		// Tokens issued before families existed only revoke themselves.
+		return ErrRefreshTokenIsRevoked
+	}
+	err := service.repo.RevokeTokenFamily(userToken.FamilyId)
+	if err != nil {
+		return err
+	}
+	return ErrRefreshTokenIsRevoked
+}
+
+// GetSessions returns the user's live sessions, one per active refresh token.
+func (service *authService) GetSessions(userId int64) ([]*entity.UserToken, error) {
+	err := service.checkUserExists(userId)
+	if err != nil {
+		return nil, err
+	}
+	return service.repo.GetActiveTokensByUserId(userId, time.Now())
+}
+
+// RevokeSession ends the session the token belongs to. The token may already
+// have been rotated, so its whole family is revoked.
+func (service *authService) RevokeSession(userId, sessionId int64) error {
+	userToken, err := service.repo.GetTokenById(userId, sessionId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if userToken.FamilyId == "" {
+		return service.repo.RevokeTokenById(userToken.Id)
+	}
+	return service.repo.RevokeTokenFamily(userToken.FamilyId)
+}
+
+// LogoutAll revokes every refresh token and every access token of the user.
+func (service *authService) LogoutAll(userId int64) error {
+	err := service.checkUserExists(userId)
+	if err != nil {
+		return err
+	}
+	err = service.repo.RevokeUserTokens(userId)
 	if err != nil {
 		return err
 	}
-	return nil
+	return service.denylist.RevokeUser(userId)
+}
+
+func (service *authService) checkUserExists(userId int64) error {
+	_, err := service.userRepo.GetUserById(userId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUserNotFound
+	}
+	return err
+}
+
+func truncateUserAgent(userAgent string) string {
+	if len(userAgent) <= constant.MaxUserAgentLength {
+		return userAgent
+	}
+	return strings.ToValidUTF8(userAgent[:constant.MaxUserAgentLength], "")
 }
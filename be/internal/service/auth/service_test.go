@@ -0,0 +1,378 @@
+package service
+
+import (
+	"BE_Friends_Management/config"
+	entity "BE_Friends_Management/internal/domain/entity"
+	mock "BE_Friends_Management/internal/repository/mock"
+	"BE_Friends_Management/internal/revocation"
+	"BE_Friends_Management/pkg/utils"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+func newRefreshToken(t *testing.T, userId int64) string {
+	config.RefreshSecret = "test-refresh-secret"
+	config.AccessSecret = "test-access-secret"
+	refreshToken, err := utils.GenerateRefreshToken(userId, "user", time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	return refreshToken
+}
+
+func TestAuthService_Login(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockAuthRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockRevocationRepo := mock.NewMockRevocationRepository(ctrl)
+	service := NewAuthService(mockRepo, mockUserRepo, revocation.NewDenylist(mockRevocationRepo))
+	newRefreshToken(t, 7)
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	assert.NoError(t, err)
+	user := &entity.User{Id: 7, Email: "user@example.com", Password: string(hashedPassword), Role: "user"}
+
+	t.Run("success starts a session", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(user, nil)
+		mockRepo.EXPECT().CreateToken(gomock.Any()).DoAndReturn(func(token *entity.UserToken) error {
+			assert.Equal(t, int64(7), token.UserId)
+			assert.NotEmpty(t, token.FamilyId)
+			assert.Equal(t, "curl/8.0", token.UserAgent)
+			assert.Equal(t, "10.0.0.1", token.IpAddress)
+			return nil
+		})
+
+		accessToken, refreshToken, err := service.Login("user@example.com", "secret", "curl/8.0", "10.0.0.1")
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, accessToken)
+		assert.NotEmpty(t, refreshToken)
+	})
+
+	t.Run("long user agent is truncated", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(user, nil)
+		mockRepo.EXPECT().CreateToken(gomock.Any()).DoAndReturn(func(token *entity.UserToken) error {
+			assert.Len(t, token.UserAgent, 512)
+			return nil
+		})
+
+		_, _, err := service.Login("user@example.com", "secret", strings.Repeat("a", 600), "10.0.0.1")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(user, nil)
+
+		_, _, err := service.Login("user@example.com", "wrong", "curl/8.0", "10.0.0.1")
+
+		assert.ErrorIs(t, err, ErrInvalidLoginRequest)
+	})
+}
+
+func TestAuthService_RefreshAccessToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockAuthRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockRevocationRepo := mock.NewMockRevocationRepository(ctrl)
+	service := NewAuthService(mockRepo, mockUserRepo, revocation.NewDenylist(mockRevocationRepo))
+
+	t.Run("success keeps the token family", func(t *testing.T) {
+		raw := newRefreshToken(t, 7)
+		userToken := &entity.UserToken{Id: 3, UserId: 7, RefreshToken: raw, FamilyId: "family-1", UserAgent: "curl/8.0", IpAddress: "10.0.0.1"}
+
+		mockRepo.EXPECT().FindByRefreshToken(raw).Return(userToken, nil)
+		mockUserRepo.EXPECT().GetUserById(int64(7)).Return(&entity.User{Id: 7, Role: "admin"}, nil)
+		mockRepo.EXPECT().SetRefreshTokenIsRevoked(raw).Return(true, nil)
+		mockRepo.EXPECT().CreateToken(gomock.Any()).DoAndReturn(func(token *entity.UserToken) error {
+			assert.Equal(t, int64(7), token.UserId)
+			assert.Equal(t, "family-1", token.FamilyId)
+			assert.Equal(t, "curl/8.0", token.UserAgent)
+			assert.Equal(t, "10.0.0.1", token.IpAddress)
+			assert.False(t, token.IsRevoked)
+			return nil
+		})
+
+		accessToken, refreshToken, err := service.RefreshAccessToken(raw)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, accessToken)
+		assert.NotEmpty(t, refreshToken)
+		claims, err := utils.ParseAccessToken(accessToken)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), claims.UserId)
+		assert.Equal(t, "admin", claims.Role)
+		assert.NotEmpty(t, claims.ID)
+	})
+
+	t.Run("deleted user", func(t *testing.T) {
+		raw := newRefreshToken(t, 7)
+		userToken := &entity.UserToken{Id: 3, UserId: 7, RefreshToken: raw, FamilyId: "family-1"}
+
+		mockRepo.EXPECT().FindByRefreshToken(raw).Return(userToken, nil)
+		mockUserRepo.EXPECT().GetUserById(int64(7)).Return(nil, gorm.ErrRecordNotFound)
+
+		_, _, err := service.RefreshAccessToken(raw)
+
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	})
+
+	t.Run("legacy token starts a new family", func(t *testing.T) {
+		raw := newRefreshToken(t, 7)
+		userToken := &entity.UserToken{Id: 3, UserId: 7, RefreshToken: raw}
+
+		mockRepo.EXPECT().FindByRefreshToken(raw).Return(userToken, nil)
+		mockUserRepo.EXPECT().GetUserById(int64(7)).Return(&entity.User{Id: 7, Role: "admin"}, nil)
+		mockRepo.EXPECT().SetRefreshTokenIsRevoked(raw).Return(true, nil)
+		mockRepo.EXPECT().CreateToken(gomock.Any()).DoAndReturn(func(token *entity.UserToken) error {
+			assert.NotEmpty(t, token.FamilyId)
+			return nil
+		})
+
+		_, _, err := service.RefreshAccessToken(raw)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockRepo.EXPECT().FindByRefreshToken("unknown").Return(nil, gorm.ErrRecordNotFound)
+
+		_, _, err := service.RefreshAccessToken("unknown")
+
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	})
+
+	t.Run("reused token revokes the family", func(t *testing.T) {
+		raw := newRefreshToken(t, 7)
+		userToken := &entity.UserToken{Id: 3, UserId: 7, RefreshToken: raw, FamilyId: "family-1", IsRevoked: true}
+
+		mockRepo.EXPECT().FindByRefreshToken(raw).Return(userToken, nil)
+		mockRepo.EXPECT().RevokeTokenFamily("family-1").Return(nil)
+
+		_, _, err := service.RefreshAccessToken(raw)
+
+		assert.ErrorIs(t, err, ErrRefreshTokenIsRevoked)
+	})
+
+	t.Run("reused legacy token does not revoke other tokens", func(t *testing.T) {
+		raw := newRefreshToken(t, 7)
+		userToken := &entity.UserToken{Id: 3, UserId: 7, RefreshToken: raw, IsRevoked: true}
+
+		mockRepo.EXPECT().FindByRefreshToken(raw).Return(userToken, nil)
+
+		_, _, err := service.RefreshAccessToken(raw)
+
+		assert.ErrorIs(t, err, ErrRefreshTokenIsRevoked)
+	})
+
+	t.Run("concurrent rotation revokes the family", func(t *testing.T) {
+		raw := newRefreshToken(t, 7)
+		userToken := &entity.UserToken{Id: 3, UserId: 7, RefreshToken: raw, FamilyId: "family-1"}
+
+		mockRepo.EXPECT().FindByRefreshToken(raw).Return(userToken, nil)
+		mockUserRepo.EXPECT().GetUserById(int64(7)).Return(&entity.User{Id: 7, Role: "user"}, nil)
+		mockRepo.EXPECT().SetRefreshTokenIsRevoked(raw).Return(false, nil)
+		mockRepo.EXPECT().RevokeTokenFamily("family-1").Return(nil)
+
+		_, _, err := service.RefreshAccessToken(raw)
+
+		assert.ErrorIs(t, err, ErrRefreshTokenIsRevoked)
+	})
+
+	t.Run("family revoke error", func(t *testing.T) {
+		raw := newRefreshToken(t, 7)
+		userToken := &entity.UserToken{Id: 3, UserId: 7, RefreshToken: raw, FamilyId: "family-1", IsRevoked: true}
+		expectedError := errors.New("database error")
+
+		mockRepo.EXPECT().FindByRefreshToken(raw).Return(userToken, nil)
+		mockRepo.EXPECT().RevokeTokenFamily("family-1").Return(expectedError)
+
+		_, _, err := service.RefreshAccessToken(raw)
+
+		assert.Equal(t, expectedError, err)
+	})
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockAuthRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockRevocationRepo := mock.NewMockRevocationRepository(ctrl)
+	service := NewAuthService(mockRepo, mockUserRepo, revocation.NewDenylist(mockRevocationRepo))
+
+	t.Run("success", func(t *testing.T) {
+		raw := newRefreshToken(t, 7)
+		userToken := &entity.UserToken{Id: 3, UserId: 7, RefreshToken: raw, FamilyId: "family-1"}
+
+		mockRepo.EXPECT().FindByRefreshToken(raw).Return(userToken, nil)
+		mockRepo.EXPECT().SetRefreshTokenIsRevoked(raw).Return(true, nil)
+
+		err := service.Logout(raw, "")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("revokes the access token", func(t *testing.T) {
+		raw := newRefreshToken(t, 7)
+		accessToken, err := utils.GenerateAccessToken(7, "user", time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		claims, err := utils.ParseAccessToken(accessToken)
+		assert.NoError(t, err)
+		userToken := &entity.UserToken{Id: 3, UserId: 7, RefreshToken: raw, FamilyId: "family-1"}
+
+		mockRepo.EXPECT().FindByRefreshToken(raw).Return(userToken, nil)
+		mockRepo.EXPECT().SetRefreshTokenIsRevoked(raw).Return(true, nil)
+		mockRevocationRepo.EXPECT().CreateRevocation(gomock.Any()).DoAndReturn(func(revocation *entity.AccessTokenRevocation) error {
+			assert.Equal(t, claims.ID, *revocation.Jti)
+			assert.Nil(t, revocation.UserId)
+			return nil
+		})
+
+		err = service.Logout(raw, accessToken)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("ignores the access token of another user", func(t *testing.T) {
+		raw := newRefreshToken(t, 7)
+		accessToken, err := utils.GenerateAccessToken(8, "user", time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		userToken := &entity.UserToken{Id: 3, UserId: 7, RefreshToken: raw, FamilyId: "family-1"}
+
+		mockRepo.EXPECT().FindByRefreshToken(raw).Return(userToken, nil)
+		mockRepo.EXPECT().SetRefreshTokenIsRevoked(raw).Return(true, nil)
+
+		err = service.Logout(raw, accessToken)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("reused token revokes the family", func(t *testing.T) {
+		raw := newRefreshToken(t, 7)
+		userToken := &entity.UserToken{Id: 3, UserId: 7, RefreshToken: raw, FamilyId: "family-1", IsRevoked: true}
+
+		mockRepo.EXPECT().FindByRefreshToken(raw).Return(userToken, nil)
+		mockRepo.EXPECT().RevokeTokenFamily("family-1").Return(nil)
+
+		err := service.Logout(raw, "")
+
+		assert.ErrorIs(t, err, ErrRefreshTokenIsRevoked)
+	})
+}
+
+func TestAuthService_GetSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockAuthRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockRevocationRepo := mock.NewMockRevocationRepository(ctrl)
+	service := NewAuthService(mockRepo, mockUserRepo, revocation.NewDenylist(mockRevocationRepo))
+
+	t.Run("success", func(t *testing.T) {
+		expectedTokens := []*entity.UserToken{{Id: 4, UserId: 7}, {Id: 3, UserId: 7}}
+		mockUserRepo.EXPECT().GetUserById(int64(7)).Return(&entity.User{Id: 7}, nil)
+		mockRepo.EXPECT().GetActiveTokensByUserId(int64(7), gomock.Any()).Return(expectedTokens, nil)
+
+		userTokens, err := service.GetSessions(7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedTokens, userTokens)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserById(int64(8)).Return(nil, gorm.ErrRecordNotFound)
+
+		userTokens, err := service.GetSessions(8)
+
+		assert.ErrorIs(t, err, ErrUserNotFound)
+		assert.Nil(t, userTokens)
+	})
+}
+
+func TestAuthService_RevokeSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockAuthRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockRevocationRepo := mock.NewMockRevocationRepository(ctrl)
+	service := NewAuthService(mockRepo, mockUserRepo, revocation.NewDenylist(mockRevocationRepo))
+
+	t.Run("revokes the family", func(t *testing.T) {
+		mockRepo.EXPECT().GetTokenById(int64(7), int64(3)).Return(&entity.UserToken{Id: 3, UserId: 7, FamilyId: "family-1"}, nil)
+		mockRepo.EXPECT().RevokeTokenFamily("family-1").Return(nil)
+
+		err := service.RevokeSession(7, 3)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("legacy token is revoked alone", func(t *testing.T) {
+		mockRepo.EXPECT().GetTokenById(int64(7), int64(3)).Return(&entity.UserToken{Id: 3, UserId: 7}, nil)
+		mockRepo.EXPECT().RevokeTokenById(int64(3)).Return(nil)
+
+		err := service.RevokeSession(7, 3)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("session of another user", func(t *testing.T) {
+		mockRepo.EXPECT().GetTokenById(int64(7), int64(9)).Return(nil, gorm.ErrRecordNotFound)
+
+		err := service.RevokeSession(7, 9)
+
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+	})
+}
+
+func TestAuthService_LogoutAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockAuthRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockRevocationRepo := mock.NewMockRevocationRepository(ctrl)
+	service := NewAuthService(mockRepo, mockUserRepo, revocation.NewDenylist(mockRevocationRepo))
+
+	t.Run("success", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserById(int64(7)).Return(&entity.User{Id: 7}, nil)
+		mockRepo.EXPECT().RevokeUserTokens(int64(7)).Return(nil)
+		mockRevocationRepo.EXPECT().CreateRevocation(gomock.Any()).DoAndReturn(func(revocation *entity.AccessTokenRevocation) error {
+			assert.Equal(t, int64(7), *revocation.UserId)
+			return nil
+		})
+
+		err := service.LogoutAll(7)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserById(int64(8)).Return(nil, gorm.ErrRecordNotFound)
+
+		err := service.LogoutAll(8)
+
+		assert.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		expectedError := errors.New("database error")
+		mockUserRepo.EXPECT().GetUserById(int64(7)).Return(&entity.User{Id: 7}, nil)
+		mockRepo.EXPECT().RevokeUserTokens(int64(7)).Return(expectedError)
+
+		err := service.LogoutAll(7)
+
+		assert.Equal(t, expectedError, err)
+	})
+}
@@ -1,17 +1,27 @@
 package service
 
-import "errors"
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"context"
+	"errors"
+)
 
 var (
-	ErrUserNotFound   = errors.New("user not found")
-	ErrAlreadyBlocked = errors.New("requestor has already blocked this target user")
-	ErrInvalidRequest = errors.New("two email can not be the same")
-	ErrNotSubscribed  = errors.New("can not block if they are friends and have not subscribed")
-	ErrNotPermitted   = errors.New("action not permitted")
+	ErrUserNotFound    = errors.New("user not found")
+	ErrAlreadyBlocked  = errors.New("requestor has already blocked this target user")
+	ErrNotBlocked      = errors.New("requestor has not blocked this target user")
+	ErrInvalidRequest  = errors.New("two email can not be the same")
+	ErrNotSubscribed   = errors.New("can not block if they are friends and have not subscribed")
+	ErrNotPermitted    = errors.New("action not permitted")
+	ErrInvalidDuration = errors.New("block duration must be a positive duration such as 30m or 8h")
 )
 
 //go:generate mockgen -source=interface.go -destination=../mock/mock_block_service.go
 
 type BlockRelationshipService interface {
-	CreateBlockRelationship(authUserId int64, requestorEmail, targetEmail string) error
+	CreateBlockRelationship(authUserId int64, requestorEmail, targetEmail, duration string) error
+	DeleteBlockRelationship(authUserId int64, authUserRole string, requestorEmail, targetEmail string) error
+	RetrieveBlockedUsers(authUserId int64, authUserRole string, email string, limit, offset int) ([]*entity.BlockRelationship, int64, error)
+	SweepExpiredBlocks(ctx context.Context) (int, error)
+	Run(ctx context.Context)
 }
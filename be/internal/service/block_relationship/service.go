@@ -1,13 +1,20 @@
 package service
 
 import (
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/entity"
+	"BE_Friends_Management/internal/realtime"
 	blockRelationshipRepository "BE_Friends_Management/internal/repository/block_relationship"
 	friendshipRepository "BE_Friends_Management/internal/repository/friendship"
 	subscriptionRepository "BE_Friends_Management/internal/repository/subscription"
 	userRepository "BE_Friends_Management/internal/repository/users"
+	outboxService "BE_Friends_Management/internal/service/outbox"
+	"context"
 	"errors"
 	"strings"
+	"time"
 
+	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
@@ -16,18 +23,34 @@ type blockRelationshipService struct {
 	userRepo         userRepository.UserRepository
 	friendshipRepo   friendshipRepository.FriendshipRepository
 	subscriptionRepo subscriptionRepository.SubscriptionRepository
+	publisher        realtime.Publisher
+	recorder         outboxService.EventRecorder
 }
 
-func NewBlockRelationshipService(repo blockRelationshipRepository.BlockRelationshipRepository, userRepo userRepository.UserRepository, friendshipRepo friendshipRepository.FriendshipRepository, subscriptionRepo subscriptionRepository.SubscriptionRepository) BlockRelationshipService {
+func NewBlockRelationshipService(repo blockRelationshipRepository.BlockRelationshipRepository, userRepo userRepository.UserRepository, friendshipRepo friendshipRepository.FriendshipRepository, subscriptionRepo subscriptionRepository.SubscriptionRepository, publisher realtime.Publisher, recorder outboxService.EventRecorder) BlockRelationshipService {
 	return &blockRelationshipService{
 		repo:             repo,
 		userRepo:         userRepo,
 		friendshipRepo:   friendshipRepo,
 		subscriptionRepo: subscriptionRepo,
+		publisher:        publisher,
+		recorder:         recorder,
 	}
 }
 
-func (service *blockRelationshipService) CreateBlockRelationship(authUserId int64, requestorEmail, targetEmail string) error {
+// CreateBlockRelationship blocks target for requestor until unblocked. A
+// non-empty duration such as "8h" makes the block temporary; it lifts itself
+// once it expires.
+func (service *blockRelationshipService) CreateBlockRelationship(authUserId int64, requestorEmail, targetEmail, duration string) error {
+	var expiresAt *time.Time
+	if duration != "" {
+		parsedDuration, err := time.ParseDuration(duration)
+		if err != nil || parsedDuration <= 0 {
+			return ErrInvalidDuration
+		}
+		expiry := time.Now().Add(parsedDuration)
+		expiresAt = &expiry
+	}
 	requestor, err := service.userRepo.GetUserByEmail(requestorEmail)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return ErrUserNotFound
@@ -61,6 +84,7 @@ func (service *blockRelationshipService) CreateBlockRelationship(authUserId int6
 	if (errSubscription != nil) && !errors.Is(errSubscription, gorm.ErrRecordNotFound) {
 		return errSubscription
 	}
+	replaced := false
 	db := service.repo.GetDB()
 	err = db.Transaction(func(tx *gorm.DB) error {
 		if errFriendship == nil && errSubscription != nil {
@@ -72,14 +96,164 @@ func (service *blockRelationshipService) CreateBlockRelationship(authUserId int6
 				return err
 			}
 		}
-		err := service.repo.CreateBlockRelationship(tx, requestor.Id, target.Id)
+		// An expired block the sweeper has not reached yet is lifted here
+		// with the same events the sweeper would emit.
+		var err error
+		replaced, err = service.repo.DeleteExpiredBlockRelationship(tx, requestor.Id, target.Id, time.Now())
+		if err != nil {
+			return err
+		}
+		if replaced {
+			err = service.recorder.Record(tx, entity.EventBlockDeleted, entity.RelationshipEventData{Requestor: requestor.Email, Target: target.Email})
+			if err != nil {
+				return err
+			}
+		}
+		err = service.repo.CreateBlockRelationship(tx, requestor.Id, target.Id, expiresAt)
 		if err != nil && strings.Contains(err.Error(), "duplicate key") {
 			return ErrAlreadyBlocked
 		}
 		if err != nil {
 			return err
 		}
+		return service.recorder.Record(tx, entity.EventBlockCreated, entity.RelationshipEventData{Requestor: requestor.Email, Target: target.Email})
+	})
+	if err != nil {
+		return err
+	}
+	if replaced {
+		service.publishBlockEvent(realtime.EventUnblock, requestor, target)
+	}
+	service.publishBlockEvent(realtime.EventBlock, requestor, target)
+	return nil
+}
+
+func (service *blockRelationshipService) DeleteBlockRelationship(authUserId int64, authUserRole string, requestorEmail, targetEmail string) error {
+	requestor, err := service.userRepo.GetUserByEmail(requestorEmail)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if authUserRole == "user" && authUserId != requestor.Id {
+		return ErrNotPermitted
+	}
+	target, err := service.userRepo.GetUserByEmail(targetEmail)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if requestor.Id == target.Id {
+		return ErrInvalidRequest
+	}
+	_, err = service.repo.GetBlockRelationship(requestor.Id, target.Id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotBlocked
+	}
+	if err != nil {
+		return err
+	}
+	db := service.repo.GetDB()
+	err = db.Transaction(func(tx *gorm.DB) error {
+		err := service.repo.DeleteBlockRelationship(tx, requestor.Id, target.Id)
+		if err != nil {
+			return err
+		}
+		return service.recorder.Record(tx, entity.EventBlockDeleted, entity.RelationshipEventData{Requestor: requestor.Email, Target: target.Email})
+	})
+	if err != nil {
+		return err
+	}
+	service.publishBlockEvent(realtime.EventUnblock, requestor, target)
+	return nil
+}
+
+func (service *blockRelationshipService) RetrieveBlockedUsers(authUserId int64, authUserRole string, email string, limit, offset int) ([]*entity.BlockRelationship, int64, error) {
+	requestor, err := service.userRepo.GetUserByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, 0, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	if authUserRole == "user" && authUserId != requestor.Id {
+		return nil, 0, ErrNotPermitted
+	}
+	blockRelationships, err := service.repo.GetBlockRelationshipsByRequestor(requestor.Id, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	count, err := service.repo.CountBlockRelationshipsByRequestor(requestor.Id)
+	if err != nil {
+		return nil, 0, err
+	}
+	return blockRelationships, count, nil
+}
+
+// SweepExpiredBlocks deletes one batch of expired blocks and emits the same
+// events as an explicit unblock. It returns the number of blocks deleted.
+func (service *blockRelationshipService) SweepExpiredBlocks(ctx context.Context) (int, error) {
+	var expired []*entity.BlockRelationship
+	db := service.repo.GetDB()
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		expired, err = service.repo.GetExpiredBlockRelationships(tx, time.Now(), constant.BlockSweepBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, blockRelationship := range expired {
+			err := service.repo.DeleteBlockRelationship(tx, blockRelationship.RequestorId, blockRelationship.TargetId)
+			if err != nil {
+				return err
+			}
+			err = service.recorder.Record(tx, entity.EventBlockDeleted, entity.RelationshipEventData{Requestor: blockRelationship.Requestor.Email, Target: blockRelationship.Target.Email})
+			if err != nil {
+				return err
+			}
+		}
 		return nil
 	})
-	return err
+	if err != nil {
+		return 0, err
+	}
+	for _, blockRelationship := range expired {
+		service.publishBlockEvent(realtime.EventUnblock, blockRelationship.Requestor, blockRelationship.Target)
+	}
+	return len(expired), nil
+}
+
+// Run sweeps expired blocks until ctx is cancelled. A full batch is followed
+// immediately by the next one instead of waiting for the ticker.
+func (service *blockRelationshipService) Run(ctx context.Context) {
+	ticker := time.NewTicker(constant.BlockSweepInterval)
+	defer ticker.Stop()
+	for {
+		swept, err := service.SweepExpiredBlocks(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Error("Happened error when sweeping expired blocks. Error: ", err)
+		}
+		if err == nil && swept == constant.BlockSweepBatchSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publishBlockEvent only notifies the requestor's own connections so the
+// blocked user is never told about the block.
+func (service *blockRelationshipService) publishBlockEvent(eventType string, requestor, target *entity.User) {
+	blockRelationship := &entity.BlockRelationship{
+		RequestorId: requestor.Id,
+		TargetId:    target.Id,
+		Requestor:   requestor,
+		Target:      target,
+	}
+	service.publisher.Publish(requestor.Id, realtime.Event{Type: eventType, Payload: blockRelationship})
 }
@@ -1,10 +1,15 @@
 package service
 
 import (
+	"BE_Friends_Management/constant"
 	"BE_Friends_Management/internal/domain/entity"
+	"BE_Friends_Management/internal/realtime"
 	mock "BE_Friends_Management/internal/repository/mock"
+	serviceMock "BE_Friends_Management/internal/service/mock"
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/golang/mock/gomock"
@@ -31,12 +36,18 @@ func TestBlockRelationshipService_CreateBlockRelationship(t *testing.T) {
 
 	mockBlockRepo.EXPECT().GetDB().Return(gormDB).AnyTimes()
 
-	service := NewBlockRelationshipService(mockBlockRepo, mockUserRepo, mockFriendshipRepo, mockSubscriptionRepo)
+	hub := realtime.NewHub(constant.StreamBufferSize)
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewBlockRelationshipService(mockBlockRepo, mockUserRepo, mockFriendshipRepo, mockSubscriptionRepo, hub, mockRecorder)
 
 	t.Run("Success_NoFriendship_NoSubscription", func(t *testing.T) {
 		authUserId := int64(1)
 		user1 := &entity.User{Id: 1, Email: "user1@example.com"}
 		user2 := &entity.User{Id: 2, Email: "user2@example.com"}
+		requestorSubscription := hub.Subscribe(1)
+		defer hub.Unsubscribe(requestorSubscription)
+		targetSubscription := hub.Subscribe(2)
+		defer hub.Unsubscribe(targetSubscription)
 
 		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
 		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
@@ -44,11 +55,18 @@ func TestBlockRelationshipService_CreateBlockRelationship(t *testing.T) {
 		mockSubscriptionRepo.EXPECT().GetSubscription(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
 
 		mockSQL.ExpectBegin()
-		mockBlockRepo.EXPECT().CreateBlockRelationship(gomock.Any(), int64(1), int64(2)).Return(nil)
+		mockBlockRepo.EXPECT().DeleteExpiredBlockRelationship(gomock.Any(), int64(1), int64(2), gomock.Any()).Return(false, nil)
+		mockBlockRepo.EXPECT().CreateBlockRelationship(gomock.Any(), int64(1), int64(2), nil).Return(nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventBlockCreated, entity.RelationshipEventData{Requestor: "user1@example.com", Target: "user2@example.com"}).Return(nil)
 		mockSQL.ExpectCommit()
 
-		err := service.CreateBlockRelationship(authUserId, "user1@example.com", "user2@example.com")
+		err := service.CreateBlockRelationship(authUserId, "user1@example.com", "user2@example.com", "")
 		assert.NoError(t, err)
+
+		event := <-requestorSubscription.Events
+		assert.Equal(t, realtime.EventBlock, event.Type)
+		assert.Equal(t, int64(2), event.Payload.(*entity.BlockRelationship).TargetId)
+		assert.Len(t, targetSubscription.Events, 0)
 	})
 
 	t.Run("Success_HasFriendship_HasSubscription", func(t *testing.T) {
@@ -63,10 +81,12 @@ func TestBlockRelationshipService_CreateBlockRelationship(t *testing.T) {
 
 		mockSQL.ExpectBegin()
 		mockSubscriptionRepo.EXPECT().DeleteSubscription(gomock.Any(), int64(1), int64(2)).Return(nil)
-		mockBlockRepo.EXPECT().CreateBlockRelationship(gomock.Any(), int64(1), int64(2)).Return(nil)
+		mockBlockRepo.EXPECT().DeleteExpiredBlockRelationship(gomock.Any(), int64(1), int64(2), gomock.Any()).Return(false, nil)
+		mockBlockRepo.EXPECT().CreateBlockRelationship(gomock.Any(), int64(1), int64(2), nil).Return(nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventBlockCreated, entity.RelationshipEventData{Requestor: "user1@example.com", Target: "user2@example.com"}).Return(nil)
 		mockSQL.ExpectCommit()
 
-		err := service.CreateBlockRelationship(authUserId, "user1@example.com", "user2@example.com")
+		err := service.CreateBlockRelationship(authUserId, "user1@example.com", "user2@example.com", "")
 		assert.NoError(t, err)
 	})
 
@@ -82,10 +102,12 @@ func TestBlockRelationshipService_CreateBlockRelationship(t *testing.T) {
 
 		mockSQL.ExpectBegin()
 		mockSubscriptionRepo.EXPECT().DeleteSubscription(gomock.Any(), int64(1), int64(2)).Return(nil)
-		mockBlockRepo.EXPECT().CreateBlockRelationship(gomock.Any(), int64(1), int64(2)).Return(nil)
+		mockBlockRepo.EXPECT().DeleteExpiredBlockRelationship(gomock.Any(), int64(1), int64(2), gomock.Any()).Return(false, nil)
+		mockBlockRepo.EXPECT().CreateBlockRelationship(gomock.Any(), int64(1), int64(2), nil).Return(nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventBlockCreated, entity.RelationshipEventData{Requestor: "user1@example.com", Target: "user2@example.com"}).Return(nil)
 		mockSQL.ExpectCommit()
 
-		err := service.CreateBlockRelationship(authUserId, "user1@example.com", "user2@example.com")
+		err := service.CreateBlockRelationship(authUserId, "user1@example.com", "user2@example.com", "")
 		assert.NoError(t, err)
 	})
 
@@ -93,7 +115,7 @@ func TestBlockRelationshipService_CreateBlockRelationship(t *testing.T) {
 		authUserId := int64(1)
 		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(nil, gorm.ErrRecordNotFound)
 
-		err := service.CreateBlockRelationship(authUserId, "user1@example.com", "user2@example.com")
+		err := service.CreateBlockRelationship(authUserId, "user1@example.com", "user2@example.com", "")
 		assert.Equal(t, ErrUserNotFound, err)
 	})
 
@@ -104,7 +126,7 @@ func TestBlockRelationshipService_CreateBlockRelationship(t *testing.T) {
 		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
 		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(nil, gorm.ErrRecordNotFound)
 
-		err := service.CreateBlockRelationship(authUserId, "user1@example.com", "user2@example.com")
+		err := service.CreateBlockRelationship(authUserId, "user1@example.com", "user2@example.com", "")
 		assert.Equal(t, ErrUserNotFound, err)
 	})
 
@@ -114,7 +136,7 @@ func TestBlockRelationshipService_CreateBlockRelationship(t *testing.T) {
 
 		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil).Times(2)
 
-		err := service.CreateBlockRelationship(authUserId, "user1@example.com", "user1@example.com")
+		err := service.CreateBlockRelationship(authUserId, "user1@example.com", "user1@example.com", "")
 		assert.Equal(t, ErrInvalidRequest, err)
 	})
 
@@ -130,10 +152,11 @@ func TestBlockRelationshipService_CreateBlockRelationship(t *testing.T) {
 		mockSubscriptionRepo.EXPECT().GetSubscription(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
 
 		mockSQL.ExpectBegin()
-		mockBlockRepo.EXPECT().CreateBlockRelationship(gomock.Any(), int64(1), int64(2)).Return(duplicateErr)
+		mockBlockRepo.EXPECT().DeleteExpiredBlockRelationship(gomock.Any(), int64(1), int64(2), gomock.Any()).Return(false, nil)
+		mockBlockRepo.EXPECT().CreateBlockRelationship(gomock.Any(), int64(1), int64(2), nil).Return(duplicateErr)
 		mockSQL.ExpectRollback()
 
-		err := service.CreateBlockRelationship(authUserId, "user1@example.com", "user2@example.com")
+		err := service.CreateBlockRelationship(authUserId, "user1@example.com", "user2@example.com", "")
 		assert.Equal(t, ErrAlreadyBlocked, err)
 	})
 
@@ -150,7 +173,7 @@ func TestBlockRelationshipService_CreateBlockRelationship(t *testing.T) {
 		mockSQL.ExpectBegin()
 		mockSQL.ExpectRollback()
 
-		err := service.CreateBlockRelationship(authUserId, "user1@example.com", "user2@example.com")
+		err := service.CreateBlockRelationship(authUserId, "user1@example.com", "user2@example.com", "")
 		assert.Equal(t, ErrNotSubscribed, err)
 	})
 	t.Run("HasFriendship_NoSubscription_Error", func(t *testing.T) {
@@ -159,7 +182,275 @@ func TestBlockRelationshipService_CreateBlockRelationship(t *testing.T) {
 
 		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(nil, dbErr)
 
-		err := service.CreateBlockRelationship(authUserId, "user1@example.com", "user2@example.com")
+		err := service.CreateBlockRelationship(authUserId, "user1@example.com", "user2@example.com", "")
 		assert.Equal(t, dbErr, err)
 	})
+
+	t.Run("Success_Temporary", func(t *testing.T) {
+		authUserId := int64(1)
+		user1 := &entity.User{Id: 1, Email: "user1@example.com"}
+		user2 := &entity.User{Id: 2, Email: "user2@example.com"}
+
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockFriendshipRepo.EXPECT().GetFriendship(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+		mockSubscriptionRepo.EXPECT().GetSubscription(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+
+		mockSQL.ExpectBegin()
+		before := time.Now()
+		mockBlockRepo.EXPECT().DeleteExpiredBlockRelationship(gomock.Any(), int64(1), int64(2), gomock.Any()).Return(false, nil)
+		mockBlockRepo.EXPECT().CreateBlockRelationship(gomock.Any(), int64(1), int64(2), gomock.Any()).
+			DoAndReturn(func(tx *gorm.DB, requestorId, targetId int64, expiresAt *time.Time) error {
+				assert.NotNil(t, expiresAt)
+				assert.WithinDuration(t, before.Add(8*time.Hour), *expiresAt, time.Minute)
+				return nil
+			})
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventBlockCreated, gomock.Any()).Return(nil)
+		mockSQL.ExpectCommit()
+
+		err := service.CreateBlockRelationship(authUserId, "user1@example.com", "user2@example.com", "8h")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Success_ReplacesExpiredBlock", func(t *testing.T) {
+		authUserId := int64(1)
+		user1 := &entity.User{Id: 1, Email: "user1@example.com"}
+		user2 := &entity.User{Id: 2, Email: "user2@example.com"}
+		requestorSubscription := hub.Subscribe(1)
+		defer hub.Unsubscribe(requestorSubscription)
+
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockFriendshipRepo.EXPECT().GetFriendship(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+		mockSubscriptionRepo.EXPECT().GetSubscription(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+
+		mockSQL.ExpectBegin()
+		mockBlockRepo.EXPECT().DeleteExpiredBlockRelationship(gomock.Any(), int64(1), int64(2), gomock.Any()).Return(true, nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventBlockDeleted, entity.RelationshipEventData{Requestor: "user1@example.com", Target: "user2@example.com"}).Return(nil)
+		mockBlockRepo.EXPECT().CreateBlockRelationship(gomock.Any(), int64(1), int64(2), nil).Return(nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventBlockCreated, entity.RelationshipEventData{Requestor: "user1@example.com", Target: "user2@example.com"}).Return(nil)
+		mockSQL.ExpectCommit()
+
+		err := service.CreateBlockRelationship(authUserId, "user1@example.com", "user2@example.com", "")
+		assert.NoError(t, err)
+
+		event := <-requestorSubscription.Events
+		assert.Equal(t, realtime.EventUnblock, event.Type)
+		event = <-requestorSubscription.Events
+		assert.Equal(t, realtime.EventBlock, event.Type)
+	})
+
+	t.Run("InvalidDuration", func(t *testing.T) {
+		for _, duration := range []string{"forever", "-1h", "0s"} {
+			err := service.CreateBlockRelationship(1, "user1@example.com", "user2@example.com", duration)
+			assert.Equal(t, ErrInvalidDuration, err)
+		}
+	})
+}
+
+func TestBlockRelationshipService_DeleteBlockRelationship(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockBlockRepo := mock.NewMockBlockRelationshipRepository(ctrl)
+	mockFriendshipRepo := mock.NewMockFriendshipRepository(ctrl)
+	mockSubscriptionRepo := mock.NewMockSubscriptionRepository(ctrl)
+
+	db, mockSQL, err := sqlmock.New()
+	assert.NoError(t, err)
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+	mockBlockRepo.EXPECT().GetDB().Return(gormDB).AnyTimes()
+	hub := realtime.NewHub(constant.StreamBufferSize)
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewBlockRelationshipService(mockBlockRepo, mockUserRepo, mockFriendshipRepo, mockSubscriptionRepo, hub, mockRecorder)
+
+	user1 := &entity.User{Id: 1, Email: "user1@example.com"}
+	user2 := &entity.User{Id: 2, Email: "user2@example.com"}
+
+	t.Run("Success_Requestor", func(t *testing.T) {
+		subscription := hub.Subscribe(1)
+		defer hub.Unsubscribe(subscription)
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockBlockRepo.EXPECT().GetBlockRelationship(int64(1), int64(2)).Return(&entity.BlockRelationship{}, nil)
+		mockSQL.ExpectBegin()
+		mockBlockRepo.EXPECT().DeleteBlockRelationship(gomock.Any(), int64(1), int64(2)).Return(nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventBlockDeleted, entity.RelationshipEventData{Requestor: "user1@example.com", Target: "user2@example.com"}).Return(nil)
+		mockSQL.ExpectCommit()
+
+		err := service.DeleteBlockRelationship(1, "user", "user1@example.com", "user2@example.com")
+		assert.NoError(t, err)
+
+		event := <-subscription.Events
+		assert.Equal(t, realtime.EventUnblock, event.Type)
+	})
+
+	t.Run("Success_Admin", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockBlockRepo.EXPECT().GetBlockRelationship(int64(1), int64(2)).Return(&entity.BlockRelationship{}, nil)
+		mockSQL.ExpectBegin()
+		mockBlockRepo.EXPECT().DeleteBlockRelationship(gomock.Any(), int64(1), int64(2)).Return(nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventBlockDeleted, entity.RelationshipEventData{Requestor: "user1@example.com", Target: "user2@example.com"}).Return(nil)
+		mockSQL.ExpectCommit()
+
+		err := service.DeleteBlockRelationship(99, "admin", "user1@example.com", "user2@example.com")
+		assert.NoError(t, err)
+	})
+
+	t.Run("TargetCanNotLiftBlock", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+
+		err := service.DeleteBlockRelationship(2, "user", "user1@example.com", "user2@example.com")
+		assert.Equal(t, ErrNotPermitted, err)
+	})
+
+	t.Run("NotBlocked", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockBlockRepo.EXPECT().GetBlockRelationship(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+
+		err := service.DeleteBlockRelationship(1, "user", "user1@example.com", "user2@example.com")
+		assert.Equal(t, ErrNotBlocked, err)
+	})
+
+	t.Run("SameUser", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil).Times(2)
+
+		err := service.DeleteBlockRelationship(1, "user", "user1@example.com", "user1@example.com")
+		assert.Equal(t, ErrInvalidRequest, err)
+	})
+}
+
+func TestBlockRelationshipService_RetrieveBlockedUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockBlockRepo := mock.NewMockBlockRelationshipRepository(ctrl)
+	mockFriendshipRepo := mock.NewMockFriendshipRepository(ctrl)
+	mockSubscriptionRepo := mock.NewMockSubscriptionRepository(ctrl)
+	hub := realtime.NewHub(constant.StreamBufferSize)
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewBlockRelationshipService(mockBlockRepo, mockUserRepo, mockFriendshipRepo, mockSubscriptionRepo, hub, mockRecorder)
+
+	user1 := &entity.User{Id: 1, Email: "user1@example.com"}
+
+	t.Run("Success", func(t *testing.T) {
+		expected := []*entity.BlockRelationship{{RequestorId: 1, TargetId: 2}}
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockBlockRepo.EXPECT().GetBlockRelationshipsByRequestor(int64(1), 20, 0).Return(expected, nil)
+		mockBlockRepo.EXPECT().CountBlockRelationshipsByRequestor(int64(1)).Return(int64(5), nil)
+
+		blockRelationships, count, err := service.RetrieveBlockedUsers(1, "user", "user1@example.com", 20, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, blockRelationships)
+		assert.Equal(t, int64(5), count)
+	})
+
+	t.Run("NotPermitted", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+
+		blockRelationships, count, err := service.RetrieveBlockedUsers(2, "user", "user1@example.com", 20, 0)
+		assert.Nil(t, blockRelationships)
+		assert.Equal(t, int64(0), count)
+		assert.Equal(t, ErrNotPermitted, err)
+	})
+
+	t.Run("UserNotFound", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+		_, _, err := service.RetrieveBlockedUsers(1, "user", "user1@example.com", 20, 0)
+		assert.Equal(t, ErrUserNotFound, err)
+	})
+
+	t.Run("RepositoryError", func(t *testing.T) {
+		repoErr := errors.New("database error")
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockBlockRepo.EXPECT().GetBlockRelationshipsByRequestor(int64(1), 20, 0).Return(nil, repoErr)
+
+		_, _, err := service.RetrieveBlockedUsers(1, "user", "user1@example.com", 20, 0)
+		assert.Equal(t, repoErr, err)
+	})
+}
+
+func TestBlockRelationshipService_SweepExpiredBlocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockBlockRepo := mock.NewMockBlockRelationshipRepository(ctrl)
+	mockFriendshipRepo := mock.NewMockFriendshipRepository(ctrl)
+	mockSubscriptionRepo := mock.NewMockSubscriptionRepository(ctrl)
+
+	db, mockSQL, err := sqlmock.New()
+	assert.NoError(t, err)
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+	mockBlockRepo.EXPECT().GetDB().Return(gormDB).AnyTimes()
+	hub := realtime.NewHub(constant.StreamBufferSize)
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewBlockRelationshipService(mockBlockRepo, mockUserRepo, mockFriendshipRepo, mockSubscriptionRepo, hub, mockRecorder)
+
+	user1 := &entity.User{Id: 1, Email: "user1@example.com"}
+	user2 := &entity.User{Id: 2, Email: "user2@example.com"}
+
+	t.Run("Success", func(t *testing.T) {
+		requestorSubscription := hub.Subscribe(1)
+		defer hub.Unsubscribe(requestorSubscription)
+		targetSubscription := hub.Subscribe(2)
+		defer hub.Unsubscribe(targetSubscription)
+		expired := []*entity.BlockRelationship{{RequestorId: 1, TargetId: 2, Requestor: user1, Target: user2}}
+
+		mockSQL.ExpectBegin()
+		mockBlockRepo.EXPECT().GetExpiredBlockRelationships(gomock.Any(), gomock.Any(), constant.BlockSweepBatchSize).Return(expired, nil)
+		mockBlockRepo.EXPECT().DeleteBlockRelationship(gomock.Any(), int64(1), int64(2)).Return(nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventBlockDeleted, entity.RelationshipEventData{Requestor: "user1@example.com", Target: "user2@example.com"}).Return(nil)
+		mockSQL.ExpectCommit()
+
+		swept, err := service.SweepExpiredBlocks(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, swept)
+
+		event := <-requestorSubscription.Events
+		assert.Equal(t, realtime.EventUnblock, event.Type)
+		assert.Len(t, targetSubscription.Events, 0)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+	})
+
+	t.Run("NothingExpired", func(t *testing.T) {
+		mockSQL.ExpectBegin()
+		mockBlockRepo.EXPECT().GetExpiredBlockRelationships(gomock.Any(), gomock.Any(), constant.BlockSweepBatchSize).Return(nil, nil)
+		mockSQL.ExpectCommit()
+
+		swept, err := service.SweepExpiredBlocks(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, swept)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+	})
+
+	t.Run("RecordError_RollsBack", func(t *testing.T) {
+		subscription := hub.Subscribe(1)
+		defer hub.Unsubscribe(subscription)
+		recordErr := errors.New("outbox error")
+		expired := []*entity.BlockRelationship{{RequestorId: 1, TargetId: 2, Requestor: user1, Target: user2}}
+
+		mockSQL.ExpectBegin()
+		mockBlockRepo.EXPECT().GetExpiredBlockRelationships(gomock.Any(), gomock.Any(), constant.BlockSweepBatchSize).Return(expired, nil)
+		mockBlockRepo.EXPECT().DeleteBlockRelationship(gomock.Any(), int64(1), int64(2)).Return(nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventBlockDeleted, gomock.Any()).Return(recordErr)
+		mockSQL.ExpectRollback()
+
+		swept, err := service.SweepExpiredBlocks(context.Background())
+		assert.Equal(t, recordErr, err)
+		assert.Equal(t, 0, swept)
+		assert.Len(t, subscription.Events, 0)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+	})
 }
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"context"
+	"errors"
+)
+
+var (
+	ErrInvalidFrequency = errors.New("digest frequency must be off, daily or weekly")
+)
+
+//go:generate mockgen -source=interface.go -destination=../mock/mock_digest_service.go
+
+type DigestService interface {
+	GetPreference(authUserId int64) (*entity.DigestPreference, error)
+	SetFrequency(authUserId int64, frequency string) (*entity.DigestPreference, error)
+	SendDueDigests(ctx context.Context) (int, error)
+	Run(ctx context.Context)
+}
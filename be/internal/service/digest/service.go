@@ -0,0 +1,227 @@
+package service
+
+import (
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/entity"
+	"BE_Friends_Management/internal/notification/channel"
+	digestRepository "BE_Friends_Management/internal/repository/digest"
+	updateRepository "BE_Friends_Management/internal/repository/update"
+	userRepository "BE_Friends_Management/internal/repository/users"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type digestService struct {
+	repo       digestRepository.DigestRepository
+	userRepo   userRepository.UserRepository
+	updateRepo updateRepository.UpdateRepository
+	channel    channel.Channel
+}
+
+func NewDigestService(repo digestRepository.DigestRepository, userRepo userRepository.UserRepository, updateRepo updateRepository.UpdateRepository, channel channel.Channel) DigestService {
+	return &digestService{
+		repo:       repo,
+		userRepo:   userRepo,
+		updateRepo: updateRepo,
+		channel:    channel,
+	}
+}
+
+// GetPreference returns the digest preference of the user. Users who never
+// set one get digests off.
+func (service *digestService) GetPreference(authUserId int64) (*entity.DigestPreference, error) {
+	preference, err := service.repo.GetPreference(authUserId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.DigestPreference{UserId: authUserId, Frequency: entity.DigestOff}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return preference, nil
+}
+
+// SetFrequency stores how often the user gets digests. Turning digests on
+// moves the watermark to the newest feed item, so the first digest only
+// covers updates received from now on.
+func (service *digestService) SetFrequency(authUserId int64, frequency string) (*entity.DigestPreference, error) {
+	if !slices.Contains(entity.DigestFrequencies, frequency) {
+		return nil, ErrInvalidFrequency
+	}
+	preference, err := service.repo.GetPreference(authUserId)
+	isNew := errors.Is(err, gorm.ErrRecordNotFound)
+	if err != nil && !isNew {
+		return nil, err
+	}
+	if isNew {
+		preference = &entity.DigestPreference{UserId: authUserId, Frequency: entity.DigestOff}
+	}
+	if preference.Frequency == entity.DigestOff && frequency != entity.DigestOff {
+		latestDeliveryId, err := service.updateRepo.GetLatestDeliveryId(authUserId)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		preference.WatermarkDeliveryId = latestDeliveryId
+		preference.LastSentAt = &now
+	}
+	preference.Frequency = frequency
+	if isNew {
+		err = service.repo.CreatePreference(preference)
+	} else {
+		err = service.repo.UpdatePreference(preference)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return preference, nil
+}
+
+// SendDueDigests sends one batch of due digests and returns how many were
+// sent. The batch runs on a single connection holding a Postgres advisory
+// lock, so only one replica sends digests at a time; the others return 0
+// straight away. Each watermark is committed on its own right after its
+// digest is sent, so a later failure cannot roll it back and send the digest
+// again. A digest that fails to send keeps its watermark and is retried after
+// a backoff, leaving the head of the queue to the others.
+func (service *digestService) SendDueDigests(ctx context.Context) (int, error) {
+	now := time.Now()
+	sent := 0
+	db := service.repo.GetDB()
+	err := db.Connection(func(conn *gorm.DB) error {
+		locked, err := service.repo.TryAdvisoryLock(conn, constant.DigestAdvisoryLockKey)
+		if err != nil || !locked {
+			return err
+		}
+		defer func() {
+			err := service.repo.AdvisoryUnlock(conn, constant.DigestAdvisoryLockKey)
+			if err != nil {
+				log.Error("Happened error when releasing the digest lock. Error: ", err)
+			}
+		}()
+		preferences, err := service.repo.GetDuePreferences(conn, now.Add(-constant.DigestDailyPeriod), now.Add(-constant.DigestWeeklyPeriod), now, constant.DigestBatchSize)
+		if err != nil || len(preferences) == 0 {
+			return err
+		}
+		userIds := make([]int64, 0, len(preferences))
+		for _, preference := range preferences {
+			userIds = append(userIds, preference.UserId)
+		}
+		optOutUserIds, err := service.userRepo.GetEmailOptOutUserIds(userIds)
+		if err != nil {
+			return err
+		}
+		for _, preference := range preferences {
+			if ctx.Err() != nil {
+				return nil
+			}
+			optedOut := slices.Contains(optOutUserIds, preference.UserId)
+			watermark, err := service.sendDigest(preference, optedOut)
+			if err != nil {
+				log.Error("Happened error when sending the digest of user ", preference.UserId, ". Error: ", err)
+				failedAttempts := preference.FailedAttempts + 1
+				err = service.repo.MarkDigestFailed(conn, preference.UserId, failedAttempts, now.Add(retryBackoff(failedAttempts)))
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			err = service.repo.MarkDigestSent(conn, preference.UserId, watermark, now)
+			if err != nil {
+				return err
+			}
+			sent++
+		}
+		return nil
+	})
+	return sent, err
+}
+
+// Run sends due digests until ctx is cancelled. A full batch is followed
+// immediately by the next one instead of waiting for the ticker.
+func (service *digestService) Run(ctx context.Context) {
+	ticker := time.NewTicker(constant.DigestPollInterval)
+	defer ticker.Stop()
+	for {
+		sent, err := service.SendDueDigests(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Error("Happened error when sending digests. Error: ", err)
+		}
+		if err == nil && sent == constant.DigestBatchSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendDigest emails the feed items received since the watermark and returns
+// the new watermark. Empty digests and digests of opted-out users are not
+// sent, but still advance the watermark.
+func (service *digestService) sendDigest(preference *entity.DigestPreference, optedOut bool) (int64, error) {
+	deliveries, err := service.updateRepo.GetFeedSince(preference.UserId, preference.WatermarkDeliveryId, constant.DigestMaxUpdates)
+	if err != nil {
+		return 0, err
+	}
+	if len(deliveries) == 0 {
+		return preference.WatermarkDeliveryId, nil
+	}
+	watermark := deliveries[len(deliveries)-1].Id
+	hasMore := false
+	if len(deliveries) == constant.DigestMaxUpdates {
+		// Skip the rest rather than sending a backlog digest after digest.
+		latestDeliveryId, err := service.updateRepo.GetLatestDeliveryId(preference.UserId)
+		if err != nil {
+			return 0, err
+		}
+		if latestDeliveryId > watermark {
+			hasMore = true
+			watermark = latestDeliveryId
+		}
+	}
+	if optedOut || preference.User == nil {
+		return watermark, nil
+	}
+
+	data := channel.DigestTemplateData{Frequency: preference.Frequency, HasMore: hasMore}
+	for _, delivery := range deliveries {
+		if delivery.Update == nil || delivery.Update.Sender == nil {
+			continue
+		}
+		data.Updates = append(data.Updates, channel.DigestUpdate{
+			Sender:    delivery.Update.Sender.Email,
+			Text:      delivery.Update.Text,
+			CreatedAt: delivery.Update.CreatedAt,
+		})
+	}
+	if len(data.Updates) == 0 {
+		return watermark, nil
+	}
+	message, err := channel.Render(channel.TemplateDigest, data)
+	if err != nil {
+		return 0, err
+	}
+	message.To = preference.User.Email
+	err = service.channel.Send(message)
+	if err != nil {
+		return 0, err
+	}
+	return watermark, nil
+}
+
+// retryBackoff doubles the wait after every failed attempt, capped at
+// constant.DigestMaxBackoff.
+func retryBackoff(attempts int) time.Duration {
+	backoff := constant.DigestInitialBackoff
+	for i := 1; i < attempts && backoff < constant.DigestMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, constant.DigestMaxBackoff)
+}
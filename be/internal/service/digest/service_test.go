@@ -0,0 +1,358 @@
+package service
+
+import (
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/entity"
+	"BE_Friends_Management/internal/notification/channel"
+	mock "BE_Friends_Management/internal/repository/mock"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// failingChannel fails the messages sent to failFor and records the others.
+type failingChannel struct {
+	failFor  string
+	messages []channel.Message
+}
+
+func (c *failingChannel) Name() string {
+	return "email"
+}
+
+func (c *failingChannel) Send(message channel.Message) error {
+	if message.To == c.failFor {
+		return errors.New("smtp error")
+	}
+	c.messages = append(c.messages, message)
+	return nil
+}
+
+type recordingChannel struct {
+	err      error
+	messages []channel.Message
+}
+
+func (c *recordingChannel) Name() string {
+	return "email"
+}
+
+func (c *recordingChannel) Send(message channel.Message) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.messages = append(c.messages, message)
+	return nil
+}
+
+func newDelivery(id int64, sender, text string) *entity.UpdateDelivery {
+	return &entity.UpdateDelivery{
+		Id: id,
+		Update: &entity.Update{
+			Text:      text,
+			CreatedAt: time.Date(2025, 8, 7, 10, 0, 0, 0, time.UTC),
+			Sender:    &entity.User{Email: sender},
+		},
+	}
+}
+
+func TestDigestService_GetPreference(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockDigestRepository(ctrl)
+	service := NewDigestService(mockRepo, mock.NewMockUserRepository(ctrl), mock.NewMockUpdateRepository(ctrl), &recordingChannel{})
+
+	t.Run("Success", func(t *testing.T) {
+		preference := &entity.DigestPreference{UserId: 1, Frequency: entity.DigestWeekly}
+		mockRepo.EXPECT().GetPreference(int64(1)).Return(preference, nil)
+
+		result, err := service.GetPreference(1)
+		assert.NoError(t, err)
+		assert.Equal(t, preference, result)
+	})
+
+	t.Run("DefaultsToOff", func(t *testing.T) {
+		mockRepo.EXPECT().GetPreference(int64(1)).Return(nil, gorm.ErrRecordNotFound)
+
+		result, err := service.GetPreference(1)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.DigestOff, result.Frequency)
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		dbErr := errors.New("database error")
+		mockRepo.EXPECT().GetPreference(int64(1)).Return(nil, dbErr)
+
+		result, err := service.GetPreference(1)
+		assert.Nil(t, result)
+		assert.Equal(t, dbErr, err)
+	})
+}
+
+func TestDigestService_SetFrequency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockDigestRepository(ctrl)
+	mockUpdateRepo := mock.NewMockUpdateRepository(ctrl)
+	service := NewDigestService(mockRepo, mock.NewMockUserRepository(ctrl), mockUpdateRepo, &recordingChannel{})
+
+	t.Run("FirstTimeStartsAtLatestFeedItem", func(t *testing.T) {
+		mockRepo.EXPECT().GetPreference(int64(1)).Return(nil, gorm.ErrRecordNotFound)
+		mockUpdateRepo.EXPECT().GetLatestDeliveryId(int64(1)).Return(int64(12), nil)
+		mockRepo.EXPECT().CreatePreference(gomock.Any()).Return(nil)
+
+		preference, err := service.SetFrequency(1, entity.DigestDaily)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.DigestDaily, preference.Frequency)
+		assert.Equal(t, int64(12), preference.WatermarkDeliveryId)
+		assert.WithinDuration(t, time.Now(), *preference.LastSentAt, time.Second)
+	})
+
+	t.Run("ChangingFrequencyKeepsWatermark", func(t *testing.T) {
+		lastSentAt := time.Now().Add(-time.Hour)
+		existing := &entity.DigestPreference{UserId: 1, Frequency: entity.DigestDaily, WatermarkDeliveryId: 7, LastSentAt: &lastSentAt}
+		mockRepo.EXPECT().GetPreference(int64(1)).Return(existing, nil)
+		mockRepo.EXPECT().UpdatePreference(existing).Return(nil)
+
+		preference, err := service.SetFrequency(1, entity.DigestWeekly)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.DigestWeekly, preference.Frequency)
+		assert.Equal(t, int64(7), preference.WatermarkDeliveryId)
+		assert.Equal(t, &lastSentAt, preference.LastSentAt)
+	})
+
+	t.Run("TurningBackOnResetsWatermark", func(t *testing.T) {
+		existing := &entity.DigestPreference{UserId: 1, Frequency: entity.DigestOff, WatermarkDeliveryId: 3}
+		mockRepo.EXPECT().GetPreference(int64(1)).Return(existing, nil)
+		mockUpdateRepo.EXPECT().GetLatestDeliveryId(int64(1)).Return(int64(40), nil)
+		mockRepo.EXPECT().UpdatePreference(existing).Return(nil)
+
+		preference, err := service.SetFrequency(1, entity.DigestWeekly)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(40), preference.WatermarkDeliveryId)
+	})
+
+	t.Run("InvalidFrequency", func(t *testing.T) {
+		preference, err := service.SetFrequency(1, "hourly")
+		assert.Nil(t, preference)
+		assert.Equal(t, ErrInvalidFrequency, err)
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		dbErr := errors.New("database error")
+		mockRepo.EXPECT().GetPreference(int64(1)).Return(nil, dbErr)
+
+		preference, err := service.SetFrequency(1, entity.DigestDaily)
+		assert.Nil(t, preference)
+		assert.Equal(t, dbErr, err)
+	})
+}
+
+func TestDigestService_SendDueDigests(t *testing.T) {
+	user1 := &entity.User{Id: 1, Email: "user1@example.com"}
+	user2 := &entity.User{Id: 2, Email: "user2@example.com"}
+
+	setup := func(t *testing.T) (*gomock.Controller, sqlmock.Sqlmock, *mock.MockDigestRepository, *mock.MockUserRepository, *mock.MockUpdateRepository) {
+		ctrl := gomock.NewController(t)
+		db, mockSQL, err := sqlmock.New()
+		assert.NoError(t, err)
+		gormDB, err := gorm.Open(postgres.New(postgres.Config{
+			Conn: db,
+		}), &gorm.Config{})
+		assert.NoError(t, err)
+		mockRepo := mock.NewMockDigestRepository(ctrl)
+		mockRepo.EXPECT().GetDB().Return(gormDB).AnyTimes()
+		return ctrl, mockSQL, mockRepo, mock.NewMockUserRepository(ctrl), mock.NewMockUpdateRepository(ctrl)
+	}
+
+	t.Run("SendsDigestsAndAdvancesWatermarks", func(t *testing.T) {
+		ctrl, mockSQL, mockRepo, mockUserRepo, mockUpdateRepo := setup(t)
+		defer ctrl.Finish()
+		emailChannel := &recordingChannel{}
+		service := NewDigestService(mockRepo, mockUserRepo, mockUpdateRepo, emailChannel)
+
+		preferences := []*entity.DigestPreference{
+			{UserId: 1, Frequency: entity.DigestDaily, WatermarkDeliveryId: 4, User: user1},
+			{UserId: 2, Frequency: entity.DigestWeekly, WatermarkDeliveryId: 9, User: user2},
+		}
+		mockRepo.EXPECT().TryAdvisoryLock(gomock.Any(), int64(constant.DigestAdvisoryLockKey)).Return(true, nil)
+		mockRepo.EXPECT().AdvisoryUnlock(gomock.Any(), int64(constant.DigestAdvisoryLockKey)).Return(nil)
+		mockRepo.EXPECT().GetDuePreferences(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), constant.DigestBatchSize).
+			DoAndReturn(func(_ *gorm.DB, dailyBefore, weeklyBefore, now time.Time, _ int) ([]*entity.DigestPreference, error) {
+				assert.Equal(t, constant.DigestWeeklyPeriod-constant.DigestDailyPeriod, dailyBefore.Sub(weeklyBefore))
+				assert.Equal(t, constant.DigestDailyPeriod, now.Sub(dailyBefore))
+				return preferences, nil
+			})
+		mockUserRepo.EXPECT().GetEmailOptOutUserIds([]int64{1, 2}).Return(nil, nil)
+		mockUpdateRepo.EXPECT().GetFeedSince(int64(1), int64(4), constant.DigestMaxUpdates).
+			Return([]*entity.UpdateDelivery{newDelivery(5, "user3@example.com", "Hello"), newDelivery(6, "user4@example.com", "Hi")}, nil)
+		mockRepo.EXPECT().MarkDigestSent(gomock.Any(), int64(1), int64(6), gomock.Any()).Return(nil)
+		mockUpdateRepo.EXPECT().GetFeedSince(int64(2), int64(9), constant.DigestMaxUpdates).Return(nil, nil)
+		mockRepo.EXPECT().MarkDigestSent(gomock.Any(), int64(2), int64(9), gomock.Any()).Return(nil)
+
+		sent, err := service.SendDueDigests(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 2, sent)
+		assert.Len(t, emailChannel.messages, 1)
+		assert.Equal(t, "user1@example.com", emailChannel.messages[0].To)
+		assert.Equal(t, "Your daily digest: 2 new updates", emailChannel.messages[0].Subject)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+	})
+
+	t.Run("LockHeldByAnotherReplica", func(t *testing.T) {
+		ctrl, mockSQL, mockRepo, mockUserRepo, mockUpdateRepo := setup(t)
+		defer ctrl.Finish()
+		emailChannel := &recordingChannel{}
+		service := NewDigestService(mockRepo, mockUserRepo, mockUpdateRepo, emailChannel)
+
+		mockRepo.EXPECT().TryAdvisoryLock(gomock.Any(), int64(constant.DigestAdvisoryLockKey)).Return(false, nil)
+
+		sent, err := service.SendDueDigests(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, sent)
+		assert.Empty(t, emailChannel.messages)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+	})
+
+	t.Run("OptedOutUserOnlyAdvancesWatermark", func(t *testing.T) {
+		ctrl, mockSQL, mockRepo, mockUserRepo, mockUpdateRepo := setup(t)
+		defer ctrl.Finish()
+		emailChannel := &recordingChannel{}
+		service := NewDigestService(mockRepo, mockUserRepo, mockUpdateRepo, emailChannel)
+
+		mockRepo.EXPECT().TryAdvisoryLock(gomock.Any(), gomock.Any()).Return(true, nil)
+		mockRepo.EXPECT().AdvisoryUnlock(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().GetDuePreferences(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*entity.DigestPreference{{UserId: 1, Frequency: entity.DigestDaily, WatermarkDeliveryId: 4, User: user1}}, nil)
+		mockUserRepo.EXPECT().GetEmailOptOutUserIds([]int64{1}).Return([]int64{1}, nil)
+		mockUpdateRepo.EXPECT().GetFeedSince(int64(1), int64(4), constant.DigestMaxUpdates).
+			Return([]*entity.UpdateDelivery{newDelivery(5, "user3@example.com", "Hello")}, nil)
+		mockRepo.EXPECT().MarkDigestSent(gomock.Any(), int64(1), int64(5), gomock.Any()).Return(nil)
+
+		sent, err := service.SendDueDigests(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		assert.Empty(t, emailChannel.messages)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+	})
+
+	t.Run("FullDigestSkipsToLatestFeedItem", func(t *testing.T) {
+		ctrl, mockSQL, mockRepo, mockUserRepo, mockUpdateRepo := setup(t)
+		defer ctrl.Finish()
+		emailChannel := &recordingChannel{}
+		service := NewDigestService(mockRepo, mockUserRepo, mockUpdateRepo, emailChannel)
+
+		deliveries := make([]*entity.UpdateDelivery, 0, constant.DigestMaxUpdates)
+		for i := 1; i <= constant.DigestMaxUpdates; i++ {
+			deliveries = append(deliveries, newDelivery(int64(i), "user3@example.com", "Hello"))
+		}
+		mockRepo.EXPECT().TryAdvisoryLock(gomock.Any(), gomock.Any()).Return(true, nil)
+		mockRepo.EXPECT().AdvisoryUnlock(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().GetDuePreferences(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*entity.DigestPreference{{UserId: 1, Frequency: entity.DigestDaily, User: user1}}, nil)
+		mockUserRepo.EXPECT().GetEmailOptOutUserIds([]int64{1}).Return(nil, nil)
+		mockUpdateRepo.EXPECT().GetFeedSince(int64(1), int64(0), constant.DigestMaxUpdates).Return(deliveries, nil)
+		mockUpdateRepo.EXPECT().GetLatestDeliveryId(int64(1)).Return(int64(80), nil)
+		mockRepo.EXPECT().MarkDigestSent(gomock.Any(), int64(1), int64(80), gomock.Any()).Return(nil)
+
+		_, err := service.SendDueDigests(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, emailChannel.messages, 1)
+		assert.Contains(t, emailChannel.messages[0].Text, "There are more updates in your feed.")
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+	})
+
+	t.Run("FailedSendKeepsWatermarkAndBacksOff", func(t *testing.T) {
+		ctrl, mockSQL, mockRepo, mockUserRepo, mockUpdateRepo := setup(t)
+		defer ctrl.Finish()
+		emailChannel := &failingChannel{failFor: "user1@example.com"}
+		service := NewDigestService(mockRepo, mockUserRepo, mockUpdateRepo, emailChannel)
+		before := time.Now()
+
+		mockRepo.EXPECT().TryAdvisoryLock(gomock.Any(), gomock.Any()).Return(true, nil)
+		mockRepo.EXPECT().AdvisoryUnlock(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().GetDuePreferences(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*entity.DigestPreference{
+				{UserId: 1, Frequency: entity.DigestDaily, WatermarkDeliveryId: 4, FailedAttempts: 1, User: user1},
+				{UserId: 2, Frequency: entity.DigestDaily, WatermarkDeliveryId: 7, User: user2},
+			}, nil)
+		mockUserRepo.EXPECT().GetEmailOptOutUserIds([]int64{1, 2}).Return(nil, nil)
+		mockUpdateRepo.EXPECT().GetFeedSince(int64(1), int64(4), constant.DigestMaxUpdates).
+			Return([]*entity.UpdateDelivery{newDelivery(5, "user3@example.com", "Hello")}, nil)
+		mockRepo.EXPECT().MarkDigestFailed(gomock.Any(), int64(1), 2, gomock.Any()).
+			DoAndReturn(func(_ *gorm.DB, _ int64, _ int, nextAttemptAt time.Time) error {
+				assert.WithinDuration(t, before.Add(2*constant.DigestInitialBackoff), nextAttemptAt, time.Second)
+				return nil
+			})
+		mockUpdateRepo.EXPECT().GetFeedSince(int64(2), int64(7), constant.DigestMaxUpdates).
+			Return([]*entity.UpdateDelivery{newDelivery(8, "user3@example.com", "Hi")}, nil)
+		mockRepo.EXPECT().MarkDigestSent(gomock.Any(), int64(2), int64(8), gomock.Any()).Return(nil)
+
+		sent, err := service.SendDueDigests(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		assert.Len(t, emailChannel.messages, 1)
+		assert.Equal(t, "user2@example.com", emailChannel.messages[0].To)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+	})
+
+	t.Run("LaterErrorKeepsSentWatermarks", func(t *testing.T) {
+		ctrl, mockSQL, mockRepo, mockUserRepo, mockUpdateRepo := setup(t)
+		defer ctrl.Finish()
+		emailChannel := &recordingChannel{}
+		service := NewDigestService(mockRepo, mockUserRepo, mockUpdateRepo, emailChannel)
+		dbErr := errors.New("database error")
+
+		mockRepo.EXPECT().TryAdvisoryLock(gomock.Any(), gomock.Any()).Return(true, nil)
+		mockRepo.EXPECT().AdvisoryUnlock(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().GetDuePreferences(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*entity.DigestPreference{
+				{UserId: 1, Frequency: entity.DigestDaily, WatermarkDeliveryId: 4, User: user1},
+				{UserId: 2, Frequency: entity.DigestDaily, WatermarkDeliveryId: 7, User: user2},
+			}, nil)
+		mockUserRepo.EXPECT().GetEmailOptOutUserIds([]int64{1, 2}).Return(nil, nil)
+		mockUpdateRepo.EXPECT().GetFeedSince(int64(1), int64(4), constant.DigestMaxUpdates).
+			Return([]*entity.UpdateDelivery{newDelivery(5, "user3@example.com", "Hello")}, nil)
+		mockRepo.EXPECT().MarkDigestSent(gomock.Any(), int64(1), int64(5), gomock.Any()).Return(nil)
+		mockUpdateRepo.EXPECT().GetFeedSince(int64(2), int64(7), constant.DigestMaxUpdates).Return(nil, nil)
+		mockRepo.EXPECT().MarkDigestSent(gomock.Any(), int64(2), int64(7), gomock.Any()).Return(dbErr)
+
+		// No transaction wraps the batch, so the watermark of user1 stays
+		// committed and their digest is not sent again.
+		sent, err := service.SendDueDigests(context.Background())
+		assert.Equal(t, dbErr, err)
+		assert.Equal(t, 1, sent)
+		assert.Len(t, emailChannel.messages, 1)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		ctrl, mockSQL, mockRepo, mockUserRepo, mockUpdateRepo := setup(t)
+		defer ctrl.Finish()
+		service := NewDigestService(mockRepo, mockUserRepo, mockUpdateRepo, &recordingChannel{})
+		dbErr := errors.New("database error")
+
+		mockRepo.EXPECT().TryAdvisoryLock(gomock.Any(), gomock.Any()).Return(false, dbErr)
+
+		sent, err := service.SendDueDigests(context.Background())
+		assert.Equal(t, dbErr, err)
+		assert.Equal(t, 0, sent)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+	})
+}
+
+func TestRetryBackoff(t *testing.T) {
+	assert.Equal(t, constant.DigestInitialBackoff, retryBackoff(1))
+	assert.Equal(t, 2*constant.DigestInitialBackoff, retryBackoff(2))
+	assert.Equal(t, 4*constant.DigestInitialBackoff, retryBackoff(3))
+	assert.Equal(t, constant.DigestMaxBackoff, retryBackoff(30))
+}
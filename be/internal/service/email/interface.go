@@ -0,0 +1,17 @@
+package service
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"context"
+)
+
+//go:generate mockgen -source=interface.go -destination=../mock/mock_email_service.go
+
+// EmailService turns relayed domain events into queued notification emails
+// and sends the queue. Its Handle methods are event bus handlers.
+type EmailService interface {
+	HandleUpdatePublished(event entity.Event) error
+	HandleFriendRequestSent(event entity.Event) error
+	SendDueMessages(ctx context.Context) (int, error)
+	Run(ctx context.Context)
+}
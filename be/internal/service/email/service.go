@@ -0,0 +1,262 @@
+package service
+
+import (
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/entity"
+	"BE_Friends_Management/internal/notification/channel"
+	emailMessageRepository "BE_Friends_Management/internal/repository/email_message"
+	notificationPreferenceRepository "BE_Friends_Management/internal/repository/notification_preference"
+	updateRepository "BE_Friends_Management/internal/repository/update"
+	userRepository "BE_Friends_Management/internal/repository/users"
+	"BE_Friends_Management/pkg/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type emailService struct {
+	userRepo       userRepository.UserRepository
+	updateRepo     updateRepository.UpdateRepository
+	preferenceRepo notificationPreferenceRepository.NotificationPreferenceRepository
+	messageRepo    emailMessageRepository.EmailMessageRepository
+	channel        channel.Channel
+}
+
+func NewEmailService(userRepo userRepository.UserRepository, updateRepo updateRepository.UpdateRepository, preferenceRepo notificationPreferenceRepository.NotificationPreferenceRepository, messageRepo emailMessageRepository.EmailMessageRepository, channel channel.Channel) EmailService {
+	return &emailService{
+		userRepo:       userRepo,
+		updateRepo:     updateRepo,
+		preferenceRepo: preferenceRepo,
+		messageRepo:    messageRepo,
+		channel:        channel,
+	}
+}
+
+// HandleUpdatePublished queues an email for every recipient of the update who
+// has not opted out, has the email channel on and wanted at least one of the
+// reasons they got the update for when it was published. Recipients who
+// wanted the mention get the "mentioned" email instead of the plain "new
+// update" one.
+func (service *emailService) HandleUpdatePublished(event entity.Event) error {
+	var data entity.UpdateEventData
+	err := decodeEventData(event, &data)
+	if err != nil {
+		return err
+	}
+	deliveries, err := service.updateRepo.GetUpdateDeliveries(data.Id)
+	if err != nil {
+		return err
+	}
+	users := make([]*entity.User, 0, len(deliveries))
+	reasons := make(map[int64][]string, len(deliveries))
+	for _, delivery := range deliveries {
+		if delivery.Recipient != nil {
+			users = append(users, delivery.Recipient)
+			reasons[delivery.RecipientId] = delivery.Reasons
+		}
+	}
+	recipients, preferences, err := service.withoutOptOuts(users)
+	if err != nil {
+		return err
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+	templateData := channel.UpdateTemplateData{Sender: data.Sender, Text: data.Text}
+	updateMessage, err := channel.Render(channel.TemplateNewUpdate, templateData)
+	if err != nil {
+		return err
+	}
+	mentionedMessage, err := channel.Render(channel.TemplateMentioned, templateData)
+	if err != nil {
+		return err
+	}
+
+	var messages []*entity.EmailMessage
+	for _, recipient := range recipients {
+		preference := preferences[recipient.Id]
+		if !utils.WantsAnyNotification(preference, reasons[recipient.Id], data.CreatedAt) {
+			continue
+		}
+		message := updateMessage
+		if slices.Contains(reasons[recipient.Id], entity.NotificationMention) && utils.WantsNotification(preference, entity.NotificationMention, data.CreatedAt) {
+			message = mentionedMessage
+		}
+		message.To = recipient.Email
+		messages = append(messages, newEmailMessage(event.Id, message))
+	}
+	return service.messageRepo.EnqueueMessages(messages)
+}
+
+func (service *emailService) HandleFriendRequestSent(event entity.Event) error {
+	var data entity.FriendRequestEventData
+	err := decodeEventData(event, &data)
+	if err != nil {
+		return err
+	}
+	target, err := service.userRepo.GetUserByEmail(data.Target)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// The target was deleted before the event was relayed.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	recipients, preferences, err := service.withoutOptOuts([]*entity.User{target})
+	if err != nil || len(recipients) == 0 {
+		return err
+	}
+	if !utils.WantsNotification(preferences[target.Id], entity.NotificationFriendRequest, time.Now()) {
+		return nil
+	}
+	message, err := channel.Render(channel.TemplateNewFriendRequest, channel.FriendRequestTemplateData{Requestor: data.Requestor})
+	if err != nil {
+		return err
+	}
+	message.To = target.Email
+	return service.messageRepo.EnqueueMessages([]*entity.EmailMessage{newEmailMessage(event.Id, message)})
+}
+
+// SendDueMessages sends one batch of due emails and records the outcome of
+// each attempt. It returns the number of emails claimed.
+func (service *emailService) SendDueMessages(ctx context.Context) (int, error) {
+	now := time.Now()
+	messages, err := service.messageRepo.ClaimDueMessages(now, now.Add(constant.EmailClaimLease), constant.EmailBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	for _, message := range messages {
+		if ctx.Err() != nil {
+			// The lease expires and another sender picks the rest up.
+			return len(messages), ctx.Err()
+		}
+		service.attempt(message)
+		err := service.messageRepo.UpdateMessageResult(message)
+		if err != nil {
+			log.Error("Happened error when saving email result. Error: ", err)
+		}
+	}
+	return len(messages), nil
+}
+
+// Run sends due emails until ctx is cancelled. A full batch is followed
+// immediately by the next one instead of waiting for the ticker.
+func (service *emailService) Run(ctx context.Context) {
+	ticker := time.NewTicker(constant.EmailPollInterval)
+	defer ticker.Stop()
+	for {
+		claimed, err := service.SendDueMessages(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Error("Happened error when sending emails. Error: ", err)
+		}
+		if err == nil && claimed == constant.EmailBatchSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (service *emailService) attempt(message *entity.EmailMessage) {
+	message.Attempts++
+	err := service.channel.Send(channel.Message{
+		To:      message.Recipient,
+		Subject: message.Subject,
+		Text:    message.Text,
+		HTML:    message.HTML,
+	})
+	if err == nil {
+		sentAt := time.Now()
+		message.Status = entity.EmailMessageSent
+		message.SentAt = &sentAt
+		message.LastError = ""
+		return
+	}
+	log.Error("Happened error when sending email to ", message.Recipient, ". Error: ", err)
+	message.LastError = err.Error()
+	if message.Attempts >= constant.EmailMaxAttempts {
+		message.Status = entity.EmailMessageFailed
+		return
+	}
+	message.NextAttemptAt = time.Now().Add(retryBackoff(message.Attempts))
+}
+
+// withoutOptOuts drops the users who opted out of emails or turned the
+// email channel off, and returns the notification preferences of the rest.
+func (service *emailService) withoutOptOuts(users []*entity.User) ([]*entity.User, map[int64]*entity.NotificationPreference, error) {
+	if len(users) == 0 {
+		return users, nil, nil
+	}
+	userIds := make([]int64, 0, len(users))
+	for _, user := range users {
+		userIds = append(userIds, user.Id)
+	}
+	optOutUserIds, err := service.userRepo.GetEmailOptOutUserIds(userIds)
+	if err != nil {
+		return nil, nil, err
+	}
+	optedOut := make(map[int64]bool, len(optOutUserIds))
+	for _, userId := range optOutUserIds {
+		optedOut[userId] = true
+	}
+	userIds = userIds[:0]
+	for _, user := range users {
+		if !optedOut[user.Id] {
+			userIds = append(userIds, user.Id)
+		}
+	}
+	if len(userIds) == 0 {
+		return nil, nil, nil
+	}
+	preferences, err := service.preferenceRepo.GetPreferences(userIds)
+	if err != nil {
+		return nil, nil, err
+	}
+	var remaining []*entity.User
+	for _, user := range users {
+		if !optedOut[user.Id] && utils.UsesChannel(preferences[user.Id], entity.ChannelEmail) {
+			remaining = append(remaining, user)
+		}
+	}
+	return remaining, preferences, nil
+}
+
+// decodeEventData reads the data of a relayed event, which arrives as raw
+// JSON, into target.
+func decodeEventData(event entity.Event, target any) error {
+	payload, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(payload, target)
+}
+
+func newEmailMessage(eventId string, message channel.Message) *entity.EmailMessage {
+	return &entity.EmailMessage{
+		EventId:       eventId,
+		Recipient:     message.To,
+		Subject:       message.Subject,
+		Text:          message.Text,
+		HTML:          message.HTML,
+		Status:        entity.EmailMessagePending,
+		NextAttemptAt: time.Now(),
+	}
+}
+
+// retryBackoff doubles the wait after every failed attempt, capped at
+// constant.EmailMaxBackoff.
+func retryBackoff(attempts int) time.Duration {
+	backoff := constant.EmailInitialBackoff
+	for i := 1; i < attempts && backoff < constant.EmailMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, constant.EmailMaxBackoff)
+}
@@ -0,0 +1,433 @@
+package service
+
+import (
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/entity"
+	"BE_Friends_Management/internal/notification/channel"
+	mock "BE_Friends_Management/internal/repository/mock"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type recordingChannel struct {
+	failFor  string
+	messages []channel.Message
+}
+
+func (c *recordingChannel) Name() string {
+	return "email"
+}
+
+func (c *recordingChannel) Send(message channel.Message) error {
+	if message.To == c.failFor {
+		return errors.New("smtp error")
+	}
+	c.messages = append(c.messages, message)
+	return nil
+}
+
+// memoryEmailMessages keeps the queue like the email_messages table does.
+type memoryEmailMessages struct {
+	err      error
+	messages []*entity.EmailMessage
+}
+
+func newMemoryEmailMessages() *memoryEmailMessages {
+	return &memoryEmailMessages{}
+}
+
+func (r *memoryEmailMessages) EnqueueMessages(messages []*entity.EmailMessage) error {
+	if r.err != nil {
+		return r.err
+	}
+	for _, message := range messages {
+		queued := slices.ContainsFunc(r.messages, func(other *entity.EmailMessage) bool {
+			return other.EventId == message.EventId && other.Recipient == message.Recipient
+		})
+		if !queued {
+			r.messages = append(r.messages, message)
+		}
+	}
+	return nil
+}
+
+func (r *memoryEmailMessages) ClaimDueMessages(now, leaseUntil time.Time, limit int) ([]*entity.EmailMessage, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	var due []*entity.EmailMessage
+	for _, message := range r.messages {
+		if len(due) < limit && message.Status == entity.EmailMessagePending && !message.NextAttemptAt.After(now) {
+			message.NextAttemptAt = leaseUntil
+			due = append(due, message)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryEmailMessages) UpdateMessageResult(message *entity.EmailMessage) error {
+	return r.err
+}
+
+func relayedEvent(t *testing.T, id, eventType string, data any) entity.Event {
+	payload, err := json.Marshal(data)
+	assert.NoError(t, err)
+	return entity.Event{Id: id, Type: eventType, Data: json.RawMessage(payload)}
+}
+
+func delivery(recipient *entity.User, reasons ...string) *entity.UpdateDelivery {
+	return &entity.UpdateDelivery{UpdateId: 7, RecipientId: recipient.Id, Recipient: recipient, Reasons: reasons}
+}
+
+func TestEmailService_HandleUpdatePublished(t *testing.T) {
+	sender := "user1@example.com"
+	user2 := &entity.User{Id: 2, Email: "user2@example.com"}
+	user3 := &entity.User{Id: 3, Email: "user3@example.com"}
+	user4 := &entity.User{Id: 4, Email: "user4@example.com"}
+	publishedAt := time.Date(2026, 3, 2, 23, 30, 0, 0, time.UTC)
+	data := entity.UpdateEventData{Id: 7, Sender: sender, Text: "Hello USER3@example.com", CreatedAt: publishedAt}
+
+	t.Run("Success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mock.NewMockUserRepository(ctrl)
+		mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+		mockUpdateRepo := mock.NewMockUpdateRepository(ctrl)
+		queue := newMemoryEmailMessages()
+		service := NewEmailService(mockUserRepo, mockUpdateRepo, mockPreferenceRepo, queue, &recordingChannel{})
+
+		mockUpdateRepo.EXPECT().GetUpdateDeliveries(int64(7)).Return([]*entity.UpdateDelivery{
+			delivery(user2, entity.NotificationFriendUpdate),
+			delivery(user3, entity.NotificationFriendUpdate, entity.NotificationMention),
+			delivery(user4, entity.NotificationSubscriptionUpdate),
+		}, nil)
+		mockUserRepo.EXPECT().GetEmailOptOutUserIds([]int64{2, 3, 4}).Return([]int64{4}, nil)
+		mockPreferenceRepo.EXPECT().GetPreferences([]int64{2, 3}).Return(map[int64]*entity.NotificationPreference{}, nil)
+
+		err := service.HandleUpdatePublished(relayedEvent(t, "evt_1", entity.EventUpdatePublished, data))
+		assert.NoError(t, err)
+		assert.Len(t, queue.messages, 2)
+		assert.Equal(t, "user2@example.com", queue.messages[0].Recipient)
+		assert.Equal(t, "New update from user1@example.com", queue.messages[0].Subject)
+		assert.Equal(t, "user3@example.com", queue.messages[1].Recipient)
+		assert.Equal(t, "user1@example.com mentioned you", queue.messages[1].Subject)
+	})
+
+	t.Run("RedeliveredEventQueuesOnce", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mock.NewMockUserRepository(ctrl)
+		mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+		mockUpdateRepo := mock.NewMockUpdateRepository(ctrl)
+		queue := newMemoryEmailMessages()
+		service := NewEmailService(mockUserRepo, mockUpdateRepo, mockPreferenceRepo, queue, &recordingChannel{})
+		event := relayedEvent(t, "evt_1", entity.EventUpdatePublished, data)
+
+		mockUpdateRepo.EXPECT().GetUpdateDeliveries(int64(7)).Return([]*entity.UpdateDelivery{
+			delivery(user2, entity.NotificationFriendUpdate),
+			delivery(user3, entity.NotificationMention),
+		}, nil).Times(2)
+		mockUserRepo.EXPECT().GetEmailOptOutUserIds([]int64{2, 3}).Return(nil, nil).Times(2)
+		mockPreferenceRepo.EXPECT().GetPreferences([]int64{2, 3}).Return(map[int64]*entity.NotificationPreference{}, nil).Times(2)
+
+		// The outbox relays events at least once.
+		assert.NoError(t, service.HandleUpdatePublished(event))
+		assert.NoError(t, service.HandleUpdatePublished(event))
+		assert.Len(t, queue.messages, 2)
+	})
+
+	t.Run("EmailChannelOff", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mock.NewMockUserRepository(ctrl)
+		mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+		mockUpdateRepo := mock.NewMockUpdateRepository(ctrl)
+		queue := newMemoryEmailMessages()
+		service := NewEmailService(mockUserRepo, mockUpdateRepo, mockPreferenceRepo, queue, &recordingChannel{})
+
+		mockUpdateRepo.EXPECT().GetUpdateDeliveries(int64(7)).Return([]*entity.UpdateDelivery{
+			delivery(user2, entity.NotificationFriendUpdate),
+			delivery(user3, entity.NotificationMention),
+		}, nil)
+		mockUserRepo.EXPECT().GetEmailOptOutUserIds([]int64{2, 3}).Return(nil, nil)
+		mockPreferenceRepo.EXPECT().GetPreferences([]int64{2, 3}).Return(map[int64]*entity.NotificationPreference{
+			2: {Channels: []string{entity.ChannelInApp}, EventTypes: entity.NotificationTypes},
+		}, nil)
+
+		err := service.HandleUpdatePublished(relayedEvent(t, "evt_1", entity.EventUpdatePublished, data))
+		assert.NoError(t, err)
+		assert.Len(t, queue.messages, 1)
+		assert.Equal(t, "user3@example.com", queue.messages[0].Recipient)
+	})
+
+	t.Run("PreferencesApplyAtPublishTime", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mock.NewMockUserRepository(ctrl)
+		mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+		mockUpdateRepo := mock.NewMockUpdateRepository(ctrl)
+		queue := newMemoryEmailMessages()
+		service := NewEmailService(mockUserRepo, mockUpdateRepo, mockPreferenceRepo, queue, &recordingChannel{})
+
+		mockUpdateRepo.EXPECT().GetUpdateDeliveries(int64(7)).Return([]*entity.UpdateDelivery{
+			delivery(user2, entity.NotificationFriendUpdate),
+			delivery(user3, entity.NotificationFriendUpdate, entity.NotificationMention),
+			delivery(user4, entity.NotificationSubscriptionUpdate),
+		}, nil)
+		mockUserRepo.EXPECT().GetEmailOptOutUserIds([]int64{2, 3, 4}).Return(nil, nil)
+		mockPreferenceRepo.EXPECT().GetPreferences([]int64{2, 3, 4}).Return(map[int64]*entity.NotificationPreference{
+			// In quiet hours when the update was published.
+			2: {
+				Channels:             entity.NotificationChannels,
+				EventTypes:           entity.NotificationTypes,
+				QuietHoursStart:      "22:00",
+				QuietHoursEnd:        "07:00",
+				QuietHoursTimezone:   "UTC",
+				QuietHoursEventTypes: []string{},
+			},
+			// Turned mentions off but still wants friend updates.
+			3: {Channels: entity.NotificationChannels, EventTypes: []string{entity.NotificationFriendUpdate}},
+			// Turned subscription updates off.
+			4: {Channels: entity.NotificationChannels, EventTypes: []string{entity.NotificationMention}},
+		}, nil)
+
+		err := service.HandleUpdatePublished(relayedEvent(t, "evt_1", entity.EventUpdatePublished, data))
+		assert.NoError(t, err)
+		assert.Len(t, queue.messages, 1)
+		assert.Equal(t, "user3@example.com", queue.messages[0].Recipient)
+		assert.Equal(t, "New update from user1@example.com", queue.messages[0].Subject)
+	})
+
+	t.Run("NoRecipients", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mock.NewMockUserRepository(ctrl)
+		mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+		mockUpdateRepo := mock.NewMockUpdateRepository(ctrl)
+		queue := newMemoryEmailMessages()
+		service := NewEmailService(mockUserRepo, mockUpdateRepo, mockPreferenceRepo, queue, &recordingChannel{})
+
+		mockUpdateRepo.EXPECT().GetUpdateDeliveries(int64(7)).Return(nil, nil)
+
+		err := service.HandleUpdatePublished(relayedEvent(t, "evt_1", entity.EventUpdatePublished, data))
+		assert.NoError(t, err)
+		assert.Empty(t, queue.messages)
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mock.NewMockUserRepository(ctrl)
+		mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+		mockUpdateRepo := mock.NewMockUpdateRepository(ctrl)
+		service := NewEmailService(mockUserRepo, mockUpdateRepo, mockPreferenceRepo, newMemoryEmailMessages(), &recordingChannel{})
+		dbErr := errors.New("database error")
+
+		mockUpdateRepo.EXPECT().GetUpdateDeliveries(int64(7)).Return(nil, dbErr)
+
+		err := service.HandleUpdatePublished(relayedEvent(t, "evt_1", entity.EventUpdatePublished, data))
+		assert.Equal(t, dbErr, err)
+	})
+}
+
+func TestEmailService_HandleFriendRequestSent(t *testing.T) {
+	target := &entity.User{Id: 2, Email: "user2@example.com"}
+	data := entity.FriendRequestEventData{Id: 10, Requestor: "user1@example.com", Target: "user2@example.com"}
+
+	t.Run("Success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mock.NewMockUserRepository(ctrl)
+		mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+		queue := newMemoryEmailMessages()
+		service := NewEmailService(mockUserRepo, mock.NewMockUpdateRepository(ctrl), mockPreferenceRepo, queue, &recordingChannel{})
+
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(target, nil)
+		mockUserRepo.EXPECT().GetEmailOptOutUserIds([]int64{2}).Return(nil, nil)
+		mockPreferenceRepo.EXPECT().GetPreferences([]int64{2}).Return(map[int64]*entity.NotificationPreference{}, nil)
+
+		err := service.HandleFriendRequestSent(relayedEvent(t, "evt_2", entity.EventFriendRequestSent, data))
+		assert.NoError(t, err)
+		assert.Len(t, queue.messages, 1)
+		assert.Equal(t, "user2@example.com", queue.messages[0].Recipient)
+		assert.Equal(t, "user1@example.com sent you a friend request", queue.messages[0].Subject)
+	})
+
+	t.Run("OptedOut", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mock.NewMockUserRepository(ctrl)
+		mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+		queue := newMemoryEmailMessages()
+		service := NewEmailService(mockUserRepo, mock.NewMockUpdateRepository(ctrl), mockPreferenceRepo, queue, &recordingChannel{})
+
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(target, nil)
+		mockUserRepo.EXPECT().GetEmailOptOutUserIds([]int64{2}).Return([]int64{2}, nil)
+
+		err := service.HandleFriendRequestSent(relayedEvent(t, "evt_2", entity.EventFriendRequestSent, data))
+		assert.NoError(t, err)
+		assert.Empty(t, queue.messages)
+	})
+
+	t.Run("FriendRequestsOff", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mock.NewMockUserRepository(ctrl)
+		mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+		queue := newMemoryEmailMessages()
+		service := NewEmailService(mockUserRepo, mock.NewMockUpdateRepository(ctrl), mockPreferenceRepo, queue, &recordingChannel{})
+
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(target, nil)
+		mockUserRepo.EXPECT().GetEmailOptOutUserIds([]int64{2}).Return(nil, nil)
+		mockPreferenceRepo.EXPECT().GetPreferences([]int64{2}).Return(map[int64]*entity.NotificationPreference{
+			2: {Channels: entity.NotificationChannels, EventTypes: []string{entity.NotificationMention}},
+		}, nil)
+
+		err := service.HandleFriendRequestSent(relayedEvent(t, "evt_2", entity.EventFriendRequestSent, data))
+		assert.NoError(t, err)
+		assert.Empty(t, queue.messages)
+	})
+
+	t.Run("TargetDeleted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mock.NewMockUserRepository(ctrl)
+		mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+		queue := newMemoryEmailMessages()
+		service := NewEmailService(mockUserRepo, mock.NewMockUpdateRepository(ctrl), mockPreferenceRepo, queue, &recordingChannel{})
+
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+		err := service.HandleFriendRequestSent(relayedEvent(t, "evt_2", entity.EventFriendRequestSent, data))
+		assert.NoError(t, err)
+		assert.Empty(t, queue.messages)
+	})
+
+	t.Run("QueueError", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mock.NewMockUserRepository(ctrl)
+		mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+		dbErr := errors.New("database error")
+		queue := &memoryEmailMessages{err: dbErr}
+		service := NewEmailService(mockUserRepo, mock.NewMockUpdateRepository(ctrl), mockPreferenceRepo, queue, &recordingChannel{})
+
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(target, nil)
+		mockUserRepo.EXPECT().GetEmailOptOutUserIds([]int64{2}).Return(nil, nil)
+		mockPreferenceRepo.EXPECT().GetPreferences([]int64{2}).Return(map[int64]*entity.NotificationPreference{}, nil)
+
+		err := service.HandleFriendRequestSent(relayedEvent(t, "evt_2", entity.EventFriendRequestSent, data))
+		assert.Equal(t, dbErr, err)
+		assert.Empty(t, queue.messages)
+	})
+}
+
+func TestEmailService_SendDueMessages(t *testing.T) {
+	queued := func(recipient string) *entity.EmailMessage {
+		return &entity.EmailMessage{
+			EventId:       "evt_1",
+			Recipient:     recipient,
+			Subject:       "New update from user1@example.com",
+			Status:        entity.EmailMessagePending,
+			NextAttemptAt: time.Now(),
+		}
+	}
+
+	t.Run("SendsAndRetriesOnlyFailedEmails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		queue := &memoryEmailMessages{messages: []*entity.EmailMessage{queued("user2@example.com"), queued("user3@example.com")}}
+		emailChannel := &recordingChannel{failFor: "user3@example.com"}
+		service := NewEmailService(mock.NewMockUserRepository(ctrl), mock.NewMockUpdateRepository(ctrl), mock.NewMockNotificationPreferenceRepository(ctrl), queue, emailChannel)
+
+		before := time.Now()
+		claimed, err := service.SendDueMessages(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 2, claimed)
+		assert.Len(t, emailChannel.messages, 1)
+		assert.Equal(t, "user2@example.com", emailChannel.messages[0].To)
+		assert.Equal(t, "New update from user1@example.com", emailChannel.messages[0].Subject)
+
+		sent, failed := queue.messages[0], queue.messages[1]
+		assert.Equal(t, entity.EmailMessageSent, sent.Status)
+		assert.NotNil(t, sent.SentAt)
+		assert.Equal(t, entity.EmailMessagePending, failed.Status)
+		assert.Equal(t, 1, failed.Attempts)
+		assert.Equal(t, "smtp error", failed.LastError)
+		assert.WithinDuration(t, before.Add(constant.EmailInitialBackoff), failed.NextAttemptAt, time.Second)
+
+		// The failed email is not due until its backoff has passed.
+		claimed, err = service.SendDueMessages(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, claimed)
+	})
+
+	t.Run("GivesUpAfterMaxAttempts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		message := queued("user2@example.com")
+		message.Attempts = constant.EmailMaxAttempts - 1
+		queue := &memoryEmailMessages{messages: []*entity.EmailMessage{message}}
+		service := NewEmailService(mock.NewMockUserRepository(ctrl), mock.NewMockUpdateRepository(ctrl), mock.NewMockNotificationPreferenceRepository(ctrl), queue, &recordingChannel{failFor: "user2@example.com"})
+
+		_, err := service.SendDueMessages(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, entity.EmailMessageFailed, message.Status)
+		assert.Equal(t, constant.EmailMaxAttempts, message.Attempts)
+	})
+
+	t.Run("ClaimError", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		dbErr := errors.New("database error")
+		service := NewEmailService(mock.NewMockUserRepository(ctrl), mock.NewMockUpdateRepository(ctrl), mock.NewMockNotificationPreferenceRepository(ctrl), &memoryEmailMessages{err: dbErr}, &recordingChannel{})
+
+		claimed, err := service.SendDueMessages(context.Background())
+		assert.Equal(t, dbErr, err)
+		assert.Equal(t, 0, claimed)
+	})
+
+	t.Run("StopsWhenCancelled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		queue := &memoryEmailMessages{messages: []*entity.EmailMessage{queued("user2@example.com")}}
+		emailChannel := &recordingChannel{}
+		service := NewEmailService(mock.NewMockUserRepository(ctrl), mock.NewMockUpdateRepository(ctrl), mock.NewMockNotificationPreferenceRepository(ctrl), queue, emailChannel)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := service.SendDueMessages(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, emailChannel.messages)
+	})
+}
+
+func TestRetryBackoff(t *testing.T) {
+	assert.Equal(t, constant.EmailInitialBackoff, retryBackoff(1))
+	assert.Equal(t, 2*constant.EmailInitialBackoff, retryBackoff(2))
+	assert.Equal(t, 4*constant.EmailInitialBackoff, retryBackoff(3))
+	assert.Equal(t, constant.EmailMaxBackoff, retryBackoff(30))
+}
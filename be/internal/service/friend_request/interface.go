@@ -0,0 +1,28 @@
+package service
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"errors"
+)
+
+var (
+	ErrUserNotFound            = errors.New("user not found")
+	ErrInvalidRequest          = errors.New("two email can not be the same")
+	ErrAlreadyFriend           = errors.New("users are already friends")
+	ErrAlreadyRequested        = errors.New("a pending friend request already exists between these users")
+	ErrIsBlocked               = errors.New("one user has blocked another")
+	ErrFriendRequestNotFound   = errors.New("friend request not found")
+	ErrFriendRequestNotPending = errors.New("friend request is no longer pending")
+	ErrNotPermitted            = errors.New("action not permitted")
+)
+
+//go:generate mockgen -source=interface.go -destination=../mock/mock_friend_request_service.go
+
+type FriendRequestService interface {
+	SendFriendRequest(authUserId int64, requestorEmail, targetEmail string) (*entity.FriendRequest, error)
+	RetrieveIncomingFriendRequests(authUserId int64, authUserRole string, email string) ([]*entity.FriendRequest, error)
+	RetrieveOutgoingFriendRequests(authUserId int64, authUserRole string, email string) ([]*entity.FriendRequest, error)
+	AcceptFriendRequest(authUserId int64, requestId int64) error
+	RejectFriendRequest(authUserId int64, requestId int64) error
+	CancelFriendRequest(authUserId int64, requestId int64) error
+}
@@ -0,0 +1,280 @@
+package service
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"BE_Friends_Management/internal/realtime"
+	blockRelationshipRepository "BE_Friends_Management/internal/repository/block_relationship"
+	friendRequestRepository "BE_Friends_Management/internal/repository/friend_request"
+	friendshipRepository "BE_Friends_Management/internal/repository/friendship"
+	notificationPreferenceRepository "BE_Friends_Management/internal/repository/notification_preference"
+	userRepository "BE_Friends_Management/internal/repository/users"
+	outboxService "BE_Friends_Management/internal/service/outbox"
+	"BE_Friends_Management/pkg/utils"
+	"errors"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type friendRequestService struct {
+	repo                  friendRequestRepository.FriendRequestRepository
+	userRepo              userRepository.UserRepository
+	friendshipRepo        friendshipRepository.FriendshipRepository
+	blockRelationshipRepo blockRelationshipRepository.BlockRelationshipRepository
+	preferenceRepo        notificationPreferenceRepository.NotificationPreferenceRepository
+	publisher             realtime.Publisher
+	recorder              outboxService.EventRecorder
+}
+
+func NewFriendRequestService(repo friendRequestRepository.FriendRequestRepository, userRepo userRepository.UserRepository, friendshipRepo friendshipRepository.FriendshipRepository, blockRelationshipRepo blockRelationshipRepository.BlockRelationshipRepository, preferenceRepo notificationPreferenceRepository.NotificationPreferenceRepository, publisher realtime.Publisher, recorder outboxService.EventRecorder) FriendRequestService {
+	return &friendRequestService{
+		repo:                  repo,
+		userRepo:              userRepo,
+		friendshipRepo:        friendshipRepo,
+		blockRelationshipRepo: blockRelationshipRepo,
+		preferenceRepo:        preferenceRepo,
+		publisher:             publisher,
+		recorder:              recorder,
+	}
+}
+
+func (service *friendRequestService) SendFriendRequest(authUserId int64, requestorEmail, targetEmail string) (*entity.FriendRequest, error) {
+	requestor, err := service.userRepo.GetUserByEmail(requestorEmail)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if authUserId != requestor.Id {
+		return nil, ErrNotPermitted
+	}
+	target, err := service.userRepo.GetUserByEmail(targetEmail)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if requestor.Id == target.Id {
+		return nil, ErrInvalidRequest
+	}
+	err = service.checkNotBlocked(requestor.Id, target.Id)
+	if err != nil {
+		return nil, err
+	}
+	userId1, userId2 := requestor.Id, target.Id
+	if userId1 > userId2 {
+		userId1, userId2 = userId2, userId1
+	}
+	_, err = service.friendshipRepo.GetFriendship(userId1, userId2)
+	if err == nil {
+		return nil, ErrAlreadyFriend
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	_, err = service.repo.GetPendingFriendRequest(requestor.Id, target.Id)
+	if err == nil {
+		return nil, ErrAlreadyRequested
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	_, err = service.repo.GetPendingFriendRequest(target.Id, requestor.Id)
+	if err == nil {
+		return nil, ErrAlreadyRequested
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	friendRequest := &entity.FriendRequest{
+		RequestorId: requestor.Id,
+		TargetId:    target.Id,
+		Status:      entity.FriendRequestPending,
+		Requestor:   requestor,
+		Target:      target,
+	}
+	db := service.repo.GetDB()
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// The checks above race with a concurrent request between the same
+		// users, which the unique index on pending requests turns away.
+		err := service.repo.CreateFriendRequest(tx, friendRequest)
+		if err != nil && strings.Contains(err.Error(), "duplicate key") {
+			return ErrAlreadyRequested
+		}
+		if err != nil {
+			return err
+		}
+		return service.recorder.Record(tx, entity.EventFriendRequestSent, entity.FriendRequestEventData{
+			Id:        friendRequest.Id,
+			Requestor: requestor.Email,
+			Target:    target.Email,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	service.publishFriendRequest(friendRequest.TargetId, friendRequest, entity.FriendRequestPending)
+	return friendRequest, nil
+}
+
+func (service *friendRequestService) RetrieveIncomingFriendRequests(authUserId int64, authUserRole string, email string) ([]*entity.FriendRequest, error) {
+	user, err := service.userRepo.GetUserByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if authUserRole == "user" && authUserId != user.Id {
+		return nil, ErrNotPermitted
+	}
+	return service.repo.GetIncomingFriendRequests(user.Id)
+}
+
+func (service *friendRequestService) RetrieveOutgoingFriendRequests(authUserId int64, authUserRole string, email string) ([]*entity.FriendRequest, error) {
+	user, err := service.userRepo.GetUserByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if authUserRole == "user" && authUserId != user.Id {
+		return nil, ErrNotPermitted
+	}
+	return service.repo.GetOutgoingFriendRequests(user.Id)
+}
+
+func (service *friendRequestService) AcceptFriendRequest(authUserId int64, requestId int64) error {
+	friendRequest, err := service.getPendingFriendRequest(requestId)
+	if err != nil {
+		return err
+	}
+	if friendRequest.TargetId != authUserId {
+		return ErrNotPermitted
+	}
+	err = service.checkNotBlocked(friendRequest.RequestorId, friendRequest.TargetId)
+	if err != nil {
+		return err
+	}
+	userId1, userId2 := friendRequest.RequestorId, friendRequest.TargetId
+	if userId1 > userId2 {
+		userId1, userId2 = userId2, userId1
+	}
+	db := service.repo.GetDB()
+	err = db.Transaction(func(tx *gorm.DB) error {
+		err := service.repo.UpdateFriendRequestStatus(tx, friendRequest.Id, entity.FriendRequestAccepted)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrFriendRequestNotPending
+		}
+		if err != nil {
+			return err
+		}
+		err = service.friendshipRepo.CreateFriendship(tx, userId1, userId2)
+		if err != nil && strings.Contains(err.Error(), "duplicate key") {
+			return ErrAlreadyFriend
+		}
+		if err != nil {
+			return err
+		}
+		return service.recorder.Record(tx, entity.EventFriendshipCreated, entity.FriendshipEventData{Friends: []string{friendRequest.Requestor.Email, friendRequest.Target.Email}})
+	})
+	if err != nil {
+		return err
+	}
+	service.publishFriendRequest(friendRequest.RequestorId, friendRequest, entity.FriendRequestAccepted)
+	return nil
+}
+
+func (service *friendRequestService) RejectFriendRequest(authUserId int64, requestId int64) error {
+	friendRequest, err := service.getPendingFriendRequest(requestId)
+	if err != nil {
+		return err
+	}
+	if friendRequest.TargetId != authUserId {
+		return ErrNotPermitted
+	}
+	err = service.closeFriendRequest(friendRequest.Id, entity.FriendRequestRejected)
+	if err != nil {
+		return err
+	}
+	service.publishFriendRequest(friendRequest.RequestorId, friendRequest, entity.FriendRequestRejected)
+	return nil
+}
+
+func (service *friendRequestService) CancelFriendRequest(authUserId int64, requestId int64) error {
+	friendRequest, err := service.getPendingFriendRequest(requestId)
+	if err != nil {
+		return err
+	}
+	if friendRequest.RequestorId != authUserId {
+		return ErrNotPermitted
+	}
+	err = service.closeFriendRequest(friendRequest.Id, entity.FriendRequestCancelled)
+	if err != nil {
+		return err
+	}
+	service.publishFriendRequest(friendRequest.TargetId, friendRequest, entity.FriendRequestCancelled)
+	return nil
+}
+
+func (service *friendRequestService) getPendingFriendRequest(requestId int64) (*entity.FriendRequest, error) {
+	friendRequest, err := service.repo.GetFriendRequestById(requestId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrFriendRequestNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if friendRequest.Status != entity.FriendRequestPending {
+		return nil, ErrFriendRequestNotPending
+	}
+	return friendRequest, nil
+}
+
+func (service *friendRequestService) closeFriendRequest(requestId int64, status string) error {
+	err := service.repo.UpdateFriendRequestStatus(service.repo.GetDB(), requestId, status)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrFriendRequestNotPending
+	}
+	return err
+}
+
+// publishFriendRequest notifies the other party of a friend request about
+// its new status, unless their notification preferences turn in-app friend
+// request notifications off.
+func (service *friendRequestService) publishFriendRequest(userId int64, friendRequest *entity.FriendRequest, status string) {
+	preference, err := service.preferenceRepo.GetPreference(userId)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		// The request already went through; fall back to the defaults.
+		log.Warn("Happened error when getting notification preferences of user ", userId, ". Error: ", err)
+	}
+	if !utils.UsesChannel(preference, entity.ChannelInApp) || !utils.WantsNotification(preference, entity.NotificationFriendRequest, time.Now()) {
+		return
+	}
+	event := *friendRequest
+	event.Status = status
+	service.publisher.Publish(userId, realtime.Event{Id: event.Id, Type: realtime.EventFriendRequest, Payload: &event})
+}
+
+func (service *friendRequestService) checkNotBlocked(userId1, userId2 int64) error {
+	_, err := service.blockRelationshipRepo.GetBlockRelationship(userId1, userId2)
+	if err == nil {
+		return ErrIsBlocked
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	_, err = service.blockRelationshipRepo.GetBlockRelationship(userId2, userId1)
+	if err == nil {
+		return ErrIsBlocked
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
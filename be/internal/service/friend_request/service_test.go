@@ -0,0 +1,399 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"BE_Friends_Management/constant"
+	entity "BE_Friends_Management/internal/domain/entity"
+	"BE_Friends_Management/internal/realtime"
+	mock "BE_Friends_Management/internal/repository/mock"
+	serviceMock "BE_Friends_Management/internal/service/mock"
+)
+
+func TestFriendRequestService_SendFriendRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFriendRequestRepo := mock.NewMockFriendRequestRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockFriendshipRepo := mock.NewMockFriendshipRepository(ctrl)
+	mockBlockRepo := mock.NewMockBlockRelationshipRepository(ctrl)
+	mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+	hub := realtime.NewHub(constant.StreamBufferSize)
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewFriendRequestService(mockFriendRequestRepo, mockUserRepo, mockFriendshipRepo, mockBlockRepo, mockPreferenceRepo, hub, mockRecorder)
+
+	db, mockSQL, err := sqlmock.New()
+	assert.NoError(t, err)
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+	mockFriendRequestRepo.EXPECT().GetDB().Return(gormDB).AnyTimes()
+
+	user1 := &entity.User{Id: 1, Email: "user1@example.com"}
+	user2 := &entity.User{Id: 2, Email: "user2@example.com"}
+
+	t.Run("successful sending", func(t *testing.T) {
+		subscription := hub.Subscribe(2)
+		defer hub.Unsubscribe(subscription)
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockBlockRepo.EXPECT().GetBlockRelationship(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+		mockBlockRepo.EXPECT().GetBlockRelationship(int64(2), int64(1)).Return(nil, gorm.ErrRecordNotFound)
+		mockFriendshipRepo.EXPECT().GetFriendship(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+		mockFriendRequestRepo.EXPECT().GetPendingFriendRequest(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+		mockFriendRequestRepo.EXPECT().GetPendingFriendRequest(int64(2), int64(1)).Return(nil, gorm.ErrRecordNotFound)
+		mockSQL.ExpectBegin()
+		mockFriendRequestRepo.EXPECT().CreateFriendRequest(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *gorm.DB, friendRequest *entity.FriendRequest) error {
+			friendRequest.Id = 10
+			return nil
+		})
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventFriendRequestSent, entity.FriendRequestEventData{Id: 10, Requestor: "user1@example.com", Target: "user2@example.com"}).Return(nil)
+		mockSQL.ExpectCommit()
+		mockPreferenceRepo.EXPECT().GetPreference(int64(2)).Return(nil, gorm.ErrRecordNotFound)
+
+		friendRequest, err := service.SendFriendRequest(1, "user1@example.com", "user2@example.com")
+		assert.NoError(t, err)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+		assert.Equal(t, int64(1), friendRequest.RequestorId)
+		assert.Equal(t, int64(2), friendRequest.TargetId)
+		assert.Equal(t, entity.FriendRequestPending, friendRequest.Status)
+
+		event := <-subscription.Events
+		assert.Equal(t, realtime.EventFriendRequest, event.Type)
+		assert.Equal(t, "user1@example.com", event.Payload.(*entity.FriendRequest).Requestor.Email)
+	})
+
+	t.Run("rolls back when the event cannot be recorded", func(t *testing.T) {
+		dbErr := errors.New("database error")
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockBlockRepo.EXPECT().GetBlockRelationship(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+		mockBlockRepo.EXPECT().GetBlockRelationship(int64(2), int64(1)).Return(nil, gorm.ErrRecordNotFound)
+		mockFriendshipRepo.EXPECT().GetFriendship(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+		mockFriendRequestRepo.EXPECT().GetPendingFriendRequest(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+		mockFriendRequestRepo.EXPECT().GetPendingFriendRequest(int64(2), int64(1)).Return(nil, gorm.ErrRecordNotFound)
+		mockSQL.ExpectBegin()
+		mockFriendRequestRepo.EXPECT().CreateFriendRequest(gomock.Any(), gomock.Any()).Return(nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventFriendRequestSent, gomock.Any()).Return(dbErr)
+		mockSQL.ExpectRollback()
+
+		friendRequest, err := service.SendFriendRequest(1, "user1@example.com", "user2@example.com")
+		assert.Nil(t, friendRequest)
+		assert.Equal(t, dbErr, err)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+	})
+
+	t.Run("concurrent request already pending", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockBlockRepo.EXPECT().GetBlockRelationship(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+		mockBlockRepo.EXPECT().GetBlockRelationship(int64(2), int64(1)).Return(nil, gorm.ErrRecordNotFound)
+		mockFriendshipRepo.EXPECT().GetFriendship(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+		mockFriendRequestRepo.EXPECT().GetPendingFriendRequest(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+		mockFriendRequestRepo.EXPECT().GetPendingFriendRequest(int64(2), int64(1)).Return(nil, gorm.ErrRecordNotFound)
+		mockSQL.ExpectBegin()
+		mockFriendRequestRepo.EXPECT().CreateFriendRequest(gomock.Any(), gomock.Any()).Return(errors.New(`ERROR: duplicate key value violates unique constraint "idx_friend_requests_pending" (SQLSTATE 23505)`))
+		mockSQL.ExpectRollback()
+
+		friendRequest, err := service.SendFriendRequest(1, "user1@example.com", "user2@example.com")
+		assert.Nil(t, friendRequest)
+		assert.Equal(t, ErrAlreadyRequested, err)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+	})
+
+	t.Run("not permitted", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+
+		friendRequest, err := service.SendFriendRequest(2, "user1@example.com", "user2@example.com")
+		assert.Nil(t, friendRequest)
+		assert.Equal(t, ErrNotPermitted, err)
+	})
+
+	t.Run("target not found", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+		friendRequest, err := service.SendFriendRequest(1, "user1@example.com", "user2@example.com")
+		assert.Nil(t, friendRequest)
+		assert.Equal(t, ErrUserNotFound, err)
+	})
+
+	t.Run("same user", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil).Times(2)
+
+		friendRequest, err := service.SendFriendRequest(1, "user1@example.com", "user1@example.com")
+		assert.Nil(t, friendRequest)
+		assert.Equal(t, ErrInvalidRequest, err)
+	})
+
+	t.Run("target blocked requestor", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockBlockRepo.EXPECT().GetBlockRelationship(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+		mockBlockRepo.EXPECT().GetBlockRelationship(int64(2), int64(1)).Return(&entity.BlockRelationship{}, nil)
+
+		friendRequest, err := service.SendFriendRequest(1, "user1@example.com", "user2@example.com")
+		assert.Nil(t, friendRequest)
+		assert.Equal(t, ErrIsBlocked, err)
+	})
+
+	t.Run("already friends", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockBlockRepo.EXPECT().GetBlockRelationship(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+		mockBlockRepo.EXPECT().GetBlockRelationship(int64(2), int64(1)).Return(nil, gorm.ErrRecordNotFound)
+		mockFriendshipRepo.EXPECT().GetFriendship(int64(1), int64(2)).Return(&entity.Friendship{}, nil)
+
+		friendRequest, err := service.SendFriendRequest(1, "user1@example.com", "user2@example.com")
+		assert.Nil(t, friendRequest)
+		assert.Equal(t, ErrAlreadyFriend, err)
+	})
+
+	t.Run("reverse request already pending", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockBlockRepo.EXPECT().GetBlockRelationship(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+		mockBlockRepo.EXPECT().GetBlockRelationship(int64(2), int64(1)).Return(nil, gorm.ErrRecordNotFound)
+		mockFriendshipRepo.EXPECT().GetFriendship(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+		mockFriendRequestRepo.EXPECT().GetPendingFriendRequest(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+		mockFriendRequestRepo.EXPECT().GetPendingFriendRequest(int64(2), int64(1)).Return(&entity.FriendRequest{}, nil)
+
+		friendRequest, err := service.SendFriendRequest(1, "user1@example.com", "user2@example.com")
+		assert.Nil(t, friendRequest)
+		assert.Equal(t, ErrAlreadyRequested, err)
+	})
+}
+
+func TestFriendRequestService_RetrieveIncomingFriendRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFriendRequestRepo := mock.NewMockFriendRequestRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockFriendshipRepo := mock.NewMockFriendshipRepository(ctrl)
+	mockBlockRepo := mock.NewMockBlockRelationshipRepository(ctrl)
+	mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+	hub := realtime.NewHub(constant.StreamBufferSize)
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewFriendRequestService(mockFriendRequestRepo, mockUserRepo, mockFriendshipRepo, mockBlockRepo, mockPreferenceRepo, hub, mockRecorder)
+
+	user2 := &entity.User{Id: 2, Email: "user2@example.com"}
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		expected := []*entity.FriendRequest{{Id: 10, RequestorId: 1, TargetId: 2, Status: entity.FriendRequestPending}}
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockFriendRequestRepo.EXPECT().GetIncomingFriendRequests(int64(2)).Return(expected, nil)
+
+		friendRequests, err := service.RetrieveIncomingFriendRequests(2, "user", "user2@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, friendRequests)
+	})
+
+	t.Run("admin can retrieve any user", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockFriendRequestRepo.EXPECT().GetIncomingFriendRequests(int64(2)).Return([]*entity.FriendRequest{}, nil)
+
+		friendRequests, err := service.RetrieveIncomingFriendRequests(99, "admin", "user2@example.com")
+		assert.NoError(t, err)
+		assert.Empty(t, friendRequests)
+	})
+
+	t.Run("not permitted", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+
+		friendRequests, err := service.RetrieveIncomingFriendRequests(1, "user", "user2@example.com")
+		assert.Nil(t, friendRequests)
+		assert.Equal(t, ErrNotPermitted, err)
+	})
+}
+
+func TestFriendRequestService_AcceptFriendRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFriendRequestRepo := mock.NewMockFriendRequestRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockFriendshipRepo := mock.NewMockFriendshipRepository(ctrl)
+	mockBlockRepo := mock.NewMockBlockRelationshipRepository(ctrl)
+	mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+
+	db, mockSQL, err := sqlmock.New()
+	assert.NoError(t, err)
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+	mockFriendRequestRepo.EXPECT().GetDB().Return(gormDB).AnyTimes()
+
+	hub := realtime.NewHub(constant.StreamBufferSize)
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewFriendRequestService(mockFriendRequestRepo, mockUserRepo, mockFriendshipRepo, mockBlockRepo, mockPreferenceRepo, hub, mockRecorder)
+
+	t.Run("successful acceptance creates normalized friendship", func(t *testing.T) {
+		subscription := hub.Subscribe(2)
+		defer hub.Unsubscribe(subscription)
+		pending := &entity.FriendRequest{
+			Id:          10,
+			RequestorId: 2,
+			TargetId:    1,
+			Status:      entity.FriendRequestPending,
+			Requestor:   &entity.User{Id: 2, Email: "user2@example.com"},
+			Target:      &entity.User{Id: 1, Email: "user1@example.com"},
+		}
+		mockFriendRequestRepo.EXPECT().GetFriendRequestById(int64(10)).Return(pending, nil)
+		mockBlockRepo.EXPECT().GetBlockRelationship(int64(2), int64(1)).Return(nil, gorm.ErrRecordNotFound)
+		mockBlockRepo.EXPECT().GetBlockRelationship(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+		mockSQL.ExpectBegin()
+		mockFriendRequestRepo.EXPECT().UpdateFriendRequestStatus(gomock.Any(), int64(10), entity.FriendRequestAccepted).Return(nil)
+		mockFriendshipRepo.EXPECT().CreateFriendship(gomock.Any(), int64(1), int64(2)).Return(nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventFriendshipCreated, entity.FriendshipEventData{Friends: []string{"user2@example.com", "user1@example.com"}}).Return(nil)
+		mockSQL.ExpectCommit()
+		mockPreferenceRepo.EXPECT().GetPreference(int64(2)).Return(nil, gorm.ErrRecordNotFound)
+
+		err := service.AcceptFriendRequest(1, 10)
+		assert.NoError(t, err)
+
+		event := <-subscription.Events
+		assert.Equal(t, int64(10), event.Id)
+		assert.Equal(t, entity.FriendRequestAccepted, event.Payload.(*entity.FriendRequest).Status)
+	})
+
+	t.Run("request not found", func(t *testing.T) {
+		mockFriendRequestRepo.EXPECT().GetFriendRequestById(int64(11)).Return(nil, gorm.ErrRecordNotFound)
+
+		err := service.AcceptFriendRequest(1, 11)
+		assert.Equal(t, ErrFriendRequestNotFound, err)
+	})
+
+	t.Run("request not pending", func(t *testing.T) {
+		rejected := &entity.FriendRequest{Id: 10, RequestorId: 2, TargetId: 1, Status: entity.FriendRequestRejected}
+		mockFriendRequestRepo.EXPECT().GetFriendRequestById(int64(10)).Return(rejected, nil)
+
+		err := service.AcceptFriendRequest(1, 10)
+		assert.Equal(t, ErrFriendRequestNotPending, err)
+	})
+
+	t.Run("only target can accept", func(t *testing.T) {
+		pending := &entity.FriendRequest{Id: 10, RequestorId: 2, TargetId: 1, Status: entity.FriendRequestPending}
+		mockFriendRequestRepo.EXPECT().GetFriendRequestById(int64(10)).Return(pending, nil)
+
+		err := service.AcceptFriendRequest(2, 10)
+		assert.Equal(t, ErrNotPermitted, err)
+	})
+
+	t.Run("blocked after request was sent", func(t *testing.T) {
+		pending := &entity.FriendRequest{Id: 10, RequestorId: 2, TargetId: 1, Status: entity.FriendRequestPending}
+		mockFriendRequestRepo.EXPECT().GetFriendRequestById(int64(10)).Return(pending, nil)
+		mockBlockRepo.EXPECT().GetBlockRelationship(int64(2), int64(1)).Return(&entity.BlockRelationship{}, nil)
+
+		err := service.AcceptFriendRequest(1, 10)
+		assert.Equal(t, ErrIsBlocked, err)
+	})
+
+	t.Run("already friends rolls back", func(t *testing.T) {
+		pending := &entity.FriendRequest{Id: 10, RequestorId: 2, TargetId: 1, Status: entity.FriendRequestPending}
+		mockFriendRequestRepo.EXPECT().GetFriendRequestById(int64(10)).Return(pending, nil)
+		mockBlockRepo.EXPECT().GetBlockRelationship(int64(2), int64(1)).Return(nil, gorm.ErrRecordNotFound)
+		mockBlockRepo.EXPECT().GetBlockRelationship(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+		mockSQL.ExpectBegin()
+		mockFriendRequestRepo.EXPECT().UpdateFriendRequestStatus(gomock.Any(), int64(10), entity.FriendRequestAccepted).Return(nil)
+		mockFriendshipRepo.EXPECT().CreateFriendship(gomock.Any(), int64(1), int64(2)).Return(errors.New("duplicate key constraint"))
+		mockSQL.ExpectRollback()
+
+		err := service.AcceptFriendRequest(1, 10)
+		assert.Equal(t, ErrAlreadyFriend, err)
+	})
+}
+
+func TestFriendRequestService_RejectAndCancelFriendRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFriendRequestRepo := mock.NewMockFriendRequestRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockFriendshipRepo := mock.NewMockFriendshipRepository(ctrl)
+	mockBlockRepo := mock.NewMockBlockRelationshipRepository(ctrl)
+	mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+	mockFriendRequestRepo.EXPECT().GetDB().Return(nil).AnyTimes()
+	hub := realtime.NewHub(constant.StreamBufferSize)
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewFriendRequestService(mockFriendRequestRepo, mockUserRepo, mockFriendshipRepo, mockBlockRepo, mockPreferenceRepo, hub, mockRecorder)
+
+	pending := &entity.FriendRequest{Id: 10, RequestorId: 2, TargetId: 1, Status: entity.FriendRequestPending}
+
+	t.Run("target rejects", func(t *testing.T) {
+		subscription := hub.Subscribe(2)
+		defer hub.Unsubscribe(subscription)
+		mockFriendRequestRepo.EXPECT().GetFriendRequestById(int64(10)).Return(pending, nil)
+		mockFriendRequestRepo.EXPECT().UpdateFriendRequestStatus(gomock.Any(), int64(10), entity.FriendRequestRejected).Return(nil)
+		mockPreferenceRepo.EXPECT().GetPreference(int64(2)).Return(nil, gorm.ErrRecordNotFound)
+
+		err := service.RejectFriendRequest(1, 10)
+		assert.NoError(t, err)
+
+		event := <-subscription.Events
+		assert.Equal(t, entity.FriendRequestRejected, event.Payload.(*entity.FriendRequest).Status)
+		assert.Equal(t, entity.FriendRequestPending, pending.Status)
+	})
+
+	t.Run("requestor can not reject", func(t *testing.T) {
+		mockFriendRequestRepo.EXPECT().GetFriendRequestById(int64(10)).Return(pending, nil)
+
+		err := service.RejectFriendRequest(2, 10)
+		assert.Equal(t, ErrNotPermitted, err)
+	})
+
+	t.Run("requestor cancels", func(t *testing.T) {
+		subscription := hub.Subscribe(1)
+		defer hub.Unsubscribe(subscription)
+		mockFriendRequestRepo.EXPECT().GetFriendRequestById(int64(10)).Return(pending, nil)
+		mockFriendRequestRepo.EXPECT().UpdateFriendRequestStatus(gomock.Any(), int64(10), entity.FriendRequestCancelled).Return(nil)
+		mockPreferenceRepo.EXPECT().GetPreference(int64(1)).Return(nil, gorm.ErrRecordNotFound)
+
+		err := service.CancelFriendRequest(2, 10)
+		assert.NoError(t, err)
+
+		event := <-subscription.Events
+		assert.Equal(t, entity.FriendRequestCancelled, event.Payload.(*entity.FriendRequest).Status)
+	})
+
+	t.Run("friend request notifications off skip the live push", func(t *testing.T) {
+		subscription := hub.Subscribe(2)
+		defer hub.Unsubscribe(subscription)
+		mockFriendRequestRepo.EXPECT().GetFriendRequestById(int64(10)).Return(pending, nil)
+		mockFriendRequestRepo.EXPECT().UpdateFriendRequestStatus(gomock.Any(), int64(10), entity.FriendRequestRejected).Return(nil)
+		mockPreferenceRepo.EXPECT().GetPreference(int64(2)).Return(&entity.NotificationPreference{
+			UserId:     2,
+			Channels:   entity.NotificationChannels,
+			EventTypes: []string{entity.NotificationMention},
+		}, nil)
+
+		err := service.RejectFriendRequest(1, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, subscription.Events)
+	})
+
+	t.Run("target can not cancel", func(t *testing.T) {
+		mockFriendRequestRepo.EXPECT().GetFriendRequestById(int64(10)).Return(pending, nil)
+
+		err := service.CancelFriendRequest(1, 10)
+		assert.Equal(t, ErrNotPermitted, err)
+	})
+
+	t.Run("concurrent transition", func(t *testing.T) {
+		mockFriendRequestRepo.EXPECT().GetFriendRequestById(int64(10)).Return(pending, nil)
+		mockFriendRequestRepo.EXPECT().UpdateFriendRequestStatus(gomock.Any(), int64(10), entity.FriendRequestCancelled).Return(gorm.ErrRecordNotFound)
+
+		err := service.CancelFriendRequest(2, 10)
+		assert.Equal(t, ErrFriendRequestNotPending, err)
+	})
+}
@@ -8,16 +8,21 @@ import (
 var (
 	ErrUserNotFound   = errors.New("user not found")
 	ErrAlreadyFriend  = errors.New("users are already friends")
+	ErrNotFriend      = errors.New("users are not friends")
 	ErrInvalidRequest = errors.New("two email can not be the same")
 	ErrIsBlocked      = errors.New("one user has blocked another")
 	ErrNotPermitted   = errors.New("action not permitted")
+	ErrPathNotFound   = errors.New("no friendship path found within max depth")
 )
 
 //go:generate mockgen -source=interface.go -destination=../mock/mock_friendship_service.go
 
 type FriendshipService interface {
-	CreateFriendship(authUserId int64, email1, email2 string) error
-	RetrieveFriendsList(authUserId int64, authUserRole string, email string) ([]*entity.User, error)
-	RetrieveCommonFriends(authUserId int64, authUserRole string, email1, email2 string) ([]*entity.User, error)
+	CreateFriendship(authUserId int64, authUserRole string, email1, email2 string) error
+	RetrieveFriendsList(authUserId int64, authUserRole string, email string, afterId int64, limit int) ([]*entity.User, int64, error)
+	RetrieveCommonFriends(authUserId int64, authUserRole string, email1, email2 string, afterId int64, limit int) ([]*entity.User, int64, error)
 	CountFriends(friendsList []*entity.User) int64
+	DeleteFriendship(authUserId int64, email1, email2 string, removeSubscriptions bool) error
+	RetrieveFriendSuggestions(authUserId int64, authUserRole string, email string, limit, offset int) ([]*entity.FriendSuggestion, error)
+	RetrieveFriendshipPath(authUserId int64, authUserRole string, fromEmail, toEmail string, maxDepth int) ([]*entity.User, error)
 }
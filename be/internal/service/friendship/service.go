@@ -4,8 +4,11 @@ import (
 	"BE_Friends_Management/internal/domain/entity"
 	blockRelationshipRepository "BE_Friends_Management/internal/repository/block_relationship"
 	friendshipRepository "BE_Friends_Management/internal/repository/friendship"
+	subscriptionRepository "BE_Friends_Management/internal/repository/subscription"
 	userRepository "BE_Friends_Management/internal/repository/users"
+	outboxService "BE_Friends_Management/internal/service/outbox"
 	"errors"
+	"slices"
 	"strings"
 
 	"gorm.io/gorm"
@@ -15,17 +18,21 @@ type friendshipService struct {
 	repo                  friendshipRepository.FriendshipRepository
 	userRepo              userRepository.UserRepository
 	blockRelationshipRepo blockRelationshipRepository.BlockRelationshipRepository
+	subscriptionRepo      subscriptionRepository.SubscriptionRepository
+	recorder              outboxService.EventRecorder
 }
 
-func NewFriendshipService(repo friendshipRepository.FriendshipRepository, userRepo userRepository.UserRepository, blockRelationshipRepo blockRelationshipRepository.BlockRelationshipRepository) FriendshipService {
+func NewFriendshipService(repo friendshipRepository.FriendshipRepository, userRepo userRepository.UserRepository, blockRelationshipRepo blockRelationshipRepository.BlockRelationshipRepository, subscriptionRepo subscriptionRepository.SubscriptionRepository, recorder outboxService.EventRecorder) FriendshipService {
 	return &friendshipService{
 		repo:                  repo,
 		userRepo:              userRepo,
 		blockRelationshipRepo: blockRelationshipRepo,
+		subscriptionRepo:      subscriptionRepo,
+		recorder:              recorder,
 	}
 }
 
-func (service *friendshipService) CreateFriendship(authUserId int64, email1, email2 string) error {
+func (service *friendshipService) CreateFriendship(authUserId int64, authUserRole string, email1, email2 string) error {
 	user1, err := service.userRepo.GetUserByEmail(email1)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return ErrUserNotFound
@@ -40,7 +47,7 @@ func (service *friendshipService) CreateFriendship(authUserId int64, email1, ema
 	if err != nil {
 		return err
 	}
-	if user1.Id != authUserId && user2.Id != authUserId {
+	if authUserRole == "user" && user1.Id != authUserId && user2.Id != authUserId {
 		return ErrNotPermitted
 	}
 	if user1.Id == user2.Id {
@@ -60,101 +67,279 @@ func (service *friendshipService) CreateFriendship(authUserId int64, email1, ema
 	if !errors.Is(err, gorm.ErrRecordNotFound) {
 		return err
 	}
-	if user1.Id < user2.Id {
-		err = service.repo.CreateFriendship(user1.Id, user2.Id)
-	} else {
-		err = service.repo.CreateFriendship(user2.Id, user1.Id)
-	}
-	if err != nil && strings.Contains(err.Error(), "duplicate key") {
-		return ErrAlreadyFriend
-	}
+	db := service.repo.GetDB()
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if user1.Id < user2.Id {
+			err = service.repo.CreateFriendship(tx, user1.Id, user2.Id)
+		} else {
+			err = service.repo.CreateFriendship(tx, user2.Id, user1.Id)
+		}
+		if err != nil && strings.Contains(err.Error(), "duplicate key") {
+			return ErrAlreadyFriend
+		}
+		if err != nil {
+			return err
+		}
+		return service.recorder.Record(tx, entity.EventFriendshipCreated, entity.FriendshipEventData{Friends: []string{user1.Email, user2.Email}})
+	})
 	return err
 }
 
-func (service *friendshipService) RetrieveFriendsList(authUserId int64, authUserRole string, email string) ([]*entity.User, error) {
+func (service *friendshipService) RetrieveFriendsList(authUserId int64, authUserRole string, email string, afterId int64, limit int) ([]*entity.User, int64, error) {
 	user, err := service.userRepo.GetUserByEmail(email)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, ErrUserNotFound
+		return nil, 0, ErrUserNotFound
 	}
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	if authUserRole == "user" && authUserId != user.Id {
-		return nil, ErrNotPermitted
+		return nil, 0, ErrNotPermitted
 	}
-	friendIds, err := service.repo.RetrieveFriendIds(user.Id)
+	total, err := service.repo.CountFriendIds(user.Id)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	friends := make([]*entity.User, len(friendIds))
-	for i, id := range friendIds {
-		friend, err := service.userRepo.GetUserById(id)
-		if err != nil {
-			return nil, err
+	friends, err := service.repo.RetrieveFriends(user.Id, afterId, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return friends, total, nil
+}
+
+func (service *friendshipService) RetrieveCommonFriends(authUserId int64, authUserRole string, email1, email2 string, afterId int64, limit int) ([]*entity.User, int64, error) {
+	user1, err := service.userRepo.GetUserByEmail(email1)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, 0, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	user2, err := service.userRepo.GetUserByEmail(email2)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, 0, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	if authUserRole == "user" && authUserId != user1.Id && authUserId != user2.Id {
+		return nil, 0, ErrNotPermitted
+	}
+	if user1.Id == user2.Id {
+		return nil, 0, ErrInvalidRequest
+	}
+	total, err := service.repo.CountCommonFriends(user1.Id, user2.Id)
+	if err != nil {
+		return nil, 0, err
+	}
+	commonFriends, err := service.repo.RetrieveCommonFriends(user1.Id, user2.Id, afterId, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return commonFriends, total, nil
+}
+
+func (service *friendshipService) CountFriends(friends []*entity.User) int64 {
+	var count int64 = 0
+	for _, friend := range friends {
+		if friend != nil {
+			count++
 		}
-		friends[i] = friend
 	}
-	return friends, nil
+	return count
 }
 
-func (service *friendshipService) RetrieveCommonFriends(authUserId int64, authUserRole string, email1, email2 string) ([]*entity.User, error) {
+func (service *friendshipService) DeleteFriendship(authUserId int64, email1, email2 string, removeSubscriptions bool) error {
 	user1, err := service.userRepo.GetUserByEmail(email1)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	user2, err := service.userRepo.GetUserByEmail(email2)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if user1.Id != authUserId && user2.Id != authUserId {
+		return ErrNotPermitted
+	}
+	if user1.Id == user2.Id {
+		return ErrInvalidRequest
+	}
+	userId1 := user1.Id
+	userId2 := user2.Id
+	if userId1 > userId2 {
+		userId1, userId2 = userId2, userId1
+	}
+	_, err = service.repo.GetFriendship(userId1, userId2)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFriend
+	}
+	if err != nil {
+		return err
+	}
+	db := service.repo.GetDB()
+	err = db.Transaction(func(tx *gorm.DB) error {
+		err := service.repo.DeleteFriendship(tx, userId1, userId2)
+		if err != nil {
+			return err
+		}
+		if removeSubscriptions {
+			err = service.subscriptionRepo.DeleteSubscription(tx, userId1, userId2)
+			if err != nil {
+				return err
+			}
+			err = service.subscriptionRepo.DeleteSubscription(tx, userId2, userId1)
+			if err != nil {
+				return err
+			}
+		}
+		return service.recorder.Record(tx, entity.EventFriendshipDeleted, entity.FriendshipEventData{Friends: []string{user1.Email, user2.Email}})
+	})
+	return err
+}
+
+func (service *friendshipService) RetrieveFriendSuggestions(authUserId int64, authUserRole string, email string, limit, offset int) ([]*entity.FriendSuggestion, error) {
+	user, err := service.userRepo.GetUserByEmail(email)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, ErrUserNotFound
 	}
 	if err != nil {
 		return nil, err
 	}
-	user2, err := service.userRepo.GetUserByEmail(email2)
+	if authUserRole == "user" && authUserId != user.Id {
+		return nil, ErrNotPermitted
+	}
+	return service.repo.RetrieveFriendSuggestions(user.Id, limit, offset)
+}
+
+func (service *friendshipService) RetrieveFriendshipPath(authUserId int64, authUserRole string, fromEmail, toEmail string, maxDepth int) ([]*entity.User, error) {
+	from, err := service.userRepo.GetUserByEmail(fromEmail)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, ErrUserNotFound
 	}
 	if err != nil {
 		return nil, err
 	}
-	if authUserRole == "user" && authUserId != user1.Id && authUserId != user2.Id {
+	to, err := service.userRepo.GetUserByEmail(toEmail)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if authUserRole == "user" && authUserId != from.Id && authUserId != to.Id {
 		return nil, ErrNotPermitted
 	}
-	if user1.Id == user2.Id {
+	if from.Id == to.Id {
 		return nil, ErrInvalidRequest
 	}
-	friendIdsOfUser1, err := service.repo.RetrieveFriendIds(user1.Id)
+	excludedIds := make(map[int64]bool)
+	for _, userId := range []int64{from.Id, to.Id} {
+		blockRequestorIds, err := service.blockRelationshipRepo.GetBlockRequestorIds(userId)
+		if err != nil {
+			return nil, err
+		}
+		blockedTargetIds, err := service.blockRelationshipRepo.GetBlockedTargetIds(userId)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range append(blockRequestorIds, blockedTargetIds...) {
+			excludedIds[id] = true
+		}
+	}
+	if excludedIds[from.Id] || excludedIds[to.Id] {
+		return nil, ErrIsBlocked
+	}
+	pathIds, err := service.findShortestPath(from.Id, to.Id, maxDepth, excludedIds)
 	if err != nil {
 		return nil, err
 	}
-	friendIdsOfUser2, err := service.repo.RetrieveFriendIds(user2.Id)
+	users, err := service.userRepo.GetUsersFromIds(pathIds)
 	if err != nil {
 		return nil, err
 	}
-
-	set := make(map[int64]bool)
-	commonFriendIds := []int64{}
-	for _, id1 := range friendIdsOfUser1 {
-		set[id1] = true
+	usersById := make(map[int64]*entity.User, len(users))
+	for _, user := range users {
+		usersById[user.Id] = user
 	}
-	for _, id2 := range friendIdsOfUser2 {
-		if set[id2] {
-			commonFriendIds = append(commonFriendIds, id2)
+	path := make([]*entity.User, 0, len(pathIds))
+	for _, id := range pathIds {
+		user, ok := usersById[id]
+		if !ok {
+			return nil, ErrPathNotFound
 		}
+		path = append(path, user)
 	}
+	return path, nil
+}
 
-	commonFriends := make([]*entity.User, len(commonFriendIds))
-	for i, id := range commonFriendIds {
-		commenFriend, err := service.userRepo.GetUserById(id)
+// findShortestPath runs a bidirectional BFS over the friendship graph, always
+// expanding the smaller frontier, and returns the user ids from fromId to toId.
+func (service *friendshipService) findShortestPath(fromId, toId int64, maxDepth int, excludedIds map[int64]bool) ([]int64, error) {
+	parentsFrom := map[int64]int64{fromId: fromId}
+	parentsTo := map[int64]int64{toId: toId}
+	frontierFrom := []int64{fromId}
+	frontierTo := []int64{toId}
+	for depth := 0; depth < maxDepth && len(frontierFrom) > 0 && len(frontierTo) > 0; depth++ {
+		expandFromSide := len(frontierFrom) <= len(frontierTo)
+		frontier, parents, otherParents := frontierTo, parentsTo, parentsFrom
+		if expandFromSide {
+			frontier, parents, otherParents = frontierFrom, parentsFrom, parentsTo
+		}
+		friendships, err := service.repo.RetrieveFriendshipsOfUsers(frontier)
 		if err != nil {
 			return nil, err
 		}
-		commonFriends[i] = commenFriend
+		inFrontier := make(map[int64]bool, len(frontier))
+		for _, id := range frontier {
+			inFrontier[id] = true
+		}
+		nextFrontier := []int64{}
+		meetingId := int64(0)
+		for _, friendship := range friendships {
+			for _, edge := range [][2]int64{{friendship.UserId1, friendship.UserId2}, {friendship.UserId2, friendship.UserId1}} {
+				current, neighbor := edge[0], edge[1]
+				if !inFrontier[current] || excludedIds[neighbor] {
+					continue
+				}
+				if _, visited := parents[neighbor]; visited {
+					continue
+				}
+				parents[neighbor] = current
+				nextFrontier = append(nextFrontier, neighbor)
+				if _, reached := otherParents[neighbor]; reached && meetingId == 0 {
+					meetingId = neighbor
+				}
+			}
+		}
+		if meetingId != 0 {
+			return buildPath(parentsFrom, parentsTo, meetingId), nil
+		}
+		if expandFromSide {
+			frontierFrom = nextFrontier
+		} else {
+			frontierTo = nextFrontier
+		}
 	}
-	return commonFriends, nil
+	return nil, ErrPathNotFound
 }
 
-func (service *friendshipService) CountFriends(friends []*entity.User) int64 {
-	var count int64 = 0
-	for _, friend := range friends {
-		if friend != nil {
-			count++
-		}
+func buildPath(parentsFrom, parentsTo map[int64]int64, meetingId int64) []int64 {
+	path := []int64{meetingId}
+	for id := meetingId; parentsFrom[id] != id; {
+		id = parentsFrom[id]
+		path = append(path, id)
 	}
-	return count
+	slices.Reverse(path)
+	for id := meetingId; parentsTo[id] != id; {
+		id = parentsTo[id]
+		path = append(path, id)
+	}
+	return path
 }
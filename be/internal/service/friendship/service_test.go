@@ -2,14 +2,19 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"slices"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
 	entity "BE_Friends_Management/internal/domain/entity"
 	mock "BE_Friends_Management/internal/repository/mock"
+	serviceMock "BE_Friends_Management/internal/service/mock"
 )
 
 func TestSubscriptionService_CreateFriendship(t *testing.T) {
@@ -19,7 +24,16 @@ func TestSubscriptionService_CreateFriendship(t *testing.T) {
 	mockFriendshipRepo := mock.NewMockFriendshipRepository(ctrl)
 	mockUserRepo := mock.NewMockUserRepository(ctrl)
 	mockBlockRepo := mock.NewMockBlockRelationshipRepository(ctrl)
-	service := NewFriendshipService(mockFriendshipRepo, mockUserRepo, mockBlockRepo)
+	mockSubscriptionRepo := mock.NewMockSubscriptionRepository(ctrl)
+	db, mockSQL, err := sqlmock.New()
+	assert.NoError(t, err)
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+	mockFriendshipRepo.EXPECT().GetDB().Return(gormDB).AnyTimes()
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewFriendshipService(mockFriendshipRepo, mockUserRepo, mockBlockRepo, mockSubscriptionRepo, mockRecorder)
 
 	t.Run("successful friendship creation with user1.Id < user2.Id", func(t *testing.T) {
 		authUserId := int64(1)
@@ -32,12 +46,37 @@ func TestSubscriptionService_CreateFriendship(t *testing.T) {
 		mockUserRepo.EXPECT().GetUserByEmail(email2).Return(user2, nil)
 		mockBlockRepo.EXPECT().GetBlockRelationship(user1.Id, user2.Id).Return(nil, gorm.ErrRecordNotFound)
 		mockBlockRepo.EXPECT().GetBlockRelationship(user2.Id, user1.Id).Return(nil, gorm.ErrRecordNotFound)
-		mockFriendshipRepo.EXPECT().CreateFriendship(user1.Id, user2.Id).Return(nil)
+		mockSQL.ExpectBegin()
+		mockFriendshipRepo.EXPECT().CreateFriendship(gomock.Any(), user1.Id, user2.Id).Return(nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventFriendshipCreated, entity.FriendshipEventData{Friends: []string{email1, email2}}).Return(nil)
+		mockSQL.ExpectCommit()
 
-		err := service.CreateFriendship(authUserId, email1, email2)
+		err := service.CreateFriendship(authUserId, "user", email1, email2)
 		assert.NoError(t, err)
 	})
 
+	t.Run("rolls back when the event cannot be recorded", func(t *testing.T) {
+		authUserId := int64(1)
+		email1 := "user1@example.com"
+		email2 := "user2@example.com"
+		user1 := &entity.User{Id: 1, Email: email1}
+		user2 := &entity.User{Id: 2, Email: email2}
+		dbError := errors.New("database error")
+
+		mockUserRepo.EXPECT().GetUserByEmail(email1).Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail(email2).Return(user2, nil)
+		mockBlockRepo.EXPECT().GetBlockRelationship(user1.Id, user2.Id).Return(nil, gorm.ErrRecordNotFound)
+		mockBlockRepo.EXPECT().GetBlockRelationship(user2.Id, user1.Id).Return(nil, gorm.ErrRecordNotFound)
+		mockSQL.ExpectBegin()
+		mockFriendshipRepo.EXPECT().CreateFriendship(gomock.Any(), user1.Id, user2.Id).Return(nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventFriendshipCreated, gomock.Any()).Return(dbError)
+		mockSQL.ExpectRollback()
+
+		err := service.CreateFriendship(authUserId, "user", email1, email2)
+		assert.Equal(t, dbError, err)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+	})
+
 	t.Run("successful friendship creation with user1.Id > user2.Id", func(t *testing.T) {
 		authUserId := int64(1)
 		email1 := "user1@example.com"
@@ -49,9 +88,12 @@ func TestSubscriptionService_CreateFriendship(t *testing.T) {
 		mockUserRepo.EXPECT().GetUserByEmail(email2).Return(user2, nil)
 		mockBlockRepo.EXPECT().GetBlockRelationship(user1.Id, user2.Id).Return(nil, gorm.ErrRecordNotFound)
 		mockBlockRepo.EXPECT().GetBlockRelationship(user2.Id, user1.Id).Return(nil, gorm.ErrRecordNotFound)
-		mockFriendshipRepo.EXPECT().CreateFriendship(user2.Id, user1.Id).Return(nil)
+		mockSQL.ExpectBegin()
+		mockFriendshipRepo.EXPECT().CreateFriendship(gomock.Any(), user2.Id, user1.Id).Return(nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventFriendshipCreated, entity.FriendshipEventData{Friends: []string{email1, email2}}).Return(nil)
+		mockSQL.ExpectCommit()
 
-		err := service.CreateFriendship(authUserId, email1, email2)
+		err := service.CreateFriendship(authUserId, "user", email1, email2)
 		assert.NoError(t, err)
 	})
 
@@ -62,7 +104,7 @@ func TestSubscriptionService_CreateFriendship(t *testing.T) {
 
 		mockUserRepo.EXPECT().GetUserByEmail(email1).Return(nil, gorm.ErrRecordNotFound)
 
-		err := service.CreateFriendship(authUserId, email1, email2)
+		err := service.CreateFriendship(authUserId, "user", email1, email2)
 		assert.Equal(t, ErrUserNotFound, err)
 	})
 
@@ -75,7 +117,7 @@ func TestSubscriptionService_CreateFriendship(t *testing.T) {
 		mockUserRepo.EXPECT().GetUserByEmail(email1).Return(user1, nil)
 		mockUserRepo.EXPECT().GetUserByEmail(email2).Return(nil, gorm.ErrRecordNotFound)
 
-		err := service.CreateFriendship(authUserId, email1, email2)
+		err := service.CreateFriendship(authUserId, "user", email1, email2)
 		assert.Equal(t, ErrUserNotFound, err)
 	})
 
@@ -86,7 +128,7 @@ func TestSubscriptionService_CreateFriendship(t *testing.T) {
 
 		mockUserRepo.EXPECT().GetUserByEmail(email).Return(user, nil).Times(2)
 
-		err := service.CreateFriendship(authUserId, email, email)
+		err := service.CreateFriendship(authUserId, "user", email, email)
 		assert.Equal(t, ErrInvalidRequest, err)
 	})
 
@@ -101,7 +143,7 @@ func TestSubscriptionService_CreateFriendship(t *testing.T) {
 		mockUserRepo.EXPECT().GetUserByEmail(email2).Return(user2, nil)
 		mockBlockRepo.EXPECT().GetBlockRelationship(user1.Id, user2.Id).Return(&entity.BlockRelationship{}, nil)
 
-		err := service.CreateFriendship(authUserId, email1, email2)
+		err := service.CreateFriendship(authUserId, "user", email1, email2)
 		assert.Equal(t, ErrIsBlocked, err)
 	})
 
@@ -117,7 +159,7 @@ func TestSubscriptionService_CreateFriendship(t *testing.T) {
 		mockBlockRepo.EXPECT().GetBlockRelationship(user1.Id, user2.Id).Return(nil, gorm.ErrRecordNotFound)
 		mockBlockRepo.EXPECT().GetBlockRelationship(user2.Id, user1.Id).Return(&entity.BlockRelationship{}, nil)
 
-		err := service.CreateFriendship(authUserId, email1, email2)
+		err := service.CreateFriendship(authUserId, "user", email1, email2)
 		assert.Equal(t, ErrIsBlocked, err)
 	})
 
@@ -133,9 +175,11 @@ func TestSubscriptionService_CreateFriendship(t *testing.T) {
 		mockUserRepo.EXPECT().GetUserByEmail(email2).Return(user2, nil)
 		mockBlockRepo.EXPECT().GetBlockRelationship(user1.Id, user2.Id).Return(nil, gorm.ErrRecordNotFound)
 		mockBlockRepo.EXPECT().GetBlockRelationship(user2.Id, user1.Id).Return(nil, gorm.ErrRecordNotFound)
-		mockFriendshipRepo.EXPECT().CreateFriendship(user1.Id, user2.Id).Return(duplicateKeyError)
+		mockSQL.ExpectBegin()
+		mockFriendshipRepo.EXPECT().CreateFriendship(gomock.Any(), user1.Id, user2.Id).Return(duplicateKeyError)
+		mockSQL.ExpectRollback()
 
-		err := service.CreateFriendship(authUserId, email1, email2)
+		err := service.CreateFriendship(authUserId, "user", email1, email2)
 		assert.Equal(t, ErrAlreadyFriend, err)
 	})
 
@@ -147,7 +191,7 @@ func TestSubscriptionService_CreateFriendship(t *testing.T) {
 
 		mockUserRepo.EXPECT().GetUserByEmail(email1).Return(nil, dbError)
 
-		err := service.CreateFriendship(authUserId, email1, email2)
+		err := service.CreateFriendship(authUserId, "user", email1, email2)
 		assert.Equal(t, dbError, err)
 	})
 
@@ -161,7 +205,7 @@ func TestSubscriptionService_CreateFriendship(t *testing.T) {
 		mockUserRepo.EXPECT().GetUserByEmail(email1).Return(user1, nil)
 		mockUserRepo.EXPECT().GetUserByEmail(email2).Return(nil, dbError)
 
-		err := service.CreateFriendship(authUserId, email1, email2)
+		err := service.CreateFriendship(authUserId, "user", email1, email2)
 		assert.Equal(t, dbError, err)
 	})
 
@@ -177,7 +221,7 @@ func TestSubscriptionService_CreateFriendship(t *testing.T) {
 		mockUserRepo.EXPECT().GetUserByEmail(email2).Return(user2, nil)
 		mockBlockRepo.EXPECT().GetBlockRelationship(user1.Id, user2.Id).Return(nil, dbError)
 
-		err := service.CreateFriendship(authUserId, email1, email2)
+		err := service.CreateFriendship(authUserId, "user", email1, email2)
 		assert.Equal(t, dbError, err)
 	})
 
@@ -194,7 +238,7 @@ func TestSubscriptionService_CreateFriendship(t *testing.T) {
 		mockBlockRepo.EXPECT().GetBlockRelationship(user1.Id, user2.Id).Return(nil, gorm.ErrRecordNotFound)
 		mockBlockRepo.EXPECT().GetBlockRelationship(user2.Id, user1.Id).Return(nil, dbError)
 
-		err := service.CreateFriendship(authUserId, email1, email2)
+		err := service.CreateFriendship(authUserId, "user", email1, email2)
 		assert.Equal(t, dbError, err)
 	})
 
@@ -210,9 +254,11 @@ func TestSubscriptionService_CreateFriendship(t *testing.T) {
 		mockUserRepo.EXPECT().GetUserByEmail(email2).Return(user2, nil)
 		mockBlockRepo.EXPECT().GetBlockRelationship(user1.Id, user2.Id).Return(nil, gorm.ErrRecordNotFound)
 		mockBlockRepo.EXPECT().GetBlockRelationship(user2.Id, user1.Id).Return(nil, gorm.ErrRecordNotFound)
-		mockFriendshipRepo.EXPECT().CreateFriendship(user1.Id, user2.Id).Return(dbError)
+		mockSQL.ExpectBegin()
+		mockFriendshipRepo.EXPECT().CreateFriendship(gomock.Any(), user1.Id, user2.Id).Return(dbError)
+		mockSQL.ExpectRollback()
 
-		err := service.CreateFriendship(authUserId, email1, email2)
+		err := service.CreateFriendship(authUserId, "user", email1, email2)
 		assert.Equal(t, dbError, err)
 	})
 }
@@ -224,7 +270,9 @@ func TestSubscriptionService_RetrieveFriendIds(t *testing.T) {
 	mockFriendshipRepo := mock.NewMockFriendshipRepository(ctrl)
 	mockUserRepo := mock.NewMockUserRepository(ctrl)
 	mockBlockRepo := mock.NewMockBlockRelationshipRepository(ctrl)
-	service := NewFriendshipService(mockFriendshipRepo, mockUserRepo, mockBlockRepo)
+	mockSubscriptionRepo := mock.NewMockSubscriptionRepository(ctrl)
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewFriendshipService(mockFriendshipRepo, mockUserRepo, mockBlockRepo, mockSubscriptionRepo, mockRecorder)
 
 	t.Run("Success - retrieve friends list", func(t *testing.T) {
 		authUserId := int64(1)
@@ -232,32 +280,32 @@ func TestSubscriptionService_RetrieveFriendIds(t *testing.T) {
 		user := &entity.User{Id: 1, Email: "user@example.com"}
 		friend1 := &entity.User{Id: 2, Email: "friend1@example.com"}
 		friend2 := &entity.User{Id: 3, Email: "friend2@example.com"}
-		friendIds := []int64{2, 3}
 
 		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(user, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(1)).Return(friendIds, nil)
-		mockUserRepo.EXPECT().GetUserById(int64(2)).Return(friend1, nil)
-		mockUserRepo.EXPECT().GetUserById(int64(3)).Return(friend2, nil)
+		mockFriendshipRepo.EXPECT().CountFriendIds(int64(1)).Return(int64(2), nil)
+		mockFriendshipRepo.EXPECT().RetrieveFriends(int64(1), int64(0), 20).Return([]*entity.User{friend1, friend2}, nil)
 
-		friends, err := service.RetrieveFriendsList(authUserId, authUserRole, "user@example.com")
+		friends, total, err := service.RetrieveFriendsList(authUserId, authUserRole, "user@example.com", 0, 20)
 		assert.NoError(t, err)
 		assert.Len(t, friends, 2)
 		assert.Equal(t, friend1, friends[0])
 		assert.Equal(t, friend2, friends[1])
+		assert.Equal(t, int64(2), total)
 	})
 
 	t.Run("Success - empty friends list", func(t *testing.T) {
 		authUserId := int64(1)
 		authUserRole := "user"
 		user := &entity.User{Id: 1, Email: "user@example.com"}
-		friendIds := []int64{}
 
 		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(user, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(1)).Return(friendIds, nil)
+		mockFriendshipRepo.EXPECT().CountFriendIds(int64(1)).Return(int64(0), nil)
+		mockFriendshipRepo.EXPECT().RetrieveFriends(int64(1), int64(0), 20).Return([]*entity.User{}, nil)
 
-		friends, err := service.RetrieveFriendsList(authUserId, authUserRole, "user@example.com")
+		friends, total, err := service.RetrieveFriendsList(authUserId, authUserRole, "user@example.com", 0, 20)
 		assert.NoError(t, err)
 		assert.Len(t, friends, 0)
+		assert.Equal(t, int64(0), total)
 	})
 
 	t.Run("Error - user not found", func(t *testing.T) {
@@ -265,8 +313,9 @@ func TestSubscriptionService_RetrieveFriendIds(t *testing.T) {
 		authUserRole := "user"
 		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(nil, gorm.ErrRecordNotFound)
 
-		friends, err := service.RetrieveFriendsList(authUserId, authUserRole, "user@example.com")
+		friends, total, err := service.RetrieveFriendsList(authUserId, authUserRole, "user@example.com", 0, 20)
 		assert.Nil(t, friends)
+		assert.Equal(t, int64(0), total)
 		assert.Equal(t, ErrUserNotFound, err)
 	})
 
@@ -276,8 +325,9 @@ func TestSubscriptionService_RetrieveFriendIds(t *testing.T) {
 		repoErr := errors.New("database error")
 		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(nil, repoErr)
 
-		friends, err := service.RetrieveFriendsList(authUserId, authUserRole, "user@example.com")
+		friends, total, err := service.RetrieveFriendsList(authUserId, authUserRole, "user@example.com", 0, 20)
 		assert.Nil(t, friends)
+		assert.Equal(t, int64(0), total)
 		assert.Equal(t, repoErr, err)
 	})
 
@@ -288,28 +338,45 @@ func TestSubscriptionService_RetrieveFriendIds(t *testing.T) {
 		repoErr := errors.New("friendship database error")
 
 		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(user, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(1)).Return(nil, repoErr)
+		mockFriendshipRepo.EXPECT().CountFriendIds(int64(1)).Return(int64(2), nil)
+		mockFriendshipRepo.EXPECT().RetrieveFriends(int64(1), int64(0), 20).Return(nil, repoErr)
 
-		friends, err := service.RetrieveFriendsList(authUserId, authUserRole, "user@example.com")
+		friends, total, err := service.RetrieveFriendsList(authUserId, authUserRole, "user@example.com", 0, 20)
 		assert.Nil(t, friends)
+		assert.Equal(t, int64(0), total)
 		assert.Equal(t, repoErr, err)
 	})
 
-	t.Run("Error - friend lookup error", func(t *testing.T) {
+	t.Run("Error - count friends error", func(t *testing.T) {
 		authUserId := int64(1)
 		authUserRole := "user"
 		user := &entity.User{Id: 1, Email: "user@example.com"}
-		friendIds := []int64{2, 3}
-		repoErr := errors.New("friend lookup error")
+		repoErr := errors.New("count error")
 
 		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(user, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(1)).Return(friendIds, nil)
-		mockUserRepo.EXPECT().GetUserById(int64(2)).Return(nil, repoErr)
+		mockFriendshipRepo.EXPECT().CountFriendIds(int64(1)).Return(int64(0), repoErr)
 
-		friends, err := service.RetrieveFriendsList(authUserId, authUserRole, "user@example.com")
+		friends, total, err := service.RetrieveFriendsList(authUserId, authUserRole, "user@example.com", 0, 20)
 		assert.Nil(t, friends)
+		assert.Equal(t, int64(0), total)
 		assert.Equal(t, repoErr, err)
 	})
+
+	t.Run("Success - page after cursor reports total", func(t *testing.T) {
+		authUserId := int64(1)
+		authUserRole := "user"
+		user := &entity.User{Id: 1, Email: "user@example.com"}
+		friend3 := &entity.User{Id: 3, Email: "friend2@example.com"}
+
+		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(user, nil)
+		mockFriendshipRepo.EXPECT().CountFriendIds(int64(1)).Return(int64(3), nil)
+		mockFriendshipRepo.EXPECT().RetrieveFriends(int64(1), int64(2), 1).Return([]*entity.User{friend3}, nil)
+
+		friends, total, err := service.RetrieveFriendsList(authUserId, authUserRole, "user@example.com", 2, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []*entity.User{friend3}, friends)
+		assert.Equal(t, int64(3), total)
+	})
 }
 func TestSubscriptionService_RetrieveCommonFriends(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -318,7 +385,9 @@ func TestSubscriptionService_RetrieveCommonFriends(t *testing.T) {
 	mockFriendshipRepo := mock.NewMockFriendshipRepository(ctrl)
 	mockUserRepo := mock.NewMockUserRepository(ctrl)
 	mockBlockRepo := mock.NewMockBlockRelationshipRepository(ctrl)
-	service := NewFriendshipService(mockFriendshipRepo, mockUserRepo, mockBlockRepo)
+	mockSubscriptionRepo := mock.NewMockSubscriptionRepository(ctrl)
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewFriendshipService(mockFriendshipRepo, mockUserRepo, mockBlockRepo, mockSubscriptionRepo, mockRecorder)
 
 	t.Run("Success - common friends found", func(t *testing.T) {
 		authUserId := int64(1)
@@ -328,21 +397,17 @@ func TestSubscriptionService_RetrieveCommonFriends(t *testing.T) {
 		commonFriend1 := &entity.User{Id: 3, Email: "friend1@example.com"}
 		commonFriend2 := &entity.User{Id: 4, Email: "friend2@example.com"}
 
-		friendIdsOfUser1 := []int64{3, 4, 5}
-		friendIdsOfUser2 := []int64{3, 4, 6}
-
 		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
 		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(1)).Return(friendIdsOfUser1, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(2)).Return(friendIdsOfUser2, nil)
-		mockUserRepo.EXPECT().GetUserById(int64(3)).Return(commonFriend1, nil)
-		mockUserRepo.EXPECT().GetUserById(int64(4)).Return(commonFriend2, nil)
+		mockFriendshipRepo.EXPECT().CountCommonFriends(int64(1), int64(2)).Return(int64(2), nil)
+		mockFriendshipRepo.EXPECT().RetrieveCommonFriends(int64(1), int64(2), int64(0), 20).Return([]*entity.User{commonFriend1, commonFriend2}, nil)
 
-		commonFriends, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user1@example.com", "user2@example.com")
+		commonFriends, total, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user1@example.com", "user2@example.com", 0, 20)
 		assert.NoError(t, err)
 		assert.Len(t, commonFriends, 2)
 		assert.Equal(t, commonFriend1, commonFriends[0])
 		assert.Equal(t, commonFriend2, commonFriends[1])
+		assert.Equal(t, int64(2), total)
 	})
 
 	t.Run("Success - no common friends", func(t *testing.T) {
@@ -351,36 +416,33 @@ func TestSubscriptionService_RetrieveCommonFriends(t *testing.T) {
 		user1 := &entity.User{Id: 1, Email: "user1@example.com"}
 		user2 := &entity.User{Id: 2, Email: "user2@example.com"}
 
-		friendIdsOfUser1 := []int64{3, 4}
-		friendIdsOfUser2 := []int64{5, 6}
-
 		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
 		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(1)).Return(friendIdsOfUser1, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(2)).Return(friendIdsOfUser2, nil)
+		mockFriendshipRepo.EXPECT().CountCommonFriends(int64(1), int64(2)).Return(int64(0), nil)
+		mockFriendshipRepo.EXPECT().RetrieveCommonFriends(int64(1), int64(2), int64(0), 20).Return([]*entity.User{}, nil)
 
-		commonFriends, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user1@example.com", "user2@example.com")
+		commonFriends, total, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user1@example.com", "user2@example.com", 0, 20)
 		assert.NoError(t, err)
 		assert.Len(t, commonFriends, 0)
+		assert.Equal(t, int64(0), total)
 	})
 
-	t.Run("Success - empty friends lists", func(t *testing.T) {
+	t.Run("Success - page after cursor reports total", func(t *testing.T) {
 		authUserId := int64(1)
 		authUserRole := "user"
 		user1 := &entity.User{Id: 1, Email: "user1@example.com"}
 		user2 := &entity.User{Id: 2, Email: "user2@example.com"}
-
-		friendIdsOfUser1 := []int64{}
-		friendIdsOfUser2 := []int64{}
+		commonFriend := &entity.User{Id: 5, Email: "friend5@example.com"}
 
 		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
 		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(1)).Return(friendIdsOfUser1, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(2)).Return(friendIdsOfUser2, nil)
+		mockFriendshipRepo.EXPECT().CountCommonFriends(int64(1), int64(2)).Return(int64(3), nil)
+		mockFriendshipRepo.EXPECT().RetrieveCommonFriends(int64(1), int64(2), int64(3), 1).Return([]*entity.User{commonFriend}, nil)
 
-		commonFriends, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user1@example.com", "user2@example.com")
+		commonFriends, total, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user1@example.com", "user2@example.com", 3, 1)
 		assert.NoError(t, err)
-		assert.Len(t, commonFriends, 0)
+		assert.Equal(t, []*entity.User{commonFriend}, commonFriends)
+		assert.Equal(t, int64(3), total)
 	})
 
 	t.Run("Error - user1 not found", func(t *testing.T) {
@@ -388,8 +450,9 @@ func TestSubscriptionService_RetrieveCommonFriends(t *testing.T) {
 		authUserRole := "user"
 		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(nil, gorm.ErrRecordNotFound)
 
-		commonFriends, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user1@example.com", "user2@example.com")
+		commonFriends, total, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user1@example.com", "user2@example.com", 0, 20)
 		assert.Nil(t, commonFriends)
+		assert.Equal(t, int64(0), total)
 		assert.Equal(t, ErrUserNotFound, err)
 	})
 
@@ -401,8 +464,9 @@ func TestSubscriptionService_RetrieveCommonFriends(t *testing.T) {
 		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
 		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(nil, gorm.ErrRecordNotFound)
 
-		commonFriends, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user1@example.com", "user2@example.com")
+		commonFriends, total, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user1@example.com", "user2@example.com", 0, 20)
 		assert.Nil(t, commonFriends)
+		assert.Equal(t, int64(0), total)
 		assert.Equal(t, ErrUserNotFound, err)
 	})
 
@@ -413,8 +477,9 @@ func TestSubscriptionService_RetrieveCommonFriends(t *testing.T) {
 
 		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(user, nil).Times(2)
 
-		commonFriends, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user@example.com", "user@example.com")
+		commonFriends, total, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user@example.com", "user@example.com", 0, 20)
 		assert.Nil(t, commonFriends)
+		assert.Equal(t, int64(0), total)
 		assert.Equal(t, ErrInvalidRequest, err)
 	})
 
@@ -424,8 +489,9 @@ func TestSubscriptionService_RetrieveCommonFriends(t *testing.T) {
 		repoErr := errors.New("database error")
 		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(nil, repoErr)
 
-		commonFriends, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user1@example.com", "user2@example.com")
+		commonFriends, total, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user1@example.com", "user2@example.com", 0, 20)
 		assert.Nil(t, commonFriends)
+		assert.Equal(t, int64(0), total)
 		assert.Equal(t, repoErr, err)
 	})
 
@@ -438,12 +504,13 @@ func TestSubscriptionService_RetrieveCommonFriends(t *testing.T) {
 		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
 		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(nil, repoErr)
 
-		commonFriends, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user1@example.com", "user2@example.com")
+		commonFriends, total, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user1@example.com", "user2@example.com", 0, 20)
 		assert.Nil(t, commonFriends)
+		assert.Equal(t, int64(0), total)
 		assert.Equal(t, repoErr, err)
 	})
 
-	t.Run("Error - friendship repo error for user1", func(t *testing.T) {
+	t.Run("Error - count common friends error", func(t *testing.T) {
 		authUserId := int64(1)
 		authUserRole := "user"
 		user1 := &entity.User{Id: 1, Email: "user1@example.com"}
@@ -452,52 +519,33 @@ func TestSubscriptionService_RetrieveCommonFriends(t *testing.T) {
 
 		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
 		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(1)).Return(nil, repoErr)
+		mockFriendshipRepo.EXPECT().CountCommonFriends(int64(1), int64(2)).Return(int64(0), repoErr)
 
-		commonFriends, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user1@example.com", "user2@example.com")
+		commonFriends, total, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user1@example.com", "user2@example.com", 0, 20)
 		assert.Nil(t, commonFriends)
+		assert.Equal(t, int64(0), total)
 		assert.Equal(t, repoErr, err)
 	})
 
-	t.Run("Error - friendship repo error for user2", func(t *testing.T) {
+	t.Run("Error - retrieve common friends error", func(t *testing.T) {
 		authUserId := int64(1)
 		authUserRole := "user"
 		user1 := &entity.User{Id: 1, Email: "user1@example.com"}
 		user2 := &entity.User{Id: 2, Email: "user2@example.com"}
-		friendIdsOfUser1 := []int64{3, 4}
 		repoErr := errors.New("friendship database error")
 
 		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
 		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(1)).Return(friendIdsOfUser1, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(2)).Return(nil, repoErr)
-
-		commonFriends, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user1@example.com", "user2@example.com")
-		assert.Nil(t, commonFriends)
-		assert.Equal(t, repoErr, err)
-	})
-
-	t.Run("Error - common friend lookup error", func(t *testing.T) {
-		authUserId := int64(1)
-		authUserRole := "user"
-		user1 := &entity.User{Id: 1, Email: "user1@example.com"}
-		user2 := &entity.User{Id: 2, Email: "user2@example.com"}
-
-		friendIdsOfUser1 := []int64{3, 4}
-		friendIdsOfUser2 := []int64{3, 5}
-		repoErr := errors.New("friend lookup error")
-
-		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
-		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(1)).Return(friendIdsOfUser1, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(2)).Return(friendIdsOfUser2, nil)
-		mockUserRepo.EXPECT().GetUserById(int64(3)).Return(nil, repoErr)
+		mockFriendshipRepo.EXPECT().CountCommonFriends(int64(1), int64(2)).Return(int64(2), nil)
+		mockFriendshipRepo.EXPECT().RetrieveCommonFriends(int64(1), int64(2), int64(0), 20).Return(nil, repoErr)
 
-		commonFriends, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user1@example.com", "user2@example.com")
+		commonFriends, total, err := service.RetrieveCommonFriends(authUserId, authUserRole, "user1@example.com", "user2@example.com", 0, 20)
 		assert.Nil(t, commonFriends)
+		assert.Equal(t, int64(0), total)
 		assert.Equal(t, repoErr, err)
 	})
 }
+
 func TestSubscriptionService_CountFriends(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -505,7 +553,9 @@ func TestSubscriptionService_CountFriends(t *testing.T) {
 	mockFriendshipRepo := mock.NewMockFriendshipRepository(ctrl)
 	mockUserRepo := mock.NewMockUserRepository(ctrl)
 	mockBlockRepo := mock.NewMockBlockRelationshipRepository(ctrl)
-	service := NewFriendshipService(mockFriendshipRepo, mockUserRepo, mockBlockRepo)
+	mockSubscriptionRepo := mock.NewMockSubscriptionRepository(ctrl)
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewFriendshipService(mockFriendshipRepo, mockUserRepo, mockBlockRepo, mockSubscriptionRepo, mockRecorder)
 	t.Run("Success - count non-nil friends", func(t *testing.T) {
 		friends := []*entity.User{
 			{Id: 1, Email: "friend1@example.com"},
@@ -543,3 +593,292 @@ func TestSubscriptionService_CountFriends(t *testing.T) {
 		assert.Equal(t, int64(0), count)
 	})
 }
+
+func TestFriendshipService_DeleteFriendship(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFriendshipRepo := mock.NewMockFriendshipRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockBlockRepo := mock.NewMockBlockRelationshipRepository(ctrl)
+	mockSubscriptionRepo := mock.NewMockSubscriptionRepository(ctrl)
+
+	db, mockSQL, err := sqlmock.New()
+	assert.NoError(t, err)
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+	mockFriendshipRepo.EXPECT().GetDB().Return(gormDB).AnyTimes()
+
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewFriendshipService(mockFriendshipRepo, mockUserRepo, mockBlockRepo, mockSubscriptionRepo, mockRecorder)
+
+	t.Run("successful deletion without subscriptions", func(t *testing.T) {
+		user1 := &entity.User{Id: 2, Email: "user1@example.com"}
+		user2 := &entity.User{Id: 1, Email: "user2@example.com"}
+
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockFriendshipRepo.EXPECT().GetFriendship(int64(1), int64(2)).Return(&entity.Friendship{UserId1: 1, UserId2: 2}, nil)
+		mockSQL.ExpectBegin()
+		mockFriendshipRepo.EXPECT().DeleteFriendship(gomock.Any(), int64(1), int64(2)).Return(nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventFriendshipDeleted, entity.FriendshipEventData{Friends: []string{"user1@example.com", "user2@example.com"}}).Return(nil)
+		mockSQL.ExpectCommit()
+
+		err := service.DeleteFriendship(int64(2), "user1@example.com", "user2@example.com", false)
+		assert.NoError(t, err)
+	})
+
+	t.Run("successful deletion with subscriptions", func(t *testing.T) {
+		user1 := &entity.User{Id: 1, Email: "user1@example.com"}
+		user2 := &entity.User{Id: 2, Email: "user2@example.com"}
+
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockFriendshipRepo.EXPECT().GetFriendship(int64(1), int64(2)).Return(&entity.Friendship{UserId1: 1, UserId2: 2}, nil)
+		mockSQL.ExpectBegin()
+		mockFriendshipRepo.EXPECT().DeleteFriendship(gomock.Any(), int64(1), int64(2)).Return(nil)
+		mockSubscriptionRepo.EXPECT().DeleteSubscription(gomock.Any(), int64(1), int64(2)).Return(nil)
+		mockSubscriptionRepo.EXPECT().DeleteSubscription(gomock.Any(), int64(2), int64(1)).Return(nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventFriendshipDeleted, entity.FriendshipEventData{Friends: []string{"user1@example.com", "user2@example.com"}}).Return(nil)
+		mockSQL.ExpectCommit()
+
+		err := service.DeleteFriendship(int64(1), "user1@example.com", "user2@example.com", true)
+		assert.NoError(t, err)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("nonexistent@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+		err := service.DeleteFriendship(int64(1), "nonexistent@example.com", "user2@example.com", false)
+		assert.Equal(t, ErrUserNotFound, err)
+	})
+
+	t.Run("not permitted", func(t *testing.T) {
+		user1 := &entity.User{Id: 1, Email: "user1@example.com"}
+		user2 := &entity.User{Id: 2, Email: "user2@example.com"}
+
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+
+		err := service.DeleteFriendship(int64(3), "user1@example.com", "user2@example.com", false)
+		assert.Equal(t, ErrNotPermitted, err)
+	})
+
+	t.Run("same user", func(t *testing.T) {
+		user1 := &entity.User{Id: 1, Email: "user1@example.com"}
+
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil).Times(2)
+
+		err := service.DeleteFriendship(int64(1), "user1@example.com", "user1@example.com", false)
+		assert.Equal(t, ErrInvalidRequest, err)
+	})
+
+	t.Run("not friends", func(t *testing.T) {
+		user1 := &entity.User{Id: 1, Email: "user1@example.com"}
+		user2 := &entity.User{Id: 2, Email: "user2@example.com"}
+
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockFriendshipRepo.EXPECT().GetFriendship(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+
+		err := service.DeleteFriendship(int64(1), "user1@example.com", "user2@example.com", false)
+		assert.Equal(t, ErrNotFriend, err)
+	})
+
+	t.Run("subscription deletion error rolls back", func(t *testing.T) {
+		user1 := &entity.User{Id: 1, Email: "user1@example.com"}
+		user2 := &entity.User{Id: 2, Email: "user2@example.com"}
+		repoErr := errors.New("database error")
+
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockFriendshipRepo.EXPECT().GetFriendship(int64(1), int64(2)).Return(&entity.Friendship{UserId1: 1, UserId2: 2}, nil)
+		mockSQL.ExpectBegin()
+		mockFriendshipRepo.EXPECT().DeleteFriendship(gomock.Any(), int64(1), int64(2)).Return(nil)
+		mockSubscriptionRepo.EXPECT().DeleteSubscription(gomock.Any(), int64(1), int64(2)).Return(repoErr)
+		mockSQL.ExpectRollback()
+
+		err := service.DeleteFriendship(int64(1), "user1@example.com", "user2@example.com", true)
+		assert.Equal(t, repoErr, err)
+	})
+}
+
+func TestFriendshipService_RetrieveFriendSuggestions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFriendshipRepo := mock.NewMockFriendshipRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockBlockRepo := mock.NewMockBlockRelationshipRepository(ctrl)
+	mockSubscriptionRepo := mock.NewMockSubscriptionRepository(ctrl)
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewFriendshipService(mockFriendshipRepo, mockUserRepo, mockBlockRepo, mockSubscriptionRepo, mockRecorder)
+
+	user := &entity.User{Id: 1, Email: "user@example.com"}
+
+	t.Run("Success", func(t *testing.T) {
+		suggestions := []*entity.FriendSuggestion{
+			{UserId: 7, Email: "user7@example.com", MutualFriends: 3},
+			{UserId: 4, Email: "user4@example.com", MutualFriends: 1},
+		}
+		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(user, nil)
+		mockFriendshipRepo.EXPECT().RetrieveFriendSuggestions(int64(1), 20, 0).Return(suggestions, nil)
+
+		result, err := service.RetrieveFriendSuggestions(1, "user", "user@example.com", 20, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, suggestions, result)
+	})
+
+	t.Run("Admin can retrieve for any user", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(user, nil)
+		mockFriendshipRepo.EXPECT().RetrieveFriendSuggestions(int64(1), 10, 10).Return([]*entity.FriendSuggestion{}, nil)
+
+		result, err := service.RetrieveFriendSuggestions(99, "admin", "user@example.com", 10, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("Error - not permitted", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(user, nil)
+
+		result, err := service.RetrieveFriendSuggestions(2, "user", "user@example.com", 20, 0)
+		assert.Nil(t, result)
+		assert.Equal(t, ErrNotPermitted, err)
+	})
+
+	t.Run("Error - user not found", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+		result, err := service.RetrieveFriendSuggestions(1, "user", "user@example.com", 20, 0)
+		assert.Nil(t, result)
+		assert.Equal(t, ErrUserNotFound, err)
+	})
+
+	t.Run("Error - repository error", func(t *testing.T) {
+		repoErr := errors.New("database error")
+		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(user, nil)
+		mockFriendshipRepo.EXPECT().RetrieveFriendSuggestions(int64(1), 20, 0).Return(nil, repoErr)
+
+		result, err := service.RetrieveFriendSuggestions(1, "user", "user@example.com", 20, 0)
+		assert.Nil(t, result)
+		assert.Equal(t, repoErr, err)
+	})
+}
+
+func TestFriendshipService_RetrieveFriendshipPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFriendshipRepo := mock.NewMockFriendshipRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockBlockRepo := mock.NewMockBlockRelationshipRepository(ctrl)
+	mockSubscriptionRepo := mock.NewMockSubscriptionRepository(ctrl)
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewFriendshipService(mockFriendshipRepo, mockUserRepo, mockBlockRepo, mockSubscriptionRepo, mockRecorder)
+
+	users := map[int64]*entity.User{}
+	for id := int64(1); id <= 6; id++ {
+		users[id] = &entity.User{Id: id, Email: fmt.Sprintf("user%d@example.com", id)}
+	}
+	// 1 - 2 - 3 - 4 and a detour 1 - 5 - 6 - 3
+	graph := []*entity.Friendship{
+		{UserId1: 1, UserId2: 2},
+		{UserId1: 2, UserId2: 3},
+		{UserId1: 3, UserId2: 4},
+		{UserId1: 1, UserId2: 5},
+		{UserId1: 5, UserId2: 6},
+		{UserId1: 3, UserId2: 6},
+	}
+	friendshipsOf := func(userIds []int64) ([]*entity.Friendship, error) {
+		result := []*entity.Friendship{}
+		for _, friendship := range graph {
+			if slices.Contains(userIds, friendship.UserId1) || slices.Contains(userIds, friendship.UserId2) {
+				result = append(result, friendship)
+			}
+		}
+		return result, nil
+	}
+	usersFromIds := func(userIds []int64) ([]*entity.User, error) {
+		result := []*entity.User{}
+		for _, id := range userIds {
+			result = append(result, users[id])
+		}
+		return result, nil
+	}
+	expectEndpoints := func(fromId, toId int64) {
+		mockUserRepo.EXPECT().GetUserByEmail(users[fromId].Email).Return(users[fromId], nil)
+		mockUserRepo.EXPECT().GetUserByEmail(users[toId].Email).Return(users[toId], nil)
+	}
+	expectBlocks := func(blocked map[int64][]int64) {
+		mockBlockRepo.EXPECT().GetBlockRequestorIds(gomock.Any()).DoAndReturn(func(userId int64) ([]int64, error) {
+			return blocked[userId], nil
+		}).Times(2)
+		mockBlockRepo.EXPECT().GetBlockedTargetIds(gomock.Any()).Return([]int64{}, nil).Times(2)
+	}
+
+	t.Run("Success - shortest path", func(t *testing.T) {
+		expectEndpoints(1, 4)
+		expectBlocks(nil)
+		mockFriendshipRepo.EXPECT().RetrieveFriendshipsOfUsers(gomock.Any()).DoAndReturn(friendshipsOf).AnyTimes()
+		mockUserRepo.EXPECT().GetUsersFromIds([]int64{1, 2, 3, 4}).DoAndReturn(usersFromIds)
+
+		path, err := service.RetrieveFriendshipPath(1, "user", "user1@example.com", "user4@example.com", 4)
+		assert.NoError(t, err)
+		assert.Equal(t, []*entity.User{users[1], users[2], users[3], users[4]}, path)
+	})
+
+	t.Run("Success - path avoids blocked users", func(t *testing.T) {
+		expectEndpoints(1, 4)
+		// user 2 has blocked user 4
+		expectBlocks(map[int64][]int64{4: {2}})
+		mockUserRepo.EXPECT().GetUsersFromIds([]int64{1, 5, 6, 3, 4}).DoAndReturn(usersFromIds)
+
+		path, err := service.RetrieveFriendshipPath(1, "user", "user1@example.com", "user4@example.com", 4)
+		assert.NoError(t, err)
+		assert.Equal(t, []*entity.User{users[1], users[5], users[6], users[3], users[4]}, path)
+	})
+
+	t.Run("Error - path longer than max depth", func(t *testing.T) {
+		expectEndpoints(1, 4)
+		expectBlocks(nil)
+
+		path, err := service.RetrieveFriendshipPath(1, "user", "user1@example.com", "user4@example.com", 2)
+		assert.Nil(t, path)
+		assert.Equal(t, ErrPathNotFound, err)
+	})
+
+	t.Run("Error - endpoints blocked", func(t *testing.T) {
+		expectEndpoints(1, 4)
+		expectBlocks(map[int64][]int64{1: {4}})
+
+		path, err := service.RetrieveFriendshipPath(1, "user", "user1@example.com", "user4@example.com", 4)
+		assert.Nil(t, path)
+		assert.Equal(t, ErrIsBlocked, err)
+	})
+
+	t.Run("Error - not permitted", func(t *testing.T) {
+		expectEndpoints(1, 4)
+
+		path, err := service.RetrieveFriendshipPath(2, "user", "user1@example.com", "user4@example.com", 4)
+		assert.Nil(t, path)
+		assert.Equal(t, ErrNotPermitted, err)
+	})
+
+	t.Run("Error - same user", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(users[1], nil).Times(2)
+
+		path, err := service.RetrieveFriendshipPath(1, "user", "user1@example.com", "user1@example.com", 4)
+		assert.Nil(t, path)
+		assert.Equal(t, ErrInvalidRequest, err)
+	})
+
+	t.Run("Error - user not found", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+		path, err := service.RetrieveFriendshipPath(1, "user", "user1@example.com", "user4@example.com", 4)
+		assert.Nil(t, path)
+		assert.Equal(t, ErrUserNotFound, err)
+	})
+}
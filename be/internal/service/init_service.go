@@ -1,31 +1,69 @@
 package service
 
 import (
+	"BE_Friends_Management/config"
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/entity"
+	"BE_Friends_Management/internal/eventbus"
+	"BE_Friends_Management/internal/notification/channel"
+	"BE_Friends_Management/internal/realtime"
 	repository "BE_Friends_Management/internal/repository"
+	"BE_Friends_Management/internal/revocation"
 	auth "BE_Friends_Management/internal/service/auth"
 	block_relationship "BE_Friends_Management/internal/service/block_relationship"
+	digest "BE_Friends_Management/internal/service/digest"
+	email "BE_Friends_Management/internal/service/email"
+	friend_request "BE_Friends_Management/internal/service/friend_request"
 	friendship "BE_Friends_Management/internal/service/friendship"
+	mute "BE_Friends_Management/internal/service/mute"
 	notification "BE_Friends_Management/internal/service/notification"
+	notification_preference "BE_Friends_Management/internal/service/notification_preference"
+	outbox "BE_Friends_Management/internal/service/outbox"
 	subscription "BE_Friends_Management/internal/service/subscription"
 	user "BE_Friends_Management/internal/service/users"
+	webhook "BE_Friends_Management/internal/service/webhook"
+	"net/http"
 )
 
 type Service struct {
-	User              user.UserService
-	Friendship        friendship.FriendshipService
-	Subscription      subscription.SubscriptionService
-	BlockRelationship block_relationship.BlockRelationshipService
-	Notification      notification.NotificationService
-	Auth              auth.AuthService
+	User                   user.UserService
+	Friendship             friendship.FriendshipService
+	FriendRequest          friend_request.FriendRequestService
+	Subscription           subscription.SubscriptionService
+	BlockRelationship      block_relationship.BlockRelationshipService
+	Mute                   mute.MuteService
+	Notification           notification.NotificationService
+	Auth                   auth.AuthService
+	Webhook                webhook.WebhookService
+	Outbox                 outbox.OutboxService
+	Email                  email.EmailService
+	Digest                 digest.DigestService
+	NotificationPreference notification_preference.NotificationPreferenceService
 }
 
-func NewService(repos *repository.Repository) *Service {
+func NewService(repos *repository.Repository, hub *realtime.Hub, bus *eventbus.Bus, denylist *revocation.Denylist) *Service {
+	webhookService := webhook.NewWebhookService(repos.Webhook, repos.User, repos.Update, repos.NotificationPreference, &http.Client{Timeout: constant.WebhookRequestTimeout})
+	outboxService := outbox.NewOutboxService(repos.Outbox, outbox.NewBusSink(bus), outbox.NewWebhookSink(webhookService), outbox.NewLogSink())
+	emailChannel := channel.NewEmailChannel(channel.NewSMTPSender(config.SmtpHost, config.SmtpPort, config.SmtpUsername, config.SmtpPasswd), config.SmtpFrom)
+	emailService := email.NewEmailService(repos.User, repos.Update, repos.NotificationPreference, repos.EmailMessage, emailChannel)
+	// Emails are only queued and sent when an SMTP server is configured.
+	if config.SmtpHost != "" {
+		bus.Subscribe(entity.EventUpdatePublished, emailService.HandleUpdatePublished)
+		bus.Subscribe(entity.EventFriendRequestSent, emailService.HandleFriendRequestSent)
+	}
 	return &Service{
-		User:              user.NewUserService(repos.User),
-		Friendship:        friendship.NewFriendshipService(repos.Friendship, repos.User, repos.BlockRelationship),
-		Subscription:      subscription.NewSubscriptionService(repos.Subscription, repos.User, repos.Friendship, repos.BlockRelationship),
-		BlockRelationship: block_relationship.NewBlockRelationshipService(repos.BlockRelationship, repos.User, repos.Friendship, repos.Subscription),
-		Notification:      notification.NewNotificationService(repos.BlockRelationship, repos.User, repos.Friendship, repos.Subscription),
-		Auth:              auth.NewAuthService(repos.Auth, repos.User),
+		User:                   user.NewUserService(repos.User, repos.Auth, denylist),
+		Friendship:             friendship.NewFriendshipService(repos.Friendship, repos.User, repos.BlockRelationship, repos.Subscription, outboxService),
+		FriendRequest:          friend_request.NewFriendRequestService(repos.FriendRequest, repos.User, repos.Friendship, repos.BlockRelationship, repos.NotificationPreference, hub, outboxService),
+		Subscription:           subscription.NewSubscriptionService(repos.Subscription, repos.User, repos.Friendship, repos.BlockRelationship, hub, outboxService),
+		BlockRelationship:      block_relationship.NewBlockRelationshipService(repos.BlockRelationship, repos.User, repos.Friendship, repos.Subscription, hub, outboxService),
+		Mute:                   mute.NewMuteService(repos.Mute, repos.User),
+		Notification:           notification.NewNotificationService(repos.User, repos.Update, repos.NotificationPreference, hub, outboxService),
+		Auth:                   auth.NewAuthService(repos.Auth, repos.User, denylist),
+		Webhook:                webhookService,
+		Outbox:                 outboxService,
+		Email:                  emailService,
+		Digest:                 digest.NewDigestService(repos.Digest, repos.User, repos.Update, emailChannel),
+		NotificationPreference: notification_preference.NewNotificationPreferenceService(repos.NotificationPreference),
 	}
 }
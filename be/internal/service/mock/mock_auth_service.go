@@ -34,10 +34,25 @@ func (m *MockAuthService) EXPECT() *MockAuthServiceMockRecorder {
 	return m.recorder
 }
 
+// GetSessions mocks base method.
+func (m *MockAuthService) GetSessions(userId int64) ([]*entity.UserToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessions", userId)
+	ret0, _ := ret[0].([]*entity.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessions indicates an expected call of GetSessions.
+func (mr *MockAuthServiceMockRecorder) GetSessions(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessions", reflect.TypeOf((*MockAuthService)(nil).GetSessions), userId)
+}
+
 // Login mocks base method.
-func (m *MockAuthService) Login(email, password string) (string, string, error) {
+func (m *MockAuthService) Login(email, password, userAgent, ipAddress string) (string, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", email, password)
+	ret := m.ctrl.Call(m, "Login", email, password, userAgent, ipAddress)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(string)
 	ret2, _ := ret[2].(error)
@@ -45,23 +60,37 @@ func (m *MockAuthService) Login(email, password string) (string, string, error)
 }
 
 // Login indicates an expected call of Login.
-func (mr *MockAuthServiceMockRecorder) Login(email, password interface{}) *gomock.Call {
+func (mr *MockAuthServiceMockRecorder) Login(email, password, userAgent, ipAddress interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthService)(nil).Login), email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthService)(nil).Login), email, password, userAgent, ipAddress)
 }
 
 // Logout mocks base method.
-func (m *MockAuthService) Logout(rawRefreshToken string) error {
+func (m *MockAuthService) Logout(rawRefreshToken, rawAccessToken string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Logout", rawRefreshToken)
+	ret := m.ctrl.Call(m, "Logout", rawRefreshToken, rawAccessToken)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Logout indicates an expected call of Logout.
-func (mr *MockAuthServiceMockRecorder) Logout(rawRefreshToken interface{}) *gomock.Call {
+func (mr *MockAuthServiceMockRecorder) Logout(rawRefreshToken, rawAccessToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthService)(nil).Logout), rawRefreshToken, rawAccessToken)
+}
+
+// LogoutAll mocks base method.
+func (m *MockAuthService) LogoutAll(userId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutAll", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockAuthServiceMockRecorder) LogoutAll(userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthService)(nil).Logout), rawRefreshToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockAuthService)(nil).LogoutAll), userId)
 }
 
 // RefreshAccessToken mocks base method.
@@ -94,3 +123,17 @@ func (mr *MockAuthServiceMockRecorder) RegisterUser(email, password interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockAuthService)(nil).RegisterUser), email, password)
 }
+
+// RevokeSession mocks base method.
+func (m *MockAuthService) RevokeSession(userId, sessionId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", userId, sessionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockAuthServiceMockRecorder) RevokeSession(userId, sessionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockAuthService)(nil).RevokeSession), userId, sessionId)
+}
@@ -5,6 +5,8 @@
 package mock_service
 
 import (
+	entity "BE_Friends_Management/internal/domain/entity"
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,15 +36,72 @@ func (m *MockBlockRelationshipService) EXPECT() *MockBlockRelationshipServiceMoc
 }
 
 // CreateBlockRelationship mocks base method.
-func (m *MockBlockRelationshipService) CreateBlockRelationship(authUserId int64, requestorEmail, targetEmail string) error {
+func (m *MockBlockRelationshipService) CreateBlockRelationship(authUserId int64, requestorEmail, targetEmail, duration string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateBlockRelationship", authUserId, requestorEmail, targetEmail)
+	ret := m.ctrl.Call(m, "CreateBlockRelationship", authUserId, requestorEmail, targetEmail, duration)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateBlockRelationship indicates an expected call of CreateBlockRelationship.
-func (mr *MockBlockRelationshipServiceMockRecorder) CreateBlockRelationship(authUserId, requestorEmail, targetEmail interface{}) *gomock.Call {
+func (mr *MockBlockRelationshipServiceMockRecorder) CreateBlockRelationship(authUserId, requestorEmail, targetEmail, duration interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBlockRelationship", reflect.TypeOf((*MockBlockRelationshipService)(nil).CreateBlockRelationship), authUserId, requestorEmail, targetEmail)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBlockRelationship", reflect.TypeOf((*MockBlockRelationshipService)(nil).CreateBlockRelationship), authUserId, requestorEmail, targetEmail, duration)
+}
+
+// DeleteBlockRelationship mocks base method.
+func (m *MockBlockRelationshipService) DeleteBlockRelationship(authUserId int64, authUserRole, requestorEmail, targetEmail string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlockRelationship", authUserId, authUserRole, requestorEmail, targetEmail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBlockRelationship indicates an expected call of DeleteBlockRelationship.
+func (mr *MockBlockRelationshipServiceMockRecorder) DeleteBlockRelationship(authUserId, authUserRole, requestorEmail, targetEmail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlockRelationship", reflect.TypeOf((*MockBlockRelationshipService)(nil).DeleteBlockRelationship), authUserId, authUserRole, requestorEmail, targetEmail)
+}
+
+// RetrieveBlockedUsers mocks base method.
+func (m *MockBlockRelationshipService) RetrieveBlockedUsers(authUserId int64, authUserRole, email string, limit, offset int) ([]*entity.BlockRelationship, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveBlockedUsers", authUserId, authUserRole, email, limit, offset)
+	ret0, _ := ret[0].([]*entity.BlockRelationship)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RetrieveBlockedUsers indicates an expected call of RetrieveBlockedUsers.
+func (mr *MockBlockRelationshipServiceMockRecorder) RetrieveBlockedUsers(authUserId, authUserRole, email, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveBlockedUsers", reflect.TypeOf((*MockBlockRelationshipService)(nil).RetrieveBlockedUsers), authUserId, authUserRole, email, limit, offset)
+}
+
+// Run mocks base method.
+func (m *MockBlockRelationshipService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockBlockRelationshipServiceMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockBlockRelationshipService)(nil).Run), ctx)
+}
+
+// SweepExpiredBlocks mocks base method.
+func (m *MockBlockRelationshipService) SweepExpiredBlocks(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SweepExpiredBlocks", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SweepExpiredBlocks indicates an expected call of SweepExpiredBlocks.
+func (mr *MockBlockRelationshipServiceMockRecorder) SweepExpiredBlocks(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepExpiredBlocks", reflect.TypeOf((*MockBlockRelationshipService)(nil).SweepExpiredBlocks), ctx)
 }
@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	entity "BE_Friends_Management/internal/domain/entity"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDigestService is a mock of DigestService interface.
+type MockDigestService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDigestServiceMockRecorder
+}
+
+// MockDigestServiceMockRecorder is the mock recorder for MockDigestService.
+type MockDigestServiceMockRecorder struct {
+	mock *MockDigestService
+}
+
+// NewMockDigestService creates a new mock instance.
+func NewMockDigestService(ctrl *gomock.Controller) *MockDigestService {
+	mock := &MockDigestService{ctrl: ctrl}
+	mock.recorder = &MockDigestServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDigestService) EXPECT() *MockDigestServiceMockRecorder {
+	return m.recorder
+}
+
+// GetPreference mocks base method.
+func (m *MockDigestService) GetPreference(authUserId int64) (*entity.DigestPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreference", authUserId)
+	ret0, _ := ret[0].(*entity.DigestPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreference indicates an expected call of GetPreference.
+func (mr *MockDigestServiceMockRecorder) GetPreference(authUserId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreference", reflect.TypeOf((*MockDigestService)(nil).GetPreference), authUserId)
+}
+
+// Run mocks base method.
+func (m *MockDigestService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockDigestServiceMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockDigestService)(nil).Run), ctx)
+}
+
+// SendDueDigests mocks base method.
+func (m *MockDigestService) SendDueDigests(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDueDigests", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendDueDigests indicates an expected call of SendDueDigests.
+func (mr *MockDigestServiceMockRecorder) SendDueDigests(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDueDigests", reflect.TypeOf((*MockDigestService)(nil).SendDueDigests), ctx)
+}
+
+// SetFrequency mocks base method.
+func (m *MockDigestService) SetFrequency(authUserId int64, frequency string) (*entity.DigestPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFrequency", authUserId, frequency)
+	ret0, _ := ret[0].(*entity.DigestPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetFrequency indicates an expected call of SetFrequency.
+func (mr *MockDigestServiceMockRecorder) SetFrequency(authUserId, frequency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFrequency", reflect.TypeOf((*MockDigestService)(nil).SetFrequency), authUserId, frequency)
+}
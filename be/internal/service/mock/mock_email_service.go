@@ -0,0 +1,91 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	entity "BE_Friends_Management/internal/domain/entity"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEmailService is a mock of EmailService interface.
+type MockEmailService struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailServiceMockRecorder
+}
+
+// MockEmailServiceMockRecorder is the mock recorder for MockEmailService.
+type MockEmailServiceMockRecorder struct {
+	mock *MockEmailService
+}
+
+// NewMockEmailService creates a new mock instance.
+func NewMockEmailService(ctrl *gomock.Controller) *MockEmailService {
+	mock := &MockEmailService{ctrl: ctrl}
+	mock.recorder = &MockEmailServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailService) EXPECT() *MockEmailServiceMockRecorder {
+	return m.recorder
+}
+
+// HandleFriendRequestSent mocks base method.
+func (m *MockEmailService) HandleFriendRequestSent(event entity.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleFriendRequestSent", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleFriendRequestSent indicates an expected call of HandleFriendRequestSent.
+func (mr *MockEmailServiceMockRecorder) HandleFriendRequestSent(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleFriendRequestSent", reflect.TypeOf((*MockEmailService)(nil).HandleFriendRequestSent), event)
+}
+
+// HandleUpdatePublished mocks base method.
+func (m *MockEmailService) HandleUpdatePublished(event entity.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleUpdatePublished", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleUpdatePublished indicates an expected call of HandleUpdatePublished.
+func (mr *MockEmailServiceMockRecorder) HandleUpdatePublished(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleUpdatePublished", reflect.TypeOf((*MockEmailService)(nil).HandleUpdatePublished), event)
+}
+
+// Run mocks base method.
+func (m *MockEmailService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockEmailServiceMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockEmailService)(nil).Run), ctx)
+}
+
+// SendDueMessages mocks base method.
+func (m *MockEmailService) SendDueMessages(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDueMessages", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendDueMessages indicates an expected call of SendDueMessages.
+func (mr *MockEmailServiceMockRecorder) SendDueMessages(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDueMessages", reflect.TypeOf((*MockEmailService)(nil).SendDueMessages), ctx)
+}
@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	entity "BE_Friends_Management/internal/domain/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFriendRequestService is a mock of FriendRequestService interface.
+type MockFriendRequestService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFriendRequestServiceMockRecorder
+}
+
+// MockFriendRequestServiceMockRecorder is the mock recorder for MockFriendRequestService.
+type MockFriendRequestServiceMockRecorder struct {
+	mock *MockFriendRequestService
+}
+
+// NewMockFriendRequestService creates a new mock instance.
+func NewMockFriendRequestService(ctrl *gomock.Controller) *MockFriendRequestService {
+	mock := &MockFriendRequestService{ctrl: ctrl}
+	mock.recorder = &MockFriendRequestServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFriendRequestService) EXPECT() *MockFriendRequestServiceMockRecorder {
+	return m.recorder
+}
+
+// AcceptFriendRequest mocks base method.
+func (m *MockFriendRequestService) AcceptFriendRequest(authUserId, requestId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptFriendRequest", authUserId, requestId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptFriendRequest indicates an expected call of AcceptFriendRequest.
+func (mr *MockFriendRequestServiceMockRecorder) AcceptFriendRequest(authUserId, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptFriendRequest", reflect.TypeOf((*MockFriendRequestService)(nil).AcceptFriendRequest), authUserId, requestId)
+}
+
+// CancelFriendRequest mocks base method.
+func (m *MockFriendRequestService) CancelFriendRequest(authUserId, requestId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelFriendRequest", authUserId, requestId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelFriendRequest indicates an expected call of CancelFriendRequest.
+func (mr *MockFriendRequestServiceMockRecorder) CancelFriendRequest(authUserId, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelFriendRequest", reflect.TypeOf((*MockFriendRequestService)(nil).CancelFriendRequest), authUserId, requestId)
+}
+
+// RejectFriendRequest mocks base method.
+func (m *MockFriendRequestService) RejectFriendRequest(authUserId, requestId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectFriendRequest", authUserId, requestId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RejectFriendRequest indicates an expected call of RejectFriendRequest.
+func (mr *MockFriendRequestServiceMockRecorder) RejectFriendRequest(authUserId, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectFriendRequest", reflect.TypeOf((*MockFriendRequestService)(nil).RejectFriendRequest), authUserId, requestId)
+}
+
+// RetrieveIncomingFriendRequests mocks base method.
+func (m *MockFriendRequestService) RetrieveIncomingFriendRequests(authUserId int64, authUserRole, email string) ([]*entity.FriendRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveIncomingFriendRequests", authUserId, authUserRole, email)
+	ret0, _ := ret[0].([]*entity.FriendRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrieveIncomingFriendRequests indicates an expected call of RetrieveIncomingFriendRequests.
+func (mr *MockFriendRequestServiceMockRecorder) RetrieveIncomingFriendRequests(authUserId, authUserRole, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveIncomingFriendRequests", reflect.TypeOf((*MockFriendRequestService)(nil).RetrieveIncomingFriendRequests), authUserId, authUserRole, email)
+}
+
+// RetrieveOutgoingFriendRequests mocks base method.
+func (m *MockFriendRequestService) RetrieveOutgoingFriendRequests(authUserId int64, authUserRole, email string) ([]*entity.FriendRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveOutgoingFriendRequests", authUserId, authUserRole, email)
+	ret0, _ := ret[0].([]*entity.FriendRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrieveOutgoingFriendRequests indicates an expected call of RetrieveOutgoingFriendRequests.
+func (mr *MockFriendRequestServiceMockRecorder) RetrieveOutgoingFriendRequests(authUserId, authUserRole, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveOutgoingFriendRequests", reflect.TypeOf((*MockFriendRequestService)(nil).RetrieveOutgoingFriendRequests), authUserId, authUserRole, email)
+}
+
+// SendFriendRequest mocks base method.
+func (m *MockFriendRequestService) SendFriendRequest(authUserId int64, requestorEmail, targetEmail string) (*entity.FriendRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendFriendRequest", authUserId, requestorEmail, targetEmail)
+	ret0, _ := ret[0].(*entity.FriendRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendFriendRequest indicates an expected call of SendFriendRequest.
+func (mr *MockFriendRequestServiceMockRecorder) SendFriendRequest(authUserId, requestorEmail, targetEmail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendFriendRequest", reflect.TypeOf((*MockFriendRequestService)(nil).SendFriendRequest), authUserId, requestorEmail, targetEmail)
+}
@@ -49,45 +49,91 @@ func (mr *MockFriendshipServiceMockRecorder) CountFriends(friendsList interface{
 }
 
 // CreateFriendship mocks base method.
-func (m *MockFriendshipService) CreateFriendship(authUserId int64, email1, email2 string) error {
+func (m *MockFriendshipService) CreateFriendship(authUserId int64, authUserRole, email1, email2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateFriendship", authUserId, email1, email2)
+	ret := m.ctrl.Call(m, "CreateFriendship", authUserId, authUserRole, email1, email2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateFriendship indicates an expected call of CreateFriendship.
-func (mr *MockFriendshipServiceMockRecorder) CreateFriendship(authUserId, email1, email2 interface{}) *gomock.Call {
+func (mr *MockFriendshipServiceMockRecorder) CreateFriendship(authUserId, authUserRole, email1, email2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFriendship", reflect.TypeOf((*MockFriendshipService)(nil).CreateFriendship), authUserId, email1, email2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFriendship", reflect.TypeOf((*MockFriendshipService)(nil).CreateFriendship), authUserId, authUserRole, email1, email2)
+}
+
+// DeleteFriendship mocks base method.
+func (m *MockFriendshipService) DeleteFriendship(authUserId int64, email1, email2 string, removeSubscriptions bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFriendship", authUserId, email1, email2, removeSubscriptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFriendship indicates an expected call of DeleteFriendship.
+func (mr *MockFriendshipServiceMockRecorder) DeleteFriendship(authUserId, email1, email2, removeSubscriptions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFriendship", reflect.TypeOf((*MockFriendshipService)(nil).DeleteFriendship), authUserId, email1, email2, removeSubscriptions)
 }
 
 // RetrieveCommonFriends mocks base method.
-func (m *MockFriendshipService) RetrieveCommonFriends(authUserId int64, authUserRole, email1, email2 string) ([]*entity.User, error) {
+func (m *MockFriendshipService) RetrieveCommonFriends(authUserId int64, authUserRole, email1, email2 string, afterId int64, limit int) ([]*entity.User, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RetrieveCommonFriends", authUserId, authUserRole, email1, email2)
+	ret := m.ctrl.Call(m, "RetrieveCommonFriends", authUserId, authUserRole, email1, email2, afterId, limit)
 	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RetrieveCommonFriends indicates an expected call of RetrieveCommonFriends.
+func (mr *MockFriendshipServiceMockRecorder) RetrieveCommonFriends(authUserId, authUserRole, email1, email2, afterId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveCommonFriends", reflect.TypeOf((*MockFriendshipService)(nil).RetrieveCommonFriends), authUserId, authUserRole, email1, email2, afterId, limit)
+}
+
+// RetrieveFriendSuggestions mocks base method.
+func (m *MockFriendshipService) RetrieveFriendSuggestions(authUserId int64, authUserRole, email string, limit, offset int) ([]*entity.FriendSuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveFriendSuggestions", authUserId, authUserRole, email, limit, offset)
+	ret0, _ := ret[0].([]*entity.FriendSuggestion)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// RetrieveCommonFriends indicates an expected call of RetrieveCommonFriends.
-func (mr *MockFriendshipServiceMockRecorder) RetrieveCommonFriends(authUserId, authUserRole, email1, email2 interface{}) *gomock.Call {
+// RetrieveFriendSuggestions indicates an expected call of RetrieveFriendSuggestions.
+func (mr *MockFriendshipServiceMockRecorder) RetrieveFriendSuggestions(authUserId, authUserRole, email, limit, offset interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveCommonFriends", reflect.TypeOf((*MockFriendshipService)(nil).RetrieveCommonFriends), authUserId, authUserRole, email1, email2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveFriendSuggestions", reflect.TypeOf((*MockFriendshipService)(nil).RetrieveFriendSuggestions), authUserId, authUserRole, email, limit, offset)
 }
 
 // RetrieveFriendsList mocks base method.
-func (m *MockFriendshipService) RetrieveFriendsList(authUserId int64, authUserRole, email string) ([]*entity.User, error) {
+func (m *MockFriendshipService) RetrieveFriendsList(authUserId int64, authUserRole, email string, afterId int64, limit int) ([]*entity.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveFriendsList", authUserId, authUserRole, email, afterId, limit)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RetrieveFriendsList indicates an expected call of RetrieveFriendsList.
+func (mr *MockFriendshipServiceMockRecorder) RetrieveFriendsList(authUserId, authUserRole, email, afterId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveFriendsList", reflect.TypeOf((*MockFriendshipService)(nil).RetrieveFriendsList), authUserId, authUserRole, email, afterId, limit)
+}
+
+// RetrieveFriendshipPath mocks base method.
+func (m *MockFriendshipService) RetrieveFriendshipPath(authUserId int64, authUserRole, fromEmail, toEmail string, maxDepth int) ([]*entity.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RetrieveFriendsList", authUserId, authUserRole, email)
+	ret := m.ctrl.Call(m, "RetrieveFriendshipPath", authUserId, authUserRole, fromEmail, toEmail, maxDepth)
 	ret0, _ := ret[0].([]*entity.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// RetrieveFriendsList indicates an expected call of RetrieveFriendsList.
-func (mr *MockFriendshipServiceMockRecorder) RetrieveFriendsList(authUserId, authUserRole, email interface{}) *gomock.Call {
+// RetrieveFriendshipPath indicates an expected call of RetrieveFriendshipPath.
+func (mr *MockFriendshipServiceMockRecorder) RetrieveFriendshipPath(authUserId, authUserRole, fromEmail, toEmail, maxDepth interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveFriendsList", reflect.TypeOf((*MockFriendshipService)(nil).RetrieveFriendsList), authUserId, authUserRole, email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveFriendshipPath", reflect.TypeOf((*MockFriendshipService)(nil).RetrieveFriendshipPath), authUserId, authUserRole, fromEmail, toEmail, maxDepth)
 }
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	entity "BE_Friends_Management/internal/domain/entity"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMuteService is a mock of MuteService interface.
+type MockMuteService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMuteServiceMockRecorder
+}
+
+// MockMuteServiceMockRecorder is the mock recorder for MockMuteService.
+type MockMuteServiceMockRecorder struct {
+	mock *MockMuteService
+}
+
+// NewMockMuteService creates a new mock instance.
+func NewMockMuteService(ctrl *gomock.Controller) *MockMuteService {
+	mock := &MockMuteService{ctrl: ctrl}
+	mock.recorder = &MockMuteServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMuteService) EXPECT() *MockMuteServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateMute mocks base method.
+func (m *MockMuteService) CreateMute(authUserId int64, authUserRole, requestorEmail, targetEmail, duration string) (*entity.Mute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMute", authUserId, authUserRole, requestorEmail, targetEmail, duration)
+	ret0, _ := ret[0].(*entity.Mute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMute indicates an expected call of CreateMute.
+func (mr *MockMuteServiceMockRecorder) CreateMute(authUserId, authUserRole, requestorEmail, targetEmail, duration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMute", reflect.TypeOf((*MockMuteService)(nil).CreateMute), authUserId, authUserRole, requestorEmail, targetEmail, duration)
+}
+
+// DeleteMute mocks base method.
+func (m *MockMuteService) DeleteMute(authUserId int64, authUserRole, requestorEmail, targetEmail string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMute", authUserId, authUserRole, requestorEmail, targetEmail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMute indicates an expected call of DeleteMute.
+func (mr *MockMuteServiceMockRecorder) DeleteMute(authUserId, authUserRole, requestorEmail, targetEmail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMute", reflect.TypeOf((*MockMuteService)(nil).DeleteMute), authUserId, authUserRole, requestorEmail, targetEmail)
+}
+
+// RetrieveMutedUsers mocks base method.
+func (m *MockMuteService) RetrieveMutedUsers(authUserId int64, authUserRole, email string, limit, offset int) ([]*entity.Mute, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveMutedUsers", authUserId, authUserRole, email, limit, offset)
+	ret0, _ := ret[0].([]*entity.Mute)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RetrieveMutedUsers indicates an expected call of RetrieveMutedUsers.
+func (mr *MockMuteServiceMockRecorder) RetrieveMutedUsers(authUserId, authUserRole, email, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveMutedUsers", reflect.TypeOf((*MockMuteService)(nil).RetrieveMutedUsers), authUserId, authUserRole, email, limit, offset)
+}
+
+// Run mocks base method.
+func (m *MockMuteService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockMuteServiceMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockMuteService)(nil).Run), ctx)
+}
+
+// SweepExpiredMutes mocks base method.
+func (m *MockMuteService) SweepExpiredMutes(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SweepExpiredMutes", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SweepExpiredMutes indicates an expected call of SweepExpiredMutes.
+func (mr *MockMuteServiceMockRecorder) SweepExpiredMutes(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepExpiredMutes", reflect.TypeOf((*MockMuteService)(nil).SweepExpiredMutes), ctx)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	entity "BE_Friends_Management/internal/domain/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNotificationPreferenceService is a mock of NotificationPreferenceService interface.
+type MockNotificationPreferenceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationPreferenceServiceMockRecorder
+}
+
+// MockNotificationPreferenceServiceMockRecorder is the mock recorder for MockNotificationPreferenceService.
+type MockNotificationPreferenceServiceMockRecorder struct {
+	mock *MockNotificationPreferenceService
+}
+
+// NewMockNotificationPreferenceService creates a new mock instance.
+func NewMockNotificationPreferenceService(ctrl *gomock.Controller) *MockNotificationPreferenceService {
+	mock := &MockNotificationPreferenceService{ctrl: ctrl}
+	mock.recorder = &MockNotificationPreferenceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationPreferenceService) EXPECT() *MockNotificationPreferenceServiceMockRecorder {
+	return m.recorder
+}
+
+// GetPreference mocks base method.
+func (m *MockNotificationPreferenceService) GetPreference(authUserId int64) (*entity.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreference", authUserId)
+	ret0, _ := ret[0].(*entity.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreference indicates an expected call of GetPreference.
+func (mr *MockNotificationPreferenceServiceMockRecorder) GetPreference(authUserId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreference", reflect.TypeOf((*MockNotificationPreferenceService)(nil).GetPreference), authUserId)
+}
+
+// UpdatePreference mocks base method.
+func (m *MockNotificationPreferenceService) UpdatePreference(authUserId int64, preference *entity.NotificationPreference) (*entity.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreference", authUserId, preference)
+	ret0, _ := ret[0].(*entity.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePreference indicates an expected call of UpdatePreference.
+func (mr *MockNotificationPreferenceServiceMockRecorder) UpdatePreference(authUserId, preference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreference", reflect.TypeOf((*MockNotificationPreferenceService)(nil).UpdatePreference), authUserId, preference)
+}
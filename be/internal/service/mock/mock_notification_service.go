@@ -34,17 +34,96 @@ func (m *MockNotificationService) EXPECT() *MockNotificationServiceMockRecorder
 	return m.recorder
 }
 
-// GetUpdateRecipients mocks base method.
-func (m *MockNotificationService) GetUpdateRecipients(authUserId int64, authUserRole, senderEmail, text string) ([]*entity.User, error) {
+// GetFeed mocks base method.
+func (m *MockNotificationService) GetFeed(authUserId, beforeId int64, limit int) ([]*entity.UpdateDelivery, int64, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUpdateRecipients", authUserId, authUserRole, senderEmail, text)
-	ret0, _ := ret[0].([]*entity.User)
+	ret := m.ctrl.Call(m, "GetFeed", authUserId, beforeId, limit)
+	ret0, _ := ret[0].([]*entity.UpdateDelivery)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(int64)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *MockNotificationServiceMockRecorder) GetFeed(authUserId, beforeId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockNotificationService)(nil).GetFeed), authUserId, beforeId, limit)
+}
+
+// GetMentions mocks base method.
+func (m *MockNotificationService) GetMentions(authUserId, beforeId int64, limit int) ([]*entity.Mention, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMentions", authUserId, beforeId, limit)
+	ret0, _ := ret[0].([]*entity.Mention)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMentions indicates an expected call of GetMentions.
+func (mr *MockNotificationServiceMockRecorder) GetMentions(authUserId, beforeId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMentions", reflect.TypeOf((*MockNotificationService)(nil).GetMentions), authUserId, beforeId, limit)
+}
+
+// GetMissedFeed mocks base method.
+func (m *MockNotificationService) GetMissedFeed(authUserId, lastDeliveryId int64, limit int) ([]*entity.UpdateDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMissedFeed", authUserId, lastDeliveryId, limit)
+	ret0, _ := ret[0].([]*entity.UpdateDelivery)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// GetMissedFeed indicates an expected call of GetMissedFeed.
+func (mr *MockNotificationServiceMockRecorder) GetMissedFeed(authUserId, lastDeliveryId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMissedFeed", reflect.TypeOf((*MockNotificationService)(nil).GetMissedFeed), authUserId, lastDeliveryId, limit)
+}
+
+// GetUpdateRecipients mocks base method.
+func (m *MockNotificationService) GetUpdateRecipients(authUserId int64, authUserRole, senderEmail, text string, afterId int64, limit int) ([]*entity.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpdateRecipients", authUserId, authUserRole, senderEmail, text, afterId, limit)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // GetUpdateRecipients indicates an expected call of GetUpdateRecipients.
-func (mr *MockNotificationServiceMockRecorder) GetUpdateRecipients(authUserId, authUserRole, senderEmail, text interface{}) *gomock.Call {
+func (mr *MockNotificationServiceMockRecorder) GetUpdateRecipients(authUserId, authUserRole, senderEmail, text, afterId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpdateRecipients", reflect.TypeOf((*MockNotificationService)(nil).GetUpdateRecipients), authUserId, authUserRole, senderEmail, text, afterId, limit)
+}
+
+// MarkFeedItemRead mocks base method.
+func (m *MockNotificationService) MarkFeedItemRead(authUserId, deliveryId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFeedItemRead", authUserId, deliveryId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFeedItemRead indicates an expected call of MarkFeedItemRead.
+func (mr *MockNotificationServiceMockRecorder) MarkFeedItemRead(authUserId, deliveryId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFeedItemRead", reflect.TypeOf((*MockNotificationService)(nil).MarkFeedItemRead), authUserId, deliveryId)
+}
+
+// PublishUpdate mocks base method.
+func (m *MockNotificationService) PublishUpdate(authUserId int64, authUserRole, senderEmail, text string) (*entity.Update, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishUpdate", authUserId, authUserRole, senderEmail, text)
+	ret0, _ := ret[0].(*entity.Update)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PublishUpdate indicates an expected call of PublishUpdate.
+func (mr *MockNotificationServiceMockRecorder) PublishUpdate(authUserId, authUserRole, senderEmail, text interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpdateRecipients", reflect.TypeOf((*MockNotificationService)(nil).GetUpdateRecipients), authUserId, authUserRole, senderEmail, text)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishUpdate", reflect.TypeOf((*MockNotificationService)(nil).PublishUpdate), authUserId, authUserRole, senderEmail, text)
 }
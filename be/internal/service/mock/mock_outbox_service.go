@@ -0,0 +1,166 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	entity "BE_Friends_Management/internal/domain/entity"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockEventRecorder is a mock of EventRecorder interface.
+type MockEventRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventRecorderMockRecorder
+}
+
+// MockEventRecorderMockRecorder is the mock recorder for MockEventRecorder.
+type MockEventRecorderMockRecorder struct {
+	mock *MockEventRecorder
+}
+
+// NewMockEventRecorder creates a new mock instance.
+func NewMockEventRecorder(ctrl *gomock.Controller) *MockEventRecorder {
+	mock := &MockEventRecorder{ctrl: ctrl}
+	mock.recorder = &MockEventRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventRecorder) EXPECT() *MockEventRecorderMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockEventRecorder) Record(tx *gorm.DB, eventType string, data any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", tx, eventType, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockEventRecorderMockRecorder) Record(tx, eventType, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockEventRecorder)(nil).Record), tx, eventType, data)
+}
+
+// MockSink is a mock of Sink interface.
+type MockSink struct {
+	ctrl     *gomock.Controller
+	recorder *MockSinkMockRecorder
+}
+
+// MockSinkMockRecorder is the mock recorder for MockSink.
+type MockSinkMockRecorder struct {
+	mock *MockSink
+}
+
+// NewMockSink creates a new mock instance.
+func NewMockSink(ctrl *gomock.Controller) *MockSink {
+	mock := &MockSink{ctrl: ctrl}
+	mock.recorder = &MockSinkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSink) EXPECT() *MockSinkMockRecorder {
+	return m.recorder
+}
+
+// Handle mocks base method.
+func (m *MockSink) Handle(event entity.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Handle", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Handle indicates an expected call of Handle.
+func (mr *MockSinkMockRecorder) Handle(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockSink)(nil).Handle), event)
+}
+
+// Name mocks base method.
+func (m *MockSink) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockSinkMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockSink)(nil).Name))
+}
+
+// MockOutboxService is a mock of OutboxService interface.
+type MockOutboxService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxServiceMockRecorder
+}
+
+// MockOutboxServiceMockRecorder is the mock recorder for MockOutboxService.
+type MockOutboxServiceMockRecorder struct {
+	mock *MockOutboxService
+}
+
+// NewMockOutboxService creates a new mock instance.
+func NewMockOutboxService(ctrl *gomock.Controller) *MockOutboxService {
+	mock := &MockOutboxService{ctrl: ctrl}
+	mock.recorder = &MockOutboxServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxService) EXPECT() *MockOutboxServiceMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockOutboxService) Record(tx *gorm.DB, eventType string, data any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", tx, eventType, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockOutboxServiceMockRecorder) Record(tx, eventType, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockOutboxService)(nil).Record), tx, eventType, data)
+}
+
+// RelayPending mocks base method.
+func (m *MockOutboxService) RelayPending(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelayPending", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelayPending indicates an expected call of RelayPending.
+func (mr *MockOutboxServiceMockRecorder) RelayPending(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayPending", reflect.TypeOf((*MockOutboxService)(nil).RelayPending), ctx)
+}
+
+// Run mocks base method.
+func (m *MockOutboxService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockOutboxServiceMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockOutboxService)(nil).Run), ctx)
+}
@@ -5,6 +5,7 @@
 package mock_service
 
 import (
+	entity "BE_Friends_Management/internal/domain/entity"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -46,3 +47,47 @@ func (mr *MockSubscriptionServiceMockRecorder) CreateSubscription(authUserId, re
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockSubscriptionService)(nil).CreateSubscription), authUserId, requestorEmail, targetEmail)
 }
+
+// DeleteSubscription mocks base method.
+func (m *MockSubscriptionService) DeleteSubscription(authUserId int64, authUserRole, requestorEmail, targetEmail string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", authUserId, authUserRole, requestorEmail, targetEmail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockSubscriptionServiceMockRecorder) DeleteSubscription(authUserId, authUserRole, requestorEmail, targetEmail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockSubscriptionService)(nil).DeleteSubscription), authUserId, authUserRole, requestorEmail, targetEmail)
+}
+
+// RetrieveFollowers mocks base method.
+func (m *MockSubscriptionService) RetrieveFollowers(authUserId int64, authUserRole, email string) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveFollowers", authUserId, authUserRole, email)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrieveFollowers indicates an expected call of RetrieveFollowers.
+func (mr *MockSubscriptionServiceMockRecorder) RetrieveFollowers(authUserId, authUserRole, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveFollowers", reflect.TypeOf((*MockSubscriptionService)(nil).RetrieveFollowers), authUserId, authUserRole, email)
+}
+
+// RetrieveFollowing mocks base method.
+func (m *MockSubscriptionService) RetrieveFollowing(authUserId int64, authUserRole, email string) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveFollowing", authUserId, authUserRole, email)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrieveFollowing indicates an expected call of RetrieveFollowing.
+func (mr *MockSubscriptionServiceMockRecorder) RetrieveFollowing(authUserId, authUserRole, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveFollowing", reflect.TypeOf((*MockSubscriptionService)(nil).RetrieveFollowing), authUserId, authUserRole, email)
+}
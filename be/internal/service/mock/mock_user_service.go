@@ -49,18 +49,19 @@ func (mr *MockUserServiceMockRecorder) DeleteUserById(userId interface{}) *gomoc
 }
 
 // GetAllUser mocks base method.
-func (m *MockUserService) GetAllUser() ([]*entity.User, error) {
+func (m *MockUserService) GetAllUser(afterId int64, limit int) ([]*entity.User, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllUser")
+	ret := m.ctrl.Call(m, "GetAllUser", afterId, limit)
 	ret0, _ := ret[0].([]*entity.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAllUser indicates an expected call of GetAllUser.
-func (mr *MockUserServiceMockRecorder) GetAllUser() *gomock.Call {
+func (mr *MockUserServiceMockRecorder) GetAllUser(afterId, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUser", reflect.TypeOf((*MockUserService)(nil).GetAllUser))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUser", reflect.TypeOf((*MockUserService)(nil).GetAllUser), afterId, limit)
 }
 
 // GetUserById mocks base method.
@@ -78,6 +79,35 @@ func (mr *MockUserServiceMockRecorder) GetUserById(userId interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockUserService)(nil).GetUserById), userId)
 }
 
+// SetEmailOptOut mocks base method.
+func (m *MockUserService) SetEmailOptOut(userId int64, optOut bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEmailOptOut", userId, optOut)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEmailOptOut indicates an expected call of SetEmailOptOut.
+func (mr *MockUserServiceMockRecorder) SetEmailOptOut(userId, optOut interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEmailOptOut", reflect.TypeOf((*MockUserService)(nil).SetEmailOptOut), userId, optOut)
+}
+
+// SetHandle mocks base method.
+func (m *MockUserService) SetHandle(userId int64, handle string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHandle", userId, handle)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetHandle indicates an expected call of SetHandle.
+func (mr *MockUserServiceMockRecorder) SetHandle(userId, handle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHandle", reflect.TypeOf((*MockUserService)(nil).SetHandle), userId, handle)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUserService) UpdateUser(userId int64, email, password string) (*entity.User, error) {
 	m.ctrl.T.Helper()
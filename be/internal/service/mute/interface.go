@@ -0,0 +1,25 @@
+package service
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"context"
+	"errors"
+)
+
+var (
+	ErrUserNotFound    = errors.New("user not found")
+	ErrNotMuted        = errors.New("requestor has not muted this target user")
+	ErrInvalidRequest  = errors.New("two email can not be the same")
+	ErrInvalidDuration = errors.New("mute duration must be a positive duration such as 30m or 8h")
+	ErrNotPermitted    = errors.New("action not permitted")
+)
+
+//go:generate mockgen -source=interface.go -destination=../mock/mock_mute_service.go
+
+type MuteService interface {
+	CreateMute(authUserId int64, authUserRole string, requestorEmail, targetEmail, duration string) (*entity.Mute, error)
+	DeleteMute(authUserId int64, authUserRole string, requestorEmail, targetEmail string) error
+	RetrieveMutedUsers(authUserId int64, authUserRole string, email string, limit, offset int) ([]*entity.Mute, int64, error)
+	SweepExpiredMutes(ctx context.Context) (int, error)
+	Run(ctx context.Context)
+}
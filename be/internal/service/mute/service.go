@@ -0,0 +1,154 @@
+package service
+
+import (
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/entity"
+	muteRepository "BE_Friends_Management/internal/repository/mute"
+	userRepository "BE_Friends_Management/internal/repository/users"
+	"context"
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type muteService struct {
+	repo     muteRepository.MuteRepository
+	userRepo userRepository.UserRepository
+}
+
+func NewMuteService(repo muteRepository.MuteRepository, userRepo userRepository.UserRepository) MuteService {
+	return &muteService{
+		repo:     repo,
+		userRepo: userRepo,
+	}
+}
+
+// CreateMute mutes the target for the requestor, for the given duration or
+// until unmuted when duration is empty. Muting an already muted target
+// replaces the expiry. Unlike a block it leaves friendships and subscriptions
+// alone and records no event, so the target is never told.
+func (service *muteService) CreateMute(authUserId int64, authUserRole string, requestorEmail, targetEmail, duration string) (*entity.Mute, error) {
+	var expiresAt *time.Time
+	if duration != "" {
+		parsedDuration, err := time.ParseDuration(duration)
+		if err != nil || parsedDuration <= 0 {
+			return nil, ErrInvalidDuration
+		}
+		expiry := time.Now().Add(parsedDuration)
+		expiresAt = &expiry
+	}
+	requestor, target, err := service.getUsers(requestorEmail, targetEmail)
+	if err != nil {
+		return nil, err
+	}
+	if authUserRole == "user" && authUserId != requestor.Id {
+		return nil, ErrNotPermitted
+	}
+	mute := &entity.Mute{
+		RequestorId: requestor.Id,
+		TargetId:    target.Id,
+		ExpiresAt:   expiresAt,
+		Requestor:   requestor,
+		Target:      target,
+	}
+	err = service.repo.SaveMute(mute)
+	if err != nil {
+		return nil, err
+	}
+	return mute, nil
+}
+
+func (service *muteService) DeleteMute(authUserId int64, authUserRole string, requestorEmail, targetEmail string) error {
+	requestor, target, err := service.getUsers(requestorEmail, targetEmail)
+	if err != nil {
+		return err
+	}
+	if authUserRole == "user" && authUserId != requestor.Id {
+		return ErrNotPermitted
+	}
+	err = service.repo.DeleteMute(requestor.Id, target.Id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotMuted
+	}
+	return err
+}
+
+// RetrieveMutedUsers returns the mutes of the user that have not expired,
+// newest first.
+func (service *muteService) RetrieveMutedUsers(authUserId int64, authUserRole string, email string, limit, offset int) ([]*entity.Mute, int64, error) {
+	requestor, err := service.userRepo.GetUserByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, 0, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	if authUserRole == "user" && authUserId != requestor.Id {
+		return nil, 0, ErrNotPermitted
+	}
+	now := time.Now()
+	mutes, err := service.repo.GetMutesByRequestor(requestor.Id, now, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	count, err := service.repo.CountMutesByRequestor(requestor.Id, now)
+	if err != nil {
+		return nil, 0, err
+	}
+	return mutes, count, nil
+}
+
+// SweepExpiredMutes deletes one batch of expired mutes. They no longer
+// filter anything, so nothing else happens. It returns the number of mutes
+// deleted.
+func (service *muteService) SweepExpiredMutes(ctx context.Context) (int, error) {
+	swept, err := service.repo.DeleteExpiredMutes(ctx, time.Now(), constant.MuteSweepBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return int(swept), nil
+}
+
+// Run sweeps expired mutes until ctx is cancelled. A full batch is followed
+// immediately by the next one instead of waiting for the ticker.
+func (service *muteService) Run(ctx context.Context) {
+	ticker := time.NewTicker(constant.MuteSweepInterval)
+	defer ticker.Stop()
+	for {
+		swept, err := service.SweepExpiredMutes(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Error("Happened error when sweeping expired mutes. Error: ", err)
+		}
+		if err == nil && swept == constant.MuteSweepBatchSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (service *muteService) getUsers(requestorEmail, targetEmail string) (*entity.User, *entity.User, error) {
+	requestor, err := service.userRepo.GetUserByEmail(requestorEmail)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	target, err := service.userRepo.GetUserByEmail(targetEmail)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if requestor.Id == target.Id {
+		return nil, nil, ErrInvalidRequest
+	}
+	return requestor, target, nil
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/entity"
+	mock "BE_Friends_Management/internal/repository/mock"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestMuteService_CreateMute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockMuteRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	service := NewMuteService(mockRepo, mockUserRepo)
+
+	user1 := &entity.User{Id: 1, Email: "user1@example.com"}
+	user2 := &entity.User{Id: 2, Email: "user2@example.com"}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockRepo.EXPECT().SaveMute(gomock.Any()).DoAndReturn(func(mute *entity.Mute) error {
+			assert.Equal(t, int64(1), mute.RequestorId)
+			assert.Equal(t, int64(2), mute.TargetId)
+			assert.Nil(t, mute.ExpiresAt)
+			return nil
+		})
+
+		mute, err := service.CreateMute(1, "user", "user1@example.com", "user2@example.com", "")
+		assert.NoError(t, err)
+		assert.Equal(t, user2, mute.Target)
+	})
+
+	t.Run("WithDuration", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockRepo.EXPECT().SaveMute(gomock.Any()).Return(nil)
+
+		mute, err := service.CreateMute(1, "user", "user1@example.com", "user2@example.com", "8h")
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(8*time.Hour), *mute.ExpiresAt, time.Minute)
+	})
+
+	t.Run("InvalidDuration", func(t *testing.T) {
+		for _, duration := range []string{"forever", "-1h", "0s"} {
+			mute, err := service.CreateMute(1, "user", "user1@example.com", "user2@example.com", duration)
+			assert.Nil(t, mute)
+			assert.Equal(t, ErrInvalidDuration, err)
+		}
+	})
+
+	t.Run("NotPermitted", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+
+		mute, err := service.CreateMute(2, "user", "user1@example.com", "user2@example.com", "")
+		assert.Nil(t, mute)
+		assert.Equal(t, ErrNotPermitted, err)
+	})
+
+	t.Run("AdminSuccess", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockRepo.EXPECT().SaveMute(gomock.Any()).Return(nil)
+
+		mute, err := service.CreateMute(3, "admin", "user1@example.com", "user2@example.com", "")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), mute.RequestorId)
+	})
+
+	t.Run("SameUser", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil).Times(2)
+
+		mute, err := service.CreateMute(1, "user", "user1@example.com", "user1@example.com", "")
+		assert.Nil(t, mute)
+		assert.Equal(t, ErrInvalidRequest, err)
+	})
+
+	t.Run("TargetNotFound", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user3@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+		mute, err := service.CreateMute(1, "user", "user1@example.com", "user3@example.com", "")
+		assert.Nil(t, mute)
+		assert.Equal(t, ErrUserNotFound, err)
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		dbErr := errors.New("database error")
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockRepo.EXPECT().SaveMute(gomock.Any()).Return(dbErr)
+
+		mute, err := service.CreateMute(1, "user", "user1@example.com", "user2@example.com", "")
+		assert.Nil(t, mute)
+		assert.Equal(t, dbErr, err)
+	})
+}
+
+func TestMuteService_DeleteMute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockMuteRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	service := NewMuteService(mockRepo, mockUserRepo)
+
+	user1 := &entity.User{Id: 1, Email: "user1@example.com"}
+	user2 := &entity.User{Id: 2, Email: "user2@example.com"}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockRepo.EXPECT().DeleteMute(int64(1), int64(2)).Return(nil)
+
+		err := service.DeleteMute(1, "user", "user1@example.com", "user2@example.com")
+		assert.NoError(t, err)
+	})
+
+	t.Run("AdminCanUnmuteForAnyone", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockRepo.EXPECT().DeleteMute(int64(1), int64(2)).Return(nil)
+
+		err := service.DeleteMute(3, "admin", "user1@example.com", "user2@example.com")
+		assert.NoError(t, err)
+	})
+
+	t.Run("NotMuted", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockRepo.EXPECT().DeleteMute(int64(1), int64(2)).Return(gorm.ErrRecordNotFound)
+
+		err := service.DeleteMute(1, "user", "user1@example.com", "user2@example.com")
+		assert.Equal(t, ErrNotMuted, err)
+	})
+
+	t.Run("NotPermitted", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+
+		err := service.DeleteMute(2, "user", "user1@example.com", "user2@example.com")
+		assert.Equal(t, ErrNotPermitted, err)
+	})
+}
+
+func TestMuteService_RetrieveMutedUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockMuteRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	service := NewMuteService(mockRepo, mockUserRepo)
+
+	user1 := &entity.User{Id: 1, Email: "user1@example.com"}
+
+	t.Run("Success", func(t *testing.T) {
+		mutes := []*entity.Mute{{RequestorId: 1, TargetId: 2, Target: &entity.User{Id: 2, Email: "user2@example.com"}}}
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockRepo.EXPECT().GetMutesByRequestor(int64(1), gomock.Any(), 10, 0).Return(mutes, nil)
+		mockRepo.EXPECT().CountMutesByRequestor(int64(1), gomock.Any()).Return(int64(1), nil)
+
+		result, count, err := service.RetrieveMutedUsers(1, "user", "user1@example.com", 10, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, mutes, result)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("NotPermitted", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+
+		result, _, err := service.RetrieveMutedUsers(2, "user", "user1@example.com", 10, 0)
+		assert.Nil(t, result)
+		assert.Equal(t, ErrNotPermitted, err)
+	})
+
+	t.Run("UserNotFound", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+		result, _, err := service.RetrieveMutedUsers(1, "user", "user1@example.com", 10, 0)
+		assert.Nil(t, result)
+		assert.Equal(t, ErrUserNotFound, err)
+	})
+}
+
+func TestMuteService_SweepExpiredMutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockMuteRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	service := NewMuteService(mockRepo, mockUserRepo)
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().DeleteExpiredMutes(gomock.Any(), gomock.Any(), constant.MuteSweepBatchSize).Return(int64(2), nil)
+
+		swept, err := service.SweepExpiredMutes(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 2, swept)
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		dbErr := errors.New("database error")
+		mockRepo.EXPECT().DeleteExpiredMutes(gomock.Any(), gomock.Any(), constant.MuteSweepBatchSize).Return(int64(0), dbErr)
+
+		swept, err := service.SweepExpiredMutes(context.Background())
+		assert.Equal(t, dbErr, err)
+		assert.Equal(t, 0, swept)
+	})
+}
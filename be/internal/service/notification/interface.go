@@ -6,12 +6,18 @@ import (
 )
 
 var (
-	ErrUserNotFound = errors.New("user not found")
-	ErrNotPermitted = errors.New("action not permitted")
+	ErrUserNotFound     = errors.New("user not found")
+	ErrNotPermitted     = errors.New("action not permitted")
+	ErrFeedItemNotFound = errors.New("feed item not found")
 )
 
 //go:generate mockgen -source=interface.go -destination=../mock/mock_notification_service.go
 
 type NotificationService interface {
-	GetUpdateRecipients(authUserId int64, authUserRole string, senderEmail, text string) ([]*entity.User, error)
+	GetUpdateRecipients(authUserId int64, authUserRole string, senderEmail, text string, afterId int64, limit int) ([]*entity.User, int64, error)
+	PublishUpdate(authUserId int64, authUserRole string, senderEmail, text string) (*entity.Update, int64, error)
+	GetFeed(authUserId int64, beforeId int64, limit int) ([]*entity.UpdateDelivery, int64, int64, error)
+	GetMissedFeed(authUserId int64, lastDeliveryId int64, limit int) ([]*entity.UpdateDelivery, error)
+	MarkFeedItemRead(authUserId int64, deliveryId int64) error
+	GetMentions(authUserId int64, beforeId int64, limit int) ([]*entity.Mention, int64, error)
 }
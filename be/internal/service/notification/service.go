@@ -2,62 +2,188 @@ package service
 
 import (
 	"BE_Friends_Management/internal/domain/entity"
-	notificationRepository "BE_Friends_Management/internal/repository/block_relationship"
-	friendshipRepository "BE_Friends_Management/internal/repository/friendship"
-	subscriptionRepository "BE_Friends_Management/internal/repository/subscription"
+	"BE_Friends_Management/internal/realtime"
+	notificationPreferenceRepository "BE_Friends_Management/internal/repository/notification_preference"
+	updateRepository "BE_Friends_Management/internal/repository/update"
 	userRepository "BE_Friends_Management/internal/repository/users"
+	outboxService "BE_Friends_Management/internal/service/outbox"
 	utils "BE_Friends_Management/pkg/utils"
 	"errors"
+	"slices"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type notificationService struct {
-	blockRepo        notificationRepository.BlockRelationshipRepository
-	userRepo         userRepository.UserRepository
-	friendshipRepo   friendshipRepository.FriendshipRepository
-	subscriptionRepo subscriptionRepository.SubscriptionRepository
+	userRepo       userRepository.UserRepository
+	updateRepo     updateRepository.UpdateRepository
+	preferenceRepo notificationPreferenceRepository.NotificationPreferenceRepository
+	publisher      realtime.Publisher
+	recorder       outboxService.EventRecorder
 }
 
-func NewNotificationService(blockRepo notificationRepository.BlockRelationshipRepository, userRepo userRepository.UserRepository, friendshipRepo friendshipRepository.FriendshipRepository, subscriptionRepo subscriptionRepository.SubscriptionRepository) NotificationService {
+func NewNotificationService(userRepo userRepository.UserRepository, updateRepo updateRepository.UpdateRepository, preferenceRepo notificationPreferenceRepository.NotificationPreferenceRepository, publisher realtime.Publisher, recorder outboxService.EventRecorder) NotificationService {
 	return &notificationService{
-		blockRepo:        blockRepo,
-		userRepo:         userRepo,
-		friendshipRepo:   friendshipRepo,
-		subscriptionRepo: subscriptionRepo,
+		userRepo:       userRepo,
+		updateRepo:     updateRepo,
+		preferenceRepo: preferenceRepo,
+		publisher:      publisher,
+		recorder:       recorder,
 	}
 }
 
-func (service *notificationService) GetUpdateRecipients(authUserId int64, authUserRole string, senderEmail, text string) ([]*entity.User, error) {
-	sender, err := service.userRepo.GetUserByEmail(senderEmail)
-	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, ErrUserNotFound
+func (service *notificationService) GetUpdateRecipients(authUserId int64, authUserRole string, senderEmail, text string, afterId int64, limit int) ([]*entity.User, int64, error) {
+	sender, err := service.getPermittedSender(authUserId, authUserRole, senderEmail)
+	if err != nil {
+		return nil, 0, err
 	}
+	mentionedIds, err := service.getMentionedUserIds(text)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	senderId := sender.Id
-	if authUserRole == "user" && authUserId != senderId {
-		return nil, ErrNotPermitted
+	// The recipients are paged in the database, so a sender with many
+	// friends or subscribers never has the whole set loaded per page.
+	now := time.Now()
+	recipients, err := service.updateRepo.GetUpdateRecipientsPage(sender.Id, mentionedIds, now, afterId, limit)
+	if err != nil {
+		return nil, 0, err
 	}
+	count, err := service.updateRepo.CountUpdateRecipients(sender.Id, mentionedIds, now)
+	if err != nil {
+		return nil, 0, err
+	}
+	return recipients, count, nil
+}
 
-	blockRequestorIds, err := service.blockRepo.GetBlockRequestorIds(senderId)
+func (service *notificationService) PublishUpdate(authUserId int64, authUserRole string, senderEmail, text string) (*entity.Update, int64, error) {
+	sender, err := service.getPermittedSender(authUserId, authUserRole, senderEmail)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+	mentionedIds, err := service.getMentionedUserIds(text)
+	if err != nil {
+		return nil, 0, err
+	}
+	update := &entity.Update{SenderId: sender.Id, Text: text}
+	var deliveries []*entity.UpdateDelivery
+	var preferences map[int64]*entity.NotificationPreference
+	now := time.Now()
+	db := service.updateRepo.GetDB()
+	err = db.Transaction(func(tx *gorm.DB) error {
+		err := service.updateRepo.CreateUpdate(tx, update)
+		if err != nil {
+			return err
+		}
+		// The recipients are selected by the query GetUpdateRecipients lists
+		// them with, before notification preferences are applied.
+		deliveries, err = service.updateRepo.CreateUpdateDeliveries(tx, update.Id, sender.Id, mentionedIds, now)
+		if err != nil {
+			return err
+		}
+		err = service.updateRepo.CreateMentions(tx, update.Id, mentionedRecipientIds(deliveries))
+		if err != nil {
+			return err
+		}
+		preferences, err = service.getPreferences(deliveries)
+		if err != nil {
+			return err
+		}
+		return service.recorder.Record(tx, entity.EventUpdatePublished, entity.UpdateEventData{
+			Id:             update.Id,
+			Sender:         sender.Email,
+			Text:           update.Text,
+			CreatedAt:      update.CreatedAt,
+			RecipientCount: int64(len(deliveries)),
+		})
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	update.Sender = sender
+	// Live connections are only notified once the deliveries are committed,
+	// so a client resuming from an event id always finds it in its feed.
+	// Recipients who turned the in-app channel or every reason they get the
+	// update for off, or who are in their quiet hours, still get it in their
+	// feed, just without a live push.
+	for _, delivery := range deliveries {
+		delivery.Update = update
+		preference := preferences[delivery.RecipientId]
+		if !utils.UsesChannel(preference, entity.ChannelInApp) || !utils.WantsAnyNotification(preference, delivery.Reasons, now) {
+			continue
+		}
+		service.publisher.Publish(delivery.RecipientId, realtime.Event{Id: delivery.Id, Type: realtime.EventUpdate, Payload: delivery})
 	}
+	return update, int64(len(deliveries)), nil
+}
 
-	friendIds, err := service.friendshipRepo.RetrieveFriendIds(senderId)
+// GetFeed returns a page of the updates delivered to the authenticated user,
+// newest first, along with the total and unread counts of the whole feed.
+func (service *notificationService) GetFeed(authUserId int64, beforeId int64, limit int) ([]*entity.UpdateDelivery, int64, int64, error) {
+	deliveries, err := service.updateRepo.GetFeed(authUserId, beforeId, limit)
 	if err != nil {
-		return nil, err
+		return nil, 0, 0, err
+	}
+	count, err := service.updateRepo.CountFeed(authUserId)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	unreadCount, err := service.updateRepo.CountUnreadFeed(authUserId)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return deliveries, count, unreadCount, nil
+}
+
+// GetMissedFeed returns the deliveries created after lastDeliveryId, oldest
+// first, for a stream resuming from its Last-Event-ID.
+func (service *notificationService) GetMissedFeed(authUserId int64, lastDeliveryId int64, limit int) ([]*entity.UpdateDelivery, error) {
+	return service.updateRepo.GetFeedSince(authUserId, lastDeliveryId, limit)
+}
+
+func (service *notificationService) MarkFeedItemRead(authUserId int64, deliveryId int64) error {
+	err := service.updateRepo.MarkDeliveryRead(authUserId, deliveryId)
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrFeedItemNotFound
+	}
+	return err
+}
+
+// GetMentions returns a page of the updates that mentioned the authenticated
+// user, newest first, along with the total count.
+func (service *notificationService) GetMentions(authUserId int64, beforeId int64, limit int) ([]*entity.Mention, int64, error) {
+	mentions, err := service.updateRepo.GetMentions(authUserId, beforeId, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	count, err := service.updateRepo.CountMentions(authUserId)
+	if err != nil {
+		return nil, 0, err
 	}
+	return mentions, count, nil
+}
 
-	subscriberIds, err := service.subscriptionRepo.GetAllSubscriberIds(senderId)
+func (service *notificationService) getPermittedSender(authUserId int64, authUserRole string, senderEmail string) (*entity.User, error) {
+	sender, err := service.userRepo.GetUserByEmail(senderEmail)
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
+	if authUserRole == "user" && authUserId != sender.Id {
+		return nil, ErrNotPermitted
+	}
+	return sender, nil
+}
 
-	mentionedEmails := utils.ExtractEmails(text)
-	mentionedUsers, err := service.userRepo.GetUsersFromEmails(mentionedEmails)
+// getMentionedUserIds returns the sorted ids of the users mentioned in text.
+func (service *notificationService) getMentionedUserIds(text string) ([]int64, error) {
+	mentionedHandles, mentionedEmails := utils.ExtractMentions(text)
+	if len(mentionedHandles) == 0 && len(mentionedEmails) == 0 {
+		return nil, nil
+	}
+	mentionedUsers, err := service.userRepo.GetUsersFromMentions(mentionedHandles, mentionedEmails)
 	if err != nil {
 		return nil, err
 	}
@@ -65,30 +191,31 @@ func (service *notificationService) GetUpdateRecipients(authUserId int64, authUs
 	for _, mentionedUser := range mentionedUsers {
 		mentionedIds = append(mentionedIds, mentionedUser.Id)
 	}
+	slices.Sort(mentionedIds)
+	return mentionedIds, nil
+}
 
-	var recipientIdsSet = make(map[int64]bool)
-	for _, friendId := range friendIds {
-		recipientIdsSet[friendId] = true
+// getPreferences returns the notification preferences of the recipients.
+func (service *notificationService) getPreferences(deliveries []*entity.UpdateDelivery) (map[int64]*entity.NotificationPreference, error) {
+	if len(deliveries) == 0 {
+		return nil, nil
 	}
-	for _, subscriberId := range subscriberIds {
-		recipientIdsSet[subscriberId] = true
-	}
-	for _, mentionedId := range mentionedIds {
-		recipientIdsSet[mentionedId] = true
-	}
-	for _, blockRequestorId := range blockRequestorIds {
-		recipientIdsSet[blockRequestorId] = false
+	recipientIds := make([]int64, len(deliveries))
+	for i, delivery := range deliveries {
+		recipientIds[i] = delivery.RecipientId
 	}
+	return service.preferenceRepo.GetPreferences(recipientIds)
+}
 
-	recipientIds := make([]int64, 0, len(recipientIdsSet))
-	for recipientId := range recipientIdsSet {
-		if recipientIdsSet[recipientId] {
-			recipientIds = append(recipientIds, recipientId)
+// mentionedRecipientIds returns the ids of the recipients mentioned in the
+// update. Mentioned users who blocked or muted the sender got no delivery, so
+// they are not recorded as mentioned either.
+func mentionedRecipientIds(deliveries []*entity.UpdateDelivery) []int64 {
+	var mentionedIds []int64
+	for _, delivery := range deliveries {
+		if slices.Contains(delivery.Reasons, entity.NotificationMention) {
+			mentionedIds = append(mentionedIds, delivery.RecipientId)
 		}
 	}
-	recipients, err := service.userRepo.GetUsersFromIds(recipientIds)
-	if err != nil {
-		return nil, err
-	}
-	return recipients, nil
+	return mentionedIds
 }
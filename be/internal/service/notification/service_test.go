@@ -1,13 +1,19 @@
 package service
 
 import (
+	"BE_Friends_Management/constant"
 	"BE_Friends_Management/internal/domain/entity"
+	"BE_Friends_Management/internal/realtime"
 	mock "BE_Friends_Management/internal/repository/mock"
+	serviceMock "BE_Friends_Management/internal/service/mock"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
@@ -16,11 +22,11 @@ func TestNotificationService_GetUpdateRecipients(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mock.NewMockUserRepository(ctrl)
-	mockBlockRepo := mock.NewMockBlockRelationshipRepository(ctrl)
-	mockFriendshipRepo := mock.NewMockFriendshipRepository(ctrl)
-	mockSubscriptionRepo := mock.NewMockSubscriptionRepository(ctrl)
+	mockUpdateRepo := mock.NewMockUpdateRepository(ctrl)
+	mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
 
-	service := NewNotificationService(mockBlockRepo, mockUserRepo, mockFriendshipRepo, mockSubscriptionRepo)
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewNotificationService(mockUserRepo, mockUpdateRepo, mockPreferenceRepo, realtime.NewHub(constant.StreamBufferSize), mockRecorder)
 
 	t.Run("Success", func(t *testing.T) {
 		authUserId := int64(1)
@@ -31,15 +37,14 @@ func TestNotificationService_GetUpdateRecipients(t *testing.T) {
 		mentioned := &entity.User{Id: 4, Email: "mentioned@example.com"}
 
 		mockUserRepo.EXPECT().GetUserByEmail("sender@example.com").Return(sender, nil)
-		mockBlockRepo.EXPECT().GetBlockRequestorIds(int64(1)).Return([]int64{}, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(1)).Return([]int64{2}, nil)
-		mockSubscriptionRepo.EXPECT().GetAllSubscriberIds(int64(1)).Return([]int64{3}, nil)
-		mockUserRepo.EXPECT().GetUsersFromEmails([]string{"mentioned@example.com"}).Return([]*entity.User{mentioned}, nil)
-		mockUserRepo.EXPECT().GetUsersFromIds(gomock.Any()).Return([]*entity.User{friend, subscriber, mentioned}, nil)
+		mockUserRepo.EXPECT().GetUsersFromMentions([]string(nil), []string{"mentioned@example.com"}).Return([]*entity.User{mentioned}, nil)
+		mockUpdateRepo.EXPECT().GetUpdateRecipientsPage(int64(1), []int64{4}, gomock.Any(), int64(0), 20).Return([]*entity.User{friend, subscriber, mentioned}, nil)
+		mockUpdateRepo.EXPECT().CountUpdateRecipients(int64(1), []int64{4}, gomock.Any()).Return(int64(3), nil)
 
-		recipients, err := service.GetUpdateRecipients(authUserId, authUserRole, "sender@example.com", "Hello mentioned@example.com")
+		recipients, total, err := service.GetUpdateRecipients(authUserId, authUserRole, "sender@example.com", "Hello mentioned@example.com", 0, 20)
 		assert.NoError(t, err)
 		assert.Len(t, recipients, 3)
+		assert.Equal(t, int64(3), total)
 	})
 
 	t.Run("SenderNotFound", func(t *testing.T) {
@@ -47,7 +52,7 @@ func TestNotificationService_GetUpdateRecipients(t *testing.T) {
 		authUserRole := "user"
 		mockUserRepo.EXPECT().GetUserByEmail("sender@example.com").Return(nil, gorm.ErrRecordNotFound)
 
-		recipients, err := service.GetUpdateRecipients(authUserId, authUserRole, "sender@example.com", "Hello world")
+		recipients, _, err := service.GetUpdateRecipients(authUserId, authUserRole, "sender@example.com", "Hello world", 0, 20)
 		assert.Nil(t, recipients)
 		assert.Equal(t, ErrUserNotFound, err)
 	})
@@ -58,144 +63,452 @@ func TestNotificationService_GetUpdateRecipients(t *testing.T) {
 		dbErr := errors.New("database error")
 		mockUserRepo.EXPECT().GetUserByEmail("sender@example.com").Return(nil, dbErr)
 
-		recipients, err := service.GetUpdateRecipients(authUserId, authUserRole, "sender@example.com", "Hello world")
+		recipients, _, err := service.GetUpdateRecipients(authUserId, authUserRole, "sender@example.com", "Hello world", 0, 20)
 		assert.Nil(t, recipients)
 		assert.Equal(t, dbErr, err)
 	})
 
-	t.Run("ErrorOnGetBlockRequestorIds", func(t *testing.T) {
+	t.Run("ErrorOnGetUsersFromMentions", func(t *testing.T) {
 		authUserId := int64(1)
 		authUserRole := "user"
 		sender := &entity.User{Id: 1, Email: "sender@example.com"}
 		dbErr := errors.New("database error")
 
 		mockUserRepo.EXPECT().GetUserByEmail("sender@example.com").Return(sender, nil)
-		mockBlockRepo.EXPECT().GetBlockRequestorIds(int64(1)).Return(nil, dbErr)
+		mockUserRepo.EXPECT().GetUsersFromMentions([]string(nil), []string{"mentioned@example.com"}).Return(nil, dbErr)
 
-		recipients, err := service.GetUpdateRecipients(authUserId, authUserRole, "sender@example.com", "Hello world")
+		recipients, _, err := service.GetUpdateRecipients(authUserId, authUserRole, "sender@example.com", "Hello mentioned@example.com", 0, 20)
 		assert.Nil(t, recipients)
 		assert.Equal(t, dbErr, err)
 	})
 
-	t.Run("ErrorOnRetrieveFriendIds", func(t *testing.T) {
+	t.Run("ErrorOnGetUpdateRecipientsPage", func(t *testing.T) {
 		authUserId := int64(1)
 		authUserRole := "user"
 		sender := &entity.User{Id: 1, Email: "sender@example.com"}
 		dbErr := errors.New("database error")
 
 		mockUserRepo.EXPECT().GetUserByEmail("sender@example.com").Return(sender, nil)
-		mockBlockRepo.EXPECT().GetBlockRequestorIds(int64(1)).Return([]int64{}, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(1)).Return(nil, dbErr)
+		mockUpdateRepo.EXPECT().GetUpdateRecipientsPage(int64(1), []int64(nil), gomock.Any(), int64(0), 20).Return(nil, dbErr)
 
-		recipients, err := service.GetUpdateRecipients(authUserId, authUserRole, "sender@example.com", "Hello world")
+		recipients, _, err := service.GetUpdateRecipients(authUserId, authUserRole, "sender@example.com", "Hello world", 0, 20)
 		assert.Nil(t, recipients)
 		assert.Equal(t, dbErr, err)
 	})
 
-	t.Run("ErrorOnGetAllSubscriberIds", func(t *testing.T) {
-		authUserId := int64(1)
-		authUserRole := "user"
+	t.Run("ErrorOnCountUpdateRecipients", func(t *testing.T) {
 		sender := &entity.User{Id: 1, Email: "sender@example.com"}
 		dbErr := errors.New("database error")
 
 		mockUserRepo.EXPECT().GetUserByEmail("sender@example.com").Return(sender, nil)
-		mockBlockRepo.EXPECT().GetBlockRequestorIds(int64(1)).Return([]int64{}, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(1)).Return([]int64{}, nil)
-		mockSubscriptionRepo.EXPECT().GetAllSubscriberIds(int64(1)).Return(nil, dbErr)
+		mockUpdateRepo.EXPECT().GetUpdateRecipientsPage(int64(1), []int64(nil), gomock.Any(), int64(0), 20).Return([]*entity.User{}, nil)
+		mockUpdateRepo.EXPECT().CountUpdateRecipients(int64(1), []int64(nil), gomock.Any()).Return(int64(0), dbErr)
 
-		recipients, err := service.GetUpdateRecipients(authUserId, authUserRole, "sender@example.com", "Hello world")
+		recipients, _, err := service.GetUpdateRecipients(1, "user", "sender@example.com", "Hello world", 0, 20)
 		assert.Nil(t, recipients)
 		assert.Equal(t, dbErr, err)
 	})
 
-	t.Run("ErrorOnGetUsersFromEmails", func(t *testing.T) {
+	t.Run("NoTextEmails", func(t *testing.T) {
 		authUserId := int64(1)
 		authUserRole := "user"
 		sender := &entity.User{Id: 1, Email: "sender@example.com"}
-		dbErr := errors.New("database error")
+		friend := &entity.User{Id: 2, Email: "friend@example.com"}
 
 		mockUserRepo.EXPECT().GetUserByEmail("sender@example.com").Return(sender, nil)
-		mockBlockRepo.EXPECT().GetBlockRequestorIds(int64(1)).Return([]int64{}, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(1)).Return([]int64{}, nil)
-		mockSubscriptionRepo.EXPECT().GetAllSubscriberIds(int64(1)).Return([]int64{}, nil)
-		mockUserRepo.EXPECT().GetUsersFromEmails([]string{"mentioned@example.com"}).Return(nil, dbErr)
+		mockUpdateRepo.EXPECT().GetUpdateRecipientsPage(int64(1), []int64(nil), gomock.Any(), int64(0), 20).Return([]*entity.User{friend}, nil)
+		mockUpdateRepo.EXPECT().CountUpdateRecipients(int64(1), []int64(nil), gomock.Any()).Return(int64(1), nil)
 
-		recipients, err := service.GetUpdateRecipients(authUserId, authUserRole, "sender@example.com", "Hello mentioned@example.com")
-		assert.Nil(t, recipients)
-		assert.Equal(t, dbErr, err)
+		recipients, _, err := service.GetUpdateRecipients(authUserId, authUserRole, "sender@example.com", "Hello world no emails", 0, 20)
+		assert.NoError(t, err)
+		assert.Len(t, recipients, 1)
 	})
 
-	t.Run("ErrorOnGetUsersFromIds", func(t *testing.T) {
+	t.Run("PageReportsTotal", func(t *testing.T) {
 		authUserId := int64(1)
 		authUserRole := "user"
 		sender := &entity.User{Id: 1, Email: "sender@example.com"}
-		mentioned := &entity.User{Id: 2, Email: "mentioned@example.com"}
-		dbErr := errors.New("database error")
+		subscriber := &entity.User{Id: 3, Email: "subscriber@example.com"}
 
 		mockUserRepo.EXPECT().GetUserByEmail("sender@example.com").Return(sender, nil)
-		mockBlockRepo.EXPECT().GetBlockRequestorIds(int64(1)).Return([]int64{}, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(1)).Return([]int64{}, nil)
-		mockSubscriptionRepo.EXPECT().GetAllSubscriberIds(int64(1)).Return([]int64{}, nil)
-		mockUserRepo.EXPECT().GetUsersFromEmails([]string{"mentioned@example.com"}).Return([]*entity.User{mentioned}, nil)
-		mockUserRepo.EXPECT().GetUsersFromIds(gomock.Any()).Return(nil, dbErr)
+		mockUpdateRepo.EXPECT().GetUpdateRecipientsPage(int64(1), []int64(nil), gomock.Any(), int64(2), 1).Return([]*entity.User{subscriber}, nil)
+		mockUpdateRepo.EXPECT().CountUpdateRecipients(int64(1), []int64(nil), gomock.Any()).Return(int64(2), nil)
 
-		recipients, err := service.GetUpdateRecipients(authUserId, authUserRole, "sender@example.com", "Hello mentioned@example.com")
-		assert.Nil(t, recipients)
-		assert.Equal(t, dbErr, err)
+		recipients, total, err := service.GetUpdateRecipients(authUserId, authUserRole, "sender@example.com", "Hello world", 2, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []*entity.User{subscriber}, recipients)
+		assert.Equal(t, int64(2), total)
 	})
+}
 
-	t.Run("BlockedUsersExcluded", func(t *testing.T) {
-		authUserId := int64(1)
-		authUserRole := "user"
-		sender := &entity.User{Id: 1, Email: "sender@example.com"}
-		friend := &entity.User{Id: 2, Email: "friend@example.com"}
-		// subscriber := &entity.User{Id: 3, Email: "subscriber@example.com"}
+func TestNotificationService_PublishUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUpdateRepo := mock.NewMockUpdateRepository(ctrl)
+	mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+
+	db, mockSQL, err := sqlmock.New()
+	assert.NoError(t, err)
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+	mockUpdateRepo.EXPECT().GetDB().Return(gormDB).AnyTimes()
+
+	hub := realtime.NewHub(constant.StreamBufferSize)
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewNotificationService(mockUserRepo, mockUpdateRepo, mockPreferenceRepo, hub, mockRecorder)
+	sender := &entity.User{Id: 1, Email: "sender@example.com"}
+
+	t.Run("Success", func(t *testing.T) {
+		mentioned := &entity.User{Id: 4, Email: "mentioned@example.com"}
+		subscription := hub.Subscribe(4)
+		defer hub.Unsubscribe(subscription)
 
 		mockUserRepo.EXPECT().GetUserByEmail("sender@example.com").Return(sender, nil)
-		mockBlockRepo.EXPECT().GetBlockRequestorIds(int64(1)).Return([]int64{3}, nil) // subscriber is blocked
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(1)).Return([]int64{2}, nil)
-		mockSubscriptionRepo.EXPECT().GetAllSubscriberIds(int64(1)).Return([]int64{3}, nil)
-		mockUserRepo.EXPECT().GetUsersFromEmails(gomock.Any()).Return([]*entity.User{}, nil)
-		mockUserRepo.EXPECT().GetUsersFromIds([]int64{2}).Return([]*entity.User{friend}, nil)
+		mockUserRepo.EXPECT().GetUsersFromMentions([]string(nil), []string{"mentioned@example.com"}).Return([]*entity.User{mentioned}, nil)
+		mockSQL.ExpectBegin()
+		mockUpdateRepo.EXPECT().CreateUpdate(gomock.Any(), gomock.Any()).DoAndReturn(func(tx *gorm.DB, update *entity.Update) error {
+			assert.Equal(t, int64(1), update.SenderId)
+			assert.Equal(t, "Hello mentioned@example.com", update.Text)
+			update.Id = 10
+			return nil
+		})
+		deliveries := []*entity.UpdateDelivery{
+			{Id: 20, UpdateId: 10, RecipientId: 2, Reasons: []string{entity.NotificationFriendUpdate}},
+			{Id: 21, UpdateId: 10, RecipientId: 4, Reasons: []string{entity.NotificationMention}},
+			{Id: 22, UpdateId: 10, RecipientId: 5, Reasons: []string{entity.NotificationFriendUpdate}},
+		}
+		mockUpdateRepo.EXPECT().CreateUpdateDeliveries(gomock.Any(), int64(10), int64(1), []int64{4}, gomock.Any()).Return(deliveries, nil)
+		mockUpdateRepo.EXPECT().CreateMentions(gomock.Any(), int64(10), []int64{4}).Return(nil)
+		mockPreferenceRepo.EXPECT().GetPreferences([]int64{2, 4, 5}).Return(map[int64]*entity.NotificationPreference{}, nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventUpdatePublished, gomock.Any()).DoAndReturn(func(_ *gorm.DB, _ string, data any) error {
+			assert.Equal(t, int64(10), data.(entity.UpdateEventData).Id)
+			assert.Equal(t, "sender@example.com", data.(entity.UpdateEventData).Sender)
+			assert.Equal(t, int64(3), data.(entity.UpdateEventData).RecipientCount)
+			return nil
+		})
+		mockSQL.ExpectCommit()
+
+		update, recipientCount, err := service.PublishUpdate(1, "user", "sender@example.com", "Hello mentioned@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), update.Id)
+		assert.Equal(t, sender, update.Sender)
+		assert.Equal(t, int64(3), recipientCount)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+
+		event := <-subscription.Events
+		assert.Equal(t, int64(21), event.Id)
+		assert.Equal(t, realtime.EventUpdate, event.Type)
+		assert.Equal(t, update, event.Payload.(*entity.UpdateDelivery).Update)
+	})
 
-		recipients, err := service.GetUpdateRecipients(authUserId, authUserRole, "sender@example.com", "Hello world")
+	t.Run("InAppOffSkipsLivePush", func(t *testing.T) {
+		subscription := hub.Subscribe(2)
+		defer hub.Unsubscribe(subscription)
+
+		mockUserRepo.EXPECT().GetUserByEmail("sender@example.com").Return(sender, nil)
+		mockSQL.ExpectBegin()
+		mockUpdateRepo.EXPECT().CreateUpdate(gomock.Any(), gomock.Any()).Return(nil)
+		mockUpdateRepo.EXPECT().CreateUpdateDeliveries(gomock.Any(), gomock.Any(), int64(1), []int64(nil), gomock.Any()).Return([]*entity.UpdateDelivery{{Id: 30, RecipientId: 2, Reasons: []string{entity.NotificationFriendUpdate}}}, nil)
+		mockUpdateRepo.EXPECT().CreateMentions(gomock.Any(), gomock.Any(), []int64(nil)).Return(nil)
+		mockPreferenceRepo.EXPECT().GetPreferences([]int64{2}).Return(map[int64]*entity.NotificationPreference{
+			2: {Channels: []string{entity.ChannelEmail}, EventTypes: entity.NotificationTypes},
+		}, nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventUpdatePublished, gomock.Any()).Return(nil)
+		mockSQL.ExpectCommit()
+
+		_, recipientCount, err := service.PublishUpdate(1, "user", "sender@example.com", "Hello world")
 		assert.NoError(t, err)
-		assert.Len(t, recipients, 1)
-		assert.Equal(t, "friend@example.com", recipients[0].Email)
+		assert.Equal(t, int64(1), recipientCount)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+		assert.Empty(t, subscription.Events)
 	})
 
-	t.Run("NoTextEmails", func(t *testing.T) {
-		authUserId := int64(1)
-		authUserRole := "user"
-		sender := &entity.User{Id: 1, Email: "sender@example.com"}
-		friend := &entity.User{Id: 2, Email: "friend@example.com"}
+	t.Run("QuietHoursKeepFeedItem", func(t *testing.T) {
+		now := time.Now().UTC()
+		subscription := hub.Subscribe(2)
+		defer hub.Unsubscribe(subscription)
 
 		mockUserRepo.EXPECT().GetUserByEmail("sender@example.com").Return(sender, nil)
-		mockBlockRepo.EXPECT().GetBlockRequestorIds(int64(1)).Return([]int64{}, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(1)).Return([]int64{2}, nil)
-		mockSubscriptionRepo.EXPECT().GetAllSubscriberIds(int64(1)).Return([]int64{}, nil)
-		mockUserRepo.EXPECT().GetUsersFromEmails(gomock.Any()).Return([]*entity.User{}, nil)
-		mockUserRepo.EXPECT().GetUsersFromIds([]int64{2}).Return([]*entity.User{friend}, nil)
+		mockSQL.ExpectBegin()
+		mockUpdateRepo.EXPECT().CreateUpdate(gomock.Any(), gomock.Any()).Return(nil)
+		mockUpdateRepo.EXPECT().CreateUpdateDeliveries(gomock.Any(), gomock.Any(), int64(1), []int64(nil), gomock.Any()).Return([]*entity.UpdateDelivery{{Id: 31, RecipientId: 2, Reasons: []string{entity.NotificationFriendUpdate}}}, nil)
+		mockUpdateRepo.EXPECT().CreateMentions(gomock.Any(), gomock.Any(), []int64(nil)).Return(nil)
+		mockPreferenceRepo.EXPECT().GetPreferences([]int64{2}).Return(map[int64]*entity.NotificationPreference{
+			2: {
+				Channels:             entity.NotificationChannels,
+				EventTypes:           entity.NotificationTypes,
+				QuietHoursStart:      now.Add(-time.Hour).Format("15:04"),
+				QuietHoursEnd:        now.Add(time.Hour).Format("15:04"),
+				QuietHoursTimezone:   "UTC",
+				QuietHoursEventTypes: []string{},
+			},
+		}, nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventUpdatePublished, gomock.Any()).Return(nil)
+		mockSQL.ExpectCommit()
+
+		_, recipientCount, err := service.PublishUpdate(1, "user", "sender@example.com", "Hello world")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), recipientCount)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+		assert.Empty(t, subscription.Events)
+	})
+
+	t.Run("MentionsDeliveredWhateverPreferences", func(t *testing.T) {
+		subscription := hub.Subscribe(4)
+		defer hub.Unsubscribe(subscription)
 
-		recipients, err := service.GetUpdateRecipients(authUserId, authUserRole, "sender@example.com", "Hello world no emails")
+		mockUserRepo.EXPECT().GetUserByEmail("sender@example.com").Return(sender, nil)
+		mockUserRepo.EXPECT().GetUsersFromMentions([]string{"alice", "carol"}, []string{"bob@example.com"}).Return([]*entity.User{
+			{Id: 4, Email: "alice@example.com"},
+			{Id: 5, Email: "Bob@Example.com"},
+			{Id: 6, Email: "carol@example.com"},
+		}, nil)
+		mockSQL.ExpectBegin()
+		mockUpdateRepo.EXPECT().CreateUpdate(gomock.Any(), gomock.Any()).DoAndReturn(func(tx *gorm.DB, update *entity.Update) error {
+			update.Id = 11
+			return nil
+		})
+		// carol blocked the sender, so the query gives her no delivery.
+		mockUpdateRepo.EXPECT().CreateUpdateDeliveries(gomock.Any(), int64(11), int64(1), []int64{4, 5, 6}, gomock.Any()).Return([]*entity.UpdateDelivery{
+			{Id: 40, RecipientId: 4, Reasons: []string{entity.NotificationMention}},
+			{Id: 41, RecipientId: 5, Reasons: []string{entity.NotificationMention}},
+		}, nil)
+		mockUpdateRepo.EXPECT().CreateMentions(gomock.Any(), int64(11), []int64{4, 5}).Return(nil)
+		// alice turned mention notifications off.
+		mockPreferenceRepo.EXPECT().GetPreferences([]int64{4, 5}).Return(map[int64]*entity.NotificationPreference{
+			4: {Channels: entity.NotificationChannels, EventTypes: []string{entity.NotificationFriendUpdate}},
+		}, nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventUpdatePublished, gomock.Any()).Return(nil)
+		mockSQL.ExpectCommit()
+
+		_, recipientCount, err := service.PublishUpdate(1, "user", "sender@example.com", "Hi @Alice, BOB@example.com and @carol (cc @alice)")
 		assert.NoError(t, err)
-		assert.Len(t, recipients, 1)
+		assert.Equal(t, int64(2), recipientCount)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+		assert.Empty(t, subscription.Events)
 	})
 
-	t.Run("EmptyRecipients", func(t *testing.T) {
-		authUserId := int64(1)
-		authUserRole := "user"
-		sender := &entity.User{Id: 1, Email: "sender@example.com"}
+	t.Run("MentionsRecordedForFriendsToo", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("sender@example.com").Return(sender, nil)
+		mockUserRepo.EXPECT().GetUsersFromMentions([]string(nil), []string{"friend@example.com"}).Return([]*entity.User{{Id: 2, Email: "friend@example.com"}}, nil)
+		mockSQL.ExpectBegin()
+		mockUpdateRepo.EXPECT().CreateUpdate(gomock.Any(), gomock.Any()).Return(nil)
+		mockUpdateRepo.EXPECT().CreateUpdateDeliveries(gomock.Any(), gomock.Any(), int64(1), []int64{2}, gomock.Any()).Return([]*entity.UpdateDelivery{
+			{Id: 32, RecipientId: 2, Reasons: []string{entity.NotificationFriendUpdate, entity.NotificationMention}},
+			{Id: 33, RecipientId: 3, Reasons: []string{entity.NotificationSubscriptionUpdate}},
+		}, nil)
+		mockUpdateRepo.EXPECT().CreateMentions(gomock.Any(), gomock.Any(), []int64{2}).Return(nil)
+		mockPreferenceRepo.EXPECT().GetPreferences([]int64{2, 3}).Return(map[int64]*entity.NotificationPreference{}, nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventUpdatePublished, gomock.Any()).Return(nil)
+		mockSQL.ExpectCommit()
+
+		_, recipientCount, err := service.PublishUpdate(1, "user", "sender@example.com", "Hello friend@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), recipientCount)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+	})
+
+	t.Run("ErrorOnGetUsersFromMentions", func(t *testing.T) {
+		dbErr := errors.New("database error")
+
+		mockUserRepo.EXPECT().GetUserByEmail("sender@example.com").Return(sender, nil)
+		mockUserRepo.EXPECT().GetUsersFromMentions([]string{"alice"}, []string(nil)).Return(nil, dbErr)
+
+		update, _, err := service.PublishUpdate(1, "user", "sender@example.com", "Hi @alice")
+		assert.Nil(t, update)
+		assert.Equal(t, dbErr, err)
+	})
+
+	t.Run("RollbackOnGetPreferencesError", func(t *testing.T) {
+		dbErr := errors.New("database error")
+
+		mockUserRepo.EXPECT().GetUserByEmail("sender@example.com").Return(sender, nil)
+		mockSQL.ExpectBegin()
+		mockUpdateRepo.EXPECT().CreateUpdate(gomock.Any(), gomock.Any()).Return(nil)
+		mockUpdateRepo.EXPECT().CreateUpdateDeliveries(gomock.Any(), gomock.Any(), int64(1), []int64(nil), gomock.Any()).Return([]*entity.UpdateDelivery{{Id: 34, RecipientId: 2, Reasons: []string{entity.NotificationFriendUpdate}}}, nil)
+		mockUpdateRepo.EXPECT().CreateMentions(gomock.Any(), gomock.Any(), []int64(nil)).Return(nil)
+		mockPreferenceRepo.EXPECT().GetPreferences([]int64{2}).Return(nil, dbErr)
+		mockSQL.ExpectRollback()
+
+		update, _, err := service.PublishUpdate(1, "user", "sender@example.com", "Hello world")
+		assert.Nil(t, update)
+		assert.Equal(t, dbErr, err)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+	})
+
+	t.Run("RollbackOnMentionError", func(t *testing.T) {
+		dbErr := errors.New("database error")
+
+		mockUserRepo.EXPECT().GetUserByEmail("sender@example.com").Return(sender, nil)
+		mockUserRepo.EXPECT().GetUsersFromMentions([]string{"alice"}, []string(nil)).Return([]*entity.User{{Id: 4}}, nil)
+		mockSQL.ExpectBegin()
+		mockUpdateRepo.EXPECT().CreateUpdate(gomock.Any(), gomock.Any()).Return(nil)
+		mockUpdateRepo.EXPECT().CreateUpdateDeliveries(gomock.Any(), gomock.Any(), int64(1), []int64{4}, gomock.Any()).Return([]*entity.UpdateDelivery{{Id: 41, RecipientId: 4, Reasons: []string{entity.NotificationMention}}}, nil)
+		mockUpdateRepo.EXPECT().CreateMentions(gomock.Any(), gomock.Any(), []int64{4}).Return(dbErr)
+		mockSQL.ExpectRollback()
+
+		update, _, err := service.PublishUpdate(1, "user", "sender@example.com", "Hi @alice")
+		assert.Nil(t, update)
+		assert.Equal(t, dbErr, err)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+	})
+
+	t.Run("RollbackOnDeliveryError", func(t *testing.T) {
+		dbErr := errors.New("database error")
 
 		mockUserRepo.EXPECT().GetUserByEmail("sender@example.com").Return(sender, nil)
-		mockBlockRepo.EXPECT().GetBlockRequestorIds(int64(1)).Return([]int64{}, nil)
-		mockFriendshipRepo.EXPECT().RetrieveFriendIds(int64(1)).Return([]int64{}, nil)
-		mockSubscriptionRepo.EXPECT().GetAllSubscriberIds(int64(1)).Return([]int64{}, nil)
-		mockUserRepo.EXPECT().GetUsersFromEmails(gomock.Any()).Return([]*entity.User{}, nil)
-		mockUserRepo.EXPECT().GetUsersFromIds([]int64{}).Return([]*entity.User{}, nil)
+		mockSQL.ExpectBegin()
+		mockUpdateRepo.EXPECT().CreateUpdate(gomock.Any(), gomock.Any()).Return(nil)
+		mockUpdateRepo.EXPECT().CreateUpdateDeliveries(gomock.Any(), gomock.Any(), int64(1), []int64(nil), gomock.Any()).Return(nil, dbErr)
+		mockSQL.ExpectRollback()
+
+		update, recipientCount, err := service.PublishUpdate(1, "user", "sender@example.com", "Hello world")
+		assert.Nil(t, update)
+		assert.Equal(t, int64(0), recipientCount)
+		assert.Equal(t, dbErr, err)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+	})
+
+	t.Run("NotPermitted", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("sender@example.com").Return(sender, nil)
+
+		update, _, err := service.PublishUpdate(2, "user", "sender@example.com", "Hello world")
+		assert.Nil(t, update)
+		assert.Equal(t, ErrNotPermitted, err)
+	})
+
+	t.Run("SenderNotFound", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("sender@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+		update, _, err := service.PublishUpdate(1, "user", "sender@example.com", "Hello world")
+		assert.Nil(t, update)
+		assert.Equal(t, ErrUserNotFound, err)
+	})
+}
+
+func TestNotificationService_GetFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUpdateRepo := mock.NewMockUpdateRepository(ctrl)
+	mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewNotificationService(mockUserRepo, mockUpdateRepo, mockPreferenceRepo, realtime.NewHub(constant.StreamBufferSize), mockRecorder)
+
+	t.Run("Success", func(t *testing.T) {
+		deliveries := []*entity.UpdateDelivery{
+			{Id: 9, UpdateId: 5, RecipientId: 2},
+			{Id: 8, UpdateId: 4, RecipientId: 2},
+		}
+		mockUpdateRepo.EXPECT().GetFeed(int64(2), int64(10), 2).Return(deliveries, nil)
+		mockUpdateRepo.EXPECT().CountFeed(int64(2)).Return(int64(5), nil)
+		mockUpdateRepo.EXPECT().CountUnreadFeed(int64(2)).Return(int64(1), nil)
+
+		result, count, unreadCount, err := service.GetFeed(2, 10, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, deliveries, result)
+		assert.Equal(t, int64(5), count)
+		assert.Equal(t, int64(1), unreadCount)
+	})
+
+	t.Run("RepositoryError", func(t *testing.T) {
+		mockUpdateRepo.EXPECT().GetFeed(int64(2), int64(0), 20).Return(nil, errors.New("database error"))
+
+		result, _, _, err := service.GetFeed(2, 0, 20)
+		assert.Nil(t, result)
+		assert.Error(t, err)
+	})
+}
+
+func TestNotificationService_MarkFeedItemRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUpdateRepo := mock.NewMockUpdateRepository(ctrl)
+	mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewNotificationService(mockUserRepo, mockUpdateRepo, mockPreferenceRepo, realtime.NewHub(constant.StreamBufferSize), mockRecorder)
+
+	t.Run("Success", func(t *testing.T) {
+		mockUpdateRepo.EXPECT().MarkDeliveryRead(int64(2), int64(9)).Return(nil)
+
+		err := service.MarkFeedItemRead(2, 9)
+		assert.NoError(t, err)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockUpdateRepo.EXPECT().MarkDeliveryRead(int64(2), int64(9)).Return(gorm.ErrRecordNotFound)
+
+		err := service.MarkFeedItemRead(2, 9)
+		assert.Equal(t, ErrFeedItemNotFound, err)
+	})
+}
+
+func TestNotificationService_GetMissedFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUpdateRepo := mock.NewMockUpdateRepository(ctrl)
+	mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewNotificationService(mockUserRepo, mockUpdateRepo, mockPreferenceRepo, realtime.NewHub(constant.StreamBufferSize), mockRecorder)
+
+	t.Run("Success", func(t *testing.T) {
+		deliveries := []*entity.UpdateDelivery{{Id: 8, UpdateId: 4, RecipientId: 2}}
+		mockUpdateRepo.EXPECT().GetFeedSince(int64(2), int64(7), 100).Return(deliveries, nil)
+
+		result, err := service.GetMissedFeed(2, 7, 100)
+		assert.NoError(t, err)
+		assert.Equal(t, deliveries, result)
+	})
+
+	t.Run("RepositoryError", func(t *testing.T) {
+		mockUpdateRepo.EXPECT().GetFeedSince(int64(2), int64(7), 100).Return(nil, errors.New("database error"))
+
+		result, err := service.GetMissedFeed(2, 7, 100)
+		assert.Nil(t, result)
+		assert.Error(t, err)
+	})
+}
+
+func TestNotificationService_GetMentions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-		recipients, err := service.GetUpdateRecipients(authUserId, authUserRole, "sender@example.com", "Hello world")
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUpdateRepo := mock.NewMockUpdateRepository(ctrl)
+	mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	service := NewNotificationService(mockUserRepo, mockUpdateRepo, mockPreferenceRepo, realtime.NewHub(constant.StreamBufferSize), mockRecorder)
+
+	t.Run("Success", func(t *testing.T) {
+		mentions := []*entity.Mention{{Id: 9, UpdateId: 5, UserId: 2}}
+		mockUpdateRepo.EXPECT().GetMentions(int64(2), int64(10), 20).Return(mentions, nil)
+		mockUpdateRepo.EXPECT().CountMentions(int64(2)).Return(int64(3), nil)
+
+		result, count, err := service.GetMentions(2, 10, 20)
 		assert.NoError(t, err)
-		assert.Len(t, recipients, 0)
+		assert.Equal(t, mentions, result)
+		assert.Equal(t, int64(3), count)
+	})
+
+	t.Run("RepositoryError", func(t *testing.T) {
+		mockUpdateRepo.EXPECT().GetMentions(int64(2), int64(0), 20).Return(nil, errors.New("database error"))
+
+		result, count, err := service.GetMentions(2, 0, 20)
+		assert.Nil(t, result)
+		assert.Equal(t, int64(0), count)
+		assert.Error(t, err)
 	})
 }
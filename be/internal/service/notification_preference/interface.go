@@ -0,0 +1,20 @@
+package service
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"errors"
+)
+
+var (
+	ErrInvalidChannel    = errors.New("channel must be in_app, email or webhook")
+	ErrInvalidEventType  = errors.New("event type must be friend_update, subscription_update, mention or friend_request")
+	ErrInvalidQuietHours = errors.New("quiet hours need a start and an end in HH:MM format that differ")
+	ErrInvalidTimezone   = errors.New("timezone must be an IANA time zone name")
+)
+
+//go:generate mockgen -source=interface.go -destination=../mock/mock_notification_preference_service.go
+
+type NotificationPreferenceService interface {
+	GetPreference(authUserId int64) (*entity.NotificationPreference, error)
+	UpdatePreference(authUserId int64, preference *entity.NotificationPreference) (*entity.NotificationPreference, error)
+}
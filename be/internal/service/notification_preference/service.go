@@ -0,0 +1,94 @@
+package service
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	notificationPreferenceRepository "BE_Friends_Management/internal/repository/notification_preference"
+	"BE_Friends_Management/pkg/utils"
+	"errors"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type notificationPreferenceService struct {
+	repo notificationPreferenceRepository.NotificationPreferenceRepository
+}
+
+func NewNotificationPreferenceService(repo notificationPreferenceRepository.NotificationPreferenceRepository) NotificationPreferenceService {
+	return &notificationPreferenceService{
+		repo: repo,
+	}
+}
+
+// GetPreference returns the notification preference of the user. Users who
+// never set one get every notification type on every channel.
+func (service *notificationPreferenceService) GetPreference(authUserId int64) (*entity.NotificationPreference, error) {
+	preference, err := service.repo.GetPreference(authUserId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.DefaultNotificationPreference(authUserId), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return preference, nil
+}
+
+// UpdatePreference replaces the notification preference of the user.
+// Duplicate channels and types are dropped; quiet hours default to UTC.
+func (service *notificationPreferenceService) UpdatePreference(authUserId int64, preference *entity.NotificationPreference) (*entity.NotificationPreference, error) {
+	channels, ok := normalize(preference.Channels, entity.NotificationChannels)
+	if !ok {
+		return nil, ErrInvalidChannel
+	}
+	types, ok := normalize(preference.EventTypes, entity.NotificationTypes)
+	if !ok {
+		return nil, ErrInvalidEventType
+	}
+	quietHoursTypes, ok := normalize(preference.QuietHoursEventTypes, entity.NotificationTypes)
+	if !ok {
+		return nil, ErrInvalidEventType
+	}
+	updated := &entity.NotificationPreference{
+		UserId:               authUserId,
+		Channels:             channels,
+		EventTypes:           types,
+		QuietHoursEventTypes: quietHoursTypes,
+	}
+	if preference.QuietHoursStart != "" || preference.QuietHoursEnd != "" {
+		if utils.ParseQuietHoursClock(preference.QuietHoursStart) != nil ||
+			utils.ParseQuietHoursClock(preference.QuietHoursEnd) != nil ||
+			preference.QuietHoursStart == preference.QuietHoursEnd {
+			return nil, ErrInvalidQuietHours
+		}
+		timezone := preference.QuietHoursTimezone
+		if timezone == "" {
+			timezone = "UTC"
+		}
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return nil, ErrInvalidTimezone
+		}
+		updated.QuietHoursStart = preference.QuietHoursStart
+		updated.QuietHoursEnd = preference.QuietHoursEnd
+		updated.QuietHoursTimezone = timezone
+	}
+	if err := service.repo.SavePreference(updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// normalize drops duplicate values and reports whether every value is
+// allowed. The result is never nil, since the columns are not nullable.
+func normalize(values []string, allowed []string) ([]string, bool) {
+	normalized := make([]string, 0, len(values))
+	for _, value := range values {
+		if !slices.Contains(allowed, value) {
+			return nil, false
+		}
+		if !slices.Contains(normalized, value) {
+			normalized = append(normalized, value)
+		}
+	}
+	return normalized, true
+}
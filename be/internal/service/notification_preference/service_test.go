@@ -0,0 +1,139 @@
+package service
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	mock "BE_Friends_Management/internal/repository/mock"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestNotificationPreferenceService_GetPreference(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+	service := NewNotificationPreferenceService(mockRepo)
+
+	t.Run("Success", func(t *testing.T) {
+		preference := &entity.NotificationPreference{UserId: 1, Channels: []string{entity.ChannelEmail}}
+		mockRepo.EXPECT().GetPreference(int64(1)).Return(preference, nil)
+
+		result, err := service.GetPreference(1)
+		assert.NoError(t, err)
+		assert.Equal(t, preference, result)
+	})
+
+	t.Run("DefaultsToEverything", func(t *testing.T) {
+		mockRepo.EXPECT().GetPreference(int64(1)).Return(nil, gorm.ErrRecordNotFound)
+
+		result, err := service.GetPreference(1)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.NotificationChannels, []string(result.Channels))
+		assert.Equal(t, entity.NotificationTypes, []string(result.EventTypes))
+		assert.Empty(t, result.QuietHoursStart)
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		dbErr := errors.New("database error")
+		mockRepo.EXPECT().GetPreference(int64(1)).Return(nil, dbErr)
+
+		result, err := service.GetPreference(1)
+		assert.ErrorIs(t, err, dbErr)
+		assert.Nil(t, result)
+	})
+}
+
+func TestNotificationPreferenceService_UpdatePreference(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+	service := NewNotificationPreferenceService(mockRepo)
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().SavePreference(gomock.Any()).DoAndReturn(func(preference *entity.NotificationPreference) error {
+			assert.Equal(t, int64(1), preference.UserId)
+			assert.Equal(t, []string{entity.ChannelEmail}, []string(preference.Channels))
+			assert.Equal(t, []string{entity.NotificationMention}, []string(preference.EventTypes))
+			assert.Equal(t, "Europe/Paris", preference.QuietHoursTimezone)
+			return nil
+		})
+
+		result, err := service.UpdatePreference(1, &entity.NotificationPreference{
+			UserId:             2,
+			Channels:           []string{entity.ChannelEmail, entity.ChannelEmail},
+			EventTypes:         []string{entity.NotificationMention},
+			QuietHoursStart:    "22:00",
+			QuietHoursEnd:      "07:00",
+			QuietHoursTimezone: "Europe/Paris",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "22:00", result.QuietHoursStart)
+		assert.NotNil(t, result.QuietHoursEventTypes)
+	})
+
+	t.Run("EverythingOff", func(t *testing.T) {
+		mockRepo.EXPECT().SavePreference(gomock.Any()).DoAndReturn(func(preference *entity.NotificationPreference) error {
+			assert.NotNil(t, preference.Channels)
+			assert.Empty(t, preference.Channels)
+			assert.NotNil(t, preference.EventTypes)
+			return nil
+		})
+
+		_, err := service.UpdatePreference(1, &entity.NotificationPreference{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("QuietHoursDefaultToUTC", func(t *testing.T) {
+		mockRepo.EXPECT().SavePreference(gomock.Any()).Return(nil)
+
+		result, err := service.UpdatePreference(1, &entity.NotificationPreference{QuietHoursStart: "08:00", QuietHoursEnd: "09:30"})
+		assert.NoError(t, err)
+		assert.Equal(t, "UTC", result.QuietHoursTimezone)
+	})
+
+	t.Run("InvalidChannel", func(t *testing.T) {
+		_, err := service.UpdatePreference(1, &entity.NotificationPreference{Channels: []string{"sms"}})
+		assert.ErrorIs(t, err, ErrInvalidChannel)
+	})
+
+	t.Run("InvalidType", func(t *testing.T) {
+		_, err := service.UpdatePreference(1, &entity.NotificationPreference{EventTypes: []string{"like"}})
+		assert.ErrorIs(t, err, ErrInvalidEventType)
+	})
+
+	t.Run("InvalidQuietHoursType", func(t *testing.T) {
+		_, err := service.UpdatePreference(1, &entity.NotificationPreference{QuietHoursEventTypes: []string{"like"}})
+		assert.ErrorIs(t, err, ErrInvalidEventType)
+	})
+
+	t.Run("InvalidQuietHours", func(t *testing.T) {
+		for _, preference := range []*entity.NotificationPreference{
+			{QuietHoursStart: "22:00"},
+			{QuietHoursStart: "25:00", QuietHoursEnd: "07:00"},
+			{QuietHoursStart: "7am", QuietHoursEnd: "9am"},
+			{QuietHoursStart: "22:00", QuietHoursEnd: "22:00"},
+		} {
+			_, err := service.UpdatePreference(1, preference)
+			assert.ErrorIs(t, err, ErrInvalidQuietHours)
+		}
+	})
+
+	t.Run("InvalidTimezone", func(t *testing.T) {
+		_, err := service.UpdatePreference(1, &entity.NotificationPreference{QuietHoursStart: "22:00", QuietHoursEnd: "07:00", QuietHoursTimezone: "Mars/Olympus"})
+		assert.ErrorIs(t, err, ErrInvalidTimezone)
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		dbErr := errors.New("database error")
+		mockRepo.EXPECT().SavePreference(gomock.Any()).Return(dbErr)
+
+		result, err := service.UpdatePreference(1, &entity.NotificationPreference{})
+		assert.ErrorIs(t, err, dbErr)
+		assert.Nil(t, result)
+	})
+}
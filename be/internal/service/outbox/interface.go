@@ -0,0 +1,30 @@
+package service
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=interface.go -destination=../mock/mock_outbox_service.go
+
+// EventRecorder writes a domain event into the outbox as part of the caller's
+// transaction, so the event exists if and only if the change it describes
+// is committed.
+type EventRecorder interface {
+	Record(tx *gorm.DB, eventType string, data any) error
+}
+
+// Sink receives relayed outbox events. Handle may see the same event more
+// than once and must treat event.Id as an idempotency key.
+type Sink interface {
+	Name() string
+	Handle(event entity.Event) error
+}
+
+type OutboxService interface {
+	EventRecorder
+	RelayPending(ctx context.Context) (int, error)
+	Run(ctx context.Context)
+}
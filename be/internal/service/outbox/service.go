@@ -0,0 +1,121 @@
+package service
+
+import (
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/entity"
+	outboxRepository "BE_Friends_Management/internal/repository/outbox"
+	"BE_Friends_Management/pkg/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type outboxService struct {
+	repo  outboxRepository.OutboxRepository
+	sinks []Sink
+}
+
+func NewOutboxService(repo outboxRepository.OutboxRepository, sinks ...Sink) OutboxService {
+	return &outboxService{
+		repo:  repo,
+		sinks: sinks,
+	}
+}
+
+func (service *outboxService) Record(tx *gorm.DB, eventType string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return service.repo.CreateEvent(tx, &entity.OutboxEvent{
+		EventId:     utils.NewEventId(),
+		EventType:   eventType,
+		Payload:     string(payload),
+		AvailableAt: time.Now(),
+	})
+}
+
+// RelayPending hands one batch of pending events to every sink. An event is
+// marked processed only once all sinks accepted it; otherwise it is retried
+// after constant.OutboxRetryDelay, only for the sinks that failed, until it
+// fails constant.OutboxMaxAttempts times and is abandoned. It returns the
+// number of events claimed.
+func (service *outboxService) RelayPending(ctx context.Context) (int, error) {
+	now := time.Now()
+	events, err := service.repo.ClaimPendingEvents(now, now.Add(constant.OutboxClaimLease), constant.OutboxBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	for _, outboxEvent := range events {
+		if ctx.Err() != nil {
+			// The lease expires and another relay picks the rest up.
+			return len(events), ctx.Err()
+		}
+		event := entity.Event{
+			Id:        outboxEvent.EventId,
+			Type:      outboxEvent.EventType,
+			CreatedAt: outboxEvent.CreatedAt.UTC(),
+			Data:      json.RawMessage(outboxEvent.Payload),
+		}
+		delivered, err := service.dispatch(event, outboxEvent.DeliveredSinks)
+		if err != nil && outboxEvent.Attempts+1 >= constant.OutboxMaxAttempts {
+			log.Error("Happened error when relaying outbox event ", event.Id, ", giving up after ", constant.OutboxMaxAttempts, " attempts. Error: ", err)
+			err = service.repo.MarkEventAbandoned(outboxEvent.Id, delivered, err.Error(), time.Now())
+		} else if err != nil {
+			log.Error("Happened error when relaying outbox event ", event.Id, ". Error: ", err)
+			err = service.repo.MarkEventFailed(outboxEvent.Id, delivered, err.Error(), time.Now().Add(constant.OutboxRetryDelay))
+		} else {
+			err = service.repo.MarkEventProcessed(outboxEvent.Id, time.Now())
+		}
+		if err != nil {
+			log.Error("Happened error when saving outbox event state. Error: ", err)
+		}
+	}
+	return len(events), nil
+}
+
+// Run relays pending events until ctx is cancelled. A full batch is followed
+// immediately by the next one instead of waiting for the ticker.
+func (service *outboxService) Run(ctx context.Context) {
+	ticker := time.NewTicker(constant.OutboxPollInterval)
+	defer ticker.Stop()
+	for {
+		claimed, err := service.RelayPending(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Error("Happened error when relaying outbox events. Error: ", err)
+		}
+		if err == nil && claimed == constant.OutboxBatchSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch hands the event to every sink that has not accepted it yet, so one
+// failing sink neither blocks nor repeats the others. It returns the sinks
+// that accepted the event so far and the errors of the rest.
+func (service *outboxService) dispatch(event entity.Event, deliveredSinks []string) ([]string, error) {
+	delivered := append([]string{}, deliveredSinks...)
+	var errs []error
+	for _, sink := range service.sinks {
+		if slices.Contains(deliveredSinks, sink.Name()) {
+			continue
+		}
+		if err := sink.Handle(event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+			continue
+		}
+		delivered = append(delivered, sink.Name())
+	}
+	return delivered, errors.Join(errs...)
+}
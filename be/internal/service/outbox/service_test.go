@@ -0,0 +1,235 @@
+package service
+
+import (
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/entity"
+	"BE_Friends_Management/internal/eventbus"
+	mock "BE_Friends_Management/internal/repository/mock"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type recordingSink struct {
+	name   string
+	err    error
+	events []entity.Event
+}
+
+func (sink *recordingSink) Name() string {
+	return sink.name
+}
+
+func (sink *recordingSink) Handle(event entity.Event) error {
+	sink.events = append(sink.events, event)
+	return sink.err
+}
+
+func TestOutboxService_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockOutboxRepository(ctrl)
+	service := NewOutboxService(mockRepo)
+	tx := &gorm.DB{}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().CreateEvent(tx, gomock.Any()).DoAndReturn(func(_ *gorm.DB, event *entity.OutboxEvent) error {
+			assert.NotEmpty(t, event.EventId)
+			assert.Equal(t, entity.EventBlockCreated, event.EventType)
+			assert.JSONEq(t, `{"requestor":"user1@example.com","target":"user2@example.com"}`, event.Payload)
+			assert.WithinDuration(t, time.Now(), event.AvailableAt, time.Second)
+			return nil
+		})
+
+		err := service.Record(tx, entity.EventBlockCreated, entity.RelationshipEventData{Requestor: "user1@example.com", Target: "user2@example.com"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("EventIdsAreUnique", func(t *testing.T) {
+		var eventIds []string
+		mockRepo.EXPECT().CreateEvent(tx, gomock.Any()).DoAndReturn(func(_ *gorm.DB, event *entity.OutboxEvent) error {
+			eventIds = append(eventIds, event.EventId)
+			return nil
+		}).Times(2)
+
+		assert.NoError(t, service.Record(tx, entity.EventBlockCreated, nil))
+		assert.NoError(t, service.Record(tx, entity.EventBlockCreated, nil))
+		assert.NotEqual(t, eventIds[0], eventIds[1])
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		dbErr := errors.New("database error")
+		mockRepo.EXPECT().CreateEvent(tx, gomock.Any()).Return(dbErr)
+
+		err := service.Record(tx, entity.EventBlockCreated, nil)
+		assert.Equal(t, dbErr, err)
+	})
+}
+
+func TestOutboxService_RelayPending(t *testing.T) {
+	newEvent := func(id int64) *entity.OutboxEvent {
+		return &entity.OutboxEvent{
+			Id:        id,
+			EventId:   "evt_" + string(rune('0'+id)),
+			EventType: entity.EventFriendshipCreated,
+			Payload:   `{"friends":["user1@example.com","user2@example.com"]}`,
+			CreatedAt: time.Now(),
+		}
+	}
+
+	t.Run("RelaysToEverySinkAndMarksProcessed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mock.NewMockOutboxRepository(ctrl)
+		first := &recordingSink{name: "first"}
+		second := &recordingSink{name: "second"}
+		service := NewOutboxService(mockRepo, first, second)
+
+		mockRepo.EXPECT().ClaimPendingEvents(gomock.Any(), gomock.Any(), constant.OutboxBatchSize).Return([]*entity.OutboxEvent{newEvent(1), newEvent(2)}, nil)
+		mockRepo.EXPECT().MarkEventProcessed(int64(1), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().MarkEventProcessed(int64(2), gomock.Any()).Return(nil)
+
+		claimed, err := service.RelayPending(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 2, claimed)
+		assert.Len(t, first.events, 2)
+		assert.Len(t, second.events, 2)
+		assert.Equal(t, "evt_1", first.events[0].Id)
+		assert.Equal(t, entity.EventFriendshipCreated, first.events[0].Type)
+
+		var data entity.FriendshipEventData
+		assert.NoError(t, json.Unmarshal(first.events[0].Data.(json.RawMessage), &data))
+		assert.Equal(t, []string{"user1@example.com", "user2@example.com"}, data.Friends)
+	})
+
+	t.Run("FailedSinkLeavesEventPending", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mock.NewMockOutboxRepository(ctrl)
+		failing := &recordingSink{name: "bus", err: errors.New("smtp error")}
+		after := &recordingSink{name: "webhook"}
+		service := NewOutboxService(mockRepo, failing, after)
+
+		before := time.Now()
+		mockRepo.EXPECT().ClaimPendingEvents(gomock.Any(), gomock.Any(), constant.OutboxBatchSize).Return([]*entity.OutboxEvent{newEvent(1)}, nil)
+		mockRepo.EXPECT().MarkEventFailed(int64(1), []string{"webhook"}, "bus: smtp error", gomock.Any()).DoAndReturn(func(_ int64, _ []string, _ string, availableAt time.Time) error {
+			assert.WithinDuration(t, before.Add(constant.OutboxRetryDelay), availableAt, time.Second)
+			return nil
+		})
+
+		_, err := service.RelayPending(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, after.events, 1)
+	})
+
+	t.Run("RetrySkipsDeliveredSinks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mock.NewMockOutboxRepository(ctrl)
+		bus := &recordingSink{name: "bus"}
+		webhook := &recordingSink{name: "webhook"}
+		service := NewOutboxService(mockRepo, bus, webhook)
+
+		event := newEvent(1)
+		event.Attempts = 1
+		event.DeliveredSinks = []string{"webhook"}
+		mockRepo.EXPECT().ClaimPendingEvents(gomock.Any(), gomock.Any(), constant.OutboxBatchSize).Return([]*entity.OutboxEvent{event}, nil)
+		mockRepo.EXPECT().MarkEventProcessed(int64(1), gomock.Any()).Return(nil)
+
+		_, err := service.RelayPending(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, bus.events, 1)
+		assert.Empty(t, webhook.events)
+	})
+
+	t.Run("GivesUpAfterMaxAttempts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mock.NewMockOutboxRepository(ctrl)
+		failing := &recordingSink{name: "webhook", err: errors.New("database error")}
+		service := NewOutboxService(mockRepo, failing)
+
+		event := newEvent(1)
+		event.Attempts = constant.OutboxMaxAttempts - 1
+		mockRepo.EXPECT().ClaimPendingEvents(gomock.Any(), gomock.Any(), constant.OutboxBatchSize).Return([]*entity.OutboxEvent{event}, nil)
+		mockRepo.EXPECT().MarkEventAbandoned(int64(1), []string{}, "webhook: database error", gomock.Any()).Return(nil)
+
+		_, err := service.RelayPending(context.Background())
+		assert.NoError(t, err)
+	})
+
+	t.Run("RedeliveredEventKeepsItsId", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mock.NewMockOutboxRepository(ctrl)
+		sink := &recordingSink{name: "bus"}
+		service := NewOutboxService(mockRepo, sink)
+
+		// The relay crashed after handing the event over but before marking it
+		// processed, so the same row is claimed again once the lease expires.
+		mockRepo.EXPECT().ClaimPendingEvents(gomock.Any(), gomock.Any(), constant.OutboxBatchSize).Return([]*entity.OutboxEvent{newEvent(1)}, nil).Times(2)
+		mockRepo.EXPECT().MarkEventProcessed(int64(1), gomock.Any()).Return(nil).Times(2)
+
+		_, err := service.RelayPending(context.Background())
+		assert.NoError(t, err)
+		_, err = service.RelayPending(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, sink.events, 2)
+		assert.Equal(t, sink.events[0].Id, sink.events[1].Id)
+	})
+
+	t.Run("ClaimError", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mock.NewMockOutboxRepository(ctrl)
+		service := NewOutboxService(mockRepo)
+		dbErr := errors.New("database error")
+		mockRepo.EXPECT().ClaimPendingEvents(gomock.Any(), gomock.Any(), constant.OutboxBatchSize).Return(nil, dbErr)
+
+		claimed, err := service.RelayPending(context.Background())
+		assert.Equal(t, dbErr, err)
+		assert.Equal(t, 0, claimed)
+	})
+
+	t.Run("StopsWhenCancelled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mock.NewMockOutboxRepository(ctrl)
+		sink := &recordingSink{name: "log"}
+		service := NewOutboxService(mockRepo, sink)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		mockRepo.EXPECT().ClaimPendingEvents(gomock.Any(), gomock.Any(), constant.OutboxBatchSize).Return([]*entity.OutboxEvent{newEvent(1)}, nil)
+
+		_, err := service.RelayPending(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, sink.events)
+	})
+}
+
+func TestBusSink_Handle(t *testing.T) {
+	bus := eventbus.NewBus()
+	var received []string
+	bus.Subscribe(entity.EventBlockCreated, func(event entity.Event) error {
+		received = append(received, event.Id)
+		return nil
+	})
+	sink := NewBusSink(bus)
+
+	assert.NoError(t, sink.Handle(entity.Event{Id: "evt_1", Type: entity.EventBlockCreated}))
+	assert.Equal(t, []string{"evt_1"}, received)
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"BE_Friends_Management/internal/eventbus"
+	webhookService "BE_Friends_Management/internal/service/webhook"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type busSink struct {
+	bus *eventbus.Bus
+}
+
+// NewBusSink publishes relayed events to the in-process event bus.
+func NewBusSink(bus *eventbus.Bus) Sink {
+	return &busSink{bus: bus}
+}
+
+func (sink *busSink) Name() string {
+	return "bus"
+}
+
+func (sink *busSink) Handle(event entity.Event) error {
+	return sink.bus.Publish(event)
+}
+
+type webhookSink struct {
+	webhookService webhookService.WebhookService
+}
+
+// NewWebhookSink queues relayed events for the webhook subscriptions
+// listening to them.
+func NewWebhookSink(webhookService webhookService.WebhookService) Sink {
+	return &webhookSink{webhookService: webhookService}
+}
+
+func (sink *webhookSink) Name() string {
+	return "webhook"
+}
+
+func (sink *webhookSink) Handle(event entity.Event) error {
+	return sink.webhookService.Deliver(event)
+}
+
+type logSink struct{}
+
+// NewLogSink writes every relayed event to the application log.
+func NewLogSink() Sink {
+	return &logSink{}
+}
+
+func (sink *logSink) Name() string {
+	return "log"
+}
+
+func (sink *logSink) Handle(event entity.Event) error {
+	log.WithFields(log.Fields{
+		"event_id":   event.Id,
+		"event_type": event.Type,
+	}).Info("Relayed outbox event")
+	return nil
+}
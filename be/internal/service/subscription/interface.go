@@ -1,10 +1,14 @@
 package service
 
-import "errors"
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"errors"
+)
 
 var (
 	ErrUserNotFound      = errors.New("user not found")
 	ErrAlreadySubscribed = errors.New("requestor has already subscribed this target user")
+	ErrNotSubscribed     = errors.New("requestor has not subscribed this target user")
 	ErrInvalidRequest    = errors.New("two email can not be the same")
 	ErrIsBlocked         = errors.New("requestor has blocked target user")
 	ErrNotPermitted      = errors.New("action not permitted")
@@ -14,4 +18,7 @@ var (
 
 type SubscriptionService interface {
 	CreateSubscription(authUserId int64, requestorEmail, targetEmail string) error
+	DeleteSubscription(authUserId int64, authUserRole string, requestorEmail, targetEmail string) error
+	RetrieveFollowing(authUserId int64, authUserRole string, email string) ([]*entity.User, error)
+	RetrieveFollowers(authUserId int64, authUserRole string, email string) ([]*entity.User, error)
 }
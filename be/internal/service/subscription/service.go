@@ -1,10 +1,13 @@
 package service
 
 import (
+	"BE_Friends_Management/internal/domain/entity"
+	"BE_Friends_Management/internal/realtime"
 	blockRelationshipRepository "BE_Friends_Management/internal/repository/block_relationship"
 	friendshipRepository "BE_Friends_Management/internal/repository/friendship"
 	subscriptionRepository "BE_Friends_Management/internal/repository/subscription"
 	userRepository "BE_Friends_Management/internal/repository/users"
+	outboxService "BE_Friends_Management/internal/service/outbox"
 	"errors"
 	"strings"
 
@@ -16,14 +19,18 @@ type subscriptionService struct {
 	userRepo              userRepository.UserRepository
 	friendshipRepo        friendshipRepository.FriendshipRepository
 	blockRelationshipRepo blockRelationshipRepository.BlockRelationshipRepository
+	publisher             realtime.Publisher
+	recorder              outboxService.EventRecorder
 }
 
-func NewSubscriptionService(repo subscriptionRepository.SubscriptionRepository, userRepo userRepository.UserRepository, friendshipRepo friendshipRepository.FriendshipRepository, blockRelationshipRepo blockRelationshipRepository.BlockRelationshipRepository) SubscriptionService {
+func NewSubscriptionService(repo subscriptionRepository.SubscriptionRepository, userRepo userRepository.UserRepository, friendshipRepo friendshipRepository.FriendshipRepository, blockRelationshipRepo blockRelationshipRepository.BlockRelationshipRepository, publisher realtime.Publisher, recorder outboxService.EventRecorder) SubscriptionService {
 	return &subscriptionService{
 		repo:                  repo,
 		userRepo:              userRepo,
 		friendshipRepo:        friendshipRepo,
 		blockRelationshipRepo: blockRelationshipRepo,
+		publisher:             publisher,
+		recorder:              recorder,
 	}
 }
 
@@ -49,7 +56,8 @@ func (service *subscriptionService) CreateSubscription(authUserId int64, request
 		return ErrInvalidRequest
 	}
 	_, err = service.blockRelationshipRepo.GetBlockRelationship(requestor.Id, target.Id)
-	if err == nil {
+	liftBlock := err == nil
+	if liftBlock {
 		userId1 := requestor.Id
 		userId2 := target.Id
 		if userId1 > userId2 {
@@ -62,17 +70,122 @@ func (service *subscriptionService) CreateSubscription(authUserId int64, request
 		if err != nil {
 			return err
 		}
-		err = service.blockRelationshipRepo.DeleteBlockRelationship(requestor.Id, target.Id)
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	db := service.repo.GetDB()
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if liftBlock {
+			err := service.blockRelationshipRepo.DeleteBlockRelationship(tx, requestor.Id, target.Id)
+			if err != nil {
+				return err
+			}
+			err = service.recorder.Record(tx, entity.EventBlockDeleted, entity.RelationshipEventData{Requestor: requestor.Email, Target: target.Email})
+			if err != nil {
+				return err
+			}
+		}
+		err := service.repo.CreateSubscription(tx, requestor.Id, target.Id)
+		if err != nil && strings.Contains(err.Error(), "duplicate key") {
+			return ErrAlreadySubscribed
+		}
 		if err != nil {
 			return err
 		}
+		return service.recorder.Record(tx, entity.EventSubscriptionCreated, entity.RelationshipEventData{Requestor: requestor.Email, Target: target.Email})
+	})
+	if err != nil {
+		return err
 	}
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+	if liftBlock {
+		service.publishUnblockEvent(requestor, target)
+	}
+	return nil
+}
+
+func (service *subscriptionService) DeleteSubscription(authUserId int64, authUserRole string, requestorEmail, targetEmail string) error {
+	requestor, err := service.userRepo.GetUserByEmail(requestorEmail)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if authUserRole == "user" && authUserId != requestor.Id {
+		return ErrNotPermitted
+	}
+	target, err := service.userRepo.GetUserByEmail(targetEmail)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUserNotFound
+	}
+	if err != nil {
 		return err
 	}
-	err = service.repo.CreateSubscription(requestor.Id, target.Id)
-	if err != nil && strings.Contains(err.Error(), "duplicate key") {
-		return ErrAlreadySubscribed
+	if requestor.Id == target.Id {
+		return ErrInvalidRequest
+	}
+	_, err = service.repo.GetSubscription(requestor.Id, target.Id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotSubscribed
+	}
+	if err != nil {
+		return err
+	}
+	db := service.repo.GetDB()
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := service.repo.DeleteSubscription(tx, requestor.Id, target.Id)
+		if err != nil {
+			return err
+		}
+		return service.recorder.Record(tx, entity.EventSubscriptionDeleted, entity.RelationshipEventData{Requestor: requestor.Email, Target: target.Email})
+	})
+}
+
+func (service *subscriptionService) RetrieveFollowing(authUserId int64, authUserRole string, email string) ([]*entity.User, error) {
+	user, err := service.userRepo.GetUserByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if authUserRole == "user" && authUserId != user.Id {
+		return nil, ErrNotPermitted
+	}
+	targetIds, err := service.repo.GetAllSubscriptionTargetIds(user.Id)
+	if err != nil {
+		return nil, err
+	}
+	return service.userRepo.GetUsersFromIds(targetIds)
+}
+
+func (service *subscriptionService) RetrieveFollowers(authUserId int64, authUserRole string, email string) ([]*entity.User, error) {
+	user, err := service.userRepo.GetUserByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if authUserRole == "user" && authUserId != user.Id {
+		return nil, ErrNotPermitted
+	}
+	subscriberIds, err := service.repo.GetAllSubscriberIds(user.Id)
+	if err != nil {
+		return nil, err
+	}
+	return service.userRepo.GetUsersFromIds(subscriberIds)
+}
+
+// publishUnblockEvent tells the requestor's own connections that subscribing
+// lifted their block, like an explicit unblock does.
+func (service *subscriptionService) publishUnblockEvent(requestor, target *entity.User) {
+	blockRelationship := &entity.BlockRelationship{
+		RequestorId: requestor.Id,
+		TargetId:    target.Id,
+		Requestor:   requestor,
+		Target:      target,
 	}
-	return err
+	service.publisher.Publish(requestor.Id, realtime.Event{Type: realtime.EventUnblock, Payload: blockRelationship})
 }
@@ -1,13 +1,18 @@
 package service
 
 import (
+	"BE_Friends_Management/constant"
 	"BE_Friends_Management/internal/domain/entity"
+	"BE_Friends_Management/internal/realtime"
 	mock "BE_Friends_Management/internal/repository/mock"
+	serviceMock "BE_Friends_Management/internal/service/mock"
 	"errors"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
@@ -20,7 +25,16 @@ func TestSubscriptionService_CreateSubscription(t *testing.T) {
 	mockFriendshipRepo := mock.NewMockFriendshipRepository(ctrl)
 	mockBlockRepo := mock.NewMockBlockRelationshipRepository(ctrl)
 
-	service := NewSubscriptionService(mockSubscriptionRepo, mockUserRepo, mockFriendshipRepo, mockBlockRepo)
+	db, mockSQL, err := sqlmock.New()
+	assert.NoError(t, err)
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+	mockSubscriptionRepo.EXPECT().GetDB().Return(gormDB).AnyTimes()
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	hub := realtime.NewHub(constant.StreamBufferSize)
+	service := NewSubscriptionService(mockSubscriptionRepo, mockUserRepo, mockFriendshipRepo, mockBlockRepo, hub, mockRecorder)
 
 	t.Run("Success", func(t *testing.T) {
 		authUserId := int64(1)
@@ -30,7 +44,10 @@ func TestSubscriptionService_CreateSubscription(t *testing.T) {
 		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
 		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
 		mockBlockRepo.EXPECT().GetBlockRelationship(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
-		mockSubscriptionRepo.EXPECT().CreateSubscription(int64(1), int64(2)).Return(nil)
+		mockSQL.ExpectBegin()
+		mockSubscriptionRepo.EXPECT().CreateSubscription(gomock.Any(), int64(1), int64(2)).Return(nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventSubscriptionCreated, entity.RelationshipEventData{Requestor: "user1@example.com", Target: "user2@example.com"}).Return(nil)
+		mockSQL.ExpectCommit()
 
 		err := service.CreateSubscription(authUserId, "user1@example.com", "user2@example.com")
 		assert.NoError(t, err)
@@ -83,15 +100,24 @@ func TestSubscriptionService_CreateSubscription(t *testing.T) {
 		authUserId := int64(1)
 		user1 := &entity.User{Id: 1, Email: "user1@example.com"}
 		user2 := &entity.User{Id: 2, Email: "user2@example.com"}
+		subscription := hub.Subscribe(1)
+		defer hub.Unsubscribe(subscription)
 
 		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
 		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
 		mockBlockRepo.EXPECT().GetBlockRelationship(int64(1), int64(2)).Return(&entity.BlockRelationship{}, nil)
 		mockFriendshipRepo.EXPECT().GetFriendship(int64(1), int64(2)).Return(&entity.Friendship{}, nil)
-		mockBlockRepo.EXPECT().DeleteBlockRelationship(int64(1), int64(2)).Return(nil)
-		mockSubscriptionRepo.EXPECT().CreateSubscription(int64(1), int64(2)).Return(nil)
+		mockSQL.ExpectBegin()
+		mockBlockRepo.EXPECT().DeleteBlockRelationship(gomock.Any(), int64(1), int64(2)).Return(nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventBlockDeleted, entity.RelationshipEventData{Requestor: "user1@example.com", Target: "user2@example.com"}).Return(nil)
+		mockSubscriptionRepo.EXPECT().CreateSubscription(gomock.Any(), int64(1), int64(2)).Return(nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventSubscriptionCreated, entity.RelationshipEventData{Requestor: "user1@example.com", Target: "user2@example.com"}).Return(nil)
+		mockSQL.ExpectCommit()
 		err := service.CreateSubscription(authUserId, "user1@example.com", "user2@example.com")
 		assert.NoError(t, err)
+
+		event := <-subscription.Events
+		assert.Equal(t, realtime.EventUnblock, event.Type)
 	})
 
 	t.Run("AlreadySubscribed", func(t *testing.T) {
@@ -103,7 +129,9 @@ func TestSubscriptionService_CreateSubscription(t *testing.T) {
 		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
 		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
 		mockBlockRepo.EXPECT().GetBlockRelationship(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
-		mockSubscriptionRepo.EXPECT().CreateSubscription(int64(1), int64(2)).Return(duplicateErr)
+		mockSQL.ExpectBegin()
+		mockSubscriptionRepo.EXPECT().CreateSubscription(gomock.Any(), int64(1), int64(2)).Return(duplicateErr)
+		mockSQL.ExpectRollback()
 
 		err := service.CreateSubscription(authUserId, "user1@example.com", "user2@example.com")
 		assert.Equal(t, ErrAlreadySubscribed, err)
@@ -118,3 +146,121 @@ func TestSubscriptionService_CreateSubscription(t *testing.T) {
 		assert.Equal(t, dbErr, err)
 	})
 }
+
+func TestSubscriptionService_DeleteSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockSubscriptionRepo := mock.NewMockSubscriptionRepository(ctrl)
+	mockFriendshipRepo := mock.NewMockFriendshipRepository(ctrl)
+	mockBlockRepo := mock.NewMockBlockRelationshipRepository(ctrl)
+	db, mockSQL, err := sqlmock.New()
+	assert.NoError(t, err)
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+	mockSubscriptionRepo.EXPECT().GetDB().Return(gormDB).AnyTimes()
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	hub := realtime.NewHub(constant.StreamBufferSize)
+	service := NewSubscriptionService(mockSubscriptionRepo, mockUserRepo, mockFriendshipRepo, mockBlockRepo, hub, mockRecorder)
+
+	user1 := &entity.User{Id: 1, Email: "user1@example.com"}
+	user2 := &entity.User{Id: 2, Email: "user2@example.com"}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockSubscriptionRepo.EXPECT().GetSubscription(int64(1), int64(2)).Return(&entity.Subscription{}, nil)
+		mockSQL.ExpectBegin()
+		mockSubscriptionRepo.EXPECT().DeleteSubscription(gomock.Any(), int64(1), int64(2)).Return(nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventSubscriptionDeleted, entity.RelationshipEventData{Requestor: "user1@example.com", Target: "user2@example.com"}).Return(nil)
+		mockSQL.ExpectCommit()
+		err := service.DeleteSubscription(1, "user", "user1@example.com", "user2@example.com")
+		assert.NoError(t, err)
+	})
+
+	t.Run("NotPermitted", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		err := service.DeleteSubscription(2, "user", "user1@example.com", "user2@example.com")
+		assert.Equal(t, ErrNotPermitted, err)
+	})
+
+	t.Run("AdminCanUnsubscribeOnBehalf", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockSubscriptionRepo.EXPECT().GetSubscription(int64(1), int64(2)).Return(&entity.Subscription{}, nil)
+		mockSQL.ExpectBegin()
+		mockSubscriptionRepo.EXPECT().DeleteSubscription(gomock.Any(), int64(1), int64(2)).Return(nil)
+		mockRecorder.EXPECT().Record(gomock.Any(), entity.EventSubscriptionDeleted, entity.RelationshipEventData{Requestor: "user1@example.com", Target: "user2@example.com"}).Return(nil)
+		mockSQL.ExpectCommit()
+		err := service.DeleteSubscription(99, "admin", "user1@example.com", "user2@example.com")
+		assert.NoError(t, err)
+	})
+
+	t.Run("NotSubscribed", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockSubscriptionRepo.EXPECT().GetSubscription(int64(1), int64(2)).Return(nil, gorm.ErrRecordNotFound)
+		err := service.DeleteSubscription(1, "user", "user1@example.com", "user2@example.com")
+		assert.Equal(t, ErrNotSubscribed, err)
+	})
+}
+
+func TestSubscriptionService_RetrieveFollowingAndFollowers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockSubscriptionRepo := mock.NewMockSubscriptionRepository(ctrl)
+	mockFriendshipRepo := mock.NewMockFriendshipRepository(ctrl)
+	mockBlockRepo := mock.NewMockBlockRelationshipRepository(ctrl)
+	mockRecorder := serviceMock.NewMockEventRecorder(ctrl)
+	hub := realtime.NewHub(constant.StreamBufferSize)
+	service := NewSubscriptionService(mockSubscriptionRepo, mockUserRepo, mockFriendshipRepo, mockBlockRepo, hub, mockRecorder)
+
+	user1 := &entity.User{Id: 1, Email: "user1@example.com"}
+	user2 := &entity.User{Id: 2, Email: "user2@example.com"}
+
+	t.Run("FollowingSuccess", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockSubscriptionRepo.EXPECT().GetAllSubscriptionTargetIds(int64(1)).Return([]int64{2}, nil)
+		mockUserRepo.EXPECT().GetUsersFromIds([]int64{2}).Return([]*entity.User{user2}, nil)
+		users, err := service.RetrieveFollowing(1, "user", "user1@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, []*entity.User{user2}, users)
+	})
+
+	t.Run("FollowersSuccess", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		mockSubscriptionRepo.EXPECT().GetAllSubscriberIds(int64(2)).Return([]int64{1}, nil)
+		mockUserRepo.EXPECT().GetUsersFromIds([]int64{1}).Return([]*entity.User{user1}, nil)
+		users, err := service.RetrieveFollowers(2, "user", "user2@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, []*entity.User{user1}, users)
+	})
+
+	t.Run("FollowersNotPermitted", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(user2, nil)
+		users, err := service.RetrieveFollowers(1, "user", "user2@example.com")
+		assert.Nil(t, users)
+		assert.Equal(t, ErrNotPermitted, err)
+	})
+
+	t.Run("FollowingUserNotFound", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(nil, gorm.ErrRecordNotFound)
+		users, err := service.RetrieveFollowing(1, "user", "user1@example.com")
+		assert.Nil(t, users)
+		assert.Equal(t, ErrUserNotFound, err)
+	})
+
+	t.Run("FollowingRepositoryError", func(t *testing.T) {
+		repoErr := errors.New("database error")
+		mockUserRepo.EXPECT().GetUserByEmail("user1@example.com").Return(user1, nil)
+		mockSubscriptionRepo.EXPECT().GetAllSubscriptionTargetIds(int64(1)).Return(nil, repoErr)
+		users, err := service.RetrieveFollowing(1, "admin", "user1@example.com")
+		assert.Nil(t, users)
+		assert.Equal(t, repoErr, err)
+	})
+}
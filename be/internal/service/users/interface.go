@@ -8,12 +8,16 @@ import (
 //go:generate mockgen -source=interface.go -destination=../mock/mock_user_service.go
 
 var (
-	ErrUserNotFound = errors.New("user not found")
+	ErrUserNotFound  = errors.New("user not found")
+	ErrInvalidHandle = errors.New("handle must be 3 to 30 letters, digits or underscores")
+	ErrHandleTaken   = errors.New("handle is already taken")
 )
 
 type UserService interface {
-	GetAllUser() ([]*entity.User, error)
+	GetAllUser(afterId int64, limit int) ([]*entity.User, int64, error)
 	GetUserById(userId int64) (*entity.User, error)
 	DeleteUserById(userId int64) error
 	UpdateUser(userId int64, email string, password string) (*entity.User, error)
+	SetEmailOptOut(userId int64, optOut bool) error
+	SetHandle(userId int64, handle string) (*entity.User, error)
 }
@@ -2,23 +2,36 @@ package service
 
 import (
 	"BE_Friends_Management/internal/domain/entity"
+	authRepository "BE_Friends_Management/internal/repository/auth"
 	userRepository "BE_Friends_Management/internal/repository/users"
+	"BE_Friends_Management/internal/revocation"
+	"BE_Friends_Management/pkg/utils"
 	"errors"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
+	"strings"
 )
 
 type userService struct {
-	repo userRepository.UserRepository
+	repo      userRepository.UserRepository
+	tokenRepo authRepository.AuthRepository
+	denylist  *revocation.Denylist
 }
 
-func NewUserService(repo userRepository.UserRepository) UserService {
-	return &userService{repo: repo}
+func NewUserService(repo userRepository.UserRepository, tokenRepo authRepository.AuthRepository, denylist *revocation.Denylist) UserService {
+	return &userService{repo: repo, tokenRepo: tokenRepo, denylist: denylist}
 }
 
-func (service *userService) GetAllUser() ([]*entity.User, error) {
-	users, err := service.repo.GetAllUser()
-	return users, err
+func (service *userService) GetAllUser(afterId int64, limit int) ([]*entity.User, int64, error) {
+	total, err := service.repo.CountUsers()
+	if err != nil {
+		return nil, 0, err
+	}
+	users, err := service.repo.GetAllUser(afterId, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
 }
 
 func (service *userService) GetUserById(userId int64) (*entity.User, error) {
@@ -32,14 +45,21 @@ func (service *userService) GetUserById(userId int64) (*entity.User, error) {
 	return user, nil
 }
 
+// DeleteUserById deletes the user and revokes their access tokens, so that
+// they are cut off at once rather than when the tokens expire.
 func (service *userService) DeleteUserById(userId int64) error {
 	err := service.repo.DeleteUserById(userId)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return ErrUserNotFound
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	return service.denylist.RevokeUser(userId)
 }
 
+// UpdateUser sets the user's email and password and revokes their refresh
+// and access tokens, so sessions opened with the old password end at once.
 func (service *userService) UpdateUser(userId int64, email string, password string) (*entity.User, error) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
@@ -53,5 +73,42 @@ func (service *userService) UpdateUser(userId int64, email string, password stri
 	if err != nil {
 		return nil, err
 	}
+	err = service.tokenRepo.RevokeUserTokens(userId)
+	if err != nil {
+		return nil, err
+	}
+	err = service.denylist.RevokeUser(userId)
+	if err != nil {
+		return nil, err
+	}
 	return updatedUser, nil
 }
+
+// SetEmailOptOut turns notification emails off for the user, or back on.
+func (service *userService) SetEmailOptOut(userId int64, optOut bool) error {
+	return service.repo.SetEmailOptOut(userId, optOut)
+}
+
+// SetHandle sets the handle others use to @mention the user. The handle is
+// stored lower-cased; an empty handle clears it.
+func (service *userService) SetHandle(userId int64, handle string) (*entity.User, error) {
+	var newHandle *string
+	if handle != "" {
+		normalized, ok := utils.NormalizeHandle(handle)
+		if !ok {
+			return nil, ErrInvalidHandle
+		}
+		newHandle = &normalized
+	}
+	err := service.repo.SetHandle(userId, newHandle)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil && strings.Contains(err.Error(), "duplicate key") {
+		return nil, ErrHandleTaken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return service.GetUserById(userId)
+}
@@ -3,11 +3,14 @@ package service
 import (
 	entity "BE_Friends_Management/internal/domain/entity"
 	mock "BE_Friends_Management/internal/repository/mock"
+	"BE_Friends_Management/internal/revocation"
 	"errors"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
 func TestUserService_GetAllUser(t *testing.T) {
@@ -15,7 +18,9 @@ func TestUserService_GetAllUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mock.NewMockUserRepository(ctrl)
-	service := NewUserService(mockRepo)
+	mockTokenRepo := mock.NewMockAuthRepository(ctrl)
+	mockRevocationRepo := mock.NewMockRevocationRepository(ctrl)
+	service := NewUserService(mockRepo, mockTokenRepo, revocation.NewDenylist(mockRevocationRepo))
 
 	t.Run("success", func(t *testing.T) {
 		expectedUsers := []*entity.User{
@@ -23,35 +28,53 @@ func TestUserService_GetAllUser(t *testing.T) {
 			{Id: 2, Email: "user2@example.com"},
 		}
 
-		mockRepo.EXPECT().GetAllUser().Return(expectedUsers, nil)
+		mockRepo.EXPECT().CountUsers().Return(int64(len(expectedUsers)), nil)
+		mockRepo.EXPECT().GetAllUser(int64(0), 20).Return(expectedUsers, nil)
 
-		users, err := service.GetAllUser()
+		users, total, err := service.GetAllUser(0, 20)
 
 		assert.NoError(t, err)
 		assert.Equal(t, expectedUsers, users)
+		assert.Equal(t, int64(len(expectedUsers)), total)
 	})
 
 	t.Run("empty result", func(t *testing.T) {
 		expectedUsers := []*entity.User{}
 
-		mockRepo.EXPECT().GetAllUser().Return(expectedUsers, nil)
+		mockRepo.EXPECT().CountUsers().Return(int64(len(expectedUsers)), nil)
+		mockRepo.EXPECT().GetAllUser(int64(0), 20).Return(expectedUsers, nil)
 
-		users, err := service.GetAllUser()
+		users, total, err := service.GetAllUser(0, 20)
 
 		assert.NoError(t, err)
 		assert.Equal(t, expectedUsers, users)
+		assert.Equal(t, int64(len(expectedUsers)), total)
 	})
 
 	t.Run("repository error", func(t *testing.T) {
 		expectedError := errors.New("database connection failed")
 
-		mockRepo.EXPECT().GetAllUser().Return(nil, expectedError)
+		mockRepo.EXPECT().CountUsers().Return(int64(2), nil)
+		mockRepo.EXPECT().GetAllUser(int64(0), 20).Return(nil, expectedError)
 
-		users, err := service.GetAllUser()
+		users, total, err := service.GetAllUser(0, 20)
 
 		assert.Error(t, err)
 		assert.Equal(t, expectedError, err)
 		assert.Nil(t, users)
+		assert.Equal(t, int64(0), total)
+	})
+
+	t.Run("count error", func(t *testing.T) {
+		expectedError := errors.New("database connection failed")
+
+		mockRepo.EXPECT().CountUsers().Return(int64(0), expectedError)
+
+		users, total, err := service.GetAllUser(0, 20)
+
+		assert.Equal(t, expectedError, err)
+		assert.Nil(t, users)
+		assert.Equal(t, int64(0), total)
 	})
 }
 func TestUserService_GetUserById(t *testing.T) {
@@ -59,7 +82,9 @@ func TestUserService_GetUserById(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mock.NewMockUserRepository(ctrl)
-	service := NewUserService(mockRepo)
+	mockTokenRepo := mock.NewMockAuthRepository(ctrl)
+	mockRevocationRepo := mock.NewMockRevocationRepository(ctrl)
+	service := NewUserService(mockRepo, mockTokenRepo, revocation.NewDenylist(mockRevocationRepo))
 
 	t.Run("success", func(t *testing.T) {
 		userId := int64(1)
@@ -92,18 +117,37 @@ func TestUserService_DeleteUserById(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mock.NewMockUserRepository(ctrl)
-	service := NewUserService(mockRepo)
+	mockTokenRepo := mock.NewMockAuthRepository(ctrl)
+	mockRevocationRepo := mock.NewMockRevocationRepository(ctrl)
+	service := NewUserService(mockRepo, mockTokenRepo, revocation.NewDenylist(mockRevocationRepo))
 
 	t.Run("success", func(t *testing.T) {
 		userId := int64(1)
 
 		mockRepo.EXPECT().DeleteUserById(userId).Return(nil)
+		mockRevocationRepo.EXPECT().CreateRevocation(gomock.Any()).DoAndReturn(func(revocation *entity.AccessTokenRevocation) error {
+			assert.Equal(t, userId, *revocation.UserId)
+			assert.Nil(t, revocation.Jti)
+			return nil
+		})
 
 		err := service.DeleteUserById(userId)
 
 		assert.NoError(t, err)
 	})
 
+	t.Run("revocation error", func(t *testing.T) {
+		userId := int64(1)
+		expectedError := errors.New("database connection failed")
+
+		mockRepo.EXPECT().DeleteUserById(userId).Return(nil)
+		mockRevocationRepo.EXPECT().CreateRevocation(gomock.Any()).Return(expectedError)
+
+		err := service.DeleteUserById(userId)
+
+		assert.Equal(t, expectedError, err)
+	})
+
 	t.Run("repository error", func(t *testing.T) {
 		userId := int64(1)
 		expectedError := errors.New("user not found")
@@ -133,7 +177,9 @@ func TestUserService_UpdateUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mock.NewMockUserRepository(ctrl)
-	service := NewUserService(mockRepo)
+	mockTokenRepo := mock.NewMockAuthRepository(ctrl)
+	mockRevocationRepo := mock.NewMockRevocationRepository(ctrl)
+	service := NewUserService(mockRepo, mockTokenRepo, revocation.NewDenylist(mockRevocationRepo))
 
 	t.Run("success", func(t *testing.T) {
 		userId := int64(1)
@@ -144,6 +190,11 @@ func TestUserService_UpdateUser(t *testing.T) {
 		expectedUser := &entity.User{Id: userId, Email: email, Role: role}
 
 		mockRepo.EXPECT().UpdateUser(gomock.Any()).Return(expectedUser, nil)
+		mockTokenRepo.EXPECT().RevokeUserTokens(userId).Return(nil)
+		mockRevocationRepo.EXPECT().CreateRevocation(gomock.Any()).DoAndReturn(func(revocation *entity.AccessTokenRevocation) error {
+			assert.Equal(t, userId, *revocation.UserId)
+			return nil
+		})
 
 		user, err := service.UpdateUser(userId, email, password)
 
@@ -153,6 +204,31 @@ func TestUserService_UpdateUser(t *testing.T) {
 		assert.Equal(t, expectedUser.Role, user.Role)
 	})
 
+	t.Run("refresh token revocation error", func(t *testing.T) {
+		userId := int64(1)
+		expectedError := errors.New("database error")
+		mockRepo.EXPECT().UpdateUser(gomock.Any()).Return(&entity.User{Id: userId}, nil)
+		mockTokenRepo.EXPECT().RevokeUserTokens(userId).Return(expectedError)
+
+		user, err := service.UpdateUser(userId, "updated@example.com", "123")
+
+		assert.Equal(t, expectedError, err)
+		assert.Nil(t, user)
+	})
+
+	t.Run("access token revocation error", func(t *testing.T) {
+		userId := int64(1)
+		expectedError := errors.New("database error")
+		mockRepo.EXPECT().UpdateUser(gomock.Any()).Return(&entity.User{Id: userId}, nil)
+		mockTokenRepo.EXPECT().RevokeUserTokens(userId).Return(nil)
+		mockRevocationRepo.EXPECT().CreateRevocation(gomock.Any()).Return(expectedError)
+
+		user, err := service.UpdateUser(userId, "updated@example.com", "123")
+
+		assert.Equal(t, expectedError, err)
+		assert.Nil(t, user)
+	})
+
 	t.Run("repository error", func(t *testing.T) {
 		userId := int64(1)
 		email := "updated@example.com"
@@ -197,3 +273,92 @@ func TestUserService_UpdateUser(t *testing.T) {
 		assert.Nil(t, user)
 	})
 }
+
+func TestUserService_SetEmailOptOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockUserRepository(ctrl)
+	mockTokenRepo := mock.NewMockAuthRepository(ctrl)
+	mockRevocationRepo := mock.NewMockRevocationRepository(ctrl)
+	service := NewUserService(mockRepo, mockTokenRepo, revocation.NewDenylist(mockRevocationRepo))
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo.EXPECT().SetEmailOptOut(int64(1), true).Return(nil)
+
+		err := service.SetEmailOptOut(1, true)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		expectedError := errors.New("database connection failed")
+		mockRepo.EXPECT().SetEmailOptOut(int64(1), false).Return(expectedError)
+
+		err := service.SetEmailOptOut(1, false)
+
+		assert.Equal(t, expectedError, err)
+	})
+}
+
+func TestUserService_SetHandle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockUserRepository(ctrl)
+	mockTokenRepo := mock.NewMockAuthRepository(ctrl)
+	mockRevocationRepo := mock.NewMockRevocationRepository(ctrl)
+	service := NewUserService(mockRepo, mockTokenRepo, revocation.NewDenylist(mockRevocationRepo))
+
+	t.Run("success", func(t *testing.T) {
+		handle := "alice_01"
+		expected := &entity.User{Id: 1, Email: "alice@example.com", Handle: &handle}
+		mockRepo.EXPECT().SetHandle(int64(1), gomock.Any()).DoAndReturn(func(userId int64, newHandle *string) error {
+			assert.Equal(t, "alice_01", *newHandle)
+			return nil
+		})
+		mockRepo.EXPECT().GetUserById(int64(1)).Return(expected, nil)
+
+		user, err := service.SetHandle(1, "@Alice_01")
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, user)
+	})
+
+	t.Run("clear handle", func(t *testing.T) {
+		mockRepo.EXPECT().SetHandle(int64(1), (*string)(nil)).Return(nil)
+		mockRepo.EXPECT().GetUserById(int64(1)).Return(&entity.User{Id: 1}, nil)
+
+		user, err := service.SetHandle(1, "")
+
+		assert.NoError(t, err)
+		assert.Nil(t, user.Handle)
+	})
+
+	t.Run("invalid handle", func(t *testing.T) {
+		for _, handle := range []string{"ab", "has space", "dash-ed", "thirty_one_characters_long_name"} {
+			user, err := service.SetHandle(1, handle)
+
+			assert.Nil(t, user)
+			assert.Equal(t, ErrInvalidHandle, err)
+		}
+	})
+
+	t.Run("handle taken", func(t *testing.T) {
+		mockRepo.EXPECT().SetHandle(int64(2), gomock.Any()).Return(&pq.Error{Code: "23505", Message: "duplicate key value violates unique constraint"})
+
+		user, err := service.SetHandle(2, "alice")
+
+		assert.Nil(t, user)
+		assert.Equal(t, ErrHandleTaken, err)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockRepo.EXPECT().SetHandle(int64(9), gomock.Any()).Return(gorm.ErrRecordNotFound)
+
+		user, err := service.SetHandle(9, "alice")
+
+		assert.Nil(t, user)
+		assert.Equal(t, ErrUserNotFound, err)
+	})
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"context"
+	"errors"
+)
+
+var (
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrInvalidUrl       = errors.New("webhook url must be an absolute http or https url")
+	ErrInvalidEventType = errors.New("unknown event type")
+)
+
+//go:generate mockgen -source=interface.go -destination=../mock/mock_webhook_service.go
+
+type WebhookService interface {
+	Deliver(event entity.Event) error
+	CreateSubscription(url, secret string, eventTypes []string) (*entity.WebhookSubscription, error)
+	GetSubscriptions() ([]*entity.WebhookSubscription, error)
+	DeleteSubscription(subscriptionId int64) error
+	GetDeliveries(subscriptionId int64, limit, offset int) ([]*entity.WebhookDelivery, int64, error)
+	DispatchDueDeliveries(ctx context.Context) (int, error)
+	Run(ctx context.Context)
+}
@@ -0,0 +1,333 @@
+package service
+
+import (
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/entity"
+	notificationPreferenceRepository "BE_Friends_Management/internal/repository/notification_preference"
+	updateRepository "BE_Friends_Management/internal/repository/update"
+	userRepository "BE_Friends_Management/internal/repository/users"
+	webhookRepository "BE_Friends_Management/internal/repository/webhook"
+	"BE_Friends_Management/pkg/utils"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type webhookService struct {
+	repo           webhookRepository.WebhookRepository
+	userRepo       userRepository.UserRepository
+	updateRepo     updateRepository.UpdateRepository
+	preferenceRepo notificationPreferenceRepository.NotificationPreferenceRepository
+	client         *http.Client
+}
+
+func NewWebhookService(repo webhookRepository.WebhookRepository, userRepo userRepository.UserRepository, updateRepo updateRepository.UpdateRepository, preferenceRepo notificationPreferenceRepository.NotificationPreferenceRepository, client *http.Client) WebhookService {
+	return &webhookService{
+		repo:           repo,
+		userRepo:       userRepo,
+		updateRepo:     updateRepo,
+		preferenceRepo: preferenceRepo,
+		client:         client,
+	}
+}
+
+// Deliver queues the event for every subscription listening to its type.
+// Delivering the same event again is a no-op, so the outbox relay can retry
+// it safely. Subscriptions get every event of their types whatever the
+// notification preferences of the users involved; updates and friend
+// requests are also queued as one notification.sent event per user who
+// wants them over the webhook channel.
+func (service *webhookService) Deliver(event entity.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	err = service.repo.EnqueueDeliveries(service.repo.GetDB(), event.Id, event.Type, string(payload))
+	if err != nil {
+		return err
+	}
+	if event.Type != entity.EventUpdatePublished && event.Type != entity.EventFriendRequestSent {
+		return nil
+	}
+	listened, err := service.isListened(entity.EventNotificationSent)
+	if err != nil || !listened {
+		return err
+	}
+	var notifications []entity.Event
+	if event.Type == entity.EventUpdatePublished {
+		notifications, err = service.updateNotifications(event)
+	} else {
+		notifications, err = service.friendRequestNotifications(event)
+	}
+	if err != nil {
+		return err
+	}
+	for _, notification := range notifications {
+		payload, err := json.Marshal(notification)
+		if err != nil {
+			return err
+		}
+		err = service.repo.EnqueueDeliveries(service.repo.GetDB(), notification.Id, notification.Type, string(payload))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (service *webhookService) CreateSubscription(rawUrl, secret string, eventTypes []string) (*entity.WebhookSubscription, error) {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil || (parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") || parsedUrl.Host == "" {
+		return nil, ErrInvalidUrl
+	}
+	if len(eventTypes) == 0 {
+		return nil, ErrInvalidEventType
+	}
+	for _, eventType := range eventTypes {
+		if !slices.Contains(entity.EventTypes, eventType) {
+			return nil, ErrInvalidEventType
+		}
+	}
+	subscription := &entity.WebhookSubscription{
+		Url:        rawUrl,
+		Secret:     secret,
+		EventTypes: eventTypes,
+	}
+	err = service.repo.CreateSubscription(subscription)
+	if err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (service *webhookService) GetSubscriptions() ([]*entity.WebhookSubscription, error) {
+	return service.repo.GetSubscriptions()
+}
+
+func (service *webhookService) DeleteSubscription(subscriptionId int64) error {
+	err := service.repo.DeleteSubscription(subscriptionId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrWebhookNotFound
+	}
+	return err
+}
+
+func (service *webhookService) GetDeliveries(subscriptionId int64, limit, offset int) ([]*entity.WebhookDelivery, int64, error) {
+	_, err := service.repo.GetSubscriptionById(subscriptionId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, 0, ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	deliveries, err := service.repo.GetDeliveries(subscriptionId, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	count, err := service.repo.CountDeliveries(subscriptionId)
+	if err != nil {
+		return nil, 0, err
+	}
+	return deliveries, count, nil
+}
+
+// DispatchDueDeliveries sends one batch of due deliveries and records the
+// outcome of each attempt. It returns the number of attempts made.
+func (service *webhookService) DispatchDueDeliveries(ctx context.Context) (int, error) {
+	now := time.Now()
+	deliveries, err := service.repo.ClaimDueDeliveries(now, now.Add(constant.WebhookClaimLease), constant.WebhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	for _, delivery := range deliveries {
+		service.attempt(ctx, delivery)
+		err := service.repo.UpdateDeliveryResult(delivery)
+		if err != nil {
+			log.Error("Happened error when saving webhook delivery result. Error: ", err)
+		}
+	}
+	return len(deliveries), nil
+}
+
+// Run dispatches due deliveries until ctx is cancelled. A full batch is
+// followed immediately by the next one instead of waiting for the ticker.
+func (service *webhookService) Run(ctx context.Context) {
+	ticker := time.NewTicker(constant.WebhookPollInterval)
+	defer ticker.Stop()
+	for {
+		attempts, err := service.DispatchDueDeliveries(ctx)
+		if err != nil {
+			log.Error("Happened error when dispatching webhooks. Error: ", err)
+		}
+		if err == nil && attempts == constant.WebhookBatchSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (service *webhookService) attempt(ctx context.Context, delivery *entity.WebhookDelivery) {
+	delivery.Attempts++
+	statusCode, err := service.send(ctx, delivery)
+	delivery.ResponseStatus = statusCode
+	if err == nil {
+		deliveredAt := time.Now()
+		delivery.Status = entity.WebhookDeliverySucceeded
+		delivery.DeliveredAt = &deliveredAt
+		delivery.LastError = ""
+		return
+	}
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= constant.WebhookMaxAttempts {
+		delivery.Status = entity.WebhookDeliveryFailed
+		return
+	}
+	delivery.NextAttemptAt = time.Now().Add(retryBackoff(delivery.Attempts))
+}
+
+func (service *webhookService) send(ctx context.Context, delivery *entity.WebhookDelivery) (int, error) {
+	if delivery.Subscription == nil {
+		return 0, ErrWebhookNotFound
+	}
+	body := []byte(delivery.Payload)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Subscription.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Webhook-Id", delivery.EventId)
+	request.Header.Set("X-Webhook-Event", delivery.EventType)
+	request.Header.Set("X-Webhook-Signature", "sha256="+utils.SignWebhookPayload(delivery.Subscription.Secret, body))
+	response, err := service.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("unexpected response status %d", response.StatusCode)
+	}
+	return response.StatusCode, nil
+}
+
+// isListened reports whether a subscription listens to the event type, so
+// notifications are only worked out when someone receives them.
+func (service *webhookService) isListened(eventType string) (bool, error) {
+	subscriptions, err := service.repo.GetSubscriptions()
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(subscriptions, func(subscription *entity.WebhookSubscription) bool {
+		return slices.Contains(subscription.EventTypes, eventType)
+	}), nil
+}
+
+// updateNotifications returns a notification for every recipient of the
+// update who has the webhook channel on and wanted at least one of the
+// reasons they got the update for when it was published.
+func (service *webhookService) updateNotifications(event entity.Event) ([]entity.Event, error) {
+	var data entity.UpdateEventData
+	err := decodeEventData(event, &data)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := service.updateRepo.GetUpdateDeliveries(data.Id)
+	if err != nil || len(deliveries) == 0 {
+		return nil, err
+	}
+	recipientIds := make([]int64, len(deliveries))
+	for i, delivery := range deliveries {
+		recipientIds[i] = delivery.RecipientId
+	}
+	preferences, err := service.preferenceRepo.GetPreferences(recipientIds)
+	if err != nil {
+		return nil, err
+	}
+	var notifications []entity.Event
+	for _, delivery := range deliveries {
+		preference := preferences[delivery.RecipientId]
+		if delivery.Recipient == nil || !utils.UsesChannel(preference, entity.ChannelWebhook) || !utils.WantsAnyNotification(preference, delivery.Reasons, data.CreatedAt) {
+			continue
+		}
+		notifications = append(notifications, newNotification(event, delivery.RecipientId, entity.NotificationEventData{
+			Recipient: delivery.Recipient.Email,
+			Reasons:   delivery.Reasons,
+			UpdateId:  data.Id,
+		}))
+	}
+	return notifications, nil
+}
+
+// friendRequestNotifications returns a notification for the target of the
+// friend request if they have the webhook channel on and wanted friend
+// requests when it was sent.
+func (service *webhookService) friendRequestNotifications(event entity.Event) ([]entity.Event, error) {
+	var data entity.FriendRequestEventData
+	err := decodeEventData(event, &data)
+	if err != nil {
+		return nil, err
+	}
+	target, err := service.userRepo.GetUserByEmail(data.Target)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// The target was deleted before the event was relayed.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	preference, err := service.preferenceRepo.GetPreference(target.Id)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if !utils.UsesChannel(preference, entity.ChannelWebhook) || !utils.WantsNotification(preference, entity.NotificationFriendRequest, event.CreatedAt) {
+		return nil, nil
+	}
+	return []entity.Event{newNotification(event, target.Id, entity.NotificationEventData{
+		Recipient:       target.Email,
+		Reasons:         []string{entity.NotificationFriendRequest},
+		FriendRequestId: data.Id,
+	})}, nil
+}
+
+// newNotification derives the id of a notification from the event and the
+// recipient, so relaying the event again queues the same notifications.
+func newNotification(event entity.Event, recipientId int64, data entity.NotificationEventData) entity.Event {
+	return entity.Event{
+		Id:        fmt.Sprintf("%s:%d", event.Id, recipientId),
+		Type:      entity.EventNotificationSent,
+		CreatedAt: event.CreatedAt,
+		Data:      data,
+	}
+}
+
+func decodeEventData(event entity.Event, target any) error {
+	payload, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(payload, target)
+}
+
+// retryBackoff doubles the wait after every failed attempt, capped at
+// constant.WebhookMaxBackoff.
+func retryBackoff(attempts int) time.Duration {
+	backoff := constant.WebhookInitialBackoff
+	for i := 1; i < attempts && backoff < constant.WebhookMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, constant.WebhookMaxBackoff)
+}
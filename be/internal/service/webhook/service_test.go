@@ -0,0 +1,372 @@
+package service
+
+import (
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/internal/domain/entity"
+	mock "BE_Friends_Management/internal/repository/mock"
+	"BE_Friends_Management/pkg/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestWebhookService_Deliver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockWebhookRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUpdateRepo := mock.NewMockUpdateRepository(ctrl)
+	mockPreferenceRepo := mock.NewMockNotificationPreferenceRepository(ctrl)
+	service := NewWebhookService(mockRepo, mockUserRepo, mockUpdateRepo, mockPreferenceRepo, http.DefaultClient)
+	db := &gorm.DB{}
+	mockRepo.EXPECT().GetDB().Return(db).AnyTimes()
+	event := entity.Event{
+		Id:        "evt_1",
+		Type:      entity.EventBlockCreated,
+		CreatedAt: time.Now(),
+		Data:      json.RawMessage(`{"requestor":"user1@example.com","target":"user2@example.com"}`),
+	}
+	notificationSubscriptions := []*entity.WebhookSubscription{{Id: 1, EventTypes: []string{entity.EventNotificationSent}}}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().EnqueueDeliveries(db, "evt_1", entity.EventBlockCreated, gomock.Any()).
+			DoAndReturn(func(_ *gorm.DB, eventId, eventType, payload string) error {
+				var delivered entity.Event
+				assert.NoError(t, json.Unmarshal([]byte(payload), &delivered))
+				assert.Equal(t, "evt_1", delivered.Id)
+				assert.Equal(t, "user2@example.com", delivered.Data.(map[string]interface{})["target"])
+				return nil
+			})
+
+		assert.NoError(t, service.Deliver(event))
+	})
+
+	t.Run("EnqueueError", func(t *testing.T) {
+		dbErr := errors.New("database error")
+		mockRepo.EXPECT().EnqueueDeliveries(db, "evt_1", entity.EventBlockCreated, gomock.Any()).Return(dbErr)
+
+		assert.Equal(t, dbErr, service.Deliver(event))
+	})
+
+	friendRequestEvent := entity.Event{
+		Id:        "evt_2",
+		Type:      entity.EventFriendRequestSent,
+		CreatedAt: time.Now(),
+		Data:      json.RawMessage(`{"id":10,"requestor":"user1@example.com","target":"user2@example.com"}`),
+	}
+	target := &entity.User{Id: 2, Email: "user2@example.com"}
+
+	t.Run("FriendRequestWithoutNotificationSubscriptions", func(t *testing.T) {
+		mockRepo.EXPECT().EnqueueDeliveries(db, "evt_2", entity.EventFriendRequestSent, gomock.Any()).Return(nil)
+		mockRepo.EXPECT().GetSubscriptions().Return([]*entity.WebhookSubscription{{Id: 1, EventTypes: []string{entity.EventFriendRequestSent}}}, nil)
+
+		assert.NoError(t, service.Deliver(friendRequestEvent))
+	})
+
+	t.Run("FriendRequestNotification", func(t *testing.T) {
+		mockRepo.EXPECT().EnqueueDeliveries(db, "evt_2", entity.EventFriendRequestSent, gomock.Any()).Return(nil)
+		mockRepo.EXPECT().GetSubscriptions().Return(notificationSubscriptions, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(target, nil)
+		mockPreferenceRepo.EXPECT().GetPreference(int64(2)).Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.EXPECT().EnqueueDeliveries(db, "evt_2:2", entity.EventNotificationSent, gomock.Any()).
+			DoAndReturn(func(_ *gorm.DB, eventId, eventType, payload string) error {
+				var delivered entity.Event
+				assert.NoError(t, json.Unmarshal([]byte(payload), &delivered))
+				data := delivered.Data.(map[string]interface{})
+				assert.Equal(t, "user2@example.com", data["recipient"])
+				assert.Equal(t, float64(10), data["friend_request_id"])
+				return nil
+			})
+
+		assert.NoError(t, service.Deliver(friendRequestEvent))
+	})
+
+	t.Run("FriendRequestWebhookChannelOff", func(t *testing.T) {
+		mockRepo.EXPECT().EnqueueDeliveries(db, "evt_2", entity.EventFriendRequestSent, gomock.Any()).Return(nil)
+		mockRepo.EXPECT().GetSubscriptions().Return(notificationSubscriptions, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(target, nil)
+		mockPreferenceRepo.EXPECT().GetPreference(int64(2)).Return(&entity.NotificationPreference{
+			UserId:     2,
+			Channels:   []string{entity.ChannelInApp, entity.ChannelEmail},
+			EventTypes: entity.NotificationTypes,
+		}, nil)
+
+		assert.NoError(t, service.Deliver(friendRequestEvent))
+	})
+
+	t.Run("FriendRequestPreferenceError", func(t *testing.T) {
+		dbErr := errors.New("database error")
+		mockRepo.EXPECT().EnqueueDeliveries(db, "evt_2", entity.EventFriendRequestSent, gomock.Any()).Return(nil)
+		mockRepo.EXPECT().GetSubscriptions().Return(notificationSubscriptions, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user2@example.com").Return(target, nil)
+		mockPreferenceRepo.EXPECT().GetPreference(int64(2)).Return(nil, dbErr)
+
+		assert.Equal(t, dbErr, service.Deliver(friendRequestEvent))
+	})
+
+	t.Run("UpdateNotifications", func(t *testing.T) {
+		publishedAt := time.Date(2025, 8, 1, 23, 0, 0, 0, time.UTC)
+		updateEvent := entity.Event{
+			Id:        "evt_3",
+			Type:      entity.EventUpdatePublished,
+			CreatedAt: publishedAt,
+			Data:      json.RawMessage(`{"id":7,"sender":"user1@example.com","text":"hi","created_at":"2025-08-01T23:00:00Z","recipient_count":3}`),
+		}
+		mockRepo.EXPECT().EnqueueDeliveries(db, "evt_3", entity.EventUpdatePublished, gomock.Any()).Return(nil)
+		mockRepo.EXPECT().GetSubscriptions().Return(notificationSubscriptions, nil)
+		mockUpdateRepo.EXPECT().GetUpdateDeliveries(int64(7)).Return([]*entity.UpdateDelivery{
+			{RecipientId: 2, Reasons: []string{entity.NotificationFriendUpdate}, Recipient: &entity.User{Id: 2, Email: "user2@example.com"}},
+			{RecipientId: 3, Reasons: []string{entity.NotificationSubscriptionUpdate}, Recipient: &entity.User{Id: 3, Email: "user3@example.com"}},
+			{RecipientId: 4, Reasons: []string{entity.NotificationMention}, Recipient: &entity.User{Id: 4, Email: "user4@example.com"}},
+		}, nil)
+		// user3 mutes subscriptions at night and user4 turned the webhook channel off.
+		mockPreferenceRepo.EXPECT().GetPreferences([]int64{2, 3, 4}).Return(map[int64]*entity.NotificationPreference{
+			3: {
+				Channels:             entity.NotificationChannels,
+				EventTypes:           entity.NotificationTypes,
+				QuietHoursStart:      "22:00",
+				QuietHoursEnd:        "07:00",
+				QuietHoursTimezone:   "UTC",
+				QuietHoursEventTypes: []string{entity.NotificationSubscriptionUpdate},
+			},
+			4: {Channels: []string{entity.ChannelInApp}, EventTypes: entity.NotificationTypes},
+		}, nil)
+		mockRepo.EXPECT().EnqueueDeliveries(db, "evt_3:2", entity.EventNotificationSent, gomock.Any()).
+			DoAndReturn(func(_ *gorm.DB, eventId, eventType, payload string) error {
+				var delivered entity.Event
+				assert.NoError(t, json.Unmarshal([]byte(payload), &delivered))
+				data := delivered.Data.(map[string]interface{})
+				assert.Equal(t, "user2@example.com", data["recipient"])
+				assert.Equal(t, []interface{}{entity.NotificationFriendUpdate}, data["reasons"])
+				assert.Equal(t, float64(7), data["update_id"])
+				return nil
+			})
+
+		assert.NoError(t, service.Deliver(updateEvent))
+	})
+}
+
+func TestWebhookService_CreateSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockWebhookRepository(ctrl)
+	service := NewWebhookService(mockRepo, mock.NewMockUserRepository(ctrl), mock.NewMockUpdateRepository(ctrl), mock.NewMockNotificationPreferenceRepository(ctrl), http.DefaultClient)
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().CreateSubscription(gomock.Any()).Return(nil)
+
+		subscription, err := service.CreateSubscription("https://example.com/hook", "secret", []string{entity.EventFriendshipCreated})
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/hook", subscription.Url)
+		assert.Equal(t, "secret", subscription.Secret)
+	})
+
+	t.Run("InvalidUrl", func(t *testing.T) {
+		_, err := service.CreateSubscription("ftp://example.com/hook", "secret", []string{entity.EventFriendshipCreated})
+		assert.Equal(t, ErrInvalidUrl, err)
+	})
+
+	t.Run("RelativeUrl", func(t *testing.T) {
+		_, err := service.CreateSubscription("/hook", "secret", []string{entity.EventFriendshipCreated})
+		assert.Equal(t, ErrInvalidUrl, err)
+	})
+
+	t.Run("UnknownEventType", func(t *testing.T) {
+		_, err := service.CreateSubscription("https://example.com/hook", "secret", []string{"friendship.exploded"})
+		assert.Equal(t, ErrInvalidEventType, err)
+	})
+
+	t.Run("NoEventTypes", func(t *testing.T) {
+		_, err := service.CreateSubscription("https://example.com/hook", "secret", nil)
+		assert.Equal(t, ErrInvalidEventType, err)
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		dbErr := errors.New("database error")
+		mockRepo.EXPECT().CreateSubscription(gomock.Any()).Return(dbErr)
+
+		_, err := service.CreateSubscription("https://example.com/hook", "secret", []string{entity.EventFriendshipCreated})
+		assert.Equal(t, dbErr, err)
+	})
+}
+
+func TestWebhookService_DeleteSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockWebhookRepository(ctrl)
+	service := NewWebhookService(mockRepo, mock.NewMockUserRepository(ctrl), mock.NewMockUpdateRepository(ctrl), mock.NewMockNotificationPreferenceRepository(ctrl), http.DefaultClient)
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().DeleteSubscription(int64(1)).Return(nil)
+		assert.NoError(t, service.DeleteSubscription(1))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockRepo.EXPECT().DeleteSubscription(int64(2)).Return(gorm.ErrRecordNotFound)
+		assert.Equal(t, ErrWebhookNotFound, service.DeleteSubscription(2))
+	})
+}
+
+func TestWebhookService_GetDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockWebhookRepository(ctrl)
+	service := NewWebhookService(mockRepo, mock.NewMockUserRepository(ctrl), mock.NewMockUpdateRepository(ctrl), mock.NewMockNotificationPreferenceRepository(ctrl), http.DefaultClient)
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().GetSubscriptionById(int64(1)).Return(&entity.WebhookSubscription{Id: 1}, nil)
+		mockRepo.EXPECT().GetDeliveries(int64(1), 10, 0).Return([]*entity.WebhookDelivery{{Id: 2}, {Id: 1}}, nil)
+		mockRepo.EXPECT().CountDeliveries(int64(1)).Return(int64(2), nil)
+
+		deliveries, count, err := service.GetDeliveries(1, 10, 0)
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 2)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockRepo.EXPECT().GetSubscriptionById(int64(2)).Return(nil, gorm.ErrRecordNotFound)
+
+		_, _, err := service.GetDeliveries(2, 10, 0)
+		assert.Equal(t, ErrWebhookNotFound, err)
+	})
+}
+
+func TestWebhookService_DispatchDueDeliveries(t *testing.T) {
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+
+	newDelivery := func(url string, attempts int) *entity.WebhookDelivery {
+		return &entity.WebhookDelivery{
+			Id:             1,
+			SubscriptionId: 1,
+			EventId:        "evt_1",
+			EventType:      entity.EventFriendshipCreated,
+			Payload:        `{"id":"evt_1","type":"friendship.created"}`,
+			Status:         entity.WebhookDeliveryPending,
+			Attempts:       attempts,
+			Subscription:   &entity.WebhookSubscription{Id: 1, Url: url, Secret: "secret"},
+		}
+	}
+
+	t.Run("SignedDeliverySucceeds", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		requests := make(chan received, 1)
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			requests <- received{header: r.Header.Clone(), body: body}
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer receiver.Close()
+
+		mockRepo := mock.NewMockWebhookRepository(ctrl)
+		service := NewWebhookService(mockRepo, mock.NewMockUserRepository(ctrl), mock.NewMockUpdateRepository(ctrl), mock.NewMockNotificationPreferenceRepository(ctrl), receiver.Client())
+		delivery := newDelivery(receiver.URL, 0)
+
+		mockRepo.EXPECT().ClaimDueDeliveries(gomock.Any(), gomock.Any(), constant.WebhookBatchSize).Return([]*entity.WebhookDelivery{delivery}, nil)
+		mockRepo.EXPECT().UpdateDeliveryResult(delivery).Return(nil)
+
+		attempts, err := service.DispatchDueDeliveries(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, attempts)
+
+		request := <-requests
+		assert.Equal(t, delivery.Payload, string(request.body))
+		assert.Equal(t, "evt_1", request.header.Get("X-Webhook-Id"))
+		assert.Equal(t, entity.EventFriendshipCreated, request.header.Get("X-Webhook-Event"))
+		assert.Equal(t, "sha256="+utils.SignWebhookPayload("secret", request.body), request.header.Get("X-Webhook-Signature"))
+
+		assert.Equal(t, entity.WebhookDeliverySucceeded, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Equal(t, http.StatusNoContent, delivery.ResponseStatus)
+		assert.NotNil(t, delivery.DeliveredAt)
+	})
+
+	t.Run("FailedDeliveryIsRetriedLater", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer receiver.Close()
+
+		mockRepo := mock.NewMockWebhookRepository(ctrl)
+		service := NewWebhookService(mockRepo, mock.NewMockUserRepository(ctrl), mock.NewMockUpdateRepository(ctrl), mock.NewMockNotificationPreferenceRepository(ctrl), receiver.Client())
+		delivery := newDelivery(receiver.URL, 1)
+
+		mockRepo.EXPECT().ClaimDueDeliveries(gomock.Any(), gomock.Any(), constant.WebhookBatchSize).Return([]*entity.WebhookDelivery{delivery}, nil)
+		mockRepo.EXPECT().UpdateDeliveryResult(delivery).Return(nil)
+
+		before := time.Now()
+		_, err := service.DispatchDueDeliveries(context.Background())
+		assert.NoError(t, err)
+
+		assert.Equal(t, entity.WebhookDeliveryPending, delivery.Status)
+		assert.Equal(t, 2, delivery.Attempts)
+		assert.Equal(t, http.StatusInternalServerError, delivery.ResponseStatus)
+		assert.Contains(t, delivery.LastError, "500")
+		assert.WithinDuration(t, before.Add(2*constant.WebhookInitialBackoff), delivery.NextAttemptAt, time.Second)
+		assert.Nil(t, delivery.DeliveredAt)
+	})
+
+	t.Run("GivesUpAfterMaxAttempts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer receiver.Close()
+
+		mockRepo := mock.NewMockWebhookRepository(ctrl)
+		service := NewWebhookService(mockRepo, mock.NewMockUserRepository(ctrl), mock.NewMockUpdateRepository(ctrl), mock.NewMockNotificationPreferenceRepository(ctrl), receiver.Client())
+		delivery := newDelivery(receiver.URL, constant.WebhookMaxAttempts-1)
+
+		mockRepo.EXPECT().ClaimDueDeliveries(gomock.Any(), gomock.Any(), constant.WebhookBatchSize).Return([]*entity.WebhookDelivery{delivery}, nil)
+		mockRepo.EXPECT().UpdateDeliveryResult(delivery).Return(nil)
+
+		_, err := service.DispatchDueDeliveries(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, entity.WebhookDeliveryFailed, delivery.Status)
+		assert.Equal(t, constant.WebhookMaxAttempts, delivery.Attempts)
+	})
+
+	t.Run("ClaimError", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mock.NewMockWebhookRepository(ctrl)
+		service := NewWebhookService(mockRepo, mock.NewMockUserRepository(ctrl), mock.NewMockUpdateRepository(ctrl), mock.NewMockNotificationPreferenceRepository(ctrl), http.DefaultClient)
+		dbErr := errors.New("database error")
+		mockRepo.EXPECT().ClaimDueDeliveries(gomock.Any(), gomock.Any(), constant.WebhookBatchSize).Return(nil, dbErr)
+
+		attempts, err := service.DispatchDueDeliveries(context.Background())
+		assert.Equal(t, dbErr, err)
+		assert.Equal(t, 0, attempts)
+	})
+}
+
+func TestRetryBackoff(t *testing.T) {
+	assert.Equal(t, constant.WebhookInitialBackoff, retryBackoff(1))
+	assert.Equal(t, 2*constant.WebhookInitialBackoff, retryBackoff(2))
+	assert.Equal(t, 4*constant.WebhookInitialBackoff, retryBackoff(3))
+	assert.Equal(t, constant.WebhookMaxBackoff, retryBackoff(30))
+}
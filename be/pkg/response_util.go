@@ -27,6 +27,15 @@ func BuildResponseSuccess[T any](responseStatus constant.ResponseStatus, data T)
 	}
 }
 
+func BuildResponseSuccessWithCursor[T any](responseStatus constant.ResponseStatus, data T, nextCursor string, count int64) dto.ApiResponseSuccessWithCursor[T] {
+	return dto.ApiResponseSuccessWithCursor[T]{
+		Msg:        responseStatus.GetResponseMessage(),
+		Data:       data,
+		NextCursor: nextCursor,
+		Count:      count,
+	}
+}
+
 func BuildResponseSuccessNoData() dto.ApiResponseSuccessNoData {
 	return dto.ApiResponseSuccessNoData{
 		Success: true,
@@ -40,18 +49,147 @@ func BuildResponseFail(message string) dto.ApiResponseFail {
 	}
 }
 
-func BuildResponseSuccessWithFriendsList(friends []string, count int64) dto.ApiResponseSuccessWithFriendsList {
+func BuildResponseSuccessWithFriendsList(friends []string, nextCursor string, count int64) dto.ApiResponseSuccessWithFriendsList {
 	return dto.ApiResponseSuccessWithFriendsList{
+		Success:    true,
+		Friends:    friends,
+		NextCursor: nextCursor,
+		Count:      count,
+	}
+}
+
+func BuildResponseSuccessWithFriendRequest(friendRequest dto.FriendRequestResponse) dto.ApiResponseSuccessWithFriendRequest {
+	return dto.ApiResponseSuccessWithFriendRequest{
+		Success:       true,
+		FriendRequest: friendRequest,
+	}
+}
+
+func BuildResponseSuccessWithFriendRequests(friendRequests []dto.FriendRequestResponse) dto.ApiResponseSuccessWithFriendRequests {
+	return dto.ApiResponseSuccessWithFriendRequests{
+		Success:        true,
+		FriendRequests: friendRequests,
+		Count:          int64(len(friendRequests)),
+	}
+}
+
+func BuildResponseSuccessWithBlockedUsers(blockedUsers []dto.BlockedUserResponse, count int64) dto.ApiResponseSuccessWithBlockedUsers {
+	return dto.ApiResponseSuccessWithBlockedUsers{
+		Success:      true,
+		BlockedUsers: blockedUsers,
+		Count:        count,
+	}
+}
+
+func BuildResponseSuccessWithMutedUsers(mutedUsers []dto.MutedUserResponse, count int64) dto.ApiResponseSuccessWithMutedUsers {
+	return dto.ApiResponseSuccessWithMutedUsers{
+		Success:    true,
+		MutedUsers: mutedUsers,
+		Count:      count,
+	}
+}
+
+func BuildResponseSuccessWithSessions(sessions []dto.SessionResponse) dto.ApiResponseSuccessWithSessions {
+	return dto.ApiResponseSuccessWithSessions{
+		Success:  true,
+		Sessions: sessions,
+		Count:    int64(len(sessions)),
+	}
+}
+
+func BuildResponseSuccessWithSubscriptionList(emails []string) dto.ApiResponseSuccessWithSubscriptionList {
+	return dto.ApiResponseSuccessWithSubscriptionList{
 		Success: true,
-		Friends: friends,
-		Count:   count,
+		Emails:  emails,
+		Count:   int64(len(emails)),
 	}
 }
 
-func BuildResponseSuccessWithRecipients(recipients []string) dto.ApiResponseSuccessWithRecipients {
+func BuildResponseSuccessWithFriendSuggestions(suggestions []dto.FriendSuggestionResponse) dto.ApiResponseSuccessWithFriendSuggestions {
+	return dto.ApiResponseSuccessWithFriendSuggestions{
+		Success:     true,
+		Suggestions: suggestions,
+		Count:       int64(len(suggestions)),
+	}
+}
+
+func BuildResponseSuccessWithFriendshipPath(path []string) dto.ApiResponseSuccessWithFriendshipPath {
+	return dto.ApiResponseSuccessWithFriendshipPath{
+		Success: true,
+		Path:    path,
+		Degree:  max(len(path)-1, 0),
+	}
+}
+
+func BuildResponseSuccessWithRecipients(recipients []string, nextCursor string, count int64) dto.ApiResponseSuccessWithRecipients {
 	return dto.ApiResponseSuccessWithRecipients{
 		Success:    true,
 		Recipients: recipients,
+		NextCursor: nextCursor,
+		Count:      count,
+	}
+}
+
+func BuildResponseSuccessWithUpdate(update dto.UpdateResponse) dto.ApiResponseSuccessWithUpdate {
+	return dto.ApiResponseSuccessWithUpdate{
+		Success: true,
+		Update:  update,
+	}
+}
+
+func BuildResponseSuccessWithFeed(items []dto.FeedItemResponse, nextCursor string, count, unreadCount int64) dto.ApiResponseSuccessWithFeed {
+	return dto.ApiResponseSuccessWithFeed{
+		Success:     true,
+		Items:       items,
+		NextCursor:  nextCursor,
+		Count:       count,
+		UnreadCount: unreadCount,
+	}
+}
+
+func BuildResponseSuccessWithMentions(mentions []dto.MentionResponse, nextCursor string, count int64) dto.ApiResponseSuccessWithMentions {
+	return dto.ApiResponseSuccessWithMentions{
+		Success:    true,
+		Mentions:   mentions,
+		NextCursor: nextCursor,
+		Count:      count,
+	}
+}
+
+func BuildResponseSuccessWithWebhook(webhook dto.WebhookResponse) dto.ApiResponseSuccessWithWebhook {
+	return dto.ApiResponseSuccessWithWebhook{
+		Success: true,
+		Webhook: webhook,
+	}
+}
+
+func BuildResponseSuccessWithWebhooks(webhooks []dto.WebhookResponse) dto.ApiResponseSuccessWithWebhooks {
+	return dto.ApiResponseSuccessWithWebhooks{
+		Success:  true,
+		Webhooks: webhooks,
+		Count:    int64(len(webhooks)),
+	}
+}
+
+func BuildResponseSuccessWithWebhookDeliveries(deliveries []dto.WebhookDeliveryResponse, count int64) dto.ApiResponseSuccessWithWebhookDeliveries {
+	return dto.ApiResponseSuccessWithWebhookDeliveries{
+		Success:    true,
+		Deliveries: deliveries,
+		Count:      count,
+	}
+}
+
+func BuildResponseSuccessWithDigestPreference(preference dto.DigestPreferenceResponse) dto.ApiResponseSuccessWithDigestPreference {
+	return dto.ApiResponseSuccessWithDigestPreference{
+		Success:          true,
+		DigestPreference: preference,
+	}
+}
+
+func BuildResponseSuccessWithNotificationPreference(preference dto.NotificationPreferenceResponse) dto.ApiResponseSuccessWithNotificationPreference {
+	return dto.ApiResponseSuccessWithNotificationPreference{
+		Success:                true,
+		NotificationPreference: preference,
 	}
 }
 
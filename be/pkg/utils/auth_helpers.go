@@ -2,6 +2,10 @@ package utils
 
 import (
 	"BE_Friends_Management/config"
+	"BE_Friends_Management/internal/keyring"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -12,20 +16,70 @@ const (
 	RefreshTokenExpiredTime = 10 * 24 * time.Hour
 )
 
+// accessKeyRing signs access tokens once set. Until then they are signed
+// with HS256 and config.AccessSecret. Refresh tokens are only ever verified
+// by this server and keep using HS256 and config.RefreshSecret, which also
+// stops a refresh token from passing for an access token.
+var accessKeyRing *keyring.KeyRing
+
+// SetAccessKeyRing makes access tokens signed with the signing key of ring,
+// with its kid in the header. It must be called before the server starts.
+func SetAccessKeyRing(ring *keyring.KeyRing) {
+	accessKeyRing = ring
+}
+
+// Claims of the issued tokens. IssuedAtMs is iat in milliseconds, since iat
+// only has whole seconds and a user revocation must tell apart the tokens
+// issued just before and just after it.
 type Claims struct {
-	UserId int64  `json:"user_id"`
-	Role   string `json:"role"`
+	UserId     int64  `json:"user_id"`
+	Role       string `json:"role"`
+	IssuedAtMs int64  `json:"iat_ms,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// NewTokenFamilyId returns a random identifier shared by the refresh tokens
+// rotated out of one login.
+func NewTokenFamilyId() string {
+	raw := make([]byte, 16)
+	_, _ = rand.Read(raw)
+	return hex.EncodeToString(raw)
+}
+
+// newTokenId returns the jti of a new token, which lets a single access token
+// be revoked before it expires.
+func newTokenId() string {
+	raw := make([]byte, 16)
+	_, _ = rand.Read(raw)
+	return hex.EncodeToString(raw)
+}
+
+// HashRefreshToken returns the hex encoded SHA-256 of a refresh token, which is
+// what gets stored instead of the token itself. The tokens are signed and
+// random enough that a pepper would not make the hash harder to reverse.
+func HashRefreshToken(rawRefreshToken string) string {
+	sum := sha256.Sum256([]byte(rawRefreshToken))
+	return hex.EncodeToString(sum[:])
+}
+
 func GenerateAccessToken(userId int64, role string, expiredTime time.Time) (string, error) {
+	issuedAt := time.Now()
 	claims := &Claims{
-		UserId: userId,
-		Role:   role,
+		UserId:     userId,
+		Role:       role,
+		IssuedAtMs: issuedAt.UnixMilli(),
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        newTokenId(),
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
 			ExpiresAt: jwt.NewNumericDate(expiredTime),
 		},
 	}
+	if accessKeyRing != nil {
+		key := accessKeyRing.SigningKey()
+		accessToken := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), claims)
+		accessToken.Header["kid"] = key.Id
+		return accessToken.SignedString(key.Private)
+	}
 	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	accessString, err := accessToken.SignedString([]byte(config.AccessSecret))
 	if err != nil {
@@ -39,6 +93,8 @@ func GenerateRefreshToken(userId int64, role string, expiredTime time.Time) (str
 		UserId: userId,
 		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        newTokenId(),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			ExpiresAt: jwt.NewNumericDate(expiredTime),
 		},
 	}
@@ -50,12 +106,31 @@ func GenerateRefreshToken(userId int64, role string, expiredTime time.Time) (str
 	return refreshString, nil
 }
 
+// ParseAccessToken verifies a token with the key of its kid. Tokens without a
+// kid were signed with config.AccessSecret, before the key ring was set up.
 func ParseAccessToken(rawAccessToken string) (*Claims, error) {
 	accessToken, err := jwt.ParseWithClaims(rawAccessToken, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, ok := token.Header["kid"].(string)
+		if !ok {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, ErrInvalidSigningMethod
+			}
+			if accessKeyRing != nil && config.AccessSecret == "" {
+				return nil, ErrInvalidAccessToken
+			}
+			return []byte(config.AccessSecret), nil
+		}
+		if accessKeyRing == nil {
+			return nil, ErrInvalidAccessToken
+		}
+		key, ok := accessKeyRing.Key(kid)
+		if !ok {
+			return nil, ErrInvalidAccessToken
+		}
+		if token.Method.Alg() != key.Algorithm {
 			return nil, ErrInvalidSigningMethod
 		}
-		return []byte(config.AccessSecret), nil
+		return key.Public, nil
 	})
 	if err != nil {
 		return nil, err
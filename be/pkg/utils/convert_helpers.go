@@ -1,7 +1,14 @@
 package utils
 
 import (
+	"BE_Friends_Management/internal/domain/dto"
 	"BE_Friends_Management/internal/domain/entity"
+	"BE_Friends_Management/internal/keyring"
+	"BE_Friends_Management/internal/realtime"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
 )
 
 func ConvertUsersToEmails(users []*entity.User) []string {
@@ -13,3 +20,272 @@ func ConvertUsersToEmails(users []*entity.User) []string {
 	}
 	return emails
 }
+
+func ConvertFriendRequestToResponse(friendRequest *entity.FriendRequest) dto.FriendRequestResponse {
+	response := dto.FriendRequestResponse{
+		Id:        friendRequest.Id,
+		Status:    friendRequest.Status,
+		CreatedAt: friendRequest.CreatedAt,
+	}
+	if friendRequest.Requestor != nil {
+		response.Requestor = friendRequest.Requestor.Email
+	}
+	if friendRequest.Target != nil {
+		response.Target = friendRequest.Target.Email
+	}
+	return response
+}
+
+func ConvertFriendRequestsToResponses(friendRequests []*entity.FriendRequest) []dto.FriendRequestResponse {
+	responses := make([]dto.FriendRequestResponse, 0, len(friendRequests))
+	for _, friendRequest := range friendRequests {
+		if friendRequest != nil {
+			responses = append(responses, ConvertFriendRequestToResponse(friendRequest))
+		}
+	}
+	return responses
+}
+
+func ConvertBlockRelationshipsToBlockedUsers(blockRelationships []*entity.BlockRelationship) []dto.BlockedUserResponse {
+	blockedUsers := make([]dto.BlockedUserResponse, 0, len(blockRelationships))
+	for _, blockRelationship := range blockRelationships {
+		if blockRelationship != nil && blockRelationship.Target != nil {
+			blockedUsers = append(blockedUsers, dto.BlockedUserResponse{
+				Email:     blockRelationship.Target.Email,
+				ExpiresAt: blockRelationship.ExpiresAt,
+				CreatedAt: blockRelationship.CreatedAt,
+			})
+		}
+	}
+	return blockedUsers
+}
+
+func ConvertMutesToMutedUsers(mutes []*entity.Mute) []dto.MutedUserResponse {
+	mutedUsers := make([]dto.MutedUserResponse, 0, len(mutes))
+	for _, mute := range mutes {
+		if mute != nil && mute.Target != nil {
+			mutedUsers = append(mutedUsers, dto.MutedUserResponse{
+				Email:     mute.Target.Email,
+				ExpiresAt: mute.ExpiresAt,
+				CreatedAt: mute.CreatedAt,
+			})
+		}
+	}
+	return mutedUsers
+}
+
+func ConvertUserTokensToSessions(userTokens []*entity.UserToken) []dto.SessionResponse {
+	sessions := make([]dto.SessionResponse, 0, len(userTokens))
+	for _, userToken := range userTokens {
+		if userToken != nil {
+			sessions = append(sessions, dto.SessionResponse{
+				Id:        userToken.Id,
+				UserAgent: userToken.UserAgent,
+				IpAddress: userToken.IpAddress,
+				CreatedAt: userToken.CreatedAt,
+				ExpiresAt: userToken.ExpiresAt,
+			})
+		}
+	}
+	return sessions
+}
+
+func ConvertFriendSuggestionsToResponses(suggestions []*entity.FriendSuggestion) []dto.FriendSuggestionResponse {
+	responses := make([]dto.FriendSuggestionResponse, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		if suggestion != nil {
+			responses = append(responses, dto.FriendSuggestionResponse{
+				Email:         suggestion.Email,
+				MutualFriends: suggestion.MutualFriends,
+			})
+		}
+	}
+	return responses
+}
+
+func ConvertUpdateToResponse(update *entity.Update, recipientCount int64) dto.UpdateResponse {
+	response := dto.UpdateResponse{
+		Id:             update.Id,
+		Text:           update.Text,
+		CreatedAt:      update.CreatedAt,
+		RecipientCount: recipientCount,
+	}
+	if update.Sender != nil {
+		response.Sender = update.Sender.Email
+	}
+	return response
+}
+
+func ConvertDeliveryToFeedItem(delivery *entity.UpdateDelivery) dto.FeedItemResponse {
+	item := dto.FeedItemResponse{
+		Id:       delivery.Id,
+		UpdateId: delivery.UpdateId,
+		Read:     delivery.ReadAt != nil,
+		ReadAt:   delivery.ReadAt,
+	}
+	if delivery.Update != nil {
+		item.Text = delivery.Update.Text
+		item.CreatedAt = delivery.Update.CreatedAt
+		if delivery.Update.Sender != nil {
+			item.Sender = delivery.Update.Sender.Email
+		}
+	}
+	return item
+}
+
+func ConvertDeliveriesToFeedItems(deliveries []*entity.UpdateDelivery) []dto.FeedItemResponse {
+	items := make([]dto.FeedItemResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		if delivery != nil {
+			items = append(items, ConvertDeliveryToFeedItem(delivery))
+		}
+	}
+	return items
+}
+
+func ConvertMentionsToResponses(mentions []*entity.Mention) []dto.MentionResponse {
+	responses := make([]dto.MentionResponse, 0, len(mentions))
+	for _, mention := range mentions {
+		if mention == nil {
+			continue
+		}
+		response := dto.MentionResponse{Id: mention.Id, UpdateId: mention.UpdateId}
+		if mention.Update != nil {
+			response.Text = mention.Update.Text
+			response.CreatedAt = mention.Update.CreatedAt
+			if mention.Update.Sender != nil {
+				response.Sender = mention.Update.Sender.Email
+			}
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+// ConvertRealtimeEventData maps the entity carried by a realtime event to the
+// response DTO sent to clients.
+func ConvertRealtimeEventData(event realtime.Event) any {
+	switch payload := event.Payload.(type) {
+	case *entity.UpdateDelivery:
+		return ConvertDeliveryToFeedItem(payload)
+	case *entity.FriendRequest:
+		return ConvertFriendRequestToResponse(payload)
+	case *entity.BlockRelationship:
+		response := dto.BlockEventResponse{}
+		if payload.Requestor != nil {
+			response.Requestor = payload.Requestor.Email
+		}
+		if payload.Target != nil {
+			response.Target = payload.Target.Email
+		}
+		return response
+	default:
+		return payload
+	}
+}
+
+func ConvertWebhookToResponse(subscription *entity.WebhookSubscription) dto.WebhookResponse {
+	return dto.WebhookResponse{
+		Id:         subscription.Id,
+		Url:        subscription.Url,
+		EventTypes: subscription.EventTypes,
+		CreatedAt:  subscription.CreatedAt,
+	}
+}
+
+func ConvertWebhooksToResponses(subscriptions []*entity.WebhookSubscription) []dto.WebhookResponse {
+	responses := make([]dto.WebhookResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if subscription != nil {
+			responses = append(responses, ConvertWebhookToResponse(subscription))
+		}
+	}
+	return responses
+}
+
+func ConvertWebhookDeliveriesToResponses(deliveries []*entity.WebhookDelivery) []dto.WebhookDeliveryResponse {
+	responses := make([]dto.WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		if delivery != nil {
+			responses = append(responses, dto.WebhookDeliveryResponse{
+				Id:             delivery.Id,
+				EventId:        delivery.EventId,
+				EventType:      delivery.EventType,
+				Status:         delivery.Status,
+				Attempts:       delivery.Attempts,
+				NextAttemptAt:  delivery.NextAttemptAt,
+				ResponseStatus: delivery.ResponseStatus,
+				LastError:      delivery.LastError,
+				DeliveredAt:    delivery.DeliveredAt,
+				CreatedAt:      delivery.CreatedAt,
+			})
+		}
+	}
+	return responses
+}
+
+func ConvertDigestPreferenceToResponse(preference *entity.DigestPreference) dto.DigestPreferenceResponse {
+	return dto.DigestPreferenceResponse{
+		Frequency:  preference.Frequency,
+		LastSentAt: preference.LastSentAt,
+	}
+}
+
+func ConvertNotificationPreferenceRequestToEntity(request dto.UpdateNotificationPreferenceRequest) *entity.NotificationPreference {
+	preference := &entity.NotificationPreference{
+		Channels:   request.Channels,
+		EventTypes: request.EventTypes,
+	}
+	if request.QuietHours != nil {
+		preference.QuietHoursStart = request.QuietHours.Start
+		preference.QuietHoursEnd = request.QuietHours.End
+		preference.QuietHoursTimezone = request.QuietHours.Timezone
+		preference.QuietHoursEventTypes = request.QuietHours.EventTypes
+	}
+	return preference
+}
+
+func ConvertNotificationPreferenceToResponse(preference *entity.NotificationPreference) dto.NotificationPreferenceResponse {
+	response := dto.NotificationPreferenceResponse{
+		Channels:   preference.Channels,
+		EventTypes: preference.EventTypes,
+	}
+	if preference.QuietHoursStart != "" {
+		response.QuietHours = &dto.QuietHoursResponse{
+			Start:      preference.QuietHoursStart,
+			End:        preference.QuietHoursEnd,
+			Timezone:   preference.QuietHoursTimezone,
+			EventTypes: preference.QuietHoursEventTypes,
+		}
+	}
+	if !preference.UpdatedAt.IsZero() {
+		response.UpdatedAt = &preference.UpdatedAt
+	}
+	return response
+}
+
+// ConvertKeyRingToJwks returns the public keys of the ring, or no keys when
+// tokens are signed with a shared secret.
+func ConvertKeyRingToJwks(ring *keyring.KeyRing) dto.JwksResponse {
+	jwks := dto.JwksResponse{Keys: []dto.JwkResponse{}}
+	if ring == nil {
+		return jwks
+	}
+	for _, key := range ring.Keys() {
+		jwk := dto.JwkResponse{Kid: key.Id, Use: "sig", Alg: key.Algorithm}
+		switch public := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		default:
+			continue
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks
+}
@@ -1,9 +1,47 @@
 package utils
 
-import "regexp"
+import (
+	"regexp"
+	"strings"
+)
 
-func ExtractEmails(text string) []string {
-	emailRegex := `[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`
-	re := regexp.MustCompile(emailRegex)
-	return re.FindAllString(text, -1)
+var (
+	// emailRegex accepts quoted local parts and internationalized domains,
+	// written either in Unicode or in punycode.
+	emailRegex = regexp.MustCompile(`(?:"[^"\r\n@]+"|[\p{L}\p{N}._%+\-]+)@(?:[\p{L}\p{N}](?:[\p{L}\p{N}\-]*[\p{L}\p{N}])?\.)+(?:xn--[\p{L}\p{N}\-]+|\p{L}{2,})`)
+	// mentionRegex runs once the emails are blanked out, so the @ of an email
+	// is never taken for a handle.
+	mentionRegex = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_])@([A-Za-z0-9_]{3,30})\b`)
+	handleRegex  = regexp.MustCompile(`^[a-z0-9_]{3,30}$`)
+)
+
+// ExtractMentions returns the @handles and the email addresses mentioned in
+// text, lower-cased and without duplicates, in order of appearance.
+func ExtractMentions(text string) ([]string, []string) {
+	var emails []string
+	for _, email := range emailRegex.FindAllString(text, -1) {
+		emails = appendUnique(emails, strings.ToLower(email))
+	}
+	textWithoutEmails := emailRegex.ReplaceAllString(text, " ")
+	var handles []string
+	for _, match := range mentionRegex.FindAllStringSubmatch(textWithoutEmails, -1) {
+		handles = appendUnique(handles, strings.ToLower(match[1]))
+	}
+	return handles, emails
+}
+
+// NormalizeHandle lower-cases handle and drops a leading @. It reports false
+// when the result is not 3 to 30 letters, digits or underscores.
+func NormalizeHandle(handle string) (string, bool) {
+	normalized := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(handle), "@"))
+	return normalized, handleRegex.MatchString(normalized)
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
 }
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"BE_Friends_Management/internal/domain/entity"
+	"slices"
+	"time"
+)
+
+const quietHoursLayout = "15:04"
+
+// DefaultNotificationPreference is the preference of users who never set
+// one: every notification type on every channel.
+func DefaultNotificationPreference(userId int64) *entity.NotificationPreference {
+	return &entity.NotificationPreference{
+		UserId:               userId,
+		Channels:             slices.Clone(entity.NotificationChannels),
+		EventTypes:           slices.Clone(entity.NotificationTypes),
+		QuietHoursEventTypes: []string{},
+	}
+}
+
+// UsesChannel reports whether the user wants notifications on the channel.
+// A nil preference means the defaults.
+func UsesChannel(preference *entity.NotificationPreference, channel string) bool {
+	if preference == nil {
+		return true
+	}
+	return slices.Contains(preference.Channels, channel)
+}
+
+// WantsNotification reports whether the user wants notifications of the
+// given type at the given time. During quiet hours only the
+// QuietHoursEventTypes are muted, or every type when that list is empty. A
+// nil preference means the defaults.
+func WantsNotification(preference *entity.NotificationPreference, notificationType string, at time.Time) bool {
+	if preference == nil {
+		return true
+	}
+	if !slices.Contains(preference.EventTypes, notificationType) {
+		return false
+	}
+	if !InQuietHours(preference, at) {
+		return true
+	}
+	return len(preference.QuietHoursEventTypes) > 0 && !slices.Contains(preference.QuietHoursEventTypes, notificationType)
+}
+
+// WantsAnyNotification reports whether the user wants notifications of at
+// least one of the given types at the given time, so a user receiving an
+// update for several reasons is notified if any of them is enabled.
+func WantsAnyNotification(preference *entity.NotificationPreference, notificationTypes []string, at time.Time) bool {
+	return slices.ContainsFunc(notificationTypes, func(notificationType string) bool {
+		return WantsNotification(preference, notificationType, at)
+	})
+}
+
+// InQuietHours reports whether at falls within the quiet hours of the
+// preference, whose "15:04" boundaries are clock times in
+// QuietHoursTimezone, UTC when unset. Windows whose end is before their start
+// wrap past midnight.
+func InQuietHours(preference *entity.NotificationPreference, at time.Time) bool {
+	if preference.QuietHoursStart == "" || preference.QuietHoursEnd == "" {
+		return false
+	}
+	start, err := time.Parse(quietHoursLayout, preference.QuietHoursStart)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(quietHoursLayout, preference.QuietHoursEnd)
+	if err != nil {
+		return false
+	}
+	location, err := time.LoadLocation(preference.QuietHoursTimezone)
+	if err != nil {
+		location = time.UTC
+	}
+	local := at.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+	if startMinute <= endMinute {
+		return minute >= startMinute && minute < endMinute
+	}
+	return minute >= startMinute || minute < endMinute
+}
+
+// ParseQuietHoursClock validates a "15:04" quiet hours boundary.
+func ParseQuietHoursClock(clock string) error {
+	_, err := time.Parse(quietHoursLayout, clock)
+	return err
+}
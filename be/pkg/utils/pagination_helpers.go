@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"BE_Friends_Management/constant"
+	"BE_Friends_Management/pkg"
+	"encoding/base64"
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+func GetPaginationParams(c *gin.Context) (int, int) {
+	limit := getLimitParam(c)
+	offset := 0
+	if rawOffset := c.Query("offset"); rawOffset != "" {
+		parsedOffset, err := strconv.Atoi(rawOffset)
+		if err != nil || parsedOffset < 0 {
+			log.Error("Happened error when mapping request. Error: invalid offset ", rawOffset)
+			pkg.PanicExeption(constant.InvalidRequest, "Invalid offset.")
+		}
+		offset = parsedOffset
+	}
+	return limit, offset
+}
+
+// GetCursorPaginationParams reads the `limit` and opaque `after` query
+// parameters and returns the page size and the id to continue after.
+func GetCursorPaginationParams(c *gin.Context) (int, int64) {
+	limit := getLimitParam(c)
+	var afterId int64 = 0
+	if rawAfter := c.Query("after"); rawAfter != "" {
+		decodedAfter, err := DecodeCursor(rawAfter)
+		if err != nil {
+			log.Error("Happened error when mapping request. Error: invalid cursor ", rawAfter)
+			pkg.PanicExeption(constant.InvalidRequest, "Invalid cursor.")
+		}
+		afterId = decodedAfter
+	}
+	return limit, afterId
+}
+
+func EncodeCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+func DecodeCursor(cursor string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	id, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || id < 0 {
+		return 0, ErrInvalidCursor
+	}
+	return id, nil
+}
+
+// BuildNextCursor returns the cursor of lastId, the id of the last of the n
+// items of a page, or an empty string when the page is the last one.
+func BuildNextCursor(lastId int64, n, limit int) string {
+	if n == 0 || n < limit {
+		return ""
+	}
+	return EncodeCursor(lastId)
+}
+
+func getLimitParam(c *gin.Context) int {
+	limit := constant.DefaultPageLimit
+	if rawLimit := c.Query("limit"); rawLimit != "" {
+		parsedLimit, err := strconv.Atoi(rawLimit)
+		if err != nil || parsedLimit <= 0 {
+			log.Error("Happened error when mapping request. Error: invalid limit ", rawLimit)
+			pkg.PanicExeption(constant.InvalidRequest, "Invalid limit.")
+		}
+		limit = min(parsedLimit, constant.MaxPageLimit)
+	}
+	return limit
+}
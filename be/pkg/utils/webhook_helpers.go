@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// NewEventId returns a random identifier receivers can use to de-duplicate
+// events delivered more than once.
+func NewEventId() string {
+	raw := make([]byte, 16)
+	_, _ = rand.Read(raw)
+	return "evt_" + hex.EncodeToString(raw)
+}
+
+// SignWebhookPayload returns the hex encoded HMAC-SHA256 of the payload,
+// sent as "sha256=<signature>" in the X-Webhook-Signature header.
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}